@@ -12,10 +12,10 @@ import (
 	"os/exec"
 	"strconv"
 
-	"github.com/golang/snappy"
 	"github.com/prometheus/client_golang/prometheus"
 	"k8s.io/klog/v2"
 
+	"kubevirt.io/containerized-data-importer/pkg/clonestream"
 	"kubevirt.io/containerized-data-importer/pkg/common"
 	"kubevirt.io/containerized-data-importer/pkg/monitoring"
 	"kubevirt.io/containerized-data-importer/pkg/util"
@@ -110,17 +110,13 @@ func createProgressReader(readCloser io.ReadCloser, ownerUID string, totalBytes
 	return promReader
 }
 
-func pipeToSnappy(reader io.ReadCloser) io.ReadCloser {
+func pipeToBlockDelta(reader io.ReadCloser) io.ReadCloser {
 	pr, pw := io.Pipe()
-	sbw := snappy.NewBufferedWriter(pw)
 
 	go func() {
-		n, err := io.Copy(sbw, reader)
+		n, err := clonestream.Copy(pw, reader)
 		if err != nil {
-			klog.Fatalf("Error %s piping to gzip", err)
-		}
-		if err = sbw.Close(); err != nil {
-			klog.Fatalf("Error closing snappy writer %+v", err)
+			klog.Fatalf("Error %s framing clone stream", err)
 		}
 		if err = pw.Close(); err != nil {
 			klog.Fatalf("Error closing pipe writer %+v", err)
@@ -213,7 +209,7 @@ func main() {
 
 	klog.V(1).Infoln("Starting cloner target")
 
-	reader := pipeToSnappy(createProgressReader(getInputStream(preallocation), ownerUID, uploadBytes))
+	reader := pipeToBlockDelta(createProgressReader(getInputStream(preallocation), ownerUID, uploadBytes))
 
 	startPrometheus()
 
@@ -225,6 +221,7 @@ func main() {
 		req.Header.Set("x-cdi-content-type", contentType)
 		klog.Infof("Set header to %s", contentType)
 	}
+	req.Header.Set(common.CloneTransportHeader, clonestream.Transport)
 
 	response, err := client.Do(req)
 	if err != nil {
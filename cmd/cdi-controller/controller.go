@@ -12,10 +12,14 @@ import (
 	"github.com/kelseyhightower/envconfig"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/klog/v2"
+	runtimecache "sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -23,6 +27,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
+	cdiclient "kubevirt.io/containerized-data-importer/pkg/client/clientset/versioned"
 	"kubevirt.io/containerized-data-importer/pkg/common"
 	"kubevirt.io/containerized-data-importer/pkg/controller"
 	"kubevirt.io/containerized-data-importer/pkg/controller/transfer"
@@ -32,16 +37,13 @@ import (
 	"kubevirt.io/containerized-data-importer/pkg/util/cert/generator"
 )
 
-const (
-	readyFile = "/tmp/ready"
-)
-
 var (
 	kubeconfig             string
 	kubeURL                string
 	importerImage          string
 	clonerImage            string
 	uploadServerImage      string
+	exportServerImage      string
 	uploadProxyServiceName string
 	configName             string
 	pullPolicy             string
@@ -49,6 +51,8 @@ var (
 	installerLabels        map[string]string
 	log                    = logf.Log.WithName("controller")
 	controllerEnvs         ControllerEnvs
+	shardConfig            controller.ShardConfig
+	healthProbes           = &healthChecks{}
 )
 
 // ControllerEnvs contains environment variables read for setting custom cert paths
@@ -64,7 +68,8 @@ type ControllerEnvs struct {
 // The importer and cloner images are obtained here along with the supported flags. IMPORTER_IMAGE, CLONER_IMAGE, and UPLOADSERVICE_IMAGE
 // are required by the controller and will cause it to fail if not defined.
 // Note: kubeconfig hierarchy is 1) -kubeconfig flag, 2) $KUBECONFIG exported var. If neither is
-//   specified we do an in-cluster config. For testing it's easiest to export KUBECONFIG.
+//
+//	specified we do an in-cluster config. For testing it's easiest to export KUBECONFIG.
 func init() {
 	// flags
 	flag.StringVar(&kubeURL, "server", "", "(Optional) URL address of a remote api server.  Do not set for local clusters.")
@@ -77,6 +82,8 @@ func init() {
 	importerImage = getRequiredEnvVar("IMPORTER_IMAGE")
 	clonerImage = getRequiredEnvVar("CLONER_IMAGE")
 	uploadServerImage = getRequiredEnvVar("UPLOADSERVER_IMAGE")
+	// optional, the volume export controller is disabled when unset
+	exportServerImage = os.Getenv("EXPORTSERVER_IMAGE")
 	uploadProxyServiceName = getRequiredEnvVar("UPLOADPROXY_SERVICE")
 	installerLabels = map[string]string{}
 
@@ -124,6 +131,15 @@ func getRequiredEnvVar(name string) string {
 	return val
 }
 
+// shardLockName returns a per shard lock name, so every shard elects its own
+// active replica
+func shardLockName(name string) string {
+	if shardConfig.Count > 1 {
+		return fmt.Sprintf("%s-shard-%d", name, shardConfig.Index)
+	}
+	return name
+}
+
 func start(ctx context.Context, cfg *rest.Config) {
 	klog.Info("Starting CDI controller components")
 
@@ -134,11 +150,27 @@ func start(ctx context.Context, cfg *rest.Config) {
 		klog.Fatalf("Unable to get kube client: %v\n", errors.WithStack(err))
 	}
 
+	cdiClient, err := cdiclient.NewForConfig(cfg)
+	if err != nil {
+		klog.Fatalf("Unable to get CDI client: %v\n", errors.WithStack(err))
+	}
+	maxParallelReconciles := getMaxParallelReconciles(ctx, cdiClient)
+
 	opts := manager.Options{
 		LeaderElection:             true,
 		LeaderElectionNamespace:    namespace,
-		LeaderElectionID:           "cdi-controller-leader-election-helper",
+		LeaderElectionID:           shardLockName("cdi-controller-leader-election-helper"),
 		LeaderElectionResourceLock: "leases",
+		// only cache CDI worker pods; caching every pod in a large cluster
+		// wastes controller memory. Code that inspects arbitrary pods, like
+		// the PVC in-use checks, must use an uncached client.
+		NewCache: runtimecache.BuilderWithOptions(runtimecache.Options{
+			SelectorsByObject: runtimecache.SelectorsByObject{
+				&corev1.Pod{}: {
+					Label: labels.SelectorFromSet(labels.Set{common.CDILabelKey: common.CDILabelValue}),
+				},
+			},
+		}),
 	}
 
 	mgr, err := manager.New(config.GetConfigOrDie(), opts)
@@ -147,6 +179,9 @@ func start(ctx context.Context, cfg *rest.Config) {
 		os.Exit(1)
 	}
 
+	healthProbes.addReadyzCheck(cacheSyncChecker(mgr.GetCache()))
+	healthProbes.addHealthzCheck(watchStalenessChecker(mgr.GetClient(), namespace, opts.LeaderElectionID))
+
 	uploadClientCAFetcher := &fetcher.FileCertFetcher{KeyFileName: controllerEnvs.UploadClientKeyFile, CertFileName: controllerEnvs.UploadClientCertFile}
 	uploadClientBundleFetcher := &fetcher.ConfigMapCertBundleFetcher{
 		Name:   controllerEnvs.UploadClientCaBundleConfigMap,
@@ -161,52 +196,93 @@ func start(ctx context.Context, cfg *rest.Config) {
 	}
 	uploadServerCertGenerator := &generator.FetchCertGenerator{Fetcher: uploadServerCAFetcher}
 
-	if _, err := controller.NewConfigController(mgr, log, uploadProxyServiceName, configName, installerLabels); err != nil {
-		klog.Errorf("Unable to setup config controller: %v", err)
-		os.Exit(1)
-	}
+	// singleton controllers watching cluster scoped objects only run on the
+	// primary shard, worker controllers run everywhere and split namespaces
+	// between the shards
+	if shardConfig.Primary() {
+		if _, err := controller.NewConfigController(mgr, log, uploadProxyServiceName, configName, installerLabels); err != nil {
+			klog.Errorf("Unable to setup config controller: %v", err)
+			os.Exit(1)
+		}
 
-	if _, err := controller.NewStorageProfileController(mgr, log, installerLabels); err != nil {
-		klog.Errorf("Unable to setup storage profiles controller: %v", err)
-		os.Exit(1)
+		if _, err := controller.NewStorageProfileController(mgr, log, installerLabels); err != nil {
+			klog.Errorf("Unable to setup storage profiles controller: %v", err)
+			os.Exit(1)
+		}
+
+		// only the primary shard exposes the phase counts, so the series are not duplicated
+		metrics.Registry.MustRegister(controller.NewDataVolumePhaseCollector(mgr.GetClient()))
 	}
 
-	// TODO: Current DV controller had threadiness 3, should we do the same here, defaults to one thread.
 	if _, err := controller.NewDatavolumeController(ctx, mgr, log,
-		clonerImage, pullPolicy, getTokenPublicKey(), getTokenPrivateKey(), installerLabels); err != nil {
+		clonerImage, pullPolicy, getTokenPublicKey(), getTokenPrivateKey(), installerLabels, shardConfig, maxParallelReconciles); err != nil {
 		klog.Errorf("Unable to setup datavolume controller: %v", err)
 		os.Exit(1)
 	}
 
-	if _, err := controller.NewImportController(mgr, log, importerImage, pullPolicy, verbose, installerLabels); err != nil {
+	if _, err := controller.NewImportController(mgr, log, importerImage, pullPolicy, verbose, installerLabels, shardConfig); err != nil {
 		klog.Errorf("Unable to setup import controller: %v", err)
 		os.Exit(1)
 	}
 
-	if _, err := controller.NewCloneController(mgr, log, clonerImage, pullPolicy, verbose, uploadClientCertGenerator, uploadServerBundleFetcher, getTokenPublicKey(), installerLabels); err != nil {
+	if _, err := controller.NewCloneController(mgr, log, clonerImage, pullPolicy, verbose, uploadClientCertGenerator, uploadServerBundleFetcher, getTokenPublicKey(), installerLabels, shardConfig); err != nil {
 		klog.Errorf("Unable to setup clone controller: %v", err)
 		os.Exit(1)
 	}
 
-	if _, err := controller.NewUploadController(mgr, log, uploadServerImage, pullPolicy, verbose, uploadServerCertGenerator, uploadClientBundleFetcher, installerLabels); err != nil {
+	if _, err := controller.NewUploadController(mgr, log, uploadServerImage, pullPolicy, verbose, uploadServerCertGenerator, uploadClientBundleFetcher, installerLabels, shardConfig); err != nil {
 		klog.Errorf("Unable to setup upload controller: %v", err)
 		os.Exit(1)
 	}
 
-	if _, err := transfer.NewObjectTransferController(mgr, log, installerLabels); err != nil {
-		klog.Errorf("Unable to setup transfer controller: %v", err)
+	if _, err := controller.NewUploadPopulatorController(mgr, log, shardConfig); err != nil {
+		klog.Errorf("Unable to setup upload populator controller: %v", err)
 		os.Exit(1)
 	}
 
-	if _, err := controller.NewDataImportCronController(mgr, log, importerImage, pullPolicy, installerLabels); err != nil {
-		klog.Errorf("Unable to setup dataimportcron controller: %v", err)
+	if _, err := controller.NewClonePopulatorController(mgr, log, installerLabels, shardConfig); err != nil {
+		klog.Errorf("Unable to setup clone populator controller: %v", err)
 		os.Exit(1)
 	}
-	if _, err := controller.NewDataSourceController(mgr, log, installerLabels); err != nil {
-		klog.Errorf("Unable to setup datasource controller: %v", err)
+
+	if _, err := controller.NewArchiveController(mgr, log, installerLabels, shardConfig); err != nil {
+		klog.Errorf("Unable to setup archive controller: %v", err)
 		os.Exit(1)
 	}
 
+	if exportServerImage != "" {
+		if _, err := controller.NewVolumeExportController(mgr, log, exportServerImage, pullPolicy, verbose, uploadServerCertGenerator, installerLabels, shardConfig); err != nil {
+			klog.Errorf("Unable to setup volume export controller: %v", err)
+			os.Exit(1)
+		}
+		if _, err := controller.NewDataExportController(mgr, log, exportServerImage, pullPolicy, verbose, installerLabels, shardConfig); err != nil {
+			klog.Errorf("Unable to setup data export controller: %v", err)
+			os.Exit(1)
+		}
+	} else {
+		klog.V(1).Infoln("EXPORTSERVER_IMAGE not set, volume export controllers disabled")
+	}
+
+	if shardConfig.Primary() {
+		if _, err := transfer.NewObjectTransferController(mgr, log, installerLabels, maxParallelReconciles); err != nil {
+			klog.Errorf("Unable to setup transfer controller: %v", err)
+			os.Exit(1)
+		}
+
+		if _, err := controller.NewDataImportCronController(mgr, log, importerImage, pullPolicy, installerLabels); err != nil {
+			klog.Errorf("Unable to setup dataimportcron controller: %v", err)
+			os.Exit(1)
+		}
+		if _, err := controller.NewDataSourceController(mgr, log, installerLabels); err != nil {
+			klog.Errorf("Unable to setup datasource controller: %v", err)
+			os.Exit(1)
+		}
+		if _, err := controller.NewCDIQuotaController(mgr, log); err != nil {
+			klog.Errorf("Unable to setup cdiquota controller: %v", err)
+			os.Exit(1)
+		}
+	}
+
 	klog.V(1).Infoln("created cdi controllers")
 
 	if err := mgr.Start(ctx); err != nil {
@@ -226,6 +302,14 @@ func main() {
 		klog.Fatalf("Unable to get environment variables: %v\n", errors.WithStack(err))
 	}
 
+	shardConfig, err = controller.NewShardConfigFromEnv()
+	if err != nil {
+		klog.Fatalf("Invalid shard configuration: %v\n", errors.WithStack(err))
+	}
+	if shardConfig.Count > 1 {
+		klog.Infof("Running as shard %d of %d", shardConfig.Index, shardConfig.Count)
+	}
+
 	logf.SetLogger(zap.New(zap.UseDevMode(debug)))
 	logf.Log.WithName("main").Info("Verbosity level", "verbose", verbose, "debug", debug)
 
@@ -236,6 +320,8 @@ func main() {
 
 	ctx := signals.SetupSignalHandler()
 
+	startHealthServer(healthProbes)
+
 	err = startLeaderElection(context.TODO(), cfg, func() {
 		start(ctx, cfg)
 	})
@@ -244,28 +330,24 @@ func main() {
 		klog.Fatalf("Unable to start leader election: %v\n", errors.WithStack(err))
 	}
 
-	if err = createReadyFile(); err != nil {
-		klog.Fatalf("Error creating ready file: %+v", err)
-	}
-
 	<-ctx.Done()
 
-	deleteReadyFile()
-
 	klog.V(2).Infoln("cdi controller exited")
 }
 
-func createReadyFile() error {
-	f, err := os.Create(readyFile)
+// getMaxParallelReconciles reads the worker count for the datavolume and
+// transfer controllers from the CDIConfig, defaulting to a single worker. The
+// value is only read at startup; a change requires a controller restart.
+func getMaxParallelReconciles(ctx context.Context, cdiClient cdiclient.Interface) int {
+	config, err := cdiClient.CdiV1beta1().CDIConfigs().Get(ctx, configName, metav1.GetOptions{})
 	if err != nil {
-		return err
+		klog.Warningf("Unable to read CDIConfig, using a single reconcile worker: %v", err)
+		return 1
 	}
-	defer f.Close()
-	return nil
-}
-
-func deleteReadyFile() {
-	os.Remove(readyFile)
+	if config.Spec.MaxParallelReconciles == nil || *config.Spec.MaxParallelReconciles < 1 {
+		return 1
+	}
+	return int(*config.Spec.MaxParallelReconciles)
 }
 
 func getTokenPublicKey() *rsa.PublicKey {
@@ -304,4 +386,12 @@ func getTokenPrivateKey() *rsa.PrivateKey {
 func registerMetrics() {
 	metrics.Registry.MustRegister(controller.IncompleteProfileGauge)
 	metrics.Registry.MustRegister(controller.DataImportCronOutdatedGauge)
+	metrics.Registry.MustRegister(controller.TransferBytesCounter)
+	metrics.Registry.MustRegister(controller.TransferDurationHistogram)
+	metrics.Registry.MustRegister(controller.TransferResultsCounter)
+	metrics.Registry.MustRegister(controller.ReconcileDurationHistogram)
+	metrics.Registry.MustRegister(controller.ReconcileErrorsCounter)
+	metrics.Registry.MustRegister(controller.WaitingForConsumerGauge)
+	metrics.Registry.MustRegister(controller.CloneProgressGauge)
+	metrics.Registry.MustRegister(controller.CheckpointProgressGauge)
 }
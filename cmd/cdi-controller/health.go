@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+const (
+	// healthProbeAddr is where the controller serves /healthz and /readyz
+	healthProbeAddr = ":8081"
+
+	// watchStalenessThreshold is how long the informer cache may trail the API
+	// server before the controller is considered broken and gets restarted
+	watchStalenessThreshold = 5 * time.Minute
+
+	// probeCheckTimeout bounds a single execution of a health checker
+	probeCheckTimeout = 10 * time.Second
+)
+
+// healthChecks holds the checkers behind the /healthz and /readyz endpoints.
+// The probe server starts before leader election so standby replicas answer
+// probes too; the checkers are only installed once this replica becomes leader
+// and the manager exists, so access needs to be serialized.
+type healthChecks struct {
+	mu      sync.RWMutex
+	healthy []healthz.Checker
+	ready   []healthz.Checker
+}
+
+func (h *healthChecks) addHealthzCheck(check healthz.Checker) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.healthy = append(h.healthy, check)
+}
+
+func (h *healthChecks) addReadyzCheck(check healthz.Checker) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.ready = append(h.ready, check)
+}
+
+func (h *healthChecks) handler(get func() []healthz.Checker) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		h.mu.RLock()
+		checks := get()
+		h.mu.RUnlock()
+		for _, check := range checks {
+			if err := check(req); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "ok")
+	}
+}
+
+// startHealthServer serves the readiness and liveness endpoints. Until
+// checkers are installed both endpoints report success, mirroring the ready
+// file the probes used before.
+func startHealthServer(checks *healthChecks) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/readyz", checks.handler(func() []healthz.Checker { return checks.ready }))
+	mux.HandleFunc("/healthz", checks.handler(func() []healthz.Checker { return checks.healthy }))
+	go func() {
+		server := &http.Server{Addr: healthProbeAddr, Handler: mux}
+		if err := server.ListenAndServe(); err != nil {
+			klog.Errorf("Health probe server exited: %v", err)
+		}
+	}()
+}
+
+// cacheSyncChecker reports ready once the manager informer caches have synced
+func cacheSyncChecker(c cache.Cache) healthz.Checker {
+	return func(_ *http.Request) error {
+		ctx, cancel := context.WithTimeout(context.Background(), probeCheckTimeout)
+		defer cancel()
+		if !c.WaitForCacheSync(ctx) {
+			return fmt.Errorf("informer caches not synced")
+		}
+		return nil
+	}
+}
+
+// watchStalenessChecker detects watches that silently died. It reads the
+// manager's leader election Lease through the informer cache; the running
+// manager renews that Lease every few seconds, so a cached copy whose renew
+// time keeps falling behind means events are no longer reaching the cache.
+func watchStalenessChecker(c client.Client, namespace, name string) healthz.Checker {
+	return func(_ *http.Request) error {
+		ctx, cancel := context.WithTimeout(context.Background(), probeCheckTimeout)
+		defer cancel()
+		lease := &coordinationv1.Lease{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, lease); err != nil {
+			return fmt.Errorf("unable to read leader election lease from cache: %v", err)
+		}
+		if lease.Spec.RenewTime == nil {
+			return nil
+		}
+		if age := time.Since(lease.Spec.RenewTime.Time); age > watchStalenessThreshold {
+			return fmt.Errorf("cached leader election lease was last renewed %v ago, watches are likely stale", age.Truncate(time.Second))
+		}
+		return nil
+	}
+}
@@ -33,12 +33,12 @@ func startLeaderElection(ctx context.Context, config *rest.Config, leaderFunc fu
 	namespace := util.GetNamespace()
 
 	// create manually so it has CDI component label
-	err := createConfigMap(client, namespace, configMapName)
+	err := createConfigMap(client, namespace, shardLockName(configMapName))
 	if err != nil && !k8serrors.IsAlreadyExists(err) {
 		return err
 	}
 
-	resourceLock, err := createResourceLock(client, namespace, configMapName)
+	resourceLock, err := createResourceLock(client, namespace, shardLockName(configMapName))
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,106 @@
+/*
+ * This file is part of the CDI project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/exporter"
+	"kubevirt.io/containerized-data-importer/pkg/monitoring"
+	"kubevirt.io/containerized-data-importer/pkg/util"
+	prometheusutil "kubevirt.io/containerized-data-importer/pkg/util/prometheus"
+)
+
+func init() {
+	klog.InitFlags(nil)
+	flag.Parse()
+}
+
+func main() {
+	defer klog.Flush()
+
+	certsDirectory, err := ioutil.TempDir("", "certsdir")
+	if err != nil {
+		panic(err)
+	}
+	defer os.RemoveAll(certsDirectory)
+	prometheusutil.StartPrometheusEndpoint(certsDirectory)
+	klog.V(1).Infoln("Starting exporter")
+
+	source, _ := util.ParseEnvVar(common.ExportSourceVar, false)
+	target, _ := util.ParseEnvVar(common.ExporterTargetVar, false)
+	endpoint, _ := util.ParseEnvVar(common.ExporterEndpointVar, false)
+	accessKey, _ := util.ParseEnvVar(common.ExporterAccessKeyID, false)
+	secKey, _ := util.ParseEnvVar(common.ExporterSecretKey, false)
+	format, _ := util.ParseEnvVar(common.ExportFormatVar, false)
+	ownerUID, _ := util.ParseEnvVar(common.OwnerUID, false)
+
+	exp := exporter.NewExporter(source, target, endpoint, accessKey, secKey, format)
+	exp.WrapReader = func(r io.ReadCloser, total uint64) io.ReadCloser {
+		return createProgressReader(r, ownerUID, total)
+	}
+
+	result, err := exp.Export()
+	if err != nil {
+		klog.Errorf("%+v", err)
+		if err := util.WriteTerminationError(util.ClassifyError(err)); err != nil {
+			klog.Errorf("%+v", err)
+		}
+		os.Exit(1)
+	}
+
+	klog.V(1).Infoln("Export complete")
+
+	// the checksum rides along in the termination message so the controller
+	// can publish it in the DataExport status
+	termMsg := &util.TerminationError{
+		Code:      util.ErrCodeCompleted,
+		Message:   fmt.Sprintf("Export Complete; checksum=%s", result.Checksum),
+		BytesDone: result.BytesDone,
+	}
+	if err := util.WriteTerminationError(termMsg); err != nil {
+		klog.Errorf("%+v", err)
+		os.Exit(1)
+	}
+}
+
+func createProgressReader(readCloser io.ReadCloser, ownerUID string, totalBytes uint64) io.ReadCloser {
+	progress := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: monitoring.MetricOptsList[monitoring.ExportProgress].Name,
+			Help: monitoring.MetricOptsList[monitoring.ExportProgress].Help,
+		},
+		[]string{"ownerUID"},
+	)
+	prometheus.MustRegister(progress)
+
+	promReader := prometheusutil.NewProgressReader(readCloser, totalBytes, progress, ownerUID)
+	promReader.StartTimedUpdate()
+
+	return promReader
+}
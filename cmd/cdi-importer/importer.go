@@ -52,8 +52,8 @@ func waitForReadyFile() {
 		}
 		time.Sleep(time.Second)
 	}
-	err := util.WriteTerminationMessage(fmt.Sprintf("Timeout waiting for file %s", readyFile))
-	if err != nil {
+	termErr := &util.TerminationError{Code: util.ErrCodeError, Message: fmt.Sprintf("Timeout waiting for file %s", readyFile), Retryable: true}
+	if err := util.WriteTerminationError(termErr); err != nil {
 		klog.Errorf("%+v", err)
 	}
 	os.Exit(1)
@@ -131,6 +131,7 @@ func handleEmptyImage(contentType string, imageSize string, availableDestSpace i
 
 	if contentType == string(cdiv1.DataVolumeKubeVirt) {
 		createBlankImage(imageSize, availableDestSpace, preallocation, volumeMode, filesystemOverhead)
+		formatBlankImage(volumeMode)
 		preallocationApplied = preallocation
 	} else {
 		errorEmptyDiskWithContentTypeArchive()
@@ -160,8 +161,9 @@ func handleImport(
 		if err == importer.ErrRequiresScratchSpace {
 			return common.ScratchSpaceNeededExitCode
 		}
-		err = util.WriteTerminationMessage(fmt.Sprintf("Unable to process data: %+v", err.Error()))
-		if err != nil {
+		termErr := util.ClassifyError(err)
+		termErr.Message = fmt.Sprintf("Unable to process data: %s", termErr.Message)
+		if err := util.WriteTerminationError(termErr); err != nil {
 			klog.Errorf("%+v", err)
 		}
 
@@ -214,10 +216,25 @@ func getImporterDestPath(contentType string, volumeMode v1.PersistentVolumeMode)
 	return dest
 }
 
+// readCredentialFile returns the content of the named credential file in the mounted
+// secret store volume, or the fallback when the store does not deliver that file.
+func readCredentialFile(dir, name, fallback string) string {
+	data, err := ioutil.ReadFile(dir + "/" + name)
+	if err != nil {
+		return fallback
+	}
+	return string(data)
+}
+
 func newDataSource(source string, contentType string, volumeMode v1.PersistentVolumeMode) importer.DataSourceInterface {
 	ep, _ := util.ParseEnvVar(common.ImporterEndpoint, false)
 	acc, _ := util.ParseEnvVar(common.ImporterAccessKeyID, false)
 	sec, _ := util.ParseEnvVar(common.ImporterSecretKey, false)
+	if credDir, _ := util.ParseEnvVar(common.ImporterCredentialsDirVar, false); credDir != "" {
+		// credentials delivered by an external secret store take precedence over the env vars
+		acc = readCredentialFile(credDir, common.KeyAccess, acc)
+		sec = readCredentialFile(credDir, common.KeySecret, sec)
+	}
 	diskID, _ := util.ParseEnvVar(common.ImporterDiskID, false)
 	uuid, _ := util.ParseEnvVar(common.ImporterUUID, false)
 	backingFile, _ := util.ParseEnvVar(common.ImporterBackingFile, false)
@@ -251,6 +268,19 @@ func newDataSource(source string, contentType string, volumeMode v1.PersistentVo
 			errorCannotConnectDataSource(err, "s3")
 		}
 		return ds
+	case controller.SourceGCS:
+		serviceAccount, _ := util.ParseEnvVar(common.ImporterGoogleCredentials, false)
+		ds, err := importer.NewGCSDataSource(ep, serviceAccount)
+		if err != nil {
+			errorCannotConnectDataSource(err, "gcs")
+		}
+		return ds
+	case controller.SourceOVA:
+		ds, err := importer.NewOVADataSource(ep, acc, sec, certDir)
+		if err != nil {
+			errorCannotConnectDataSource(err, "ova")
+		}
+		return ds
 	case controller.SourceVDDK:
 		ds, err := importer.NewVDDKDataSource(ep, acc, sec, thumbprint, uuid, backingFile, currentCheckpoint, previousCheckpoint, finalCheckpoint, volumeMode)
 		if err != nil {
@@ -259,8 +289,8 @@ func newDataSource(source string, contentType string, volumeMode v1.PersistentVo
 		return ds
 	default:
 		klog.Errorf("Unknown source type %s\n", source)
-		err := util.WriteTerminationMessage(fmt.Sprintf("Unknown data source: %s", source))
-		if err != nil {
+		termErr := &util.TerminationError{Code: util.ErrCodeError, Message: fmt.Sprintf("Unknown data source: %s", source)}
+		if err := util.WriteTerminationError(termErr); err != nil {
 			klog.Errorf("%+v", err)
 		}
 		os.Exit(1)
@@ -290,9 +320,33 @@ func createBlankImage(imageSize string, availableDestSpace int64, preallocation
 
 	if err != nil {
 		klog.Errorf("%+v", err)
-		message := fmt.Sprintf("Unable to create blank image: %+v", err)
-		err = util.WriteTerminationMessage(message)
-		if err != nil {
+		termErr := util.ClassifyError(err)
+		termErr.Message = fmt.Sprintf("Unable to create blank image: %s", termErr.Message)
+		if err := util.WriteTerminationError(termErr); err != nil {
+			klog.Errorf("%+v", err)
+		}
+		os.Exit(1)
+	}
+}
+
+func formatBlankImage(volumeMode v1.PersistentVolumeMode) {
+	fsType, _ := util.ParseEnvVar(common.ImporterBlankFilesystem, false)
+	if fsType == "" {
+		return
+	}
+	label, _ := util.ParseEnvVar(common.ImporterBlankFilesystemLabel, false)
+	reservedBlocks, _ := util.ParseEnvVar(common.ImporterBlankReservedBlocks, false)
+
+	dest := common.ImporterWritePath
+	if volumeMode == v1.PersistentVolumeBlock {
+		dest = common.WriteBlockPath
+	}
+
+	if err := image.FormatBlankFilesystem(dest, fsType, label, reservedBlocks); err != nil {
+		klog.Errorf("%+v", err)
+		termErr := util.ClassifyError(err)
+		termErr.Message = fmt.Sprintf("Unable to create filesystem on blank image: %s", termErr.Message)
+		if err := util.WriteTerminationError(termErr); err != nil {
 			klog.Errorf("%+v", err)
 		}
 		os.Exit(1)
@@ -301,8 +355,9 @@ func createBlankImage(imageSize string, availableDestSpace int64, preallocation
 
 func errorCannotConnectDataSource(err error, dsName string) {
 	klog.Errorf("%+v", err)
-	err = util.WriteTerminationMessage(fmt.Sprintf("Unable to connect to %s data source: %+v", dsName, err))
-	if err != nil {
+	termErr := util.ClassifyError(err)
+	termErr.Message = fmt.Sprintf("Unable to connect to %s data source: %s", dsName, termErr.Message)
+	if err := util.WriteTerminationError(termErr); err != nil {
 		klog.Errorf("%+v", err)
 	}
 	os.Exit(1)
@@ -310,8 +365,8 @@ func errorCannotConnectDataSource(err error, dsName string) {
 
 func errorEmptyDiskWithContentTypeArchive() {
 	klog.Errorf("%+v", errors.New("Cannot create empty disk with content type archive"))
-	err := util.WriteTerminationMessage("Cannot create empty disk with content type archive")
-	if err != nil {
+	termErr := &util.TerminationError{Code: util.ErrCodeError, Message: "Cannot create empty disk with content type archive"}
+	if err := util.WriteTerminationError(termErr); err != nil {
 		klog.Errorf("%+v", err)
 	}
 	os.Exit(1)
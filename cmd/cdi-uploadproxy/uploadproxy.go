@@ -89,6 +89,7 @@ func main() {
 	if err != nil {
 		klog.Fatalf("Unable to create certwatcher: %v\n", errors.WithStack(err))
 	}
+	certWatcher.RegisterCallback(uploadproxy.RecordCertExpiry)
 
 	clientCertFetcher := &certfetcher.FileCertFetcher{KeyFileName: uploadProxyEnvs.UploadClientKeyFile, CertFileName: uploadProxyEnvs.UploadClientCertFile}
 	serverCAFetcher := &certfetcher.ConfigMapCertBundleFetcher{
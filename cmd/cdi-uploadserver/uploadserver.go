@@ -57,6 +57,7 @@ func main() {
 		listenAddress,
 		listenPort,
 		destination,
+		os.Getenv("TLS_CERT_DIR"),
 		os.Getenv("TLS_KEY"),
 		os.Getenv("TLS_CERT"),
 		os.Getenv("CLIENT_CERT"),
@@ -37,6 +37,7 @@ import (
 
 	restful "github.com/emicklei/go-restful"
 	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
@@ -46,6 +47,7 @@ import (
 	cdiuploadv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/upload/v1beta1"
 	pkgcdiuploadv1 "kubevirt.io/containerized-data-importer/pkg/apis/upload/v1beta1"
 	"kubevirt.io/containerized-data-importer/pkg/apiserver/webhooks"
+	"kubevirt.io/containerized-data-importer/pkg/audit"
 	cdiclient "kubevirt.io/containerized-data-importer/pkg/client/clientset/versioned"
 	"kubevirt.io/containerized-data-importer/pkg/common"
 	"kubevirt.io/containerized-data-importer/pkg/keys"
@@ -70,6 +72,8 @@ const (
 
 	dataImportCronValidatePath = "/dataimportcron-validate"
 
+	cdiConvertPath = "/cdi-convert"
+
 	healthzPath = "/healthz"
 )
 
@@ -190,6 +194,11 @@ func NewCdiAPIServer(bindAddress string,
 		return nil, errors.Errorf("failed to create DataImportCron validating webhook: %s", err)
 	}
 
+	err = app.createCdiConversionWebhook()
+	if err != nil {
+		return nil, errors.Errorf("failed to create CDI conversion webhook: %s", err)
+	}
+
 	return app, nil
 }
 
@@ -289,6 +298,13 @@ func (app *cdiAPIApp) uploadHandler(request *restful.Request, response *restful.
 		return
 	} else if !allowed {
 		klog.Infof("Rejected Request: %s", reason)
+		audit.Log(audit.Entry{
+			Operation:       audit.OperationUploadToken,
+			User:            app.requestUser(request),
+			TargetNamespace: request.PathParameter("namespace"),
+			Decision:        audit.DecisionDenied,
+			Reason:          reason,
+		})
 		response.WriteErrorString(http.StatusUnauthorized, reason)
 		return
 	}
@@ -321,6 +337,16 @@ func (app *cdiAPIApp) uploadHandler(request *restful.Request, response *restful.
 		},
 	}
 
+	// scope the token to the target PVC when it already exists
+	pvc, err := app.client.CoreV1().PersistentVolumeClaims(namespace).Get(context.TODO(), uploadToken.Spec.PvcName, metav1.GetOptions{})
+	if err == nil {
+		tokenData.Params = map[string]string{"uid": string(pvc.UID)}
+	} else if !k8serrors.IsNotFound(err) {
+		klog.Error(err)
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
 	token, err := app.tokenGenerator.Generate(tokenData)
 	if err != nil {
 		klog.Error(err)
@@ -328,11 +354,35 @@ func (app *cdiAPIApp) uploadHandler(request *restful.Request, response *restful.
 		return
 	}
 
+	audit.Log(audit.Entry{
+		Operation:       audit.OperationUploadToken,
+		User:            app.requestUser(request),
+		TargetNamespace: namespace,
+		TargetName:      uploadToken.Spec.PvcName,
+		Decision:        audit.DecisionAllowed,
+		Reason:          "allowed by SubjectAccessReview",
+	})
+
 	uploadToken.Status.Token = token
 	response.WriteAsJson(uploadToken)
 
 }
 
+// requestUser returns the authenticated user forwarded by the aggregator, or
+// an empty string when no user header is present
+func (app *cdiAPIApp) requestUser(request *restful.Request) string {
+	if app.authConfigWatcher == nil {
+		return ""
+	}
+	authConfig := app.authConfigWatcher.GetAuthConfig()
+	for _, header := range authConfig.UserHeaders {
+		if values := request.Request.Header[header]; len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
 func uploadTokenAPIGroup() metav1.APIGroup {
 	apiGroup := metav1.APIGroup{
 		Name: uploadTokenGroup,
@@ -513,3 +563,8 @@ func (app *cdiAPIApp) createDataImportCronValidatingWebhook() error {
 	app.container.ServeMux.Handle(dataImportCronValidatePath, webhooks.NewDataImportCronValidatingWebhook(app.client, app.cdiClient))
 	return nil
 }
+
+func (app *cdiAPIApp) createCdiConversionWebhook() error {
+	app.container.ServeMux.Handle(cdiConvertPath, webhooks.NewCdiConversionWebhook())
+	return nil
+}
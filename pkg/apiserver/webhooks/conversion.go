@@ -0,0 +1,203 @@
+/*
+ * This file is part of the CDI project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+
+	cdicorev1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1"
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+)
+
+// AnnRetryPolicy preserves the v1 typed retry policy on v1beta1 objects during conversion
+const AnnRetryPolicy = "cdi.kubevirt.io/retryPolicy"
+
+type conversionHandler struct{}
+
+// NewCdiConversionWebhook creates a handler converting CDI objects between v1beta1 and v1
+func NewCdiConversionWebhook() http.Handler {
+	return &conversionHandler{}
+}
+
+func (h *conversionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body []byte
+	if r.Body != nil {
+		if data, err := ioutil.ReadAll(r.Body); err == nil {
+			body = data
+		}
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	if contentType != "application/json" {
+		klog.Errorf("contentType=%s, expect application/json", contentType)
+		return
+	}
+
+	klog.V(2).Info(fmt.Sprintf("handling conversion request: %s", body))
+
+	requestedReview := apiextensionsv1.ConversionReview{}
+	responseReview := apiextensionsv1.ConversionReview{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: apiextensionsv1.SchemeGroupVersion.String(),
+			Kind:       "ConversionReview",
+		},
+	}
+
+	if err := json.Unmarshal(body, &requestedReview); err != nil {
+		klog.Error(err)
+		responseReview.Response = toConversionResponseError(err)
+	} else if requestedReview.Request == nil {
+		responseReview.Response = toConversionResponseError(fmt.Errorf("ConversionReview.Request is nil"))
+	} else {
+		responseReview.Response = convertObjects(requestedReview.Request)
+		responseReview.Response.UID = requestedReview.Request.UID
+	}
+
+	respBytes, err := json.Marshal(responseReview)
+	if err != nil {
+		klog.Error(err)
+	}
+	if _, err := w.Write(respBytes); err != nil {
+		klog.Error(err)
+	}
+}
+
+func convertObjects(request *apiextensionsv1.ConversionRequest) *apiextensionsv1.ConversionResponse {
+	convertedObjects := []runtime.RawExtension{}
+	for _, obj := range request.Objects {
+		unstructuredObj := &unstructured.Unstructured{}
+		if err := json.Unmarshal(obj.Raw, unstructuredObj); err != nil {
+			return toConversionResponseError(err)
+		}
+		converted, err := convertObject(unstructuredObj, request.DesiredAPIVersion)
+		if err != nil {
+			return toConversionResponseError(err)
+		}
+		convertedObjects = append(convertedObjects, runtime.RawExtension{Object: converted})
+	}
+	return &apiextensionsv1.ConversionResponse{
+		ConvertedObjects: convertedObjects,
+		Result:           metav1.Status{Status: metav1.StatusSuccess},
+	}
+}
+
+func convertObject(obj *unstructured.Unstructured, desiredAPIVersion string) (*unstructured.Unstructured, error) {
+	converted := obj.DeepCopy()
+	fromAPIVersion := obj.GetAPIVersion()
+	if fromAPIVersion == desiredAPIVersion {
+		return converted, nil
+	}
+
+	if converted.GetKind() == "DataVolume" {
+		switch {
+		case fromAPIVersion == cdiv1.SchemeGroupVersion.String() && desiredAPIVersion == cdicorev1.SchemeGroupVersion.String():
+			if err := convertDataVolumeToV1(converted); err != nil {
+				return nil, err
+			}
+		case fromAPIVersion == cdicorev1.SchemeGroupVersion.String() && desiredAPIVersion == cdiv1.SchemeGroupVersion.String():
+			if err := convertDataVolumeToV1beta1(converted); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unsupported DataVolume conversion from %s to %s", fromAPIVersion, desiredAPIVersion)
+		}
+	}
+	// CDIConfig specs are identical between versions, only the apiVersion changes
+
+	converted.SetAPIVersion(desiredAPIVersion)
+	return converted, nil
+}
+
+func convertDataVolumeToV1(obj *unstructured.Unstructured) error {
+	// the v1beta1 pvc section becomes the v1 storage section
+	pvc, found, err := unstructured.NestedMap(obj.Object, "spec", "pvc")
+	if err != nil {
+		return err
+	}
+	if found {
+		_, storageFound, err := unstructured.NestedMap(obj.Object, "spec", "storage")
+		if err != nil {
+			return err
+		}
+		if !storageFound {
+			if err := unstructured.SetNestedMap(obj.Object, pvc, "spec", "storage"); err != nil {
+				return err
+			}
+		}
+		unstructured.RemoveNestedField(obj.Object, "spec", "pvc")
+	}
+
+	// the retry policy annotation becomes the typed field
+	annotations := obj.GetAnnotations()
+	if policy, ok := annotations[AnnRetryPolicy]; ok {
+		policyMap := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(policy), &policyMap); err != nil {
+			return err
+		}
+		if err := unstructured.SetNestedMap(obj.Object, policyMap, "spec", "retryPolicy"); err != nil {
+			return err
+		}
+		delete(annotations, AnnRetryPolicy)
+		obj.SetAnnotations(annotations)
+	}
+
+	return nil
+}
+
+func convertDataVolumeToV1beta1(obj *unstructured.Unstructured) error {
+	// the typed retry policy round-trips through an annotation
+	policy, found, err := unstructured.NestedMap(obj.Object, "spec", "retryPolicy")
+	if err != nil {
+		return err
+	}
+	if found {
+		policyBytes, err := json.Marshal(policy)
+		if err != nil {
+			return err
+		}
+		annotations := obj.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[AnnRetryPolicy] = string(policyBytes)
+		obj.SetAnnotations(annotations)
+		unstructured.RemoveNestedField(obj.Object, "spec", "retryPolicy")
+	}
+
+	return nil
+}
+
+func toConversionResponseError(err error) *apiextensionsv1.ConversionResponse {
+	return &apiextensionsv1.ConversionResponse{
+		Result: metav1.Status{
+			Status:  metav1.StatusFailure,
+			Message: err.Error(),
+		},
+	}
+}
@@ -0,0 +1,152 @@
+/*
+ * This file is part of the CDI project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package webhooks
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	cdicorev1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1"
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+)
+
+var _ = Describe("CDI Conversion Webhook", func() {
+	unstructuredDataVolume := func(apiVersion string, spec map[string]interface{}, annotations map[string]interface{}) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": apiVersion,
+				"kind":       "DataVolume",
+				"metadata": map[string]interface{}{
+					"name":      "testDV",
+					"namespace": metav1.NamespaceDefault,
+				},
+				"spec": spec,
+			},
+		}
+		if annotations != nil {
+			Expect(unstructured.SetNestedMap(obj.Object, annotations, "metadata", "annotations")).To(Succeed())
+		}
+		return obj
+	}
+
+	Context("converting a DataVolume to v1", func() {
+		It("should move the pvc section to the storage section", func() {
+			obj := unstructuredDataVolume(cdiv1.SchemeGroupVersion.String(), map[string]interface{}{
+				"source": map[string]interface{}{
+					"blank": map[string]interface{}{},
+				},
+				"pvc": map[string]interface{}{
+					"storageClassName": "testSC",
+				},
+			}, nil)
+
+			converted, err := convertObject(obj, cdicorev1.SchemeGroupVersion.String())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(converted.GetAPIVersion()).To(Equal(cdicorev1.SchemeGroupVersion.String()))
+
+			_, found, err := unstructured.NestedMap(converted.Object, "spec", "pvc")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			storageClass, found, err := unstructured.NestedString(converted.Object, "spec", "storage", "storageClassName")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(storageClass).To(Equal("testSC"))
+		})
+
+		It("should lift the retry policy annotation into the typed field", func() {
+			obj := unstructuredDataVolume(cdiv1.SchemeGroupVersion.String(), map[string]interface{}{
+				"storage": map[string]interface{}{},
+			}, map[string]interface{}{
+				AnnRetryPolicy: `{"maxRetries":3,"backoff":"Exponential"}`,
+			})
+
+			converted, err := convertObject(obj, cdicorev1.SchemeGroupVersion.String())
+			Expect(err).ToNot(HaveOccurred())
+
+			backoff, found, err := unstructured.NestedString(converted.Object, "spec", "retryPolicy", "backoff")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(backoff).To(Equal(string(cdicorev1.RetryBackoffExponential)))
+			Expect(converted.GetAnnotations()).ToNot(HaveKey(AnnRetryPolicy))
+		})
+	})
+
+	Context("converting a DataVolume to v1beta1", func() {
+		It("should store the retry policy in an annotation and round-trip", func() {
+			policy := map[string]interface{}{
+				"maxRetries": int64(5),
+				"backoff":    "Linear",
+			}
+			obj := unstructuredDataVolume(cdicorev1.SchemeGroupVersion.String(), map[string]interface{}{
+				"storage": map[string]interface{}{},
+			}, nil)
+			Expect(unstructured.SetNestedMap(obj.Object, policy, "spec", "retryPolicy")).To(Succeed())
+
+			converted, err := convertObject(obj, cdiv1.SchemeGroupVersion.String())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(converted.GetAPIVersion()).To(Equal(cdiv1.SchemeGroupVersion.String()))
+
+			_, found, err := unstructured.NestedMap(converted.Object, "spec", "retryPolicy")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			roundTripped := map[string]interface{}{}
+			Expect(json.Unmarshal([]byte(converted.GetAnnotations()[AnnRetryPolicy]), &roundTripped)).To(Succeed())
+
+			back, err := convertObject(converted, cdicorev1.SchemeGroupVersion.String())
+			Expect(err).ToNot(HaveOccurred())
+			restored, found, err := unstructured.NestedMap(back.Object, "spec", "retryPolicy")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(restored).To(HaveKeyWithValue("backoff", "Linear"))
+		})
+	})
+
+	Context("converting a CDIConfig", func() {
+		It("should only change the apiVersion", func() {
+			obj := &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": cdiv1.SchemeGroupVersion.String(),
+					"kind":       "CDIConfig",
+					"metadata": map[string]interface{}{
+						"name": "config",
+					},
+					"spec": map[string]interface{}{
+						"scratchSpaceStorageClass": "testSC",
+					},
+				},
+			}
+
+			converted, err := convertObject(obj, cdicorev1.SchemeGroupVersion.String())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(converted.GetAPIVersion()).To(Equal(cdicorev1.SchemeGroupVersion.String()))
+
+			storageClass, found, err := unstructured.NestedString(converted.Object, "spec", "scratchSpaceStorageClass")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(storageClass).To(Equal("testSC"))
+		})
+	})
+})
@@ -88,10 +88,10 @@ func (wh *dataImportCronValidatingWebhook) Admit(ar admissionv1.AdmissionReview)
 func (wh *dataImportCronValidatingWebhook) validateDataImportCronSpec(request *admissionv1.AdmissionRequest, field *k8sfield.Path, spec *cdiv1.DataImportCronSpec, namespace *string) []metav1.StatusCause {
 	var causes []metav1.StatusCause
 
-	if spec.Template.Spec.Source == nil || spec.Template.Spec.Source.Registry == nil {
+	if spec.Template.Spec.Source == nil || (spec.Template.Spec.Source.Registry == nil && spec.Template.Spec.Source.HTTP == nil) {
 		causes = append(causes, metav1.StatusCause{
 			Type:    metav1.CauseTypeFieldValueInvalid,
-			Message: fmt.Sprintf("Missing registry source"),
+			Message: fmt.Sprintf("Missing registry or http source"),
 			Field:   field.Child("Template").String(),
 		})
 		return causes
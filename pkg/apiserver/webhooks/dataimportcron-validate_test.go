@@ -55,6 +55,14 @@ var _ = Describe("Validating Webhook", func() {
 			resp := validateDataImportCronCreate(cron)
 			Expect(resp.Allowed).To(Equal(true))
 		})
+		It("should accept DataImportCron with HTTP source URL on create", func() {
+			cron := newDataImportCron(cdiv1.DataVolumeSourceRegistry{})
+			cron.Spec.Template.Spec.Source = &cdiv1.DataVolumeSource{
+				HTTP: &cdiv1.DataVolumeSourceHTTP{URL: "https://example.com/image.qcow2"},
+			}
+			resp := validateDataImportCronCreate(cron)
+			Expect(resp.Allowed).To(Equal(true))
+		})
 		It("should reject DataImportCron with name length longer than 253 characters", func() {
 			cron := newDataImportCron(cdiv1.DataVolumeSourceRegistry{URL: &testRegistryURL})
 			cron.Name = "the-name-length-of-this-dataimportcron-is-longer-then-253-characters" +
@@ -22,15 +22,20 @@ package webhooks
 import (
 	"context"
 	"encoding/json"
+	neturl "net/url"
+	"strings"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	authv1 "k8s.io/api/authorization/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/audit"
 	cdiclient "kubevirt.io/containerized-data-importer/pkg/client/clientset/versioned"
 	"kubevirt.io/containerized-data-importer/pkg/clone"
 	"kubevirt.io/containerized-data-importer/pkg/common"
@@ -114,6 +119,19 @@ func (wh *dataVolumeMutatingWebhook) Admit(ar admissionv1.AdmissionReview) *admi
 		modified = true
 	}
 
+	if ar.Request.Operation == admissionv1.Create && len(config.Spec.DataVolumeDefaults) > 0 {
+		applied, err := wh.applyDataVolumeDefaults(config.Spec.DataVolumeDefaults, targetNamespace, modifiedDataVolume)
+		if err != nil {
+			return toAdmissionResponseError(err)
+		}
+		modified = modified || applied
+	}
+
+	if ar.Request.Operation == admissionv1.Create && modifiedDataVolume.Spec.ContentType == "" {
+		modifiedDataVolume.Spec.ContentType = defaultContentType(&modifiedDataVolume.Spec)
+		modified = true
+	}
+
 	if pvcSource == nil {
 		klog.V(3).Infof("DataVolume %s/%s not cloning", targetNamespace, targetName)
 		if modified {
@@ -139,12 +157,30 @@ func (wh *dataVolumeMutatingWebhook) Admit(ar admissionv1.AdmissionReview) *admi
 		}
 	}
 
-	ok, reason, err := clone.CanUserClonePVC(wh.proxy, sourceNamespace, sourceName, targetNamespace, ar.Request.UserInfo)
+	granted, err := wh.hasCloneGrant(sourceNamespace, sourceName, ar.Request.UserInfo)
 	if err != nil {
 		return toAdmissionResponseError(err)
 	}
 
+	ok, reason := true, ""
+	if !granted {
+		ok, reason, err = clone.CanUserClonePVC(wh.proxy, sourceNamespace, sourceName, targetNamespace, ar.Request.UserInfo)
+		if err != nil {
+			return toAdmissionResponseError(err)
+		}
+	}
+
 	if !ok {
+		audit.Log(audit.Entry{
+			Operation:       audit.OperationCloneToken,
+			User:            ar.Request.UserInfo.Username,
+			SourceNamespace: sourceNamespace,
+			SourceName:      sourceName,
+			TargetNamespace: targetNamespace,
+			TargetName:      targetName,
+			Decision:        audit.DecisionDenied,
+			Reason:          reason,
+		})
 		causes := []metav1.StatusCause{
 			{
 				Type:    metav1.CauseTypeFieldValueInvalid,
@@ -171,6 +207,21 @@ func (wh *dataVolumeMutatingWebhook) Admit(ar admissionv1.AdmissionReview) *admi
 		return toAdmissionResponseError(err)
 	}
 
+	grantReason := "allowed by SubjectAccessReview"
+	if granted {
+		grantReason = "allowed by CloneGrant"
+	}
+	audit.Log(audit.Entry{
+		Operation:       audit.OperationCloneToken,
+		User:            ar.Request.UserInfo.Username,
+		SourceNamespace: sourceNamespace,
+		SourceName:      sourceName,
+		TargetNamespace: targetNamespace,
+		TargetName:      targetName,
+		Decision:        audit.DecisionAllowed,
+		Reason:          grantReason,
+	})
+
 	if modifiedDataVolume.Annotations == nil {
 		modifiedDataVolume.Annotations = make(map[string]string)
 	}
@@ -180,3 +231,109 @@ func (wh *dataVolumeMutatingWebhook) Admit(ar admissionv1.AdmissionReview) *admi
 
 	return toPatchResponse(dataVolume, modifiedDataVolume)
 }
+
+// defaultContentType makes the implicit contentType explicit on the object:
+// archive for sources that clearly point at a tar archive, kubevirt otherwise
+func defaultContentType(spec *cdiv1.DataVolumeSpec) cdiv1.DataVolumeContentType {
+	if spec.Source != nil && spec.Source.HTTP != nil {
+		if url, err := neturl.Parse(spec.Source.HTTP.URL); err == nil && strings.HasSuffix(url.Path, ".tar") {
+			return cdiv1.DataVolumeArchive
+		}
+	}
+	return cdiv1.DataVolumeKubeVirt
+}
+
+// hasCloneGrant checks whether a CloneGrant in the source namespace allows the
+// requesting ServiceAccount to clone the source PVC without a SubjectAccessReview
+func (wh *dataVolumeMutatingWebhook) hasCloneGrant(sourceNamespace, sourceName string, userInfo authenticationv1.UserInfo) (bool, error) {
+	saNamespace, saName, ok := parseServiceAccountUsername(userInfo.Username)
+	if !ok {
+		return false, nil
+	}
+	grants, err := wh.cdiClient.CdiV1beta1().CloneGrants(sourceNamespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return false, err
+	}
+	for i := range grants.Items {
+		grant := &grants.Items[i]
+		if grant.Spec.ServiceAccount.Namespace != saNamespace || grant.Spec.ServiceAccount.Name != saName {
+			continue
+		}
+		if grant.Spec.PVCSelector == nil {
+			klog.V(3).Infof("CloneGrant %s/%s allows %s/%s to clone %s/%s", sourceNamespace, grant.Name, saNamespace, saName, sourceNamespace, sourceName)
+			return true, nil
+		}
+		selector, err := metav1.LabelSelectorAsSelector(grant.Spec.PVCSelector)
+		if err != nil {
+			return false, err
+		}
+		pvc, err := wh.k8sClient.CoreV1().PersistentVolumeClaims(sourceNamespace).Get(context.TODO(), sourceName, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		if selector.Matches(labels.Set(pvc.Labels)) {
+			klog.V(3).Infof("CloneGrant %s/%s allows %s/%s to clone %s/%s", sourceNamespace, grant.Name, saNamespace, saName, sourceNamespace, sourceName)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// parseServiceAccountUsername extracts the namespace and name from a
+// system:serviceaccount username
+func parseServiceAccountUsername(username string) (string, string, bool) {
+	parts := strings.Split(username, ":")
+	if len(parts) != 4 || parts[0] != "system" || parts[1] != "serviceaccount" {
+		return "", "", false
+	}
+	return parts[2], parts[3], true
+}
+
+// applyDataVolumeDefaults applies the CDIConfig DataVolume defaults matching the target namespace
+// to a new DataVolume, returning true when the object was modified
+func (wh *dataVolumeMutatingWebhook) applyDataVolumeDefaults(defaults []cdiv1.DataVolumeDefaults, namespace string, dv *cdiv1.DataVolume) (bool, error) {
+	ns, err := wh.k8sClient.CoreV1().Namespaces().Get(context.TODO(), namespace, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	modified := false
+	for i := range defaults {
+		def := &defaults[i]
+		if def.NamespaceSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(def.NamespaceSelector)
+			if err != nil {
+				return modified, err
+			}
+			if !selector.Matches(labels.Set(ns.Labels)) {
+				continue
+			}
+		}
+		if def.Preallocation != nil && dv.Spec.Preallocation == nil {
+			dv.Spec.Preallocation = def.Preallocation
+			modified = true
+		}
+		if def.StorageClass != nil {
+			if dv.Spec.PVC != nil && dv.Spec.PVC.StorageClassName == nil {
+				dv.Spec.PVC.StorageClassName = def.StorageClass
+				modified = true
+			} else if dv.Spec.Storage != nil && dv.Spec.Storage.StorageClassName == nil {
+				dv.Spec.Storage.StorageClassName = def.StorageClass
+				modified = true
+			}
+		}
+		if def.PriorityClassName != nil && dv.Spec.PriorityClassName == "" {
+			dv.Spec.PriorityClassName = *def.PriorityClassName
+			modified = true
+		}
+		for key, value := range def.Labels {
+			if _, ok := dv.Labels[key]; !ok {
+				if dv.Labels == nil {
+					dv.Labels = make(map[string]string)
+				}
+				dv.Labels[key] = value
+				modified = true
+			}
+		}
+	}
+	return modified, nil
+}
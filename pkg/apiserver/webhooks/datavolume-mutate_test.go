@@ -31,7 +31,9 @@ import (
 
 	"github.com/appscode/jsonpatch"
 	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	authorization "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	fakeclient "k8s.io/client-go/kubernetes/fake"
@@ -197,6 +199,140 @@ var _ = Describe("Mutating DataVolume Webhook", func() {
 			Expect(resp.Patch).To(BeNil())
 		})
 
+		DescribeTable("should default contentType on create", func(url string, expectedContentType string) {
+			dataVolume := newHTTPDataVolume("testDV", url)
+			dvBytes, _ := json.Marshal(&dataVolume)
+
+			ar := &admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Resource: metav1.GroupVersionResource{
+						Group:    cdicorev1.SchemeGroupVersion.Group,
+						Version:  cdicorev1.SchemeGroupVersion.Version,
+						Resource: "datavolumes",
+					},
+					Object: runtime.RawExtension{
+						Raw: dvBytes,
+					},
+				},
+			}
+
+			resp := mutateDVs(key, ar, true)
+			Expect(resp.Allowed).To(BeTrue())
+			Expect(resp.Patch).ToNot(BeNil())
+
+			var patchObjs []jsonpatch.Operation
+			err := json.Unmarshal(resp.Patch, &patchObjs)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(patchObjs).Should(HaveLen(1))
+			Expect(patchObjs[0].Operation).Should(Equal("add"))
+			Expect(patchObjs[0].Path).Should(Equal("/spec/contentType"))
+			Expect(patchObjs[0].Value).Should(Equal(expectedContentType))
+		},
+			Entry("to archive for a tar source", "http://www.example.com/disk.tar", "archive"),
+			Entry("to kubevirt for a disk image source", "http://www.example.com/disk.qcow2", "kubevirt"),
+		)
+
+		It("should keep an explicit contentType on create", func() {
+			dataVolume := newHTTPDataVolume("testDV", "http://www.example.com/disk.tar")
+			dataVolume.Spec.ContentType = cdicorev1.DataVolumeKubeVirt
+			dvBytes, _ := json.Marshal(&dataVolume)
+
+			ar := &admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Resource: metav1.GroupVersionResource{
+						Group:    cdicorev1.SchemeGroupVersion.Group,
+						Version:  cdicorev1.SchemeGroupVersion.Version,
+						Resource: "datavolumes",
+					},
+					Object: runtime.RawExtension{
+						Raw: dvBytes,
+					},
+				},
+			}
+
+			resp := mutateDVs(key, ar, true)
+			Expect(resp.Allowed).To(BeTrue())
+			Expect(resp.Patch).To(BeNil())
+		})
+
+		It("should allow a clone DataVolume with matching CloneGrant when authorization fails", func() {
+			dataVolume := newPVCDataVolume("testDV", "testNamespace", "test")
+			dvBytes, _ := json.Marshal(&dataVolume)
+
+			ar := &admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					UserInfo: authenticationv1.UserInfo{
+						Username: "system:serviceaccount:default:gitops",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    cdicorev1.SchemeGroupVersion.Group,
+						Version:  cdicorev1.SchemeGroupVersion.Version,
+						Resource: "datavolumes",
+					},
+					Object: runtime.RawExtension{
+						Raw: dvBytes,
+					},
+				},
+			}
+
+			grant := &cdicorev1.CloneGrant{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "grant",
+					Namespace: "testNamespace",
+				},
+				Spec: cdicorev1.CloneGrantSpec{
+					ServiceAccount: cdicorev1.CloneGrantServiceAccount{
+						Namespace: "default",
+						Name:      "gitops",
+					},
+				},
+			}
+
+			resp := mutateDVsEx(key, ar, false, nil, []runtime.Object{grant})
+			Expect(resp.Allowed).To(BeTrue())
+			Expect(resp.Patch).ToNot(BeNil())
+		})
+
+		It("should reject a clone DataVolume when the CloneGrant names another ServiceAccount", func() {
+			dataVolume := newPVCDataVolume("testDV", "testNamespace", "test")
+			dvBytes, _ := json.Marshal(&dataVolume)
+
+			ar := &admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					UserInfo: authenticationv1.UserInfo{
+						Username: "system:serviceaccount:default:gitops",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    cdicorev1.SchemeGroupVersion.Group,
+						Version:  cdicorev1.SchemeGroupVersion.Version,
+						Resource: "datavolumes",
+					},
+					Object: runtime.RawExtension{
+						Raw: dvBytes,
+					},
+				},
+			}
+
+			grant := &cdicorev1.CloneGrant{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "grant",
+					Namespace: "testNamespace",
+				},
+				Spec: cdicorev1.CloneGrantSpec{
+					ServiceAccount: cdicorev1.CloneGrantServiceAccount{
+						Namespace: "default",
+						Name:      "other",
+					},
+				},
+			}
+
+			resp := mutateDVsEx(key, ar, false, nil, []runtime.Object{grant})
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Patch).To(BeNil())
+		})
+
 		DescribeTable("should", func(srcNamespace string) {
 			dataVolume := newPVCDataVolume("testDV", srcNamespace, "test")
 			dvBytes, _ := json.Marshal(&dataVolume)
@@ -275,6 +411,104 @@ var _ = Describe("Mutating DataVolume Webhook", func() {
 			Entry("not set GC annotation if TTL is not set", nil),
 		)
 
+		It("should apply CDIConfig DataVolume defaults to new DataVolumes", func() {
+			dataVolume := newHTTPDataVolume("testDV", "http://www.example.com")
+			dataVolume.Namespace = "testNamespace"
+			dvBytes, _ := json.Marshal(&dataVolume)
+
+			ar := &admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Resource: metav1.GroupVersionResource{
+						Group:    cdicorev1.SchemeGroupVersion.Group,
+						Version:  cdicorev1.SchemeGroupVersion.Version,
+						Resource: "datavolumes",
+					},
+					Object: runtime.RawExtension{
+						Raw: dvBytes,
+					},
+				},
+			}
+
+			namespace := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "testNamespace",
+					Labels: map[string]string{"env": "dev"},
+				},
+			}
+			client := fakeclient.NewSimpleClientset(namespace)
+			preallocation := true
+			scName := "defaultSC"
+			cdiConfig := controller.MakeEmptyCDIConfigSpec(common.ConfigName)
+			cdiConfig.Spec.DataVolumeDefaults = []cdicorev1.DataVolumeDefaults{{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev"}},
+				Preallocation:     &preallocation,
+				StorageClass:      &scName,
+				Labels:            map[string]string{"team": "platform"},
+			}}
+			cdiClient := cdiclientfake.NewSimpleClientset(cdiConfig)
+			wh := NewDataVolumeMutatingWebhook(client, cdiClient, key)
+
+			resp := serve(ar, wh)
+			Expect(resp.Allowed).To(BeTrue())
+			Expect(resp.Patch).ToNot(BeNil())
+
+			var patchObjs []jsonpatch.Operation
+			err := json.Unmarshal(resp.Patch, &patchObjs)
+			Expect(err).ToNot(HaveOccurred())
+			paths := []string{}
+			for _, patch := range patchObjs {
+				paths = append(paths, patch.Path)
+			}
+			Expect(paths).To(ContainElement("/spec/preallocation"))
+			Expect(paths).To(ContainElement("/spec/pvc/storageClassName"))
+			Expect(paths).To(ContainElement("/metadata/labels"))
+		})
+
+		It("should not apply CDIConfig DataVolume defaults when the namespace selector does not match", func() {
+			dataVolume := newHTTPDataVolume("testDV", "http://www.example.com")
+			dataVolume.Namespace = "testNamespace"
+			dvBytes, _ := json.Marshal(&dataVolume)
+
+			ar := &admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Resource: metav1.GroupVersionResource{
+						Group:    cdicorev1.SchemeGroupVersion.Group,
+						Version:  cdicorev1.SchemeGroupVersion.Version,
+						Resource: "datavolumes",
+					},
+					Object: runtime.RawExtension{
+						Raw: dvBytes,
+					},
+				},
+			}
+
+			namespace := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "testNamespace",
+				},
+			}
+			client := fakeclient.NewSimpleClientset(namespace)
+			preallocation := true
+			cdiConfig := controller.MakeEmptyCDIConfigSpec(common.ConfigName)
+			cdiConfig.Spec.DataVolumeDefaults = []cdicorev1.DataVolumeDefaults{{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev"}},
+				Preallocation:     &preallocation,
+			}}
+			cdiClient := cdiclientfake.NewSimpleClientset(cdiConfig)
+			wh := NewDataVolumeMutatingWebhook(client, cdiClient, key)
+
+			resp := serve(ar, wh)
+			Expect(resp.Allowed).To(BeTrue())
+
+			var patchObjs []jsonpatch.Operation
+			err := json.Unmarshal(resp.Patch, &patchObjs)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(patchObjs).Should(HaveLen(1))
+			Expect(patchObjs[0].Path).Should(Equal("/spec/contentType"))
+		})
+
 	})
 })
 
@@ -25,6 +25,9 @@ import (
 	"fmt"
 	neturl "net/url"
 	"reflect"
+	"strings"
+
+	"github.com/docker/distribution/reference"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	v1 "k8s.io/api/core/v1"
@@ -38,6 +41,7 @@ import (
 
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	cdiclient "kubevirt.io/containerized-data-importer/pkg/client/clientset/versioned"
+	"kubevirt.io/containerized-data-importer/pkg/common"
 	"kubevirt.io/containerized-data-importer/pkg/controller"
 )
 
@@ -134,6 +138,13 @@ func (wh *dataVolumeValidatingWebhook) validateDataVolumeSpec(request *admission
 			})
 			return causes
 		}
+		if request.Operation == admissionv1.Create {
+			cause := wh.validatePvcAgainstStorageProfile(spec.PVC, field.Child("PVC"))
+			if cause != nil {
+				causes = append(causes, *cause)
+				return causes
+			}
+		}
 	} else if spec.Storage != nil {
 		cause, valid := validateStorageSize(spec.Storage.Resources, field, "Storage")
 		if !valid {
@@ -154,6 +165,17 @@ func (wh *dataVolumeValidatingWebhook) validateDataVolumeSpec(request *admission
 		}
 	}
 
+	switch spec.OverwritePolicy {
+	case "", cdiv1.OverwritePolicyFail, cdiv1.OverwritePolicyWipe, cdiv1.OverwritePolicyResume:
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("Unsupported value: \"%s\": supported values: \"fail\", \"wipe\", \"resume\"", string(spec.OverwritePolicy)),
+			Field:   field.Child("overwritePolicy").String(),
+		})
+		return causes
+	}
+
 	if (spec.Source == nil && spec.SourceRef == nil) || (spec.Source != nil && spec.SourceRef != nil) {
 		causes = append(causes, metav1.StatusCause{
 			Type:    metav1.CauseTypeFieldValueInvalid,
@@ -193,8 +215,8 @@ func (wh *dataVolumeValidatingWebhook) validateDataVolumeSpec(request *admission
 		})
 		return causes
 	}
-	// if source types are HTTP, Imageio, S3 or VDDK, check if URL is valid
-	if spec.Source.HTTP != nil || spec.Source.S3 != nil || spec.Source.Imageio != nil || spec.Source.VDDK != nil {
+	// if source types are HTTP, Imageio, S3, VDDK or VolumeExport, check if URL is valid
+	if spec.Source.HTTP != nil || spec.Source.S3 != nil || spec.Source.Imageio != nil || spec.Source.VDDK != nil || spec.Source.VolumeExport != nil {
 		if spec.Source.HTTP != nil {
 			url = spec.Source.HTTP.URL
 			sourceType = field.Child("source", "HTTP", "url").String()
@@ -207,6 +229,9 @@ func (wh *dataVolumeValidatingWebhook) validateDataVolumeSpec(request *admission
 		} else if spec.Source.VDDK != nil {
 			url = spec.Source.VDDK.URL
 			sourceType = field.Child("source", "VDDK", "url").String()
+		} else if spec.Source.VolumeExport != nil {
+			url = spec.Source.VolumeExport.URL
+			sourceType = field.Child("source", "VolumeExport", "url").String()
 		}
 		err := validateSourceURL(url)
 		if err != "" {
@@ -219,6 +244,30 @@ func (wh *dataVolumeValidatingWebhook) validateDataVolumeSpec(request *admission
 		}
 	}
 
+	if spec.Source.GCS != nil {
+		gcsURL, err := neturl.ParseRequestURI(spec.Source.GCS.URL)
+		if err != nil || gcsURL.Scheme != "gs" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s Invalid source URL, expected a gs:// URL: %s", field.Child("source").String(), spec.Source.GCS.URL),
+				Field:   field.Child("source", "GCS", "url").String(),
+			})
+			return causes
+		}
+	}
+
+	if spec.Source.OVA != nil {
+		ovaURL, err := neturl.ParseRequestURI(spec.Source.OVA.URL)
+		if err != nil || (ovaURL.Scheme != "http" && ovaURL.Scheme != "https" && ovaURL.Scheme != "s3") {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s Invalid source URL, expected a http(s) or s3 URL: %s", field.Child("source").String(), spec.Source.OVA.URL),
+				Field:   field.Child("source", "OVA", "url").String(),
+			})
+			return causes
+		}
+	}
+
 	// Make sure contentType is either empty (kubevirt), or kubevirt or archive
 	if spec.ContentType != "" && string(spec.ContentType) != string(cdiv1.DataVolumeKubeVirt) && string(spec.ContentType) != string(cdiv1.DataVolumeArchive) {
 		sourceType = field.Child("contentType").String()
@@ -270,16 +319,29 @@ func (wh *dataVolumeValidatingWebhook) validateDataVolumeSpec(request *admission
 	}
 
 	if spec.Source.VDDK != nil {
-		if spec.Source.VDDK.SecretRef == "" || spec.Source.VDDK.UUID == "" || spec.Source.VDDK.BackingFile == "" || spec.Source.VDDK.Thumbprint == "" {
-			causes = append(causes, metav1.StatusCause{
-				Type:    metav1.CauseTypeFieldValueInvalid,
-				Message: fmt.Sprintf("%s source VDDK is not valid", field.Child("source", "VDDK").String()),
-				Field:   field.Child("source", "VDDK").String(),
-			})
+		causes := validateDataVolumeSourceVDDK(spec.Source.VDDK, field)
+		if len(causes) > 0 {
 			return causes
 		}
 	}
 
+	if spec.Source.VolumeExport != nil && spec.Source.VolumeExport.TokenSecretRef == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s source VolumeExport requires a tokenSecretRef", field.Child("source", "VolumeExport").String()),
+			Field:   field.Child("source", "VolumeExport", "tokenSecretRef").String(),
+		})
+		return causes
+	}
+
+	if causes := validateDataVolumeCertSources(spec.Source, field); len(causes) > 0 {
+		return causes
+	}
+
+	if causes := wh.validateRestrictedEgress(spec, field); len(causes) > 0 {
+		return causes
+	}
+
 	if spec.Source.PVC != nil {
 		if spec.Source.PVC.Namespace == "" || spec.Source.PVC.Name == "" {
 			causes = append(causes, metav1.StatusCause{
@@ -297,9 +359,134 @@ func (wh *dataVolumeValidatingWebhook) validateDataVolumeSpec(request *admission
 		}
 	}
 
+	if spec.Source.Snapshot != nil && spec.Source.Snapshot.Name == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s source Snapshot is not valid", field.Child("source", "Snapshot").String()),
+			Field:   field.Child("source", "Snapshot").String(),
+		})
+		return causes
+	}
+
+	if blank := spec.Source.Blank; blank != nil && (blank.Filesystem != "" || blank.ReservedBlocksPercentage != nil) {
+		if blank.Filesystem != "ext4" && blank.Filesystem != "xfs" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s source Blank filesystem must be either ext4 or xfs", field.Child("source", "Blank").String()),
+				Field:   field.Child("source", "Blank", "filesystem").String(),
+			})
+			return causes
+		}
+		if blank.ReservedBlocksPercentage != nil {
+			if blank.Filesystem != "ext4" {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s source Blank reserved blocks are only supported on ext4", field.Child("source", "Blank").String()),
+					Field:   field.Child("source", "Blank", "reservedBlocksPercentage").String(),
+				})
+				return causes
+			}
+			if p := *blank.ReservedBlocksPercentage; p < 0 || p > 50 {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s source Blank reserved blocks percentage must be between 0 and 50", field.Child("source", "Blank").String()),
+					Field:   field.Child("source", "Blank", "reservedBlocksPercentage").String(),
+				})
+				return causes
+			}
+		}
+	}
+
 	return causes
 }
 
+// validateRestrictedEgress rejects sources pointing outside the allowlisted hosts
+// when restricted egress is enabled in the CDI config
+func (wh *dataVolumeValidatingWebhook) validateRestrictedEgress(spec *cdiv1.DataVolumeSpec, field *k8sfield.Path) []metav1.StatusCause {
+	config, err := wh.cdiClient.CdiV1beta1().CDIConfigs().Get(context.TODO(), common.ConfigName, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeUnexpectedServerResponse,
+			Message: fmt.Sprintf("Unable to read the CDI config: %v", err),
+			Field:   field.Child("source").String(),
+		}}
+	}
+
+	restricted := config.Spec.RestrictedEgress
+	if restricted == nil || !restricted.Enabled {
+		return nil
+	}
+
+	var host string
+	var sourceField *k8sfield.Path
+	allowlist := restricted.AllowedHosts
+
+	switch {
+	case spec.Source.HTTP != nil:
+		host = hostOfURL(spec.Source.HTTP.URL)
+		sourceField = field.Child("source", "HTTP", "url")
+	case spec.Source.S3 != nil:
+		host = hostOfURL(spec.Source.S3.URL)
+		sourceField = field.Child("source", "S3", "url")
+	case spec.Source.GCS != nil:
+		host = hostOfURL(spec.Source.GCS.URL)
+		sourceField = field.Child("source", "GCS", "url")
+	case spec.Source.OVA != nil:
+		host = hostOfURL(spec.Source.OVA.URL)
+		sourceField = field.Child("source", "OVA", "url")
+	case spec.Source.Imageio != nil:
+		host = hostOfURL(spec.Source.Imageio.URL)
+		sourceField = field.Child("source", "Imageio", "url")
+	case spec.Source.VDDK != nil:
+		host = hostOfURL(spec.Source.VDDK.URL)
+		sourceField = field.Child("source", "VDDK", "url")
+	case spec.Source.Registry != nil && spec.Source.Registry.URL != nil:
+		host = hostOfURL(*spec.Source.Registry.URL)
+		sourceField = field.Child("source", "Registry", "URL")
+		allowlist = restricted.AllowedRegistries
+	default:
+		return nil
+	}
+
+	if hostAllowed(host, allowlist) {
+		return nil
+	}
+
+	return []metav1.StatusCause{{
+		Type:    metav1.CauseTypeFieldValueInvalid,
+		Message: fmt.Sprintf("Source host %q is not allowed by the restricted egress configuration", host),
+		Field:   sourceField.String(),
+	}}
+}
+
+func hostOfURL(sourceURL string) string {
+	url, err := neturl.Parse(sourceURL)
+	if err != nil {
+		return ""
+	}
+	return url.Hostname()
+}
+
+// hostAllowed matches host against the allowlist, an entry starting with "*." matches any subdomain
+func hostAllowed(host string, allowlist []string) bool {
+	if host == "" {
+		return false
+	}
+	for _, allowed := range allowlist {
+		if strings.EqualFold(host, allowed) {
+			return true
+		}
+		if strings.HasPrefix(allowed, "*.") && len(host) > len(allowed)-2 &&
+			strings.EqualFold(host[len(host)-(len(allowed)-1):], allowed[1:]) {
+			return true
+		}
+	}
+	return false
+}
+
 func validateDataVolumeSourceRegistry(sourceRegistry *cdiv1.DataVolumeSourceRegistry, field *k8sfield.Path) []metav1.StatusCause {
 	var causes []metav1.StatusCause
 	sourceURL := sourceRegistry.URL
@@ -363,6 +550,108 @@ func validateDataVolumeSourceRegistry(sourceRegistry *cdiv1.DataVolumeSourceRegi
 	return causes
 }
 
+func validateDataVolumeSourceVDDK(sourceVDDK *cdiv1.DataVolumeSourceVDDK, field *k8sfield.Path) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	for _, required := range []struct {
+		fieldName string
+		value     string
+	}{
+		{"backingFile", sourceVDDK.BackingFile},
+		{"uuid", sourceVDDK.UUID},
+		{"thumbprint", sourceVDDK.Thumbprint},
+		{"secretRef", sourceVDDK.SecretRef},
+	} {
+		if required.value == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("Source VDDK %s is missing", required.fieldName),
+				Field:   field.Child("source", "VDDK", required.fieldName).String(),
+			})
+		}
+	}
+	if sourceVDDK.InitImageURL != "" {
+		if _, err := reference.ParseNormalizedNamed(sourceVDDK.InitImageURL); err != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("Illegal VDDK init image %s: %v", sourceVDDK.InitImageURL, err),
+				Field:   field.Child("source", "VDDK", "initImageURL").String(),
+			})
+		}
+	}
+	return causes
+}
+
+func validateDataVolumeCertSources(source *cdiv1.DataVolumeSource, field *k8sfield.Path) []metav1.StatusCause {
+	var sourceName string
+	var certConfigMap, certSecret string
+	switch {
+	case source.HTTP != nil:
+		sourceName = "HTTP"
+		certConfigMap = source.HTTP.CertConfigMap
+		certSecret = source.HTTP.CertSecret
+	case source.S3 != nil:
+		sourceName = "S3"
+		certConfigMap = source.S3.CertConfigMap
+		certSecret = source.S3.CertSecret
+	case source.OVA != nil:
+		sourceName = "OVA"
+		certConfigMap = source.OVA.CertConfigMap
+		certSecret = source.OVA.CertSecret
+	case source.Registry != nil:
+		sourceName = "Registry"
+		certConfigMap = getStringValue(source.Registry.CertConfigMap)
+		certSecret = getStringValue(source.Registry.CertSecret)
+	case source.Imageio != nil:
+		sourceName = "Imageio"
+		certConfigMap = source.Imageio.CertConfigMap
+		certSecret = source.Imageio.CertSecret
+	default:
+		return nil
+	}
+	if certConfigMap != "" && certSecret != "" {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("Source %s cannot have both certConfigMap and certSecret", sourceName),
+			Field:   field.Child("source", sourceName, "certSecret").String(),
+		}}
+	}
+	return nil
+}
+
+func getStringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// validateCheckpointUpdate admits the only legal spec mutation on an existing
+// DataVolume: appending warm migration checkpoints and flipping finalCheckpoint.
+func validateCheckpointUpdate(oldSpec, newSpec *cdiv1.DataVolumeSpec) *metav1.StatusCause {
+	checkpointsChanged := !apiequality.Semantic.DeepEqual(newSpec.Checkpoints, oldSpec.Checkpoints)
+	if !checkpointsChanged && newSpec.FinalCheckpoint == oldSpec.FinalCheckpoint {
+		return nil
+	}
+	if newSpec.Source == nil || (newSpec.Source.VDDK == nil && newSpec.Source.Imageio == nil) {
+		return &metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("Checkpoint fields can only change on a multi-stage import source"),
+			Field:   k8sfield.NewPath("DataVolume").Child("Spec", "Source").String(),
+		}
+	}
+	if checkpointsChanged {
+		if len(newSpec.Checkpoints) < len(oldSpec.Checkpoints) ||
+			!apiequality.Semantic.DeepEqual(newSpec.Checkpoints[:len(oldSpec.Checkpoints)], oldSpec.Checkpoints) {
+			return &metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("Checkpoints can only be appended"),
+				Field:   k8sfield.NewPath("DataVolume").Child("Spec", "Checkpoints").String(),
+			}
+		}
+	}
+	return nil
+}
+
 func (wh *dataVolumeValidatingWebhook) validateSourceRef(request *admissionv1.AdmissionRequest, spec *cdiv1.DataVolumeSpec, field *k8sfield.Path, namespace *string) *metav1.StatusCause {
 	if spec.SourceRef.Kind == "" {
 		return &metav1.StatusCause{
@@ -395,18 +684,21 @@ func (wh *dataVolumeValidatingWebhook) validateSourceRef(request *admissionv1.Ad
 	dataSource, err := wh.cdiClient.CdiV1beta1().DataSources(*ns).Get(context.TODO(), spec.SourceRef.Name, metav1.GetOptions{})
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
-			return &metav1.StatusCause{
-				Type:    metav1.CauseTypeFieldValueNotFound,
-				Message: fmt.Sprintf("SourceRef %s/%s/%s not found", spec.SourceRef.Kind, *ns, spec.SourceRef.Name),
-				Field:   field.Child("sourceRef").String(),
-			}
+			// Admit the DataVolume, the controller flags it with the SourceRefNotReady
+			// condition and starts once the DataSource shows up
+			return nil
 		}
 		return &metav1.StatusCause{
 			Message: err.Error(),
 			Field:   field.Child("sourceRef").String(),
 		}
 	}
-	return wh.validateDataVolumeSourcePVC(dataSource.Spec.Source.PVC, field.Child("sourceRef"), spec)
+	if dataSource.Spec.Source.PVC != nil {
+		return wh.validateDataVolumeSourcePVC(dataSource.Spec.Source.PVC, field.Child("sourceRef"), spec)
+	}
+	// Snapshot sources and DataSources without a source yet are admitted, the
+	// controller waits for the DataSource Ready condition before starting
+	return nil
 }
 
 func (wh *dataVolumeValidatingWebhook) validateDataVolumeSourcePVC(PVC *cdiv1.DataVolumeSourcePVC, field *k8sfield.Path, spec *cdiv1.DataVolumeSpec) *metav1.StatusCause {
@@ -456,6 +748,54 @@ func (wh *dataVolumeValidatingWebhook) validateDataVolumeSourcePVC(PVC *cdiv1.Da
 	return nil
 }
 
+// validatePvcAgainstStorageProfile rejects explicit PVC specs the target storage profile
+// can obviously not satisfy, so users get an actionable message at admission time instead
+// of a PVC that never binds
+func (wh *dataVolumeValidatingWebhook) validatePvcAgainstStorageProfile(pvcSpec *v1.PersistentVolumeClaimSpec, field *k8sfield.Path) *metav1.StatusCause {
+	if pvcSpec.StorageClassName == nil || *pvcSpec.StorageClassName == "" || len(pvcSpec.AccessModes) == 0 {
+		return nil
+	}
+	storageProfile, err := wh.cdiClient.CdiV1beta1().StorageProfiles().Get(context.TODO(), *pvcSpec.StorageClassName, metav1.GetOptions{})
+	if err != nil {
+		// The profile may not exist yet, the controller will resolve the claim later
+		return nil
+	}
+	if len(storageProfile.Status.ClaimPropertySets) == 0 {
+		// Incomplete profile, nothing to validate against
+		return nil
+	}
+	accessMode := pvcSpec.AccessModes[0]
+	supportedPairs := []string{}
+	for _, cps := range storageProfile.Status.ClaimPropertySets {
+		modeMatch := false
+		for _, mode := range cps.AccessModes {
+			if mode == accessMode {
+				modeMatch = true
+			}
+		}
+		if modeMatch && (pvcSpec.VolumeMode == nil || cps.VolumeMode == nil || *cps.VolumeMode == *pvcSpec.VolumeMode) {
+			return nil
+		}
+		volumeMode := "<any>"
+		if cps.VolumeMode != nil {
+			volumeMode = string(*cps.VolumeMode)
+		}
+		for _, mode := range cps.AccessModes {
+			supportedPairs = append(supportedPairs, fmt.Sprintf("%s/%s", mode, volumeMode))
+		}
+	}
+	volumeMode := "<any>"
+	if pvcSpec.VolumeMode != nil {
+		volumeMode = string(*pvcSpec.VolumeMode)
+	}
+	return &metav1.StatusCause{
+		Type: metav1.CauseTypeFieldValueInvalid,
+		Message: fmt.Sprintf("Requested accessMode/volumeMode combination %s/%s is not supported by StorageProfile %s, supported combinations: %s",
+			accessMode, volumeMode, storageProfile.Name, strings.Join(supportedPairs, ", ")),
+		Field: field.Child("accessModes").String(),
+	}
+}
+
 func validateStorageSize(resources v1.ResourceRequirements, field *k8sfield.Path, name string) (*metav1.StatusCause, bool) {
 	if pvcSize, ok := resources.Requests["storage"]; ok {
 		if pvcSize.IsZero() || pvcSize.Value() < 0 {
@@ -498,23 +838,17 @@ func (wh *dataVolumeValidatingWebhook) Admit(ar admissionv1.AdmissionReview) *ad
 			return toAdmissionResponseError(err)
 		}
 
-		// Always admit checkpoint updates for multi-stage migrations.
-		multiStageAdmitted := false
-		isMultiStage := dv.Spec.Source != nil && len(dv.Spec.Checkpoints) > 0 &&
-			(dv.Spec.Source.VDDK != nil || dv.Spec.Source.Imageio != nil)
-		if isMultiStage {
-			oldSpec := oldDV.Spec.DeepCopy()
-			oldSpec.FinalCheckpoint = false
-			oldSpec.Checkpoints = nil
-
-			newSpec := dv.Spec.DeepCopy()
-			newSpec.FinalCheckpoint = false
-			newSpec.Checkpoints = nil
+		// The spec is immutable except for an explicit carve-out: warm
+		// migrations append spec.checkpoints and flip spec.finalCheckpoint.
+		oldSpec := oldDV.Spec.DeepCopy()
+		oldSpec.FinalCheckpoint = false
+		oldSpec.Checkpoints = nil
 
-			multiStageAdmitted = apiequality.Semantic.DeepEqual(newSpec, oldSpec)
-		}
+		newSpec := dv.Spec.DeepCopy()
+		newSpec.FinalCheckpoint = false
+		newSpec.Checkpoints = nil
 
-		if !multiStageAdmitted && !apiequality.Semantic.DeepEqual(dv.Spec, oldDV.Spec) {
+		if !apiequality.Semantic.DeepEqual(newSpec, oldSpec) {
 			klog.Errorf("Cannot update spec for DataVolume %s/%s", dv.GetNamespace(), dv.GetName())
 			var causes []metav1.StatusCause
 			causes = append(causes, metav1.StatusCause{
@@ -524,6 +858,11 @@ func (wh *dataVolumeValidatingWebhook) Admit(ar admissionv1.AdmissionReview) *ad
 			})
 			return toRejectedAdmissionResponse(causes)
 		}
+
+		if cause := validateCheckpointUpdate(&oldDV.Spec, &dv.Spec); cause != nil {
+			klog.Errorf("Cannot update checkpoints for DataVolume %s/%s: %s", dv.GetNamespace(), dv.GetName(), cause.Message)
+			return toRejectedAdmissionResponse([]metav1.StatusCause{*cause})
+		}
 	}
 
 	causes := validateNameLength(dv.Name, kvalidation.DNS1123SubdomainMaxLength)
@@ -567,7 +906,153 @@ func (wh *dataVolumeValidatingWebhook) Admit(ar admissionv1.AdmissionReview) *ad
 		return toRejectedAdmissionResponse(causes)
 	}
 
+	if ar.Request.Operation == admissionv1.Create {
+		if cause := wh.validateCDIQuota(&dv); cause != nil {
+			klog.Infof("rejected DataVolume admission %s", cause.Message)
+			return toRejectedAdmissionResponse([]metav1.StatusCause{*cause})
+		}
+	}
+
 	reviewResponse := admissionv1.AdmissionResponse{}
 	reviewResponse.Allowed = true
+	if ar.Request.Operation == admissionv1.Create {
+		reviewResponse.Warnings = wh.admissionWarnings(&dv)
+	}
 	return &reviewResponse
 }
+
+// admissionWarnings collects non-fatal warnings about a DataVolume that will be
+// admitted but may not behave the way the user expects
+func (wh *dataVolumeValidatingWebhook) admissionWarnings(dv *cdiv1.DataVolume) []string {
+	var warnings []string
+	warnings = append(warnings, wh.accessModeWarnings(dv)...)
+	warnings = append(warnings, wh.cloneFallbackWarnings(dv)...)
+	warnings = append(warnings, wh.storageProfileWarnings(dv)...)
+	warnings = append(warnings, preallocationWarnings(dv)...)
+	return warnings
+}
+
+// validateCDIQuota enforces the namespace CDIQuota limits that ResourceQuota cannot express,
+// based on the usage tracked in the quota status by the CDIQuota controller
+func (wh *dataVolumeValidatingWebhook) validateCDIQuota(dv *cdiv1.DataVolume) *metav1.StatusCause {
+	quotas, err := wh.cdiClient.CdiV1beta1().CDIQuotas(dv.Namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return &metav1.StatusCause{
+			Message: err.Error(),
+		}
+	}
+	for i := range quotas.Items {
+		quota := &quotas.Items[i]
+		if max := quota.Spec.MaxConcurrentTransfers; max != nil && quota.Status.CurrentTransfers >= *max {
+			return &metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("CDIQuota %s exceeded: %d transfers already running, at most %d allowed", quota.Name, quota.Status.CurrentTransfers, *max),
+			}
+		}
+		if max := quota.Spec.MaxImportedBytesPerDay; max != nil && controller.DataVolumeIsImport(dv) {
+			imported := controller.ImportedBytesToday(quota)
+			if imported.Cmp(*max) >= 0 {
+				return &metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("CDIQuota %s exceeded: %s already imported today, at most %s allowed per day", quota.Name, imported.String(), max.String()),
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// accessModeWarnings returns admission warnings when the DataVolume requests ReadWriteMany
+// access but the target storage profile only supports other access modes, so users learn
+// about the degradation instead of discovering it when live migration fails
+func (wh *dataVolumeValidatingWebhook) accessModeWarnings(dv *cdiv1.DataVolume) []string {
+	var storageClassName *string
+	var accessModes []v1.PersistentVolumeAccessMode
+	if dv.Spec.PVC != nil {
+		storageClassName = dv.Spec.PVC.StorageClassName
+		accessModes = dv.Spec.PVC.AccessModes
+	} else if dv.Spec.Storage != nil {
+		storageClassName = dv.Spec.Storage.StorageClassName
+		accessModes = dv.Spec.Storage.AccessModes
+	}
+	if storageClassName == nil || *storageClassName == "" {
+		return nil
+	}
+	requestsRWX := false
+	for _, mode := range accessModes {
+		if mode == v1.ReadWriteMany {
+			requestsRWX = true
+		}
+	}
+	if !requestsRWX {
+		return nil
+	}
+	storageProfile, err := wh.cdiClient.CdiV1beta1().StorageProfiles().Get(context.TODO(), *storageClassName, metav1.GetOptions{})
+	if err != nil {
+		// The profile may not exist yet, nothing to warn about
+		return nil
+	}
+	if len(storageProfile.Status.ClaimPropertySets) == 0 ||
+		controller.StorageProfileSupportsAccessMode(storageProfile, v1.ReadWriteMany) {
+		return nil
+	}
+	return []string{fmt.Sprintf("DataVolume requests ReadWriteMany access, but StorageProfile %s only supports other access modes; the volume will not support live migration", storageProfile.Name)}
+}
+
+// cloneFallbackWarnings warns when a PVC clone cannot use smart clone and will
+// fall back to the slower host-assisted copy
+func (wh *dataVolumeValidatingWebhook) cloneFallbackWarnings(dv *cdiv1.DataVolume) []string {
+	if dv.Spec.Source == nil || dv.Spec.Source.PVC == nil {
+		return nil
+	}
+	sourceNamespace := dv.Spec.Source.PVC.Namespace
+	if sourceNamespace == "" {
+		sourceNamespace = dv.Namespace
+	}
+	sourcePvc, err := wh.k8sClient.CoreV1().PersistentVolumeClaims(sourceNamespace).Get(context.TODO(), dv.Spec.Source.PVC.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil
+	}
+	var targetStorageClassName *string
+	if dv.Spec.PVC != nil {
+		targetStorageClassName = dv.Spec.PVC.StorageClassName
+	} else if dv.Spec.Storage != nil {
+		targetStorageClassName = dv.Spec.Storage.StorageClassName
+	}
+	sourceStorageClassName := sourcePvc.Spec.StorageClassName
+	if sourceStorageClassName == nil || targetStorageClassName == nil || *sourceStorageClassName == *targetStorageClassName {
+		return nil
+	}
+	return []string{fmt.Sprintf("Source PVC %s/%s uses storage class %s but the DataVolume requests %s; smart clone is not possible and the clone will fall back to a host-assisted copy", sourceNamespace, sourcePvc.Name, *sourceStorageClassName, *targetStorageClassName)}
+}
+
+// storageProfileWarnings warns when the DataVolume relies on the StorageProfile
+// to fill in access and volume modes but the profile is incomplete
+func (wh *dataVolumeValidatingWebhook) storageProfileWarnings(dv *cdiv1.DataVolume) []string {
+	if dv.Spec.Storage == nil || len(dv.Spec.Storage.AccessModes) > 0 {
+		return nil
+	}
+	storageClassName := dv.Spec.Storage.StorageClassName
+	if storageClassName == nil || *storageClassName == "" {
+		return nil
+	}
+	storageProfile, err := wh.cdiClient.CdiV1beta1().StorageProfiles().Get(context.TODO(), *storageClassName, metav1.GetOptions{})
+	if err != nil {
+		return nil
+	}
+	if len(storageProfile.Status.ClaimPropertySets) > 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf("StorageProfile %s is incomplete; CDI cannot infer access and volume modes and will apply the kubernetes defaults", storageProfile.Name)}
+}
+
+// preallocationWarnings warns when a requested preallocation has no effect
+func preallocationWarnings(dv *cdiv1.DataVolume) []string {
+	if dv.Spec.Preallocation == nil || !*dv.Spec.Preallocation {
+		return nil
+	}
+	if dv.Spec.Source != nil && dv.Spec.Source.PVC != nil {
+		return []string{"Preallocation is not applied to cloned DataVolumes and will be ignored"}
+	}
+	return nil
+}
@@ -41,6 +41,7 @@ import (
 	cdiclientfake "kubevirt.io/containerized-data-importer/pkg/client/clientset/versioned/fake"
 
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
 )
 
 var (
@@ -153,6 +154,135 @@ var _ = Describe("Validating Webhook", func() {
 			Expect(resp.Allowed).To(Equal(true))
 		})
 
+		It("should warn when DataVolume requests ReadWriteMany but storage profile only supports ReadWriteOnce", func() {
+			scName := "testSC"
+			volumeMode := corev1.PersistentVolumeFilesystem
+			dataVolume := newHTTPDataVolume("testDV", "http://www.example.com")
+			dataVolume.Spec.Storage = &cdiv1.StorageSpec{
+				StorageClassName: &scName,
+				AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+				Resources:        dataVolume.Spec.PVC.Resources,
+			}
+			dataVolume.Spec.PVC = nil
+			storageProfile := &cdiv1.StorageProfile{
+				ObjectMeta: metav1.ObjectMeta{Name: scName},
+				Status: cdiv1.StorageProfileStatus{
+					ClaimPropertySets: []cdiv1.ClaimPropertySet{
+						{AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}, VolumeMode: &volumeMode},
+					},
+				},
+			}
+			resp := validateDataVolumeCreateEx(dataVolume, nil, []runtime.Object{storageProfile})
+			Expect(resp.Allowed).To(Equal(true))
+			Expect(resp.Warnings).To(HaveLen(1))
+			Expect(resp.Warnings[0]).To(ContainSubstring("ReadWriteMany"))
+		})
+
+		It("should reject DataVolume with explicit PVC spec the storage profile cannot satisfy", func() {
+			scName := "testSC"
+			blockMode := corev1.PersistentVolumeBlock
+			fsMode := corev1.PersistentVolumeFilesystem
+			dataVolume := newHTTPDataVolume("testDV", "http://www.example.com")
+			dataVolume.Spec.PVC.StorageClassName = &scName
+			dataVolume.Spec.PVC.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}
+			dataVolume.Spec.PVC.VolumeMode = &blockMode
+			storageProfile := &cdiv1.StorageProfile{
+				ObjectMeta: metav1.ObjectMeta{Name: scName},
+				Status: cdiv1.StorageProfileStatus{
+					ClaimPropertySets: []cdiv1.ClaimPropertySet{
+						{AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}, VolumeMode: &fsMode},
+					},
+				},
+			}
+			resp := validateDataVolumeCreateEx(dataVolume, nil, []runtime.Object{storageProfile})
+			Expect(resp.Allowed).To(Equal(false))
+			Expect(resp.Result.Details.Causes[0].Message).To(ContainSubstring("not supported by StorageProfile"))
+		})
+
+		It("should accept DataVolume with explicit PVC spec when the storage profile is incomplete", func() {
+			scName := "testSC"
+			dataVolume := newHTTPDataVolume("testDV", "http://www.example.com")
+			dataVolume.Spec.PVC.StorageClassName = &scName
+			dataVolume.Spec.PVC.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}
+			storageProfile := &cdiv1.StorageProfile{
+				ObjectMeta: metav1.ObjectMeta{Name: scName},
+			}
+			resp := validateDataVolumeCreateEx(dataVolume, nil, []runtime.Object{storageProfile})
+			Expect(resp.Allowed).To(Equal(true))
+		})
+
+		It("should not warn when DataVolume requests ReadWriteMany and storage profile supports it", func() {
+			scName := "testSC"
+			volumeMode := corev1.PersistentVolumeBlock
+			dataVolume := newHTTPDataVolume("testDV", "http://www.example.com")
+			dataVolume.Spec.PVC.StorageClassName = &scName
+			dataVolume.Spec.PVC.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}
+			storageProfile := &cdiv1.StorageProfile{
+				ObjectMeta: metav1.ObjectMeta{Name: scName},
+				Status: cdiv1.StorageProfileStatus{
+					ClaimPropertySets: []cdiv1.ClaimPropertySet{
+						{AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}, VolumeMode: &volumeMode},
+					},
+				},
+			}
+			resp := validateDataVolumeCreateEx(dataVolume, nil, []runtime.Object{storageProfile})
+			Expect(resp.Allowed).To(Equal(true))
+			Expect(resp.Warnings).To(BeEmpty())
+		})
+
+		It("should warn when the DataVolume storage profile is incomplete", func() {
+			scName := "testSC"
+			dataVolume := newHTTPDataVolume("testDV", "http://www.example.com")
+			dataVolume.Spec.Storage = &cdiv1.StorageSpec{
+				StorageClassName: &scName,
+				Resources:        dataVolume.Spec.PVC.Resources,
+			}
+			dataVolume.Spec.PVC = nil
+			storageProfile := &cdiv1.StorageProfile{
+				ObjectMeta: metav1.ObjectMeta{Name: scName},
+			}
+			resp := validateDataVolumeCreateEx(dataVolume, nil, []runtime.Object{storageProfile})
+			Expect(resp.Allowed).To(Equal(true))
+			Expect(resp.Warnings).To(HaveLen(1))
+			Expect(resp.Warnings[0]).To(ContainSubstring("incomplete"))
+		})
+
+		It("should warn when a clone falls back to host-assisted copy", func() {
+			sourceSC := "sourceSC"
+			targetSC := "targetSC"
+			dataVolume := newPVCDataVolume("testDV", "testNamespace", "test")
+			dataVolume.Spec.PVC.StorageClassName = &targetSC
+			pvc := &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      dataVolume.Spec.Source.PVC.Name,
+					Namespace: dataVolume.Spec.Source.PVC.Namespace,
+				},
+				Spec: *dataVolume.Spec.PVC.DeepCopy(),
+			}
+			pvc.Spec.StorageClassName = &sourceSC
+			resp := validateDataVolumeCreate(dataVolume, pvc)
+			Expect(resp.Allowed).To(Equal(true))
+			Expect(resp.Warnings).To(HaveLen(1))
+			Expect(resp.Warnings[0]).To(ContainSubstring("host-assisted"))
+		})
+
+		It("should warn when preallocation is requested for a clone", func() {
+			preallocation := true
+			dataVolume := newPVCDataVolume("testDV", "testNamespace", "test")
+			dataVolume.Spec.Preallocation = &preallocation
+			pvc := &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      dataVolume.Spec.Source.PVC.Name,
+					Namespace: dataVolume.Spec.Source.PVC.Namespace,
+				},
+				Spec: *dataVolume.Spec.PVC,
+			}
+			resp := validateDataVolumeCreate(dataVolume, pvc)
+			Expect(resp.Allowed).To(Equal(true))
+			Expect(resp.Warnings).To(HaveLen(1))
+			Expect(resp.Warnings[0]).To(ContainSubstring("Preallocation"))
+		})
+
 		It("should accept DataVolume with PVC source on create", func() {
 			dataVolume := newPVCDataVolume("testDV", "testNamespace", "test")
 			pvc := &corev1.PersistentVolumeClaim{
@@ -217,6 +347,77 @@ var _ = Describe("Validating Webhook", func() {
 			Expect(resp.Allowed).To(Equal(false))
 		})
 
+		It("should accept DataVolume with GCS source on create", func() {
+			dataVolume := newGCSDataVolume("testDV", "gs://bucket-1/object-1")
+			resp := validateDataVolumeCreate(dataVolume)
+			Expect(resp.Allowed).To(Equal(true))
+		})
+
+		It("should reject DataVolume with GCS source without the gs scheme on create", func() {
+			dataVolume := newGCSDataVolume("testDV", "http://bucket-1/object-1")
+			resp := validateDataVolumeCreate(dataVolume)
+			Expect(resp.Allowed).To(Equal(false))
+		})
+
+		It("should accept DataVolume with OVA source on create", func() {
+			dataVolume := newOVADataVolume("testDV", "https://example.com/appliance.ova")
+			resp := validateDataVolumeCreate(dataVolume)
+			Expect(resp.Allowed).To(Equal(true))
+		})
+
+		It("should accept DataVolume with s3 backed OVA source on create", func() {
+			dataVolume := newOVADataVolume("testDV", "s3://s3.amazonaws.com/bucket-1/appliance.ova")
+			resp := validateDataVolumeCreate(dataVolume)
+			Expect(resp.Allowed).To(Equal(true))
+		})
+
+		It("should reject DataVolume with OVA source with an invalid scheme on create", func() {
+			dataVolume := newOVADataVolume("testDV", "ftp://example.com/appliance.ova")
+			resp := validateDataVolumeCreate(dataVolume)
+			Expect(resp.Allowed).To(Equal(false))
+		})
+
+		It("should accept DataVolume with valid VDDK source on create", func() {
+			dataVolume := newDataVolume("testDV", *vddkSource(), newPVCSpec(pvcSizeDefault))
+			resp := validateDataVolumeCreate(dataVolume)
+			Expect(resp.Allowed).To(Equal(true))
+		})
+
+		It("should reject DataVolume with VDDK source missing required fields on create", func() {
+			dataVolume := newDataVolume("testDV", *vddkSource(), newPVCSpec(pvcSizeDefault))
+			dataVolume.Spec.Source.VDDK.Thumbprint = ""
+			dataVolume.Spec.Source.VDDK.UUID = ""
+			resp := validateDataVolumeCreate(dataVolume)
+			Expect(resp.Allowed).To(Equal(false))
+			Expect(resp.Result.Details.Causes).To(HaveLen(2))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.source.VDDK.uuid"))
+			Expect(resp.Result.Details.Causes[1].Field).To(Equal("spec.source.VDDK.thumbprint"))
+		})
+
+		It("should reject DataVolume with unparseable VDDK init image on create", func() {
+			dataVolume := newDataVolume("testDV", *vddkSource(), newPVCSpec(pvcSizeDefault))
+			dataVolume.Spec.Source.VDDK.InitImageURL = "registry:5000/vddk:latest:extra"
+			resp := validateDataVolumeCreate(dataVolume)
+			Expect(resp.Allowed).To(Equal(false))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.source.VDDK.initImageURL"))
+		})
+
+		It("should accept DataVolume with HTTP source and certSecret on create", func() {
+			dataVolume := newHTTPDataVolume("testDV", "http://www.example.com")
+			dataVolume.Spec.Source.HTTP.CertSecret = "test-secret"
+			resp := validateDataVolumeCreate(dataVolume)
+			Expect(resp.Allowed).To(Equal(true))
+		})
+
+		It("should reject DataVolume with HTTP source and both certConfigMap and certSecret on create", func() {
+			dataVolume := newHTTPDataVolume("testDV", "http://www.example.com")
+			dataVolume.Spec.Source.HTTP.CertConfigMap = "test-configmap"
+			dataVolume.Spec.Source.HTTP.CertSecret = "test-secret"
+			resp := validateDataVolumeCreate(dataVolume)
+			Expect(resp.Allowed).To(Equal(false))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.source.HTTP.certSecret"))
+		})
+
 		It("should reject DataVolume with multiple sources on create", func() {
 			dataVolume := newDataVolumeWithMultipleSources("testDV")
 			resp := validateDataVolumeCreate(dataVolume)
@@ -258,6 +459,46 @@ var _ = Describe("Validating Webhook", func() {
 
 		})
 
+		It("should accept DataVolume with Blank source and ext4 filesystem options", func() {
+			dataVolume := newBlankDataVolume("blank")
+			reservedBlocks := int32(5)
+			dataVolume.Spec.Source.Blank.Filesystem = "ext4"
+			dataVolume.Spec.Source.Blank.Label = "data"
+			dataVolume.Spec.Source.Blank.ReservedBlocksPercentage = &reservedBlocks
+			resp := validateDataVolumeCreate(dataVolume)
+			Expect(resp.Allowed).To(Equal(true))
+		})
+
+		It("should reject DataVolume with Blank source and unsupported filesystem", func() {
+			dataVolume := newBlankDataVolume("blank")
+			dataVolume.Spec.Source.Blank.Filesystem = "btrfs"
+			resp := validateDataVolumeCreate(dataVolume)
+			Expect(resp.Allowed).To(Equal(false))
+		})
+
+		It("should reject DataVolume with Blank source and reserved blocks on xfs", func() {
+			dataVolume := newBlankDataVolume("blank")
+			reservedBlocks := int32(5)
+			dataVolume.Spec.Source.Blank.Filesystem = "xfs"
+			dataVolume.Spec.Source.Blank.ReservedBlocksPercentage = &reservedBlocks
+			resp := validateDataVolumeCreate(dataVolume)
+			Expect(resp.Allowed).To(Equal(false))
+		})
+
+		It("should accept DataVolume with wipe overwritePolicy", func() {
+			dataVolume := newHTTPDataVolume("testDV", "http://www.example.com")
+			dataVolume.Spec.OverwritePolicy = cdiv1.OverwritePolicyWipe
+			resp := validateDataVolumeCreate(dataVolume)
+			Expect(resp.Allowed).To(Equal(true))
+		})
+
+		It("should reject DataVolume with invalid overwritePolicy", func() {
+			dataVolume := newHTTPDataVolume("testDV", "http://www.example.com")
+			dataVolume.Spec.OverwritePolicy = "merge"
+			resp := validateDataVolumeCreate(dataVolume)
+			Expect(resp.Allowed).To(Equal(false))
+		})
+
 		It("should reject DataVolume with invalid contentType", func() {
 			dataVolume := newHTTPDataVolume("testDV", "http://www.example.com")
 			dataVolume.Spec.ContentType = "invalid"
@@ -441,9 +682,67 @@ var _ = Describe("Validating Webhook", func() {
 			Entry("accept a spec change on multi-stage ImageIO import fields", false, []string{"snapshot-123"}, true, []string{"snapshot-123", "snapshot-234"}, nil, true, imageIOSource),
 
 			Entry("reject a spec change on source type that does not support multi-stage import", false, []string{}, true, []string{}, nil, false, blankSource),
+
+			Entry("accept flipping finalCheckpoint without new checkpoints", false, []string{"stage-1"}, true, []string{"stage-1"}, nil, true, vddkSource),
+
+			Entry("reject removing previous checkpoints", false, []string{"stage-1", "stage-2"}, false, []string{"stage-1"}, nil, false, vddkSource),
+
+			Entry("reject rewriting previous checkpoints", false, []string{"stage-1", "stage-2"}, false, []string{"stage-1", "stage-3"}, nil, false, vddkSource),
 		)
 	})
 
+	Context("with restricted egress configured", func() {
+		newRestrictedEgressConfig := func(restricted *cdiv1.RestrictedEgress) *cdiv1.CDIConfig {
+			return &cdiv1.CDIConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: common.ConfigName,
+				},
+				Spec: cdiv1.CDIConfigSpec{
+					RestrictedEgress: restricted,
+				},
+			}
+		}
+
+		It("should reject DataVolume with HTTP source outside the allowlist", func() {
+			config := newRestrictedEgressConfig(&cdiv1.RestrictedEgress{
+				Enabled:      true,
+				AllowedHosts: []string{"mirror.internal.example.com"},
+			})
+			dataVolume := newHTTPDataVolume("testDV", "http://www.example.com")
+			resp := validateDataVolumeCreateEx(dataVolume, nil, []runtime.Object{config})
+			Expect(resp.Allowed).To(Equal(false))
+		})
+
+		It("should accept DataVolume with HTTP source matching a wildcard allowlist entry", func() {
+			config := newRestrictedEgressConfig(&cdiv1.RestrictedEgress{
+				Enabled:      true,
+				AllowedHosts: []string{"*.example.com"},
+			})
+			dataVolume := newHTTPDataVolume("testDV", "http://www.example.com")
+			resp := validateDataVolumeCreateEx(dataVolume, nil, []runtime.Object{config})
+			Expect(resp.Allowed).To(Equal(true))
+		})
+
+		It("should reject DataVolume with Registry source outside the allowed registries", func() {
+			config := newRestrictedEgressConfig(&cdiv1.RestrictedEgress{
+				Enabled:           true,
+				AllowedRegistries: []string{"registry.internal.example.com"},
+			})
+			dataVolume := newRegistryDataVolume("testDV", "docker://registry:5000/test")
+			resp := validateDataVolumeCreateEx(dataVolume, nil, []runtime.Object{config})
+			Expect(resp.Allowed).To(Equal(false))
+		})
+
+		It("should not restrict sources when disabled", func() {
+			config := newRestrictedEgressConfig(&cdiv1.RestrictedEgress{
+				AllowedHosts: []string{"mirror.internal.example.com"},
+			})
+			dataVolume := newHTTPDataVolume("testDV", "http://www.example.com")
+			resp := validateDataVolumeCreateEx(dataVolume, nil, []runtime.Object{config})
+			Expect(resp.Allowed).To(Equal(true))
+		})
+	})
+
 	Context("with DataVolume (using sourceRef) admission review", func() {
 		DescribeTable("should", func(dataSourceNamespace *string) {
 			pvcName := "testPVC"
@@ -478,11 +777,11 @@ var _ = Describe("Validating Webhook", func() {
 			Entry("accept DataVolume with PVC and sourceRef missing namespace on create", &emptyNamespace),
 		)
 
-		It("should reject DataVolume with SourceRef on create if DataSource does not exist", func() {
+		It("should accept DataVolume with SourceRef on create if DataSource does not exist, the controller waits for it", func() {
 			ns := "testNamespace"
 			dataVolume := newDataSourceDataVolume("testDV", &ns, "test")
 			resp := validateDataVolumeCreate(dataVolume)
-			Expect(resp.Allowed).To(Equal(false))
+			Expect(resp.Allowed).To(Equal(true))
 		})
 
 		It("should reject DataVolume with SourceRef on create if DataSource exists but PVC does not exist", func() {
@@ -597,6 +896,22 @@ func newHTTPDataVolume(name, url string) *cdiv1.DataVolume {
 	return newDataVolume(name, httpSource, pvc)
 }
 
+func newGCSDataVolume(name, url string) *cdiv1.DataVolume {
+	gcsSource := cdiv1.DataVolumeSource{
+		GCS: &cdiv1.DataVolumeSourceGCS{URL: url},
+	}
+	pvc := newPVCSpec(pvcSizeDefault)
+	return newDataVolume(name, gcsSource, pvc)
+}
+
+func newOVADataVolume(name, url string) *cdiv1.DataVolume {
+	ovaSource := cdiv1.DataVolumeSource{
+		OVA: &cdiv1.DataVolumeSourceOVA{URL: url},
+	}
+	pvc := newPVCSpec(pvcSizeDefault)
+	return newDataVolume(name, ovaSource, pvc)
+}
+
 func newRegistryDataVolume(name, url string) *cdiv1.DataVolume {
 	registrySource := cdiv1.DataVolumeSource{
 		Registry: &cdiv1.DataVolumeSourceRegistry{URL: &url},
@@ -68,7 +68,7 @@ func NewCDIValidatingWebhook(client cdiclient.Interface) http.Handler {
 
 // NewObjectTransferValidatingWebhook creates a new ObjectTransfer validating webhook
 func NewObjectTransferValidatingWebhook(k8sClient kubernetes.Interface, cdiClient cdiclient.Interface) http.Handler {
-	return newAdmissionHandler(&objectTransferValidatingWebhook{k8sClient: k8sClient, cdiClient: cdiClient})
+	return newAdmissionHandler(&objectTransferValidatingWebhook{k8sClient: k8sClient, cdiClient: cdiClient, proxy: &sarProxy{client: k8sClient}})
 }
 
 // NewDataImportCronValidatingWebhook creates a new DataVolumeValidation webhook
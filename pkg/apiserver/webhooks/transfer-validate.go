@@ -25,6 +25,8 @@ import (
 	"fmt"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	authentication "k8s.io/api/authentication/v1"
+	authorization "k8s.io/api/authorization/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -33,11 +35,13 @@ import (
 
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	cdiclient "kubevirt.io/containerized-data-importer/pkg/client/clientset/versioned"
+	"kubevirt.io/containerized-data-importer/pkg/clone"
 )
 
 type objectTransferValidatingWebhook struct {
 	k8sClient kubernetes.Interface
 	cdiClient cdiclient.Interface
+	proxy     clone.SubjectAccessReviewsProxy
 }
 
 func (wh *objectTransferValidatingWebhook) Admit(ar admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
@@ -78,34 +82,146 @@ func (wh *objectTransferValidatingWebhook) Admit(ar admissionv1.AdmissionReview)
 		return toAdmissionResponseError(fmt.Errorf("Target namespace and/or target name must be supplied"))
 	}
 
-	var err error
-	ns := obj.Spec.Source.Namespace
-	name := obj.Spec.Source.Name
+	sourceNamespace := obj.Spec.Source.Namespace
+	sourceName := obj.Spec.Source.Name
+	targetNamespace := sourceNamespace
+	targetName := sourceName
 
 	if obj.Spec.Target.Namespace != nil {
-		ns = *obj.Spec.Target.Namespace
+		targetNamespace = *obj.Spec.Target.Namespace
 	}
 
 	if obj.Spec.Target.Name != nil {
-		name = *obj.Spec.Target.Name
+		targetName = *obj.Spec.Target.Name
 	}
 
-	switch obj.Spec.Source.Kind {
-	case "DataVolume":
-		_, err = wh.cdiClient.CdiV1beta1().DataVolumes(ns).Get(context.TODO(), name, metav1.GetOptions{})
-	case "PersistentVolumeClaim":
-		_, err = wh.k8sClient.CoreV1().PersistentVolumeClaims(ns).Get(context.TODO(), name, metav1.GetOptions{})
-	default:
-		return toAdmissionResponseError(fmt.Errorf("Unsupported kind %q", obj.Spec.Source.Kind))
+	err := wh.getTransferObject(obj.Spec.Source.Kind, sourceNamespace, sourceName)
+	if err != nil {
+		if unsupportedKind(err) {
+			return toAdmissionResponseError(err)
+		}
+		if errors.IsNotFound(err) {
+			return toAdmissionResponseError(fmt.Errorf("ObjectTransfer source \"%s/%s\" does not exist", sourceNamespace, sourceName))
+		}
+		return toAdmissionResponseError(err)
+	}
+
+	if obj.Spec.Target.Namespace != nil {
+		if _, err := wh.k8sClient.CoreV1().Namespaces().Get(context.TODO(), targetNamespace, metav1.GetOptions{}); err != nil {
+			if errors.IsNotFound(err) {
+				return toAdmissionResponseError(fmt.Errorf("ObjectTransfer target namespace %q does not exist", targetNamespace))
+			}
+			return toAdmissionResponseError(err)
+		}
 	}
 
+	err = wh.getTransferObject(obj.Spec.Source.Kind, targetNamespace, targetName)
 	if err == nil {
-		return toAdmissionResponseError(fmt.Errorf("ObjectTransfer target \"%s/%s\" already exists", ns, name))
+		return toAdmissionResponseError(fmt.Errorf("ObjectTransfer target \"%s/%s\" already exists", targetNamespace, targetName))
 	}
 
 	if !errors.IsNotFound(err) {
 		return toAdmissionResponseError(err)
 	}
 
+	ok, reason, err := wh.canUserTransfer(obj, sourceNamespace, targetNamespace, ar.Request.UserInfo)
+	if err != nil {
+		return toAdmissionResponseError(err)
+	}
+
+	if !ok {
+		return toAdmissionResponseError(fmt.Errorf(reason))
+	}
+
 	return allowedAdmissionResponse()
 }
+
+type errUnsupportedKind struct {
+	kind string
+}
+
+func (e errUnsupportedKind) Error() string {
+	return fmt.Sprintf("Unsupported kind %q", e.kind)
+}
+
+func unsupportedKind(err error) bool {
+	_, ok := err.(errUnsupportedKind)
+	return ok
+}
+
+func (wh *objectTransferValidatingWebhook) getTransferObject(kind, namespace, name string) error {
+	var err error
+	switch kind {
+	case "DataVolume":
+		_, err = wh.cdiClient.CdiV1beta1().DataVolumes(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	case "PersistentVolumeClaim":
+		_, err = wh.k8sClient.CoreV1().PersistentVolumeClaims(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	default:
+		err = errUnsupportedKind{kind: kind}
+	}
+	return err
+}
+
+// canUserTransfer checks that the requester may remove the object from the source
+// namespace and create it in the target namespace
+func (wh *objectTransferValidatingWebhook) canUserTransfer(obj *cdiv1.ObjectTransfer, sourceNamespace, targetNamespace string, userInfo authentication.UserInfo) (bool, string, error) {
+	if sourceNamespace == targetNamespace {
+		return true, "", nil
+	}
+
+	var newExtra map[string]authorization.ExtraValue
+	if len(userInfo.Extra) > 0 {
+		newExtra = make(map[string]authorization.ExtraValue)
+		for k, v := range userInfo.Extra {
+			newExtra[k] = authorization.ExtraValue(v)
+		}
+	}
+
+	group := ""
+	resource := "persistentvolumeclaims"
+	if obj.Spec.Source.Kind == "DataVolume" {
+		group = cdiv1.SchemeGroupVersion.Group
+		resource = "datavolumes"
+	}
+
+	for _, ra := range []authorization.ResourceAttributes{
+		{
+			Namespace: sourceNamespace,
+			Verb:      "delete",
+			Group:     group,
+			Resource:  resource,
+			Name:      obj.Spec.Source.Name,
+		},
+		{
+			Namespace: targetNamespace,
+			Verb:      "create",
+			Group:     group,
+			Resource:  resource,
+		},
+	} {
+		ra := ra
+		sar := &authorization.SubjectAccessReview{
+			Spec: authorization.SubjectAccessReviewSpec{
+				User:               userInfo.Username,
+				Groups:             userInfo.Groups,
+				Extra:              newExtra,
+				ResourceAttributes: &ra,
+			},
+		}
+
+		klog.V(3).Infof("Sending SubjectAccessReview %+v", sar)
+
+		response, err := wh.proxy.Create(sar)
+		if err != nil {
+			return false, "", err
+		}
+
+		klog.V(3).Infof("SubjectAccessReview response %+v", response)
+
+		if !response.Status.Allowed {
+			return false, fmt.Sprintf("User %s has insufficient permissions to %s %s in namespace %s", userInfo.Username, ra.Verb, ra.Resource, ra.Namespace), nil
+		}
+	}
+
+	return true, "", nil
+}
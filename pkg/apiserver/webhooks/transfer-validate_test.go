@@ -27,10 +27,12 @@ import (
 	. "github.com/onsi/gomega"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	authorization "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	k8sclient "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	cdiclient "kubevirt.io/containerized-data-importer/pkg/client/clientset/versioned/fake"
@@ -183,37 +185,88 @@ var _ = Describe("ObjectTransfer webhook", func() {
 			Expect(resp.Allowed).To(BeFalse())
 			Expect(resp.Result.Message).To(ContainSubstring("ObjectTransfer target \"foo/source\" already exists"))
 		},
-			Entry("DV", "DataVolume", nil, []runtime.Object{&cdiv1.DataVolume{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "source",
-					Namespace: "foo",
+			Entry("DV", "DataVolume", []runtime.Object{targetNamespace()}, []runtime.Object{
+				&cdiv1.DataVolume{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "source",
+						Namespace: "ns",
+					},
 				},
-			}}),
-			Entry("PVC", "PersistentVolumeClaim", []runtime.Object{&corev1.PersistentVolumeClaim{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "source",
-					Namespace: "foo",
+				&cdiv1.DataVolume{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "source",
+						Namespace: "foo",
+					},
+				},
+			}),
+			Entry("PVC", "PersistentVolumeClaim", []runtime.Object{
+				targetNamespace(),
+				&corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "source",
+						Namespace: "ns",
+					},
+				},
+				&corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "source",
+						Namespace: "foo",
+					},
 				},
-			}}, nil),
+			}, nil),
 		)
 
-		It("Should accept good stuff", func() {
-			ot := &cdiv1.ObjectTransfer{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: "ot",
+		It("Should reject missing source", func() {
+			ot := newDataVolumeTransfer()
+
+			bytes, _ := json.Marshal(ot)
+
+			ar := &admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Resource: metav1.GroupVersionResource{
+						Group:    cdiv1.SchemeGroupVersion.Group,
+						Version:  cdiv1.SchemeGroupVersion.Version,
+						Resource: "objecttransfers",
+					},
+					Object: runtime.RawExtension{
+						Raw: bytes,
+					},
 				},
-				Spec: cdiv1.ObjectTransferSpec{
-					Source: cdiv1.TransferSource{
-						Kind:      "DataVolume",
-						Name:      "dv",
-						Namespace: "ns",
+			}
+
+			resp := validateObjectTransfers(ar, []runtime.Object{targetNamespace()}, nil)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).To(ContainSubstring("ObjectTransfer source \"ns/dv\" does not exist"))
+		})
+
+		It("Should reject missing target namespace", func() {
+			ot := newDataVolumeTransfer()
+
+			bytes, _ := json.Marshal(ot)
+
+			ar := &admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Resource: metav1.GroupVersionResource{
+						Group:    cdiv1.SchemeGroupVersion.Group,
+						Version:  cdiv1.SchemeGroupVersion.Version,
+						Resource: "objecttransfers",
 					},
-					Target: cdiv1.TransferTarget{
-						Namespace: &[]string{"foo"}[0],
+					Object: runtime.RawExtension{
+						Raw: bytes,
 					},
 				},
 			}
 
+			resp := validateObjectTransfers(ar, nil, []runtime.Object{sourceDataVolume()})
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).To(ContainSubstring("ObjectTransfer target namespace \"foo\" does not exist"))
+		})
+
+		It("Should reject unauthorized requester", func() {
+			ot := newDataVolumeTransfer()
+
 			bytes, _ := json.Marshal(ot)
 
 			ar := &admissionv1.AdmissionReview{
@@ -230,7 +283,31 @@ var _ = Describe("ObjectTransfer webhook", func() {
 				},
 			}
 
-			resp := validateObjectTransfers(ar, nil, nil)
+			resp := validateObjectTransfersEx(ar, false, []runtime.Object{targetNamespace()}, []runtime.Object{sourceDataVolume()})
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).To(ContainSubstring("insufficient permissions"))
+		})
+
+		It("Should accept good stuff", func() {
+			ot := newDataVolumeTransfer()
+
+			bytes, _ := json.Marshal(ot)
+
+			ar := &admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Resource: metav1.GroupVersionResource{
+						Group:    cdiv1.SchemeGroupVersion.Group,
+						Version:  cdiv1.SchemeGroupVersion.Version,
+						Resource: "objecttransfers",
+					},
+					Object: runtime.RawExtension{
+						Raw: bytes,
+					},
+				},
+			}
+
+			resp := validateObjectTransfers(ar, []runtime.Object{targetNamespace()}, []runtime.Object{sourceDataVolume()})
 			Expect(resp.Allowed).To(BeTrue())
 		})
 	})
@@ -328,8 +405,55 @@ var _ = Describe("ObjectTransfer webhook", func() {
 })
 
 func validateObjectTransfers(ar *admissionv1.AdmissionReview, k8sObjects, cdiObjects []runtime.Object) *admissionv1.AdmissionResponse {
+	return validateObjectTransfersEx(ar, true, k8sObjects, cdiObjects)
+}
+
+func validateObjectTransfersEx(ar *admissionv1.AdmissionReview, isAuthorized bool, k8sObjects, cdiObjects []runtime.Object) *admissionv1.AdmissionResponse {
 	k8sClient := k8sclient.NewSimpleClientset(k8sObjects...)
+	k8sClient.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		sar := &authorization.SubjectAccessReview{
+			Status: authorization.SubjectAccessReviewStatus{
+				Allowed: isAuthorized,
+			},
+		}
+		return true, sar, nil
+	})
 	cdiClient := cdiclient.NewSimpleClientset(cdiObjects...)
 	wh := NewObjectTransferValidatingWebhook(k8sClient, cdiClient)
 	return serve(ar, wh)
 }
+
+func newDataVolumeTransfer() *cdiv1.ObjectTransfer {
+	return &cdiv1.ObjectTransfer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "ot",
+		},
+		Spec: cdiv1.ObjectTransferSpec{
+			Source: cdiv1.TransferSource{
+				Kind:      "DataVolume",
+				Name:      "dv",
+				Namespace: "ns",
+			},
+			Target: cdiv1.TransferTarget{
+				Namespace: &[]string{"foo"}[0],
+			},
+		},
+	}
+}
+
+func sourceDataVolume() *cdiv1.DataVolume {
+	return &cdiv1.DataVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dv",
+			Namespace: "ns",
+		},
+	}
+}
+
+func targetNamespace() *corev1.Namespace {
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2022 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/containerized-data-importer/tests/reporters"
+)
+
+func TestAudit(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecsWithDefaultAndCustomReporters(t, "Audit Suite", reporters.NewReporters())
+}
+
+var _ = Describe("Audit entries", func() {
+	It("Should keep the wire format stable", func() {
+		entry := Entry{
+			Time:            time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC),
+			Operation:       OperationCloneToken,
+			User:            "system:serviceaccount:default:builder",
+			SourceNamespace: "source-ns",
+			SourceName:      "source-pvc",
+			TargetNamespace: "target-ns",
+			TargetName:      "target-dv",
+			Decision:        DecisionAllowed,
+			Reason:          "allowed by SubjectAccessReview",
+		}
+		line, err := json.Marshal(&entry)
+		Expect(err).ToNot(HaveOccurred())
+
+		fields := map[string]interface{}{}
+		Expect(json.Unmarshal(line, &fields)).To(Succeed())
+		Expect(fields).To(HaveKeyWithValue("time", "2022-01-02T03:04:05Z"))
+		Expect(fields).To(HaveKeyWithValue("operation", "issue-clone-token"))
+		Expect(fields).To(HaveKeyWithValue("user", "system:serviceaccount:default:builder"))
+		Expect(fields).To(HaveKeyWithValue("sourceNamespace", "source-ns"))
+		Expect(fields).To(HaveKeyWithValue("sourceName", "source-pvc"))
+		Expect(fields).To(HaveKeyWithValue("targetNamespace", "target-ns"))
+		Expect(fields).To(HaveKeyWithValue("targetName", "target-dv"))
+		Expect(fields).To(HaveKeyWithValue("decision", "allowed"))
+		Expect(fields).To(HaveKeyWithValue("reason", "allowed by SubjectAccessReview"))
+	})
+
+	It("Should omit empty optional fields", func() {
+		entry := Entry{
+			Time:      time.Now(),
+			Operation: OperationUploadToken,
+			Decision:  DecisionDenied,
+		}
+		line, err := json.Marshal(&entry)
+		Expect(err).ToNot(HaveOccurred())
+
+		fields := map[string]interface{}{}
+		Expect(json.Unmarshal(line, &fields)).To(Succeed())
+		Expect(fields).ToNot(HaveKey("user"))
+		Expect(fields).ToNot(HaveKey("sourceNamespace"))
+		Expect(fields).ToNot(HaveKey("reason"))
+	})
+})
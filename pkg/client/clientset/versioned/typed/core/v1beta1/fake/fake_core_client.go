@@ -36,6 +36,14 @@ func (c *FakeCdiV1beta1) CDIConfigs() v1beta1.CDIConfigInterface {
 	return &FakeCDIConfigs{c}
 }
 
+func (c *FakeCdiV1beta1) CDIQuotas(namespace string) v1beta1.CDIQuotaInterface {
+	return &FakeCDIQuotas{c, namespace}
+}
+
+func (c *FakeCdiV1beta1) CloneGrants(namespace string) v1beta1.CloneGrantInterface {
+	return &FakeCloneGrants{c, namespace}
+}
+
 func (c *FakeCdiV1beta1) DataImportCrons(namespace string) v1beta1.DataImportCronInterface {
 	return &FakeDataImportCrons{c, namespace}
 }
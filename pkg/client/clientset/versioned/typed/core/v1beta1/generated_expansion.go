@@ -22,6 +22,10 @@ type CDIExpansion interface{}
 
 type CDIConfigExpansion interface{}
 
+type CDIQuotaExpansion interface{}
+
+type CloneGrantExpansion interface{}
+
 type DataImportCronExpansion interface{}
 
 type DataSourceExpansion interface{}
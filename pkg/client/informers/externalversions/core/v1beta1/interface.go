@@ -28,6 +28,10 @@ type Interface interface {
 	CDIs() CDIInformer
 	// CDIConfigs returns a CDIConfigInformer.
 	CDIConfigs() CDIConfigInformer
+	// CDIQuotas returns a CDIQuotaInformer.
+	CDIQuotas() CDIQuotaInformer
+	// CloneGrants returns a CloneGrantInformer.
+	CloneGrants() CloneGrantInformer
 	// DataImportCrons returns a DataImportCronInformer.
 	DataImportCrons() DataImportCronInformer
 	// DataSources returns a DataSourceInformer.
@@ -61,6 +65,16 @@ func (v *version) CDIConfigs() CDIConfigInformer {
 	return &cDIConfigInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// CDIQuotas returns a CDIQuotaInformer.
+func (v *version) CDIQuotas() CDIQuotaInformer {
+	return &cDIQuotaInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// CloneGrants returns a CloneGrantInformer.
+func (v *version) CloneGrants() CloneGrantInformer {
+	return &cloneGrantInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // DataImportCrons returns a DataImportCronInformer.
 func (v *version) DataImportCrons() DataImportCronInformer {
 	return &dataImportCronInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
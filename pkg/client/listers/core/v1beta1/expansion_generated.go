@@ -26,6 +26,22 @@ type CDIListerExpansion interface{}
 // CDIConfigLister.
 type CDIConfigListerExpansion interface{}
 
+// CDIQuotaListerExpansion allows custom methods to be added to
+// CDIQuotaLister.
+type CDIQuotaListerExpansion interface{}
+
+// CDIQuotaNamespaceListerExpansion allows custom methods to be added to
+// CDIQuotaNamespaceLister.
+type CDIQuotaNamespaceListerExpansion interface{}
+
+// CloneGrantListerExpansion allows custom methods to be added to
+// CloneGrantLister.
+type CloneGrantListerExpansion interface{}
+
+// CloneGrantNamespaceListerExpansion allows custom methods to be added to
+// CloneGrantNamespaceLister.
+type CloneGrantNamespaceListerExpansion interface{}
+
 // DataImportCronListerExpansion allows custom methods to be added to
 // DataImportCronLister.
 type DataImportCronListerExpansion interface{}
@@ -0,0 +1,233 @@
+// Package clonestream implements the framed wire format used by network
+// clones. The source splits the raw stream into fixed size blocks, elides
+// blocks that contain only zeroes, compresses the rest with zstd and protects
+// every frame with a CRC32C checksum. The upload server reconstructs the
+// original byte stream, so the consumers of the stream do not need to know
+// about the framing.
+package clonestream
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+)
+
+// Transport is the value clone sources send in the transport negotiation
+// header when the request body is framed by this package
+const Transport = "block-delta/v1"
+
+// BlockSize is the amount of raw data carried by a single frame. Frames carry
+// their offset in the raw stream, so a future incremental clone can send only
+// the blocks that changed.
+const BlockSize = 2 * 1024 * 1024
+
+const (
+	frameData byte = iota
+	frameHole
+	frameTrailer
+)
+
+// frame header: type, raw stream offset, raw length, compressed length, CRC32C
+const frameHeaderSize = 1 + 8 + 4 + 4 + 4
+
+var streamMagic = []byte("cdi-blkdelta")
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// Copy frames src into dst until src is exhausted, returning the number of raw
+// bytes consumed
+func Copy(dst io.Writer, src io.Reader) (int64, error) {
+	encoder, err := zstd.NewWriter(nil)
+	if err != nil {
+		return 0, err
+	}
+	defer encoder.Close()
+
+	if _, err := dst.Write(streamMagic); err != nil {
+		return 0, err
+	}
+
+	var offset int64
+	raw := make([]byte, BlockSize)
+	var compressed []byte
+	header := make([]byte, frameHeaderSize)
+
+	for {
+		n, readErr := io.ReadFull(src, raw)
+		if n > 0 {
+			block := raw[:n]
+			if isZeroBlock(block) {
+				putFrameHeader(header, frameHole, offset, n, 0, 0)
+				if _, err := dst.Write(header); err != nil {
+					return offset, err
+				}
+			} else {
+				compressed = encoder.EncodeAll(block, compressed[:0])
+				putFrameHeader(header, frameData, offset, n, len(compressed), crc32.Checksum(block, castagnoli))
+				if _, err := dst.Write(header); err != nil {
+					return offset, err
+				}
+				if _, err := dst.Write(compressed); err != nil {
+					return offset, err
+				}
+			}
+			offset += int64(n)
+		}
+		if readErr != nil {
+			if readErr != io.EOF && readErr != io.ErrUnexpectedEOF {
+				return offset, readErr
+			}
+			break
+		}
+	}
+
+	// the trailer carries the total raw size, so a truncated stream is
+	// detected even when it was cut on a frame boundary
+	putFrameHeader(header, frameTrailer, offset, 0, 0, 0)
+	if _, err := dst.Write(header); err != nil {
+		return offset, err
+	}
+	return offset, nil
+}
+
+func putFrameHeader(header []byte, frameType byte, offset int64, rawLen, compLen int, checksum uint32) {
+	header[0] = frameType
+	binary.BigEndian.PutUint64(header[1:], uint64(offset))
+	binary.BigEndian.PutUint32(header[9:], uint32(rawLen))
+	binary.BigEndian.PutUint32(header[13:], uint32(compLen))
+	binary.BigEndian.PutUint32(header[17:], checksum)
+}
+
+func isZeroBlock(block []byte) bool {
+	for _, b := range block {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+type reader struct {
+	src        io.ReadCloser
+	decoder    *zstd.Decoder
+	pending    []byte
+	raw        []byte
+	compressed []byte
+	offset     int64
+	started    bool
+	finished   bool
+	err        error
+}
+
+// NewReadCloser returns an io.ReadCloser that reconstructs the raw byte
+// stream from a framed clone stream, verifying the checksum of every frame
+func NewReadCloser(src io.ReadCloser) io.ReadCloser {
+	return &reader{src: src}
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	for len(r.pending) == 0 {
+		if r.finished {
+			r.err = io.EOF
+			return 0, r.err
+		}
+		if err := r.nextFrame(); err != nil {
+			r.err = err
+			return 0, r.err
+		}
+	}
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+func (r *reader) nextFrame() error {
+	if !r.started {
+		magic := make([]byte, len(streamMagic))
+		if _, err := io.ReadFull(r.src, magic); err != nil {
+			return errors.Wrap(err, "error reading clone stream magic")
+		}
+		if string(magic) != string(streamMagic) {
+			return errors.New("clone stream magic mismatch")
+		}
+		r.started = true
+	}
+
+	header := make([]byte, frameHeaderSize)
+	if _, err := io.ReadFull(r.src, header); err != nil {
+		return errors.Wrap(err, "error reading clone frame header")
+	}
+	frameType := header[0]
+	offset := int64(binary.BigEndian.Uint64(header[1:]))
+	rawLen := int(binary.BigEndian.Uint32(header[9:]))
+	compLen := int(binary.BigEndian.Uint32(header[13:]))
+	checksum := binary.BigEndian.Uint32(header[17:])
+
+	if offset != r.offset {
+		return errors.Errorf("clone frame offset %d does not follow previous data ending at %d", offset, r.offset)
+	}
+	if rawLen > BlockSize {
+		return errors.Errorf("clone frame length %d exceeds block size", rawLen)
+	}
+
+	switch frameType {
+	case frameData:
+		r.compressed = resize(r.compressed, compLen)
+		if _, err := io.ReadFull(r.src, r.compressed); err != nil {
+			return errors.Wrap(err, "error reading clone frame data")
+		}
+		if r.decoder == nil {
+			decoder, err := zstd.NewReader(nil)
+			if err != nil {
+				return err
+			}
+			r.decoder = decoder
+		}
+		raw, err := r.decoder.DecodeAll(r.compressed, r.raw[:0])
+		if err != nil {
+			return errors.Wrap(err, "error decompressing clone frame")
+		}
+		if len(raw) != rawLen {
+			return errors.Errorf("clone frame decompressed to %d bytes, expected %d", len(raw), rawLen)
+		}
+		if crc32.Checksum(raw, castagnoli) != checksum {
+			return errors.Errorf("clone frame checksum mismatch at offset %d", offset)
+		}
+		r.raw = raw
+		r.pending = raw
+	case frameHole:
+		r.raw = resize(r.raw, rawLen)
+		for i := range r.raw {
+			r.raw[i] = 0
+		}
+		r.pending = r.raw
+	case frameTrailer:
+		r.finished = true
+		return nil
+	default:
+		return errors.Errorf("unknown clone frame type %d", frameType)
+	}
+
+	r.offset += int64(rawLen)
+	return nil
+}
+
+func resize(buf []byte, size int) []byte {
+	if cap(buf) < size {
+		return make([]byte, size)
+	}
+	return buf[:size]
+}
+
+func (r *reader) Close() error {
+	if r.decoder != nil {
+		r.decoder.Close()
+	}
+	return r.src.Close()
+}
@@ -0,0 +1,96 @@
+package clonestream
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/rand"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+func roundTrip(data []byte) ([]byte, int64, []byte) {
+	framed := &bytes.Buffer{}
+	written, err := Copy(framed, bytes.NewReader(data))
+	Expect(err).ToNot(HaveOccurred())
+
+	decoded, err := ioutil.ReadAll(NewReadCloser(ioutil.NopCloser(bytes.NewReader(framed.Bytes()))))
+	Expect(err).ToNot(HaveOccurred())
+	return decoded, written, framed.Bytes()
+}
+
+var _ = Describe("Clone stream round trip", func() {
+	table.DescribeTable("should reconstruct the raw stream", func(size int) {
+		data := make([]byte, size)
+		rand.Read(data)
+
+		decoded, written, _ := roundTrip(data)
+		Expect(written).To(Equal(int64(size)))
+		Expect(decoded).To(Equal(data))
+	},
+		table.Entry("empty stream", 0),
+		table.Entry("less than one block", 1234),
+		table.Entry("exactly one block", BlockSize),
+		table.Entry("several blocks plus a partial one", 2*BlockSize+42),
+	)
+
+	It("should elide blocks of zeroes on the wire", func() {
+		data := make([]byte, 4*BlockSize)
+		rand.Read(data[:BlockSize])
+		rand.Read(data[3*BlockSize:])
+
+		decoded, _, framed := roundTrip(data)
+		Expect(decoded).To(Equal(data))
+		// the two middle blocks travel as headers only, the random blocks
+		// do not compress
+		Expect(len(framed)).To(BeNumerically("<", 2*BlockSize+4096))
+	})
+
+	It("should reconstruct zeroes at the end of the stream", func() {
+		data := make([]byte, BlockSize+100)
+		rand.Read(data[:100])
+
+		decoded, _, _ := roundTrip(data)
+		Expect(decoded).To(Equal(data))
+	})
+})
+
+var _ = Describe("Clone stream validation", func() {
+	frame := func(data []byte) []byte {
+		framed := &bytes.Buffer{}
+		_, err := Copy(framed, bytes.NewReader(data))
+		Expect(err).ToNot(HaveOccurred())
+		return framed.Bytes()
+	}
+
+	readAll := func(framed []byte) error {
+		_, err := ioutil.ReadAll(NewReadCloser(ioutil.NopCloser(bytes.NewReader(framed))))
+		return err
+	}
+
+	It("should reject a stream with a bad magic", func() {
+		framed := frame([]byte("some data"))
+		framed[0] ^= 0xff
+
+		Expect(readAll(framed)).To(MatchError(ContainSubstring("magic mismatch")))
+	})
+
+	It("should detect corrupted frame data", func() {
+		data := make([]byte, BlockSize)
+		rand.Read(data)
+		framed := frame(data)
+		// flip a bit in the compressed payload
+		framed[len(streamMagic)+frameHeaderSize+10] ^= 0xff
+
+		Expect(readAll(framed)).To(HaveOccurred())
+	})
+
+	It("should detect a truncated stream", func() {
+		data := make([]byte, BlockSize)
+		rand.Read(data)
+		framed := frame(data)
+		// cut the stream right before the trailer
+		Expect(readAll(framed[:len(framed)-frameHeaderSize])).To(MatchError(ContainSubstring("frame header")))
+	})
+})
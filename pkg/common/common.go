@@ -64,14 +64,28 @@ const (
 	PodTerminationMessageFile = "/dev/termination-log"
 	// ImporterPodName provides a constant to use as a prefix for Pods created by CDI (controller only)
 	ImporterPodName = "importer"
+	// ImporterServiceAccountName provides the name of the ServiceAccount importer pods run under
+	ImporterServiceAccountName = "cdi-importer"
+	// ClonerServiceAccountName provides the name of the ServiceAccount clone source pods run under
+	ClonerServiceAccountName = "cdi-cloner"
+	// UploadServerServiceAccountName provides the name of the ServiceAccount upload server pods run under
+	UploadServerServiceAccountName = "cdi-upload-server"
+	// ExportServerServiceAccountName provides the name of the ServiceAccount export server pods run under
+	ExportServerServiceAccountName = "cdi-export-server"
 	// ImporterDataDir provides a constant for the controller pkg to use as a hardcoded path to where content is transferred to/from (controller only)
 	ImporterDataDir = "/data"
 	// ScratchDataDir provides a constant for the controller pkg to use as a hardcoded path to where scratch space is located.
 	ScratchDataDir = "/scratch"
+	// LayerCacheDataDir provides a constant for the controller pkg to use as a hardcoded path to where the node-local registry layer cache is mounted.
+	LayerCacheDataDir = "/layer-cache"
 	// ImporterS3Host provides an S3 string used by importer/dataStream.go only
 	ImporterS3Host = "s3.amazonaws.com"
 	// ImporterCertDir is where the configmap containing certs will be mounted
 	ImporterCertDir = "/certs"
+	// ImporterAuthDir is where a dockerconfigjson pull secret will be mounted
+	ImporterAuthDir = "/auth"
+	// ImporterCredentialsDir is where a secrets-store CSI volume delivering endpoint credentials will be mounted
+	ImporterCredentialsDir = "/credentials"
 	// DefaultPullPolicy imports k8s "IfNotPresent" string for the import_controller_gingko_test and the cdi-controller executable
 	DefaultPullPolicy = string(v1.PullIfNotPresent)
 	// ImportProxyConfigMapName provides the name of the ConfigMap in the cdi namespace containing a CA certificate bundle
@@ -93,12 +107,18 @@ const (
 	ImporterAccessKeyID = "IMPORTER_ACCESS_KEY_ID"
 	// ImporterSecretKey provides a constant to capture our env variable "IMPORTER_SECRET_KEY"
 	ImporterSecretKey = "IMPORTER_SECRET_KEY"
+	// ImporterGoogleCredentials provides a constant to capture our env variable "IMPORTER_GOOGLE_CREDENTIALS"
+	ImporterGoogleCredentials = "IMPORTER_GOOGLE_CREDENTIALS"
 	// ImporterImageSize provides a constant to capture our env variable "IMPORTER_IMAGE_SIZE"
 	ImporterImageSize = "IMPORTER_IMAGE_SIZE"
 	// ImporterCertDirVar provides a constant to capture our env variable "IMPORTER_CERT_DIR"
 	ImporterCertDirVar = "IMPORTER_CERT_DIR"
 	// InsecureTLSVar provides a constant to capture our env variable "INSECURE_TLS"
 	InsecureTLSVar = "INSECURE_TLS"
+	// ImporterTLSMinVersionVar provides a constant to capture our env variable "IMPORTER_TLS_MIN_VERSION"
+	ImporterTLSMinVersionVar = "IMPORTER_TLS_MIN_VERSION"
+	// ImporterTLSCiphersVar provides a constant to capture our env variable "IMPORTER_TLS_CIPHERS"
+	ImporterTLSCiphersVar = "IMPORTER_TLS_CIPHERS"
 	// ImporterDiskID provides a constant to capture our env variable "IMPORTER_DISK_ID"
 	ImporterDiskID = "IMPORTER_DISK_ID"
 	// ImporterUUID provides a constant to capture our env variable "IMPORTER_UUID"
@@ -127,14 +147,38 @@ const (
 	ImportProxyNoProxy = "no_proxy"
 	// ImporterProxyCertDirVar provides a constant to capture our env variable "IMPORTER_PROXY_CERT_DIR"
 	ImporterProxyCertDirVar = "IMPORTER_PROXY_CERT_DIR"
+	// ImportProxyCredentialsSecret provides a constant to capture the ImportProxy credentialsSecret field name
+	ImportProxyCredentialsSecret = "credentialsSecret"
+	// ImporterProxyUsernameVar provides a constant to capture our env variable "IMPORTER_PROXY_USERNAME"
+	ImporterProxyUsernameVar = "IMPORTER_PROXY_USERNAME"
+	// ImporterProxyPasswordVar provides a constant to capture our env variable "IMPORTER_PROXY_PASSWORD"
+	ImporterProxyPasswordVar = "IMPORTER_PROXY_PASSWORD"
 	// InstallerPartOfLabel provides a constant to capture our env variable "INSTALLER_PART_OF_LABEL"
 	InstallerPartOfLabel = "INSTALLER_PART_OF_LABEL"
 	// InstallerVersionLabel provides a constant to capture our env variable "INSTALLER_VERSION_LABEL"
 	InstallerVersionLabel = "INSTALLER_VERSION_LABEL"
 	// ImporterExtraHeader provides a constant to include extra HTTP headers, as the prefix to a format string
 	ImporterExtraHeader = "IMPORTER_EXTRA_HEADER_"
+	// ImporterWriteQueueDepth provides a constant to capture our env variable "IMPORTER_WRITE_QUEUE_DEPTH"
+	ImporterWriteQueueDepth = "IMPORTER_WRITE_QUEUE_DEPTH"
+	// ImporterLayerCacheDirVar provides a constant to capture our env variable "IMPORTER_LAYER_CACHE_DIR"
+	ImporterLayerCacheDirVar = "IMPORTER_LAYER_CACHE_DIR"
+	// ImporterAuthFileVar provides a constant to capture our env variable "IMPORTER_AUTH_FILE"
+	ImporterAuthFileVar = "IMPORTER_AUTH_FILE"
+	// ImporterCredentialsDirVar provides a constant to capture our env variable "IMPORTER_CREDENTIALS_DIR"
+	ImporterCredentialsDirVar = "IMPORTER_CREDENTIALS_DIR"
 	// ImporterSecretExtraHeadersDir is where the secrets containing extra HTTP headers will be mounted
 	ImporterSecretExtraHeadersDir = "/extraheaders"
+	// ImporterExportTokenVar provides a constant to capture our env variable "IMPORTER_EXPORT_TOKEN"
+	ImporterExportTokenVar = "IMPORTER_EXPORT_TOKEN"
+	// ImporterChecksumVar provides a constant to capture our env variable "IMPORTER_CHECKSUM"
+	ImporterChecksumVar = "IMPORTER_CHECKSUM"
+	// ImporterBlankFilesystem provides a constant to capture our env variable "IMPORTER_BLANK_FILESYSTEM"
+	ImporterBlankFilesystem = "IMPORTER_BLANK_FILESYSTEM"
+	// ImporterBlankFilesystemLabel provides a constant to capture our env variable "IMPORTER_BLANK_FILESYSTEM_LABEL"
+	ImporterBlankFilesystemLabel = "IMPORTER_BLANK_FILESYSTEM_LABEL"
+	// ImporterBlankReservedBlocks provides a constant to capture our env variable "IMPORTER_BLANK_RESERVED_BLOCKS"
+	ImporterBlankReservedBlocks = "IMPORTER_BLANK_RESERVED_BLOCKS"
 
 	// CloningLabelValue provides a constant to use as a label value for pod affinity (controller pkg only)
 	CloningLabelValue = "host-assisted-cloning"
@@ -146,6 +190,12 @@ const (
 	ClonerMountPath = "/var/run/cdi/clone/source"
 	// ClonerSourcePodNameSuffix (controller pkg only)
 	ClonerSourcePodNameSuffix = "-source-pod"
+	// ClonerReflinkPodName (controller pkg only)
+	ClonerReflinkPodName = "cdi-reflink-clone"
+	// ClonerReflinkPodNameSuffix (controller pkg only)
+	ClonerReflinkPodNameSuffix = "-reflink-pod"
+	// ClonerTargetMountPath (controller pkg only)
+	ClonerTargetMountPath = "/var/run/cdi/clone/target"
 
 	// KubeVirtAnnKey is part of a kubevirt.io key.
 	KubeVirtAnnKey = "kubevirt.io/"
@@ -165,11 +215,54 @@ const (
 	UploadServerDataDir = ImporterDataDir
 	// UploadServerServiceLabel is the label selector for upload server services
 	UploadServerServiceLabel = "service"
+	// UploadServerCertDir is where the upload server tls key/cert secret is mounted
+	UploadServerCertDir = "/var/run/certs/cdi-uploadserver-server-cert"
 	// UploadImageSize provides a constant to capture our env variable "UPLOAD_IMAGE_SIZE"
 	UploadImageSize = "UPLOAD_IMAGE_SIZE"
 
+	// ExportPodName (controller pkg only)
+	ExportPodName = "cdi-export"
+	// ExportServerCDILabel is the label applied to export server resources
+	ExportServerCDILabel = "cdi-export-server"
+	// ExportServerPodname is name of the export server pod container
+	ExportServerPodname = ExportServerCDILabel
+	// ExportServerServiceLabel is the label selector for export server services
+	ExportServerServiceLabel = "export-service"
+	// ExportServerCertDir is where the export server tls key/cert secret is mounted
+	ExportServerCertDir = "/var/run/certs/cdi-exportserver-server-cert"
+	// ExportServerTokenKey is the key in the export token secret holding the bearer token
+	ExportServerTokenKey = "token"
+	// ExportSourceVar provides a constant to capture our env variable "EXPORT_SOURCE"
+	ExportSourceVar = "EXPORT_SOURCE"
+	// ExportFormatVar provides a constant to capture our env variable "EXPORT_FORMAT"
+	ExportFormatVar = "EXPORT_FORMAT"
+	// ExportTokenVar provides a constant to capture our env variable "EXPORT_TOKEN"
+	ExportTokenVar = "EXPORT_TOKEN"
+	// ExportServerVolumePath is the path export clients download the volume from
+	ExportServerVolumePath = "/volume"
+	// ExportServerTokenHeader is the header export clients may present the token in instead of an Authorization bearer token
+	ExportServerTokenHeader = "x-cdi-export-token"
+
+	// ExporterPodName (controller pkg only)
+	ExporterPodName = "exporter"
+	// ExporterTargetVar provides a constant to capture our env variable "EXPORTER_TARGET"
+	ExporterTargetVar = "EXPORTER_TARGET"
+	// ExporterEndpointVar provides a constant to capture our env variable "EXPORTER_ENDPOINT"
+	ExporterEndpointVar = "EXPORTER_ENDPOINT"
+	// ExporterAccessKeyID provides a constant to capture our env variable "EXPORTER_ACCESS_KEY_ID"
+	ExporterAccessKeyID = "EXPORTER_ACCESS_KEY_ID"
+	// ExporterSecretKey provides a constant to capture our env variable "EXPORTER_SECRET_KEY"
+	ExporterSecretKey = "EXPORTER_SECRET_KEY"
+	// ExporterTargetS3 is the ExporterTargetVar value for an S3 upload
+	ExporterTargetS3 = "s3"
+	// ExporterTargetHTTP is the ExporterTargetVar value for an HTTP PUT upload
+	ExporterTargetHTTP = "http"
+
 	// FilesystemOverheadVar provides a constant to capture our env variable "FILESYSTEM_OVERHEAD"
 	FilesystemOverheadVar = "FILESYSTEM_OVERHEAD"
+
+	// QemuCacheMode provides a constant to capture our env variable "QEMU_CACHE_MODE"
+	QemuCacheMode = "QEMU_CACHE_MODE"
 	// DefaultGlobalOverhead is the amount of space reserved on Filesystem volumes by default
 	DefaultGlobalOverhead = "0.055"
 
@@ -183,6 +276,8 @@ const (
 	KeyAccess = "accessKeyId"
 	// KeySecret provides a constant to the secretKey label using in controller pkg and transport_test.go
 	KeySecret = "secretKey"
+	// KeyGoogleCredentials provides a constant for the secret key holding the GCS service account JSON
+	KeyGoogleCredentials = "credentials.json"
 
 	// DefaultResyncPeriod sets a 10 minute resync period, used in the controller pkg and the controller cmd executable
 	DefaultResyncPeriod = 10 * time.Minute
@@ -207,6 +302,10 @@ const (
 	// QemuSubGid is the gid used as the qemu group in fsGroup
 	QemuSubGid = int64(107)
 
+	// RootlessUID is the fixed non-root uid worker pods run with when the
+	// RootlessWorkloads feature gate is enabled
+	RootlessUID = int64(107)
+
 	// ControllerServiceAccountName is the name of the CDI controller service account
 	ControllerServiceAccountName = "cdi-sa"
 
@@ -220,6 +319,9 @@ const (
 	// UploadContentTypeHeader is the header upload clients may use to set the content type explicitly
 	UploadContentTypeHeader = "x-cdi-content-type"
 
+	// CloneTransportHeader is the header clone sources use to advertise the wire format of the request body
+	CloneTransportHeader = "x-cdi-clone-transport"
+
 	// FilesystemCloneContentType is the content type when cloning a filesystem
 	FilesystemCloneContentType = "filesystem-clone"
 
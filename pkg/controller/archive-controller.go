@@ -0,0 +1,329 @@
+/*
+Copyright 2023 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/util"
+	"kubevirt.io/containerized-data-importer/pkg/util/naming"
+)
+
+const (
+	archiveControllerName = "archive-controller"
+
+	// AnnArchiveRequest asks for the PVC of a Succeeded DataVolume to be
+	// archived to a VolumeSnapshot
+	AnnArchiveRequest = AnnAPIGroup + "/storage.archive.requested"
+	// AnnArchiveDeletePVC asks for the PVC to be deleted once the archive
+	// snapshot is ready, freeing the space
+	AnnArchiveDeletePVC = AnnAPIGroup + "/storage.archive.deletePvc"
+	// AnnArchiveSnapshot records the name of the archive snapshot on the DataVolume
+	AnnArchiveSnapshot = AnnAPIGroup + "/storage.archive.snapshotName"
+	// AnnArchiveRestore asks for the PVC to be restored from the archive snapshot
+	AnnArchiveRestore = AnnAPIGroup + "/storage.archive.restore"
+
+	// ArchiveScheduled is the event reason emitted once the archive snapshot got created
+	ArchiveScheduled = "ArchiveScheduled"
+	// ArchiveSucceeded is the event reason emitted once the archived PVC got deleted
+	ArchiveSucceeded = "ArchiveSucceeded"
+	// ArchiveRestored is the event reason emitted once the PVC got restored from the snapshot
+	ArchiveRestored = "ArchiveRestored"
+	// ErrArchiveFailed is the event reason on archive and restore problems
+	ErrArchiveFailed = "ErrArchiveFailed"
+
+	archiveSnapshotPrefix = "archive"
+
+	archiveRequeueDelay = 5 * time.Second
+)
+
+// ArchiveReconciler archives the PVCs of Succeeded DataVolumes to
+// VolumeSnapshots and restores them on demand, both driven by annotations
+// on the DataVolume
+type ArchiveReconciler struct {
+	client          client.Client
+	uncachedClient  client.Client
+	recorder        record.EventRecorder
+	scheme          *runtime.Scheme
+	log             logr.Logger
+	installerLabels map[string]string
+	shard           ShardConfig
+}
+
+// NewArchiveController creates a new instance of the archive controller
+func NewArchiveController(mgr manager.Manager, log logr.Logger, installerLabels map[string]string, shard ShardConfig) (controller.Controller, error) {
+	uncachedClient, err := client.New(mgr.GetConfig(), client.Options{
+		Scheme: mgr.GetScheme(),
+		Mapper: mgr.GetRESTMapper(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	reconciler := &ArchiveReconciler{
+		client:          mgr.GetClient(),
+		uncachedClient:  uncachedClient,
+		scheme:          mgr.GetScheme(),
+		log:             log.WithName(archiveControllerName),
+		recorder:        mgr.GetEventRecorderFor(archiveControllerName),
+		installerLabels: installerLabels,
+		shard:           shard,
+	}
+	archiveController, err := controller.New(archiveControllerName, mgr, controller.Options{
+		Reconciler: NewInstrumentedReconciler(archiveControllerName, reconciler),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := archiveController.Watch(&source.Kind{Type: &cdiv1.DataVolume{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return nil, err
+	}
+	return archiveController, nil
+}
+
+// Reconcile the reconcile loop for archive and restore requests
+func (r *ArchiveReconciler) Reconcile(_ context.Context, req reconcile.Request) (reconcile.Result, error) {
+	if !r.shard.OwnsNamespace(req.Namespace) {
+		return reconcile.Result{}, nil
+	}
+
+	log := r.log.WithValues("DataVolume", req.NamespacedName)
+
+	dv := &cdiv1.DataVolume{}
+	if err := r.client.Get(context.TODO(), req.NamespacedName, dv); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	if dv.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	if dv.Annotations[AnnArchiveRestore] == "true" && dv.Annotations[AnnArchiveSnapshot] != "" {
+		return r.reconcileRestore(log, dv)
+	}
+	if dv.Annotations[AnnArchiveRequest] == "true" && dv.Status.Phase == cdiv1.Succeeded {
+		return r.reconcileArchive(log, dv)
+	}
+	return reconcile.Result{}, nil
+}
+
+// reconcileArchive snapshots the PVC and optionally deletes it once the
+// snapshot is ready
+func (r *ArchiveReconciler) reconcileArchive(log logr.Logger, dv *cdiv1.DataVolume) (reconcile.Result, error) {
+	pvcName := GetDataVolumeClaimName(dv)
+
+	if dv.Annotations[AnnArchiveSnapshot] == "" {
+		return r.createArchiveSnapshot(log, dv, pvcName)
+	}
+
+	snapshot := &snapshotv1.VolumeSnapshot{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: dv.Annotations[AnnArchiveSnapshot], Namespace: dv.Namespace}, snapshot); err != nil {
+		if k8serrors.IsNotFound(err) {
+			r.recorder.Eventf(dv, corev1.EventTypeWarning, ErrArchiveFailed, "Archive snapshot %s does not exist", dv.Annotations[AnnArchiveSnapshot])
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	if snapshot.Status == nil || snapshot.Status.ReadyToUse == nil || !*snapshot.Status.ReadyToUse {
+		return reconcile.Result{RequeueAfter: archiveRequeueDelay}, nil
+	}
+
+	if dv.Annotations[AnnArchiveDeletePVC] != "true" {
+		return reconcile.Result{}, nil
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: pvcName, Namespace: dv.Namespace}, pvc); err != nil {
+		if k8serrors.IsNotFound(err) {
+			// already archived
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	// use the uncached client, arbitrary user pods are filtered out of the manager cache
+	pods, err := GetPodsUsingPVCs(r.uncachedClient, dv.Namespace, sets.NewString(pvcName), false)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if len(pods) > 0 {
+		log.V(1).Info("PVC still in use, delaying archive deletion", "pvc", pvcName)
+		return reconcile.Result{RequeueAfter: archiveRequeueDelay}, nil
+	}
+
+	if err := r.client.Delete(context.TODO(), pvc); err != nil && !k8serrors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+	r.recorder.Eventf(dv, corev1.EventTypeNormal, ArchiveSucceeded, "PVC %s archived to snapshot %s", pvcName, snapshot.Name)
+	return reconcile.Result{}, nil
+}
+
+// createArchiveSnapshot creates the snapshot and records it on the DataVolume
+func (r *ArchiveReconciler) createArchiveSnapshot(log logr.Logger, dv *cdiv1.DataVolume, pvcName string) (reconcile.Result, error) {
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: pvcName, Namespace: dv.Namespace}, pvc); err != nil {
+		if k8serrors.IsNotFound(err) {
+			r.recorder.Eventf(dv, corev1.EventTypeWarning, ErrArchiveFailed, "PVC %s to archive does not exist", pvcName)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	storageClass, err := GetStorageClassByName(r.client, pvc.Spec.StorageClassName)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if storageClass == nil {
+		r.recorder.Eventf(dv, corev1.EventTypeWarning, ErrArchiveFailed, "Storage class of PVC %s not found", pvcName)
+		return reconcile.Result{}, nil
+	}
+	snapshotClass := snapshotClassForStorageClass(r.client, r.log, storageClass)
+	if snapshotClass == "" {
+		r.recorder.Eventf(dv, corev1.EventTypeWarning, ErrArchiveFailed, "No snapshot class for storage class %s", storageClass.Name)
+		return reconcile.Result{}, nil
+	}
+
+	snapshot := &snapshotv1.VolumeSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      archiveSnapshotName(dv),
+			Namespace: dv.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(dv, cdiv1.SchemeGroupVersion.WithKind("DataVolume")),
+			},
+		},
+		Spec: snapshotv1.VolumeSnapshotSpec{
+			Source: snapshotv1.VolumeSnapshotSource{
+				PersistentVolumeClaimName: &pvcName,
+			},
+			VolumeSnapshotClassName: &snapshotClass,
+		},
+	}
+	util.SetRecommendedLabels(snapshot, r.installerLabels, "cdi-controller")
+	if err := r.client.Create(context.TODO(), snapshot); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return reconcile.Result{}, err
+	}
+
+	AddAnnotation(dv, AnnArchiveSnapshot, snapshot.Name)
+	if err := r.client.Update(context.TODO(), dv); err != nil {
+		return reconcile.Result{}, err
+	}
+	log.V(1).Info("Created archive snapshot", "snapshot", snapshot.Name)
+	r.recorder.Eventf(dv, corev1.EventTypeNormal, ArchiveScheduled, "Archiving PVC %s to snapshot %s", pvcName, snapshot.Name)
+	return reconcile.Result{RequeueAfter: archiveRequeueDelay}, nil
+}
+
+// reconcileRestore rehydrates the PVC from the archive snapshot and clears
+// the archive state once the claim is bound again
+func (r *ArchiveReconciler) reconcileRestore(log logr.Logger, dv *cdiv1.DataVolume) (reconcile.Result, error) {
+	pvcName := GetDataVolumeClaimName(dv)
+	snapshotName := dv.Annotations[AnnArchiveSnapshot]
+
+	snapshot := &snapshotv1.VolumeSnapshot{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: snapshotName, Namespace: dv.Namespace}, snapshot); err != nil {
+		if k8serrors.IsNotFound(err) {
+			r.recorder.Eventf(dv, corev1.EventTypeWarning, ErrArchiveFailed, "Archive snapshot %s does not exist", snapshotName)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: pvcName, Namespace: dv.Namespace}, pvc); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, err
+		}
+		pvc = r.newRestorePvc(dv, pvcName, snapshotName)
+		log.V(1).Info("Restoring PVC from archive snapshot", "pvc", pvcName, "snapshot", snapshotName)
+		if err := r.client.Create(context.TODO(), pvc); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: archiveRequeueDelay}, nil
+	}
+
+	if pvc.Status.Phase != corev1.ClaimBound {
+		return reconcile.Result{RequeueAfter: archiveRequeueDelay}, nil
+	}
+
+	if err := r.client.Delete(context.TODO(), snapshot); err != nil && !k8serrors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+	delete(dv.Annotations, AnnArchiveRestore)
+	delete(dv.Annotations, AnnArchiveSnapshot)
+	delete(dv.Annotations, AnnArchiveRequest)
+	if err := r.client.Update(context.TODO(), dv); err != nil {
+		return reconcile.Result{}, err
+	}
+	r.recorder.Eventf(dv, corev1.EventTypeNormal, ArchiveRestored, "PVC %s restored from snapshot %s", pvcName, snapshotName)
+	return reconcile.Result{}, nil
+}
+
+// newRestorePvc builds the PVC restored from the archive snapshot, marked
+// populated so no new import gets started for it
+func (r *ArchiveReconciler) newRestorePvc(dv *cdiv1.DataVolume, pvcName, snapshotName string) *corev1.PersistentVolumeClaim {
+	spec := corev1.PersistentVolumeClaimSpec{}
+	if dv.Spec.PVC != nil {
+		spec = *dv.Spec.PVC.DeepCopy()
+	} else if dv.Spec.Storage != nil {
+		spec.AccessModes = dv.Spec.Storage.AccessModes
+		spec.Resources = dv.Spec.Storage.Resources
+		spec.StorageClassName = dv.Spec.Storage.StorageClassName
+		spec.VolumeMode = dv.Spec.Storage.VolumeMode
+	}
+	apiGroup := snapshotv1.GroupName
+	spec.DataSource = &corev1.TypedLocalObjectReference{
+		APIGroup: &apiGroup,
+		Kind:     "VolumeSnapshot",
+		Name:     snapshotName,
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pvcName,
+			Namespace: dv.Namespace,
+			Annotations: map[string]string{
+				AnnPopulatedFor: dv.Name,
+			},
+		},
+		Spec: spec,
+	}
+	util.SetRecommendedLabels(pvc, r.installerLabels, "cdi-controller")
+	return pvc
+}
+
+// archiveSnapshotName is the name given to the archive snapshot of a DataVolume
+func archiveSnapshotName(dv *cdiv1.DataVolume) string {
+	return naming.GetResourceName(archiveSnapshotPrefix, dv.Name)
+}
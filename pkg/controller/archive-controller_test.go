@@ -0,0 +1,203 @@
+/*
+Copyright 2023 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
+	corev1 "k8s.io/api/core/v1"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+var (
+	archiveLog = logf.Log.WithName("archive-controller-test")
+)
+
+var _ = Describe("Archive controller reconcile loop", func() {
+
+	reconcileDv := func(r *ArchiveReconciler, name string) {
+		_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: "default"}})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	It("Should ignore DataVolumes without an archive request", func() {
+		dv := newArchiveDV("testDv", nil)
+		reconciler := createArchiveReconciler(dv)
+		reconcileDv(reconciler, "testDv")
+
+		snapshot := &snapshotv1.VolumeSnapshot{}
+		err := reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "archive-testDv", Namespace: "default"}, snapshot)
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("Should snapshot the PVC and record it on the DataVolume", func() {
+		scName := "archivesc"
+		dv := newArchiveDV("testDv", map[string]string{AnnArchiveRequest: "true"})
+		reconciler := createArchiveReconciler(dv,
+			createPvcInStorageClass("testDv", "default", &scName, nil, nil, corev1.ClaimBound),
+			createStorageClassWithProvisioner(scName, map[string]string{}, map[string]string{}, "csi-plugin"),
+			createSnapshotClass("snap-class", nil, "csi-plugin"),
+			createVolumeSnapshotContentCrd(), createVolumeSnapshotClassCrd(), createVolumeSnapshotCrd())
+		reconcileDv(reconciler, "testDv")
+
+		snapshot := &snapshotv1.VolumeSnapshot{}
+		err := reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "archive-testDv", Namespace: "default"}, snapshot)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*snapshot.Spec.Source.PersistentVolumeClaimName).To(Equal("testDv"))
+		Expect(*snapshot.Spec.VolumeSnapshotClassName).To(Equal("snap-class"))
+
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testDv", Namespace: "default"}, dv)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dv.Annotations[AnnArchiveSnapshot]).To(Equal("archive-testDv"))
+
+		event := <-reconciler.recorder.(*record.FakeRecorder).Events
+		Expect(event).To(ContainSubstring(ArchiveScheduled))
+	})
+
+	It("Should delete the PVC once the snapshot is ready", func() {
+		dv := newArchiveDV("testDv", map[string]string{
+			AnnArchiveRequest:   "true",
+			AnnArchiveDeletePVC: "true",
+			AnnArchiveSnapshot:  "archive-testDv",
+		})
+		reconciler := createArchiveReconciler(dv,
+			createPvc("testDv", "default", nil, nil),
+			createReadySnapshot("archive-testDv"))
+		reconcileDv(reconciler, "testDv")
+
+		pvc := &corev1.PersistentVolumeClaim{}
+		err := reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testDv", Namespace: "default"}, pvc)
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+
+		event := <-reconciler.recorder.(*record.FakeRecorder).Events
+		Expect(event).To(ContainSubstring(ArchiveSucceeded))
+	})
+
+	It("Should keep the PVC when deletion was not requested", func() {
+		dv := newArchiveDV("testDv", map[string]string{
+			AnnArchiveRequest:  "true",
+			AnnArchiveSnapshot: "archive-testDv",
+		})
+		reconciler := createArchiveReconciler(dv,
+			createPvc("testDv", "default", nil, nil),
+			createReadySnapshot("archive-testDv"))
+		reconcileDv(reconciler, "testDv")
+
+		pvc := &corev1.PersistentVolumeClaim{}
+		err := reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testDv", Namespace: "default"}, pvc)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("Should restore the PVC from the snapshot", func() {
+		dv := newArchiveDV("testDv", map[string]string{
+			AnnArchiveRequest:  "true",
+			AnnArchiveSnapshot: "archive-testDv",
+			AnnArchiveRestore:  "true",
+		})
+		reconciler := createArchiveReconciler(dv, createReadySnapshot("archive-testDv"))
+		reconcileDv(reconciler, "testDv")
+
+		pvc := &corev1.PersistentVolumeClaim{}
+		err := reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testDv", Namespace: "default"}, pvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pvc.Spec.DataSource).ToNot(BeNil())
+		Expect(pvc.Spec.DataSource.Kind).To(Equal("VolumeSnapshot"))
+		Expect(pvc.Spec.DataSource.Name).To(Equal("archive-testDv"))
+		Expect(pvc.Annotations[AnnPopulatedFor]).To(Equal("testDv"))
+
+		// once the claim is bound the archive state gets cleared
+		pvc.Status.Phase = corev1.ClaimBound
+		err = reconciler.client.Update(context.TODO(), pvc)
+		Expect(err).ToNot(HaveOccurred())
+		reconcileDv(reconciler, "testDv")
+
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testDv", Namespace: "default"}, dv)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dv.Annotations).ToNot(HaveKey(AnnArchiveSnapshot))
+		Expect(dv.Annotations).ToNot(HaveKey(AnnArchiveRestore))
+		Expect(dv.Annotations).ToNot(HaveKey(AnnArchiveRequest))
+
+		snapshot := &snapshotv1.VolumeSnapshot{}
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "archive-testDv", Namespace: "default"}, snapshot)
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+
+		event := <-reconciler.recorder.(*record.FakeRecorder).Events
+		Expect(event).To(ContainSubstring(ArchiveRestored))
+	})
+})
+
+func newArchiveDV(name string, annotations map[string]string) *cdiv1.DataVolume {
+	dv := newImportDataVolume(name)
+	dv.Annotations = annotations
+	dv.Status.Phase = cdiv1.Succeeded
+	return dv
+}
+
+func createReadySnapshot(name string) *snapshotv1.VolumeSnapshot {
+	ready := true
+	return &snapshotv1.VolumeSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Status: &snapshotv1.VolumeSnapshotStatus{
+			ReadyToUse: &ready,
+		},
+	}
+}
+
+func createArchiveReconciler(objects ...runtime.Object) *ArchiveReconciler {
+	objs := []runtime.Object{MakeEmptyCDICR()}
+	objs = append(objs, objects...)
+
+	s := scheme.Scheme
+	cdiv1.AddToScheme(s)
+	snapshotv1.AddToScheme(s)
+	extv1.AddToScheme(s)
+
+	cl := fake.NewFakeClientWithScheme(s, objs...)
+
+	rec := record.NewFakeRecorder(10)
+
+	return &ArchiveReconciler{
+		client:         cl,
+		uncachedClient: cl,
+		scheme:         s,
+		log:            archiveLog,
+		recorder:       rec,
+		installerLabels: map[string]string{
+			common.AppKubernetesPartOfLabel:  "testing",
+			common.AppKubernetesVersionLabel: "v0.0.0-tests",
+		},
+	}
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2022 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	cdiQuotaControllerName = "cdiquota-controller"
+
+	// AnnQuotaCounted marks a succeeded DataVolume whose size was already added to the CDIQuota day window
+	AnnQuotaCounted = AnnAPIGroup + "/storage.quotaCounted"
+
+	// quotaDayWindow is the length of the imported bytes accounting window
+	quotaDayWindow = 24 * time.Hour
+)
+
+// CDIQuotaReconciler members
+type CDIQuotaReconciler struct {
+	client   client.Client
+	recorder record.EventRecorder
+	scheme   *runtime.Scheme
+	log      logr.Logger
+}
+
+// NewCDIQuotaController creates a new instance of the CDIQuota controller
+func NewCDIQuotaController(mgr manager.Manager, log logr.Logger) (controller.Controller, error) {
+	reconciler := &CDIQuotaReconciler{
+		client:   mgr.GetClient(),
+		recorder: mgr.GetEventRecorderFor(cdiQuotaControllerName),
+		scheme:   mgr.GetScheme(),
+		log:      log.WithName(cdiQuotaControllerName),
+	}
+	cdiQuotaController, err := controller.New(cdiQuotaControllerName, mgr, controller.Options{Reconciler: NewInstrumentedReconciler(cdiQuotaControllerName, reconciler)})
+	if err != nil {
+		return nil, err
+	}
+	if err := addCDIQuotaControllerWatches(mgr, cdiQuotaController); err != nil {
+		return nil, err
+	}
+	log.Info("Initialized CDIQuota controller")
+	return cdiQuotaController, nil
+}
+
+func addCDIQuotaControllerWatches(mgr manager.Manager, c controller.Controller) error {
+	if err := cdiv1.AddToScheme(mgr.GetScheme()); err != nil {
+		return err
+	}
+	if err := c.Watch(&source.Kind{Type: &cdiv1.CDIQuota{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+	// DataVolume changes update the usage of the quotas in their namespace
+	if err := c.Watch(&source.Kind{Type: &cdiv1.DataVolume{}}, handler.EnqueueRequestsFromMapFunc(
+		func(obj client.Object) (reqs []reconcile.Request) {
+			quotas := &cdiv1.CDIQuotaList{}
+			if err := mgr.GetClient().List(context.TODO(), quotas, client.InNamespace(obj.GetNamespace())); err != nil {
+				return
+			}
+			for _, quota := range quotas.Items {
+				reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: quota.Namespace, Name: quota.Name}})
+			}
+			return
+		},
+	)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Reconcile loop for CDIQuotaReconciler, keeps the quota status in sync with
+// the DataVolumes in the namespace
+func (r *CDIQuotaReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	quota := &cdiv1.CDIQuota{}
+	if err := r.client.Get(ctx, req.NamespacedName, quota); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	log := r.log.WithValues("CDIQuota", req.NamespacedName)
+
+	dvList := &cdiv1.DataVolumeList{}
+	if err := r.client.List(ctx, dvList, client.InNamespace(quota.Namespace)); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	quotaCopy := quota.DeepCopy()
+	r.resetDayWindowIfExpired(quotaCopy)
+
+	currentTransfers := int32(0)
+	for i := range dvList.Items {
+		dv := &dvList.Items[i]
+		if DataVolumeIsActiveTransfer(dv) {
+			currentTransfers++
+		}
+		if err := r.countImportedBytes(ctx, quotaCopy, dv); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	quotaCopy.Status.CurrentTransfers = currentTransfers
+
+	if !reflect.DeepEqual(quota.Status, quotaCopy.Status) {
+		log.V(1).Info("Updating CDIQuota status", "currentTransfers", currentTransfers)
+		if err := r.client.Status().Update(ctx, quotaCopy); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	// Wake up to reset the imported bytes window once the day rolls over
+	if quotaCopy.Spec.MaxImportedBytesPerDay != nil && quotaCopy.Status.DayStart != nil {
+		return reconcile.Result{RequeueAfter: time.Until(quotaCopy.Status.DayStart.Add(quotaDayWindow))}, nil
+	}
+	return reconcile.Result{}, nil
+}
+
+// resetDayWindowIfExpired starts a new accounting day when the previous one is over
+func (r *CDIQuotaReconciler) resetDayWindowIfExpired(quota *cdiv1.CDIQuota) {
+	if quota.Status.DayStart == nil || time.Since(quota.Status.DayStart.Time) >= quotaDayWindow {
+		now := metav1.Now()
+		quota.Status.DayStart = &now
+		quota.Status.ImportedBytes = resource.NewQuantity(0, resource.BinarySI)
+	}
+}
+
+// countImportedBytes adds the size of a freshly succeeded import to the quota day
+// window, marking the DataVolume so it is only counted once
+func (r *CDIQuotaReconciler) countImportedBytes(ctx context.Context, quota *cdiv1.CDIQuota, dv *cdiv1.DataVolume) error {
+	if quota.Spec.MaxImportedBytesPerDay == nil ||
+		dv.Status.Phase != cdiv1.Succeeded ||
+		!DataVolumeIsImport(dv) ||
+		dv.Annotations[AnnQuotaCounted] == "true" {
+		return nil
+	}
+	size := dataVolumeRequestedSize(dv)
+	if quota.Status.ImportedBytes == nil {
+		quota.Status.ImportedBytes = resource.NewQuantity(0, resource.BinarySI)
+	}
+	quota.Status.ImportedBytes.Add(size)
+	dvCopy := dv.DeepCopy()
+	AddAnnotation(dvCopy, AnnQuotaCounted, "true")
+	return r.client.Update(ctx, dvCopy)
+}
+
+// DataVolumeIsActiveTransfer tells whether the DataVolume currently runs a transfer
+func DataVolumeIsActiveTransfer(dv *cdiv1.DataVolume) bool {
+	switch dv.Status.Phase {
+	case cdiv1.PhaseUnset, cdiv1.Pending, cdiv1.Succeeded, cdiv1.Failed, cdiv1.Unknown, cdiv1.Paused:
+		return false
+	}
+	return true
+}
+
+// DataVolumeIsImport tells whether the DataVolume pulls data from an external source
+func DataVolumeIsImport(dv *cdiv1.DataVolume) bool {
+	source := dv.Spec.Source
+	if source == nil {
+		return false
+	}
+	return source.HTTP != nil || source.S3 != nil || source.GCS != nil || source.OVA != nil || source.Registry != nil || source.Imageio != nil || source.VDDK != nil
+}
+
+// dataVolumeRequestedSize returns the storage size the DataVolume requests
+func dataVolumeRequestedSize(dv *cdiv1.DataVolume) resource.Quantity {
+	if dv.Spec.PVC != nil {
+		if size, ok := dv.Spec.PVC.Resources.Requests[corev1.ResourceStorage]; ok {
+			return size
+		}
+	}
+	if dv.Spec.Storage != nil {
+		if size, ok := dv.Spec.Storage.Resources.Requests[corev1.ResourceStorage]; ok {
+			return size
+		}
+	}
+	return *resource.NewQuantity(0, resource.BinarySI)
+}
+
+// ImportedBytesToday returns the imported bytes counted in the current day window
+func ImportedBytesToday(quota *cdiv1.CDIQuota) resource.Quantity {
+	if quota.Status.ImportedBytes == nil ||
+		quota.Status.DayStart == nil ||
+		time.Since(quota.Status.DayStart.Time) >= quotaDayWindow {
+		return *resource.NewQuantity(0, resource.BinarySI)
+	}
+	return *quota.Status.ImportedBytes
+}
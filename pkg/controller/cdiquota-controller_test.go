@@ -0,0 +1,119 @@
+/*
+Copyright 2022 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const quotaName = "test-quota"
+
+var (
+	quotaLog = logf.Log.WithName("cdiquota-controller-test")
+)
+
+var _ = Describe("CDIQuota controller reconcile loop", func() {
+	var (
+		quotaKey = types.NamespacedName{Name: quotaName, Namespace: metav1.NamespaceDefault}
+		quotaReq = reconcile.Request{NamespacedName: quotaKey}
+	)
+
+	It("Should do nothing and return nil when no CDIQuota exists", func() {
+		reconciler := createCDIQuotaReconciler()
+		_, err := reconciler.Reconcile(context.TODO(), quotaReq)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("Should count running transfers in the quota status", func() {
+		maxTransfers := int32(2)
+		quota := createCDIQuota()
+		quota.Spec.MaxConcurrentTransfers = &maxTransfers
+		running := newImportDataVolume("running-dv")
+		running.Status.Phase = cdiv1.ImportInProgress
+		succeeded := newImportDataVolume("succeeded-dv")
+		succeeded.Status.Phase = cdiv1.Succeeded
+		reconciler := createCDIQuotaReconciler(quota, running, succeeded)
+
+		_, err := reconciler.Reconcile(context.TODO(), quotaReq)
+		Expect(err).ToNot(HaveOccurred())
+		err = reconciler.client.Get(context.TODO(), quotaKey, quota)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(quota.Status.CurrentTransfers).To(Equal(int32(1)))
+	})
+
+	It("Should count a succeeded import only once in the day window", func() {
+		maxBytes := resource.MustParse("10Gi")
+		quota := createCDIQuota()
+		quota.Spec.MaxImportedBytesPerDay = &maxBytes
+		dv := newImportDataVolume("imported-dv")
+		dv.Status.Phase = cdiv1.Succeeded
+		dv.Spec.PVC.Resources = corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+		}
+		reconciler := createCDIQuotaReconciler(quota, dv)
+
+		for i := 0; i < 2; i++ {
+			_, err := reconciler.Reconcile(context.TODO(), quotaReq)
+			Expect(err).ToNot(HaveOccurred())
+		}
+		err := reconciler.client.Get(context.TODO(), quotaKey, quota)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(quota.Status.ImportedBytes).ToNot(BeNil())
+		expectedBytes := resource.MustParse("1Gi")
+		Expect(quota.Status.ImportedBytes.Value()).To(Equal(expectedBytes.Value()))
+		Expect(quota.Status.DayStart).ToNot(BeNil())
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "imported-dv", Namespace: metav1.NamespaceDefault}, dv)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dv.Annotations[AnnQuotaCounted]).To(Equal("true"))
+	})
+})
+
+func createCDIQuotaReconciler(objects ...runtime.Object) *CDIQuotaReconciler {
+	s := scheme.Scheme
+	cdiv1.AddToScheme(s)
+	cl := fake.NewFakeClientWithScheme(s, objects...)
+	r := &CDIQuotaReconciler{
+		client: cl,
+		scheme: s,
+		log:    quotaLog,
+	}
+	return r
+}
+
+func createCDIQuota() *cdiv1.CDIQuota {
+	return &cdiv1.CDIQuota{
+		TypeMeta: metav1.TypeMeta{APIVersion: cdiv1.SchemeGroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      quotaName,
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+}
@@ -30,6 +30,7 @@ import (
 
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	"kubevirt.io/containerized-data-importer/pkg/common"
+	featuregates "kubevirt.io/containerized-data-importer/pkg/feature-gates"
 	"kubevirt.io/containerized-data-importer/pkg/token"
 	"kubevirt.io/containerized-data-importer/pkg/util"
 	"kubevirt.io/containerized-data-importer/pkg/util/cert/fetcher"
@@ -50,6 +51,9 @@ const (
 	CloneUniqueID = "cdi.kubevirt.io/storage.clone.cloneUniqeId"
 	// AnnCloneSourcePod name of the source clone pod
 	AnnCloneSourcePod = "cdi.kubevirt.io/storage.sourceClonePodName"
+	// AnnReflinkClone marks a clone target PVC as handled by a single reflink clone
+	// pod instead of the clone source/upload server pair
+	AnnReflinkClone = "cdi.kubevirt.io/storage.clone.reflink"
 
 	// ErrIncompatiblePVC provides a const to indicate a clone is not possible due to an incompatible PVC
 	ErrIncompatiblePVC = "ErrIncompatiblePVC"
@@ -69,8 +73,13 @@ const (
 	// CloneSourceInUse is reason for event created when clone source pvc is in use
 	CloneSourceInUse = "CloneSourceInUse"
 
+	// CloneRestartLimitExceeded is reason for event created when the clone source pod exceeded the configured restart limit
+	CloneRestartLimitExceeded = "CloneRestartLimitExceeded"
+
 	cloneSourcePodFinalizer = "cdi.kubevirt.io/cloneSource"
 
+	cloneSourceVolName = "cdi-clone-source-vol"
+
 	cloneTokenLeeway = 10 * time.Second
 
 	uploadClientCertDuration = 365 * 24 * time.Hour
@@ -81,6 +90,7 @@ const (
 // CloneReconciler members
 type CloneReconciler struct {
 	client              client.Client
+	uncachedClient      client.Client
 	scheme              *runtime.Scheme
 	recorder            record.EventRecorder
 	clientCertGenerator generator.CertGenerator
@@ -91,7 +101,9 @@ type CloneReconciler struct {
 	image               string
 	verbose             string
 	pullPolicy          string
+	featureGates        featuregates.FeatureGates
 	installerLabels     map[string]string
+	shard               ShardConfig
 }
 
 // NewCloneController creates a new instance of the config controller.
@@ -102,9 +114,18 @@ func NewCloneController(mgr manager.Manager,
 	clientCertGenerator generator.CertGenerator,
 	serverCAFetcher fetcher.CertBundleFetcher,
 	apiServerKey *rsa.PublicKey,
-	installerLabels map[string]string) (controller.Controller, error) {
+	installerLabels map[string]string,
+	shard ShardConfig) (controller.Controller, error) {
+	uncachedClient, err := client.New(mgr.GetConfig(), client.Options{
+		Scheme: mgr.GetScheme(),
+		Mapper: mgr.GetRESTMapper(),
+	})
+	if err != nil {
+		return nil, err
+	}
 	reconciler := &CloneReconciler{
 		client:              mgr.GetClient(),
+		uncachedClient:      uncachedClient,
 		scheme:              mgr.GetScheme(),
 		log:                 log.WithName("clone-controller"),
 		shortTokenValidator: newCloneTokenValidator(common.CloneTokenIssuer, apiServerKey),
@@ -115,10 +136,12 @@ func NewCloneController(mgr manager.Manager,
 		recorder:            mgr.GetEventRecorderFor("clone-controller"),
 		clientCertGenerator: clientCertGenerator,
 		serverCAFetcher:     serverCAFetcher,
+		featureGates:        featuregates.NewFeatureGates(mgr.GetClient()),
 		installerLabels:     installerLabels,
+		shard:               shard,
 	}
 	cloneController, err := controller.New("clone-controller", mgr, controller.Options{
-		Reconciler: reconciler,
+		Reconciler: NewInstrumentedReconciler("clone-controller", reconciler),
 	})
 	if err != nil {
 		return nil, err
@@ -166,8 +189,8 @@ func addCloneControllerWatches(mgr manager.Manager, cloneController controller.C
 	return nil
 }
 
-func newCloneTokenValidator(issuer string, key *rsa.PublicKey) token.Validator {
-	return token.NewValidator(issuer, key, cloneTokenLeeway)
+func newCloneTokenValidator(issuer string, keys ...*rsa.PublicKey) token.Validator {
+	return token.NewMultiKeyValidator(issuer, keys, cloneTokenLeeway)
 }
 
 func (r *CloneReconciler) shouldReconcile(pvc *corev1.PersistentVolumeClaim, log logr.Logger) bool {
@@ -178,6 +201,10 @@ func (r *CloneReconciler) shouldReconcile(pvc *corev1.PersistentVolumeClaim, log
 
 // Reconcile the reconcile loop for host assisted clone pvc.
 func (r *CloneReconciler) Reconcile(_ context.Context, req reconcile.Request) (reconcile.Result, error) {
+	if !r.shard.OwnsNamespace(req.Namespace) {
+		return reconcile.Result{}, nil
+	}
+
 	// Get the PVC.
 	pvc := &corev1.PersistentVolumeClaim{}
 	if err := r.client.Get(context.TODO(), req.NamespacedName, pvc); err != nil {
@@ -203,6 +230,12 @@ func (r *CloneReconciler) Reconcile(_ context.Context, req reconcile.Request) (r
 		return reconcile.Result{}, nil
 	}
 
+	if possible, err := r.reflinkClonePossible(pvc); err != nil {
+		return reconcile.Result{}, err
+	} else if possible {
+		return r.reconcileReflinkClone(pvc, log)
+	}
+
 	ready, err := r.waitTargetPodRunningOrSucceeded(pvc, log)
 	if err != nil {
 		return reconcile.Result{}, errors.Wrap(err, "error ensuring target upload pod running")
@@ -266,7 +299,7 @@ func (r *CloneReconciler) reconcileSourcePod(sourcePod *corev1.Pod, targetPvc *c
 			return 0, err
 		}
 
-		pods, err := GetPodsUsingPVCs(r.client, sourcePvc.Namespace, sets.NewString(sourcePvc.Name), true)
+		pods, err := GetPodsUsingPVCs(r.uncachedClient, sourcePvc.Namespace, sets.NewString(sourcePvc.Name), true)
 		if err != nil {
 			return 0, err
 		}
@@ -281,7 +314,11 @@ func (r *CloneReconciler) reconcileSourcePod(sourcePod *corev1.Pod, targetPvc *c
 			return 2 * time.Second, nil
 		}
 
-		sourcePod, err := r.CreateCloneSourcePod(r.image, r.pullPolicy, targetPvc, log)
+		image, pullPolicy, err := GetWorkerImageAndPullPolicy(r.client, r.image, r.pullPolicy)
+		if err != nil {
+			return 0, err
+		}
+		sourcePod, err := r.CreateCloneSourcePod(image, pullPolicy, targetPvc, log)
 		if err != nil {
 			return 0, err
 		}
@@ -354,8 +391,31 @@ func (r *CloneReconciler) updatePvcFromPod(sourcePod *corev1.Pod, pvc *corev1.Pe
 
 	setAnnotationsFromPodWithPrefix(pvc.Annotations, sourcePod, AnnSourceRunningCondition)
 
+	restartLimitExceeded := false
+	if sourcePod != nil {
+		var err error
+		restartLimitExceeded, err = ExceedsRestartLimit(r.client, sourcePod)
+		if err != nil {
+			return err
+		}
+		if restartLimitExceeded {
+			log.V(1).Info("Clone source pod exceeded the restart limit, marking clone failed", "sourcePod.Name", sourcePod.Name)
+			r.recorder.Event(pvc, corev1.EventTypeWarning, CloneRestartLimitExceeded, "Clone source pod exceeded the configured restart limit")
+			pvc.Annotations[AnnPodPhase] = string(corev1.PodFailed)
+		}
+	}
+
 	if !reflect.DeepEqual(currentPvcCopy, pvc) {
-		return r.updatePVC(pvc)
+		if err := r.updatePVC(pvc); err != nil {
+			return err
+		}
+	}
+
+	if restartLimitExceeded {
+		log.V(1).Info("Deleting clone source pod that exceeded the restart limit", "sourcePod.Name", sourcePod.Name)
+		if err := r.client.Delete(context.TODO(), sourcePod); IgnoreNotFound(err) != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -512,7 +572,7 @@ func (r *CloneReconciler) CreateCloneSourcePod(image, pullPolicy string, pvc *co
 		return nil, err
 	}
 
-	podResourceRequirements, err := GetDefaultPodResourceRequirements(r.client)
+	podResourceRequirements, err := GetWorkloadPodResourceRequirements(r.client, WorkloadTypeCloneSource)
 	if err != nil {
 		return nil, err
 	}
@@ -535,7 +595,25 @@ func (r *CloneReconciler) CreateCloneSourcePod(image, pullPolicy string, pvc *co
 	}
 
 	pod := MakeCloneSourcePodSpec(sourceVolumeMode, image, pullPolicy, sourcePvcName, sourcePvcNamespace, ownerKey, serverCABundle, pvc, podResourceRequirements, workloadNodePlacement)
+	if rootless, err := r.featureGates.RootlessWorkloadsEnabled(); err != nil {
+		return nil, err
+	} else if rootless {
+		SetRootlessSecurityContext(pod, sourceVolumeMode == corev1.PersistentVolumeBlock)
+	}
 	util.SetRecommendedLabels(pod, r.installerLabels, "cdi-controller")
+	if err := ApplyWorkloadMetadata(r.client, pod); err != nil {
+		return nil, err
+	}
+	if err := ApplySecurityProfile(r.client, pod); err != nil {
+		return nil, err
+	}
+	if err := ApplyArchitectureAffinity(r.client, pod); err != nil {
+		return nil, err
+	}
+
+	if err := ensureWorkerServiceAccount(r.client, pod.Namespace, common.ClonerServiceAccountName); err != nil {
+		return nil, err
+	}
 
 	if err := r.client.Create(context.TODO(), pod); err != nil {
 		return nil, errors.Wrap(err, "source pod API create errored")
@@ -550,6 +628,272 @@ func createCloneSourcePodName(targetPvc *corev1.PersistentVolumeClaim) string {
 	return string(targetPvc.GetUID()) + common.ClonerSourcePodNameSuffix
 }
 
+// reflinkClonePossible returns true when the clone can be performed as an instant
+// reflink copy: source and target share namespace, storage class and filesystem
+// volume mode, and the StorageProfile declares reflink support.
+func (r *CloneReconciler) reflinkClonePossible(pvc *corev1.PersistentVolumeClaim) (bool, error) {
+	if pvc.Annotations[AnnReflinkClone] == "true" {
+		// the choice is sticky, so a profile change mid-clone does not strand the pod
+		return true, nil
+	}
+	exists, sourceNamespace, sourceName := ParseCloneRequestAnnotation(pvc)
+	if !exists || sourceNamespace != pvc.Namespace {
+		return false, nil
+	}
+	sourcePvc := &corev1.PersistentVolumeClaim{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: sourceNamespace, Name: sourceName}, sourcePvc); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if getVolumeMode(pvc) != corev1.PersistentVolumeFilesystem || getVolumeMode(sourcePvc) != corev1.PersistentVolumeFilesystem {
+		return false, nil
+	}
+	if pvc.Spec.StorageClassName == nil || sourcePvc.Spec.StorageClassName == nil ||
+		*pvc.Spec.StorageClassName != *sourcePvc.Spec.StorageClassName {
+		return false, nil
+	}
+	storageProfile := &cdiv1.StorageProfile{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: *pvc.Spec.StorageClassName}, storageProfile); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return storageProfile.Status.SupportsReflink != nil && *storageProfile.Status.SupportsReflink, nil
+}
+
+// reconcileReflinkClone clones by running a single pod that mounts both PVCs and
+// copies the data with reflinks, instead of the clone source/upload server pair.
+func (r *CloneReconciler) reconcileReflinkClone(pvc *corev1.PersistentVolumeClaim, log logr.Logger) (reconcile.Result, error) {
+	if pvc.Annotations[AnnReflinkClone] != "true" {
+		// mark the PVC first so the upload controller leaves it alone
+		pvc.Annotations[AnnReflinkClone] = "true"
+		if err := r.updatePVC(pvc); err != nil {
+			return reconcile.Result{}, err
+		}
+		// will reconcile again after the PVC update notification
+		return reconcile.Result{}, nil
+	}
+
+	pod, err := r.findReflinkClonePod(pvc)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if pod == nil {
+		if podSucceededFromPVC(pvc) {
+			return reconcile.Result{}, nil
+		}
+		requeueAfter, err := r.createReflinkClonePod(pvc, log)
+		return reconcile.Result{RequeueAfter: requeueAfter}, err
+	}
+
+	currentPvcCopy := pvc.DeepCopyObject()
+	pvc.Annotations[AnnPodPhase] = string(pod.Status.Phase)
+	setAnnotationsFromPodWithPrefix(pvc.Annotations, pod, AnnRunningCondition)
+	if podSucceededFromPVC(pvc) && pvc.Annotations[AnnCloneOf] != "true" {
+		log.V(1).Info("Adding CloneOf annotation to PVC")
+		pvc.Annotations[AnnCloneOf] = "true"
+		r.recorder.Event(pvc, corev1.EventTypeNormal, CloneSucceededPVC, cloneComplete)
+	}
+	if !reflect.DeepEqual(currentPvcCopy, pvc) {
+		if err := r.updatePVC(pvc); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	if podSucceededFromPVC(pvc) && shouldDeletePod(pvc) {
+		log.V(3).Info("Deleting reflink clone pod", "pod.Name", pod.Name)
+		if err := r.client.Delete(context.TODO(), pod); IgnoreNotFound(err) != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{}, nil
+}
+
+func (r *CloneReconciler) findReflinkClonePod(pvc *corev1.PersistentVolumeClaim) (*corev1.Pod, error) {
+	pod := &corev1.Pod{}
+	nn := types.NamespacedName{Namespace: pvc.Namespace, Name: createReflinkClonePodName(pvc)}
+	if err := r.client.Get(context.TODO(), nn, pod); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !metav1.IsControlledBy(pod, pvc) {
+		return nil, errors.Errorf("pod %s/%s not controlled by pvc %s", pod.Namespace, pod.Name, pvc.Name)
+	}
+	return pod, nil
+}
+
+func (r *CloneReconciler) createReflinkClonePod(pvc *corev1.PersistentVolumeClaim, log logr.Logger) (time.Duration, error) {
+	sourcePvc, err := r.getCloneRequestSourcePVC(pvc)
+	if err != nil {
+		return 0, err
+	}
+
+	sourcePopulated, err := IsPopulated(sourcePvc, r.client)
+	if err != nil {
+		return 0, err
+	}
+	if !sourcePopulated {
+		return 2 * time.Second, nil
+	}
+
+	if err := r.validateSourceAndTarget(sourcePvc, pvc); err != nil {
+		return 0, err
+	}
+
+	pods, err := GetPodsUsingPVCs(r.uncachedClient, pvc.Namespace, sets.NewString(sourcePvc.Name, pvc.Name), true)
+	if err != nil {
+		return 0, err
+	}
+	if len(pods) > 0 {
+		for _, pod := range pods {
+			r.log.V(1).Info("can't create reflink clone pod, pvc in use by other pod",
+				"namespace", pvc.Namespace, "pod", pod.Name)
+			r.recorder.Eventf(pvc, corev1.EventTypeWarning, CloneSourceInUse,
+				"pod %s/%s using PersistentVolumeClaim %s", pod.Namespace, pod.Name, sourcePvc.Name)
+		}
+		return 2 * time.Second, nil
+	}
+
+	image, pullPolicy, err := GetWorkerImageAndPullPolicy(r.client, r.image, r.pullPolicy)
+	if err != nil {
+		return 0, err
+	}
+	podResourceRequirements, err := GetWorkloadPodResourceRequirements(r.client, WorkloadTypeCloneSource)
+	if err != nil {
+		return 0, err
+	}
+	workloadNodePlacement, err := GetWorkloadNodePlacement(r.client)
+	if err != nil {
+		return 0, err
+	}
+
+	pod := MakeReflinkClonePodSpec(image, pullPolicy, sourcePvc.Name, pvc, podResourceRequirements, workloadNodePlacement)
+	if rootless, err := r.featureGates.RootlessWorkloadsEnabled(); err != nil {
+		return 0, err
+	} else if rootless {
+		// reflink clones only run on filesystem PVCs
+		SetRootlessSecurityContext(pod, false)
+	}
+	util.SetRecommendedLabels(pod, r.installerLabels, "cdi-controller")
+	if err := ApplyWorkloadMetadata(r.client, pod); err != nil {
+		return 0, err
+	}
+	if err := ApplySecurityProfile(r.client, pod); err != nil {
+		return 0, err
+	}
+	if err := ApplyArchitectureAffinity(r.client, pod); err != nil {
+		return 0, err
+	}
+
+	if err := ensureWorkerServiceAccount(r.client, pod.Namespace, common.ClonerServiceAccountName); err != nil {
+		return 0, err
+	}
+
+	if err := r.client.Create(context.TODO(), pod); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return 0, errors.Wrap(err, "reflink clone pod API create errored")
+	}
+	log.V(1).Info("reflink clone pod created", "pod.Namespace", pod.Namespace, "pod.Name", pod.Name, "image", image)
+	return 0, nil
+}
+
+func createReflinkClonePodName(targetPvc *corev1.PersistentVolumeClaim) string {
+	return string(targetPvc.GetUID()) + common.ClonerReflinkPodNameSuffix
+}
+
+// MakeReflinkClonePodSpec creates and returns the reflink clone pod spec, mounting
+// both the clone source and target PVCs.
+func MakeReflinkClonePodSpec(image, pullPolicy, sourcePvcName string, targetPvc *corev1.PersistentVolumeClaim,
+	resourceRequirements *corev1.ResourceRequirements, workloadNodePlacement *sdkapi.NodePlacement) *corev1.Pod {
+
+	podName := createReflinkClonePodName(targetPvc)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      podName,
+			Namespace: targetPvc.Namespace,
+			Annotations: map[string]string{
+				AnnCreatedBy: "yes",
+			},
+			Labels: map[string]string{
+				common.CDILabelKey:       common.CDILabelValue, //filtered by the podInformer
+				common.CDIComponentLabel: common.ClonerReflinkPodName,
+				CloneUniqueID:            podName,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				MakePVCOwnerReference(targetPvc),
+			},
+		},
+		Spec: corev1.PodSpec{
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsUser: &[]int64{0}[0],
+				SELinuxOptions: &corev1.SELinuxOptions{
+					User:  "system_u",
+					Role:  "system_r",
+					Type:  "spc_t",
+					Level: "s0",
+				},
+			},
+			Containers: []corev1.Container{
+				{
+					Name:            common.ClonerReflinkPodName,
+					Image:           image,
+					ImagePullPolicy: corev1.PullPolicy(pullPolicy),
+					Command:         []string{"/bin/sh", "-c"},
+					Args: []string{
+						fmt.Sprintf("cp -a --reflink=always %s/. %s/ && sync", common.ClonerMountPath, common.ClonerTargetMountPath),
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      cloneSourceVolName,
+							MountPath: common.ClonerMountPath,
+							ReadOnly:  true,
+						},
+						{
+							Name:      DataVolName,
+							MountPath: common.ClonerTargetMountPath,
+						},
+					},
+				},
+			},
+			RestartPolicy:      corev1.RestartPolicyOnFailure,
+			ServiceAccountName: common.ClonerServiceAccountName,
+			Volumes: []corev1.Volume{
+				{
+					Name: cloneSourceVolName,
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: sourcePvcName,
+							ReadOnly:  true,
+						},
+					},
+				},
+				{
+					Name: DataVolName,
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: targetPvc.Name,
+						},
+					},
+				},
+			},
+			NodeSelector:      workloadNodePlacement.NodeSelector,
+			Tolerations:       workloadNodePlacement.Tolerations,
+			Affinity:          workloadNodePlacement.Affinity,
+			PriorityClassName: getPriorityClass(targetPvc),
+		},
+	}
+
+	if resourceRequirements != nil {
+		pod.Spec.Containers[0].Resources = *resourceRequirements
+	}
+
+	return pod
+}
+
 // MakeCloneSourcePodSpec creates and returns the clone source pod spec based on the target pvc.
 func MakeCloneSourcePodSpec(sourceVolumeMode corev1.PersistentVolumeMode, image, pullPolicy, sourcePvcName, sourcePvcNamespace, ownerRefAnno string,
 	serverCACert []byte, targetPvc *corev1.PersistentVolumeClaim, resourceRequirements *corev1.ResourceRequirements,
@@ -650,7 +994,8 @@ func MakeCloneSourcePodSpec(sourceVolumeMode corev1.PersistentVolumeMode, image,
 					},
 				},
 			},
-			RestartPolicy: corev1.RestartPolicyOnFailure,
+			RestartPolicy:      corev1.RestartPolicyOnFailure,
+			ServiceAccountName: common.ClonerServiceAccountName,
 			Volumes: []corev1.Volume{
 				{
 					Name: DataVolName,
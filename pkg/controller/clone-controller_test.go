@@ -42,6 +42,7 @@ import (
 
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	"kubevirt.io/containerized-data-importer/pkg/common"
+	featuregates "kubevirt.io/containerized-data-importer/pkg/feature-gates"
 	"kubevirt.io/containerized-data-importer/pkg/token"
 	"kubevirt.io/containerized-data-importer/pkg/util/cert/fetcher"
 	"kubevirt.io/containerized-data-importer/pkg/util/cert/triple"
@@ -157,6 +158,56 @@ var _ = Describe("Clone controller reconcile loop", func() {
 		Expect(HasFinalizer(testPvc, cloneSourcePodFinalizer)).To(BeTrue())
 	})
 
+	It("Should perform a reflink clone if the storage profile supports it", func() {
+		testPvc := createPvcInStorageClass("testPvc1", "default", &testStorageClass, map[string]string{
+			AnnCloneRequest:     "default/source",
+			AnnCloneToken:       "foobaz",
+			AnnUploadClientName: "uploadclient"}, nil, corev1.ClaimBound)
+		sourcePvc := createPvcInStorageClass("source", "default", &testStorageClass, map[string]string{}, nil, corev1.ClaimBound)
+		supportsReflink := true
+		storageProfile := &cdiv1.StorageProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: testStorageClass},
+			Status:     cdiv1.StorageProfileStatus{SupportsReflink: &supportsReflink},
+		}
+		reconciler = createCloneReconciler(testPvc, sourcePvc, storageProfile)
+		By("Setting up the match token")
+		reconciler.shortTokenValidator.(*FakeValidator).match = "foobaz"
+		reconciler.shortTokenValidator.(*FakeValidator).Name = "source"
+		reconciler.shortTokenValidator.(*FakeValidator).Namespace = "default"
+		reconciler.shortTokenValidator.(*FakeValidator).Params["targetNamespace"] = "default"
+		reconciler.shortTokenValidator.(*FakeValidator).Params["targetName"] = "testPvc1"
+		By("Verifying the first reconcile marks the PVC for reflink cloning")
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "testPvc1", Namespace: "default"}})
+		Expect(err).ToNot(HaveOccurred())
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testPvc1", Namespace: "default"}, testPvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(testPvc.Annotations[AnnReflinkClone]).To(Equal("true"))
+		By("Verifying the next reconcile creates the reflink clone pod")
+		_, err = reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "testPvc1", Namespace: "default"}})
+		Expect(err).ToNot(HaveOccurred())
+		pod := &corev1.Pod{}
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "default-testPvc1-reflink-pod", Namespace: "default"}, pod)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pod.Spec.Volumes).To(HaveLen(2))
+	})
+
+	It("Should NOT perform a reflink clone if the storage profile does not support it", func() {
+		testPvc := createPvcInStorageClass("testPvc1", "default", &testStorageClass, map[string]string{
+			AnnCloneRequest:     "default/source",
+			AnnCloneToken:       "foobaz",
+			AnnUploadClientName: "uploadclient"}, nil, corev1.ClaimBound)
+		sourcePvc := createPvcInStorageClass("source", "default", &testStorageClass, map[string]string{}, nil, corev1.ClaimBound)
+		storageProfile := &cdiv1.StorageProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: testStorageClass},
+		}
+		reconciler = createCloneReconciler(testPvc, sourcePvc, storageProfile)
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "testPvc1", Namespace: "default"}})
+		Expect(err).ToNot(HaveOccurred())
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testPvc1", Namespace: "default"}, testPvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(testPvc.Annotations).ToNot(HaveKey(AnnReflinkClone))
+	})
+
 	DescribeTable("Should NOT create new source pod if source PVC is in use", func(podFunc func(*corev1.PersistentVolumeClaim) *corev1.Pod) {
 		testPvc := createPvc("testPvc1", "default", map[string]string{
 			AnnCloneRequest:     "default/source",
@@ -712,16 +763,18 @@ func createCloneReconciler(objects ...runtime.Object) *CloneReconciler {
 
 	// Create a ReconcileMemcached object with the scheme and fake client.
 	return &CloneReconciler{
-		client:   cl,
-		scheme:   s,
-		log:      cloneLog,
-		recorder: rec,
+		client:         cl,
+		uncachedClient: cl,
+		scheme:         s,
+		log:            cloneLog,
+		recorder:       rec,
 		shortTokenValidator: &FakeValidator{
 			Params: make(map[string]string, 0),
 		},
 		image:               testImage,
 		clientCertGenerator: &fakeCertGenerator{},
 		serverCAFetcher:     &fetcher.MemCertBundleFetcher{Bundle: []byte("baz")},
+		featureGates:        featuregates.NewFeatureGates(cl),
 		installerLabels: map[string]string{
 			common.AppKubernetesPartOfLabel:  "testing",
 			common.AppKubernetesVersionLabel: "v0.0.0-tests",
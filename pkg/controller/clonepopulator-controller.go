@@ -0,0 +1,462 @@
+/*
+Copyright 2023 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/util"
+)
+
+const (
+	clonePopulatorControllerName = "clone-populator-controller"
+
+	// volumeCloneSourceKind is the dataSourceRef kind handled by this controller
+	volumeCloneSourceKind = "VolumeCloneSource"
+
+	// annPopulatedByCloneSource names the VolumeCloneSource that populated the PVC
+	annPopulatedByCloneSource = "cdi.kubevirt.io/storage.populatedByCloneSource"
+
+	// annSelectedNode is set by the scheduler on claims of WaitForFirstConsumer
+	// storage classes, the prime PVC inherits it so it can bind
+	annSelectedNode = "volume.kubernetes.io/selected-node"
+
+	// clonePrimePrefix is the name prefix of the temporary PVC the clone lands on
+	clonePrimePrefix = "prime"
+
+	// ClonePopulationStarted is the event reason emitted once the prime PVC got created
+	ClonePopulationStarted = "ClonePopulationStarted"
+	// ClonePopulationSucceeded is the event reason emitted once the target PVC got bound
+	ClonePopulationSucceeded = "ClonePopulationSucceeded"
+
+	clonePopulatorRequeueDelay = 2 * time.Second
+)
+
+// ClonePopulatorReconciler populates PVCs referencing a VolumeCloneSource,
+// reusing the clone strategies of the DataVolume flow. The clone lands on a
+// temporary prime PVC, its volume then gets rebound to the target claim.
+type ClonePopulatorReconciler struct {
+	client          client.Client
+	recorder        record.EventRecorder
+	scheme          *runtime.Scheme
+	log             logr.Logger
+	installerLabels map[string]string
+	shard           ShardConfig
+}
+
+// NewClonePopulatorController creates a new instance of the clone populator controller
+func NewClonePopulatorController(mgr manager.Manager, log logr.Logger, installerLabels map[string]string, shard ShardConfig) (controller.Controller, error) {
+	reconciler := &ClonePopulatorReconciler{
+		client:          mgr.GetClient(),
+		scheme:          mgr.GetScheme(),
+		log:             log.WithName(clonePopulatorControllerName),
+		recorder:        mgr.GetEventRecorderFor(clonePopulatorControllerName),
+		installerLabels: installerLabels,
+		shard:           shard,
+	}
+	clonePopulatorController, err := controller.New(clonePopulatorControllerName, mgr, controller.Options{
+		Reconciler: NewInstrumentedReconciler(clonePopulatorControllerName, reconciler),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := addClonePopulatorControllerWatches(mgr, clonePopulatorController); err != nil {
+		return nil, err
+	}
+	return clonePopulatorController, nil
+}
+
+func addClonePopulatorControllerWatches(mgr manager.Manager, clonePopulatorController controller.Controller) error {
+	if err := clonePopulatorController.Watch(&source.Kind{Type: &corev1.PersistentVolumeClaim{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+	// a VolumeCloneSource may show up after the PVCs referencing it
+	if err := clonePopulatorController.Watch(&source.Kind{Type: &cdiv1.VolumeCloneSource{}}, handler.EnqueueRequestsFromMapFunc(
+		func(obj client.Object) []reconcile.Request {
+			return enqueuePvcsForCloneSource(mgr.GetClient(), obj.GetNamespace(), obj.GetName())
+		},
+	)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func enqueuePvcsForCloneSource(c client.Client, namespace, name string) []reconcile.Request {
+	pvcList := &corev1.PersistentVolumeClaimList{}
+	if err := c.List(context.TODO(), pvcList, &client.ListOptions{Namespace: namespace}); err != nil {
+		return nil
+	}
+	var reqs []reconcile.Request
+	for i := range pvcList.Items {
+		pvc := &pvcList.Items[i]
+		if isVolumeCloneSourceRef(pvc) && pvc.Spec.DataSourceRef.Name == name {
+			reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: pvc.Namespace, Name: pvc.Name}})
+		}
+	}
+	return reqs
+}
+
+// Reconcile the reconcile loop for PVCs populated by a VolumeCloneSource
+func (r *ClonePopulatorReconciler) Reconcile(_ context.Context, req reconcile.Request) (reconcile.Result, error) {
+	if !r.shard.OwnsNamespace(req.Namespace) {
+		return reconcile.Result{}, nil
+	}
+
+	log := r.log.WithValues("PVC", req.NamespacedName)
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := r.client.Get(context.TODO(), req.NamespacedName, pvc); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	if pvc.DeletionTimestamp != nil || !isVolumeCloneSourceRef(pvc) {
+		return reconcile.Result{}, nil
+	}
+
+	if pvc.Spec.VolumeName != "" {
+		// the rebind went through, drop the temporary objects
+		return reconcile.Result{}, r.cleanupPrime(log, pvc)
+	}
+
+	cloneSource := &cdiv1.VolumeCloneSource{}
+	sourceKey := types.NamespacedName{Name: pvc.Spec.DataSourceRef.Name, Namespace: pvc.Namespace}
+	if err := r.client.Get(context.TODO(), sourceKey, cloneSource); err != nil {
+		if k8serrors.IsNotFound(err) {
+			// wait for the VolumeCloneSource watch to requeue the PVC
+			log.V(1).Info("VolumeCloneSource not found", "name", sourceKey.Name)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	sourcePvc := &corev1.PersistentVolumeClaim{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: cloneSource.Spec.Source, Namespace: pvc.Namespace}, sourcePvc); err != nil {
+		if k8serrors.IsNotFound(err) {
+			log.V(1).Info("Source PVC not found", "name", cloneSource.Spec.Source)
+			return reconcile.Result{RequeueAfter: clonePopulatorRequeueDelay}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	prime := &corev1.PersistentVolumeClaim{}
+	primeKey := types.NamespacedName{Name: clonePrimeName(pvc), Namespace: pvc.Namespace}
+	if err := r.client.Get(context.TODO(), primeKey, prime); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, err
+		}
+		return r.startClone(log, pvc, cloneSource, sourcePvc)
+	}
+
+	return r.reconcilePrime(log, pvc, prime)
+}
+
+// startClone selects a strategy and creates the prime PVC the clone lands on
+func (r *ClonePopulatorReconciler) startClone(log logr.Logger, pvc *corev1.PersistentVolumeClaim, cloneSource *cdiv1.VolumeCloneSource, sourcePvc *corev1.PersistentVolumeClaim) (reconcile.Result, error) {
+	strategy, snapshotClass, err := r.selectPopulationStrategy(sourcePvc, pvc)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if strategy == SmartClone {
+		ready, err := r.ensureCloneSnapshot(log, pvc, sourcePvc, snapshotClass)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if !ready {
+			return reconcile.Result{RequeueAfter: clonePopulatorRequeueDelay}, nil
+		}
+	}
+
+	prime := r.newPrimePvc(pvc, cloneSource, sourcePvc, strategy)
+	if err := r.client.Create(context.TODO(), prime); err != nil {
+		if k8serrors.IsAlreadyExists(err) {
+			return reconcile.Result{RequeueAfter: clonePopulatorRequeueDelay}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	log.V(1).Info("Created prime PVC for clone population", "prime", prime.Name, "strategy", cloneStrategyName(strategy))
+	r.recorder.Eventf(pvc, corev1.EventTypeNormal, ClonePopulationStarted,
+		"Cloning %s into %s using %s clone", sourcePvc.Name, pvc.Name, cloneStrategyName(strategy))
+	return reconcile.Result{RequeueAfter: clonePopulatorRequeueDelay}, nil
+}
+
+// reconcilePrime waits for the clone to land on the prime PVC and then rebinds
+// the volume to the target claim
+func (r *ClonePopulatorReconciler) reconcilePrime(log logr.Logger, pvc, prime *corev1.PersistentVolumeClaim) (reconcile.Result, error) {
+	if prime.Status.Phase != corev1.ClaimBound {
+		return reconcile.Result{RequeueAfter: clonePopulatorRequeueDelay}, nil
+	}
+	if _, isHostAssisted := prime.Annotations[AnnCloneRequest]; isHostAssisted && prime.Annotations[AnnCloneOf] != "true" {
+		// the host assisted copy is still running
+		return reconcile.Result{RequeueAfter: clonePopulatorRequeueDelay}, nil
+	}
+
+	pv := &corev1.PersistentVolume{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: prime.Spec.VolumeName}, pv); err != nil {
+		return reconcile.Result{}, err
+	}
+	if pv.Spec.ClaimRef == nil || pv.Spec.ClaimRef.UID != pvc.UID {
+		log.V(1).Info("Rebinding cloned volume to the target PVC", "pv", pv.Name)
+		pv.Spec.ClaimRef = &corev1.ObjectReference{
+			Kind:       "PersistentVolumeClaim",
+			APIVersion: "v1",
+			Namespace:  pvc.Namespace,
+			Name:       pvc.Name,
+			UID:        pvc.UID,
+		}
+		if err := r.client.Update(context.TODO(), pv); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	// wait for the PV controller to bind the target claim
+	return reconcile.Result{RequeueAfter: clonePopulatorRequeueDelay}, nil
+}
+
+// cleanupPrime deletes the prime PVC and snapshot once the target claim is bound
+func (r *ClonePopulatorReconciler) cleanupPrime(log logr.Logger, pvc *corev1.PersistentVolumeClaim) error {
+	cleaned := false
+
+	prime := &corev1.PersistentVolumeClaim{}
+	primeKey := types.NamespacedName{Name: clonePrimeName(pvc), Namespace: pvc.Namespace}
+	if err := r.client.Get(context.TODO(), primeKey, prime); err == nil {
+		if err := r.client.Delete(context.TODO(), prime); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+		cleaned = true
+	} else if !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	snapshot := &snapshotv1.VolumeSnapshot{}
+	if err := r.client.Get(context.TODO(), primeKey, snapshot); err == nil {
+		if err := r.client.Delete(context.TODO(), snapshot); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+		cleaned = true
+	} else if !k8serrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return err
+	}
+
+	if cleaned {
+		log.V(1).Info("Clone population completed")
+		r.recorder.Eventf(pvc, corev1.EventTypeNormal, ClonePopulationSucceeded, "Successfully populated %s", pvc.Name)
+	}
+	return nil
+}
+
+// selectPopulationStrategy mirrors the DataVolume strategy selection for
+// populated PVCs, falling back to host assisted whenever the smarter paths
+// do not apply
+func (r *ClonePopulatorReconciler) selectPopulationStrategy(sourcePvc, targetPvc *corev1.PersistentVolumeClaim) (cloneStrategy, string, error) {
+	preferred, err := r.preferredCloneStrategy(targetPvc)
+	if err != nil {
+		return NoClone, "", err
+	}
+
+	targetStorageClass, err := GetStorageClassByName(r.client, targetPvc.Spec.StorageClassName)
+	if err != nil || targetStorageClass == nil {
+		return HostAssistedClone, "", err
+	}
+	if sourcePvc.Spec.StorageClassName == nil || *sourcePvc.Spec.StorageClassName != targetStorageClass.Name {
+		return HostAssistedClone, "", nil
+	}
+	if GetVolumeMode(sourcePvc) != GetVolumeMode(targetPvc) {
+		return HostAssistedClone, "", nil
+	}
+
+	switch preferred {
+	case cdiv1.CloneStrategyCsiClone:
+		return CsiClone, "", nil
+	case cdiv1.CloneStrategySnapshot:
+		snapshotClass := snapshotClassForStorageClass(r.client, r.log, targetStorageClass)
+		if snapshotClass != "" {
+			return SmartClone, snapshotClass, nil
+		}
+	}
+	return HostAssistedClone, "", nil
+}
+
+// preferredCloneStrategy returns the global override, the storage profile
+// preference or the snapshot default, in that order
+func (r *ClonePopulatorReconciler) preferredCloneStrategy(targetPvc *corev1.PersistentVolumeClaim) (cdiv1.CDICloneStrategy, error) {
+	cr, err := GetActiveCDI(r.client)
+	if err != nil {
+		return "", err
+	}
+	if cr != nil && cr.Spec.CloneStrategyOverride != nil {
+		return *cr.Spec.CloneStrategyOverride, nil
+	}
+
+	if targetPvc.Spec.StorageClassName != nil {
+		storageProfile := &cdiv1.StorageProfile{}
+		err := r.client.Get(context.TODO(), types.NamespacedName{Name: *targetPvc.Spec.StorageClassName}, storageProfile)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return "", err
+		}
+		if err == nil && storageProfile.Status.CloneStrategy != nil {
+			return *storageProfile.Status.CloneStrategy, nil
+		}
+	}
+	return cdiv1.CloneStrategySnapshot, nil
+}
+
+// snapshotClassForStorageClass returns a snapshot class matching the
+// provisioner of the storage class, or "" when snapshotting is not possible
+func snapshotClassForStorageClass(c client.Client, log logr.Logger, storageClass *storagev1.StorageClass) string {
+	if !IsCsiCrdsDeployed(c, log) {
+		return ""
+	}
+	snapshotClasses := &snapshotv1.VolumeSnapshotClassList{}
+	if err := c.List(context.TODO(), snapshotClasses); err != nil {
+		return ""
+	}
+	for _, snapshotClass := range snapshotClasses.Items {
+		if snapshotClass.Driver == storageClass.Provisioner {
+			return snapshotClass.Name
+		}
+	}
+	return ""
+}
+
+// ensureCloneSnapshot creates the temporary snapshot of the source PVC and
+// reports whether it is ready to restore from
+func (r *ClonePopulatorReconciler) ensureCloneSnapshot(log logr.Logger, pvc, sourcePvc *corev1.PersistentVolumeClaim, snapshotClass string) (bool, error) {
+	snapshot := &snapshotv1.VolumeSnapshot{}
+	key := types.NamespacedName{Name: clonePrimeName(pvc), Namespace: pvc.Namespace}
+	if err := r.client.Get(context.TODO(), key, snapshot); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return false, err
+		}
+		snapshot = &snapshotv1.VolumeSnapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+				OwnerReferences: []metav1.OwnerReference{
+					MakePVCOwnerReference(pvc),
+				},
+			},
+			Spec: snapshotv1.VolumeSnapshotSpec{
+				Source: snapshotv1.VolumeSnapshotSource{
+					PersistentVolumeClaimName: &sourcePvc.Name,
+				},
+				VolumeSnapshotClassName: &snapshotClass,
+			},
+		}
+		util.SetRecommendedLabels(snapshot, r.installerLabels, "cdi-controller")
+		log.V(1).Info("Creating snapshot of the clone source", "snapshot", key.Name)
+		return false, r.client.Create(context.TODO(), snapshot)
+	}
+	return snapshot.Status != nil && snapshot.Status.ReadyToUse != nil && *snapshot.Status.ReadyToUse, nil
+}
+
+// newPrimePvc builds the temporary PVC the clone lands on before the volume
+// gets rebound to the target claim
+func (r *ClonePopulatorReconciler) newPrimePvc(pvc *corev1.PersistentVolumeClaim, cloneSource *cdiv1.VolumeCloneSource, sourcePvc *corev1.PersistentVolumeClaim, strategy cloneStrategy) *corev1.PersistentVolumeClaim {
+	prime := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        clonePrimeName(pvc),
+			Namespace:   pvc.Namespace,
+			Annotations: map[string]string{},
+			OwnerReferences: []metav1.OwnerReference{
+				MakePVCOwnerReference(pvc),
+			},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      pvc.Spec.AccessModes,
+			Resources:        pvc.Spec.Resources,
+			StorageClassName: pvc.Spec.StorageClassName,
+			VolumeMode:       pvc.Spec.VolumeMode,
+		},
+	}
+	util.SetRecommendedLabels(prime, r.installerLabels, "cdi-controller")
+	AddAnnotation(prime, annPopulatedByCloneSource, cloneSource.Name)
+	if selectedNode, ok := pvc.Annotations[annSelectedNode]; ok {
+		AddAnnotation(prime, annSelectedNode, selectedNode)
+	}
+
+	switch strategy {
+	case CsiClone:
+		prime.Spec.DataSource = &corev1.TypedLocalObjectReference{
+			Kind: "PersistentVolumeClaim",
+			Name: sourcePvc.Name,
+		}
+	case SmartClone:
+		apiGroup := snapshotv1.GroupName
+		prime.Spec.DataSource = &corev1.TypedLocalObjectReference{
+			APIGroup: &apiGroup,
+			Kind:     "VolumeSnapshot",
+			Name:     clonePrimeName(pvc),
+		}
+	default:
+		AddAnnotation(prime, AnnCloneRequest, fmt.Sprintf("%s/%s", sourcePvc.Namespace, sourcePvc.Name))
+		AddAnnotation(prime, AnnImmediateBinding, "")
+		if cloneSource.Spec.Preallocation != nil {
+			AddAnnotation(prime, AnnPreallocationRequested, strconv.FormatBool(*cloneSource.Spec.Preallocation))
+		}
+	}
+	return prime
+}
+
+// clonePrimeName is the name of the temporary PVC and snapshot of the clone
+func clonePrimeName(pvc *corev1.PersistentVolumeClaim) string {
+	return fmt.Sprintf("%s-%s", clonePrimePrefix, pvc.UID)
+}
+
+// cloneStrategyName is the human readable strategy used in events and logs
+func cloneStrategyName(strategy cloneStrategy) string {
+	switch strategy {
+	case SmartClone:
+		return "snapshot"
+	case CsiClone:
+		return "csi"
+	default:
+		return "host assisted"
+	}
+}
+
+// isVolumeCloneSourceRef tells whether the PVC asks to be populated by a VolumeCloneSource
+func isVolumeCloneSourceRef(pvc *corev1.PersistentVolumeClaim) bool {
+	ref := pvc.Spec.DataSourceRef
+	return ref != nil &&
+		ref.APIGroup != nil && *ref.APIGroup == cdiv1.SchemeGroupVersion.Group &&
+		ref.Kind == volumeCloneSourceKind &&
+		ref.Name != ""
+}
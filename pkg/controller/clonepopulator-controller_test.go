@@ -0,0 +1,230 @@
+/*
+Copyright 2023 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
+	corev1 "k8s.io/api/core/v1"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+var (
+	clonePopulatorLog = logf.Log.WithName("clone-populator-controller-test")
+)
+
+var _ = Describe("Clone populator reconcile loop", func() {
+
+	reconcileTarget := func(r *ClonePopulatorReconciler, name string) {
+		_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: "default"}})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	getPrime := func(r *ClonePopulatorReconciler, target *corev1.PersistentVolumeClaim) *corev1.PersistentVolumeClaim {
+		prime := &corev1.PersistentVolumeClaim{}
+		err := r.client.Get(context.TODO(), types.NamespacedName{Name: clonePrimeName(target), Namespace: "default"}, prime)
+		Expect(err).ToNot(HaveOccurred())
+		return prime
+	}
+
+	It("Should ignore PVCs without a VolumeCloneSource dataSourceRef", func() {
+		reconciler := createClonePopulatorReconciler(createPvc("testPvc1", "default", nil, nil))
+		reconcileTarget(reconciler, "testPvc1")
+
+		prime := &corev1.PersistentVolumeClaim{}
+		err := reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "prime-default-testPvc1", Namespace: "default"}, prime)
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("Should wait until the referenced VolumeCloneSource exists", func() {
+		target := createPvcWithCloneSourceRef("testPvc1", "clone-source", nil)
+		reconciler := createClonePopulatorReconciler(target)
+		reconcileTarget(reconciler, "testPvc1")
+
+		prime := &corev1.PersistentVolumeClaim{}
+		err := reconciler.client.Get(context.TODO(), types.NamespacedName{Name: clonePrimeName(target), Namespace: "default"}, prime)
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("Should fall back to a host assisted prime PVC by default", func() {
+		target := createPvcWithCloneSourceRef("testPvc1", "clone-source", nil)
+		reconciler := createClonePopulatorReconciler(target,
+			createCloneSource("clone-source", "source-pvc"),
+			createPvc("source-pvc", "default", nil, nil))
+		reconcileTarget(reconciler, "testPvc1")
+
+		prime := getPrime(reconciler, target)
+		Expect(prime.Annotations[AnnCloneRequest]).To(Equal("default/source-pvc"))
+		_, found := prime.Annotations[AnnImmediateBinding]
+		Expect(found).To(BeTrue())
+		Expect(prime.Annotations[annPopulatedByCloneSource]).To(Equal("clone-source"))
+		Expect(metav1.IsControlledBy(prime, target)).To(BeTrue())
+
+		event := <-reconciler.recorder.(*record.FakeRecorder).Events
+		Expect(event).To(ContainSubstring(ClonePopulationStarted))
+	})
+
+	It("Should use csi clone when the storage profile prefers it", func() {
+		scName := "csiclonesc"
+		csiCloneStrategy := cdiv1.CloneStrategyCsiClone
+		storageProfile := &cdiv1.StorageProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: scName},
+			Status:     cdiv1.StorageProfileStatus{CloneStrategy: &csiCloneStrategy},
+		}
+		target := createPvcWithCloneSourceRef("testPvc1", "clone-source", &scName)
+		reconciler := createClonePopulatorReconciler(target,
+			createCloneSource("clone-source", "source-pvc"),
+			createPvcInStorageClass("source-pvc", "default", &scName, nil, nil, corev1.ClaimBound),
+			createStorageClassWithProvisioner(scName, map[string]string{}, map[string]string{}, "csi-plugin"),
+			storageProfile)
+		reconcileTarget(reconciler, "testPvc1")
+
+		prime := getPrime(reconciler, target)
+		Expect(prime.Spec.DataSource).ToNot(BeNil())
+		Expect(prime.Spec.DataSource.Kind).To(Equal("PersistentVolumeClaim"))
+		Expect(prime.Spec.DataSource.Name).To(Equal("source-pvc"))
+	})
+
+	It("Should snapshot the source and restore the prime PVC from it", func() {
+		scName := "snapclonesc"
+		target := createPvcWithCloneSourceRef("testPvc1", "clone-source", &scName)
+		reconciler := createClonePopulatorReconciler(target,
+			createCloneSource("clone-source", "source-pvc"),
+			createPvcInStorageClass("source-pvc", "default", &scName, nil, nil, corev1.ClaimBound),
+			createStorageClassWithProvisioner(scName, map[string]string{}, map[string]string{}, "csi-plugin"),
+			createSnapshotClass("snap-class", nil, "csi-plugin"),
+			createVolumeSnapshotContentCrd(), createVolumeSnapshotClassCrd(), createVolumeSnapshotCrd())
+		reconcileTarget(reconciler, "testPvc1")
+
+		snapshot := &snapshotv1.VolumeSnapshot{}
+		err := reconciler.client.Get(context.TODO(), types.NamespacedName{Name: clonePrimeName(target), Namespace: "default"}, snapshot)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*snapshot.Spec.Source.PersistentVolumeClaimName).To(Equal("source-pvc"))
+
+		readyToUse := true
+		snapshot.Status = &snapshotv1.VolumeSnapshotStatus{ReadyToUse: &readyToUse}
+		err = reconciler.client.Update(context.TODO(), snapshot)
+		Expect(err).ToNot(HaveOccurred())
+		reconcileTarget(reconciler, "testPvc1")
+
+		prime := getPrime(reconciler, target)
+		Expect(prime.Spec.DataSource).ToNot(BeNil())
+		Expect(prime.Spec.DataSource.Kind).To(Equal("VolumeSnapshot"))
+		Expect(prime.Spec.DataSource.Name).To(Equal(clonePrimeName(target)))
+	})
+
+	It("Should rebind the cloned volume to the target PVC", func() {
+		target := createPvcWithCloneSourceRef("testPvc1", "clone-source", nil)
+		prime := createBoundPrimePvc(target, "pv1")
+		pv := &corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: "pv1"}}
+		reconciler := createClonePopulatorReconciler(target, prime, pv,
+			createCloneSource("clone-source", "source-pvc"),
+			createPvc("source-pvc", "default", nil, nil))
+		reconcileTarget(reconciler, "testPvc1")
+
+		updatedPv := &corev1.PersistentVolume{}
+		err := reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "pv1"}, updatedPv)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updatedPv.Spec.ClaimRef).ToNot(BeNil())
+		Expect(updatedPv.Spec.ClaimRef.Name).To(Equal("testPvc1"))
+		Expect(updatedPv.Spec.ClaimRef.UID).To(Equal(target.UID))
+	})
+
+	It("Should clean up the prime PVC once the target is bound", func() {
+		target := createPvcWithCloneSourceRef("testPvc1", "clone-source", nil)
+		target.Spec.VolumeName = "pv1"
+		prime := createBoundPrimePvc(target, "pv1")
+		reconciler := createClonePopulatorReconciler(target, prime)
+		reconcileTarget(reconciler, "testPvc1")
+
+		deleted := &corev1.PersistentVolumeClaim{}
+		err := reconciler.client.Get(context.TODO(), types.NamespacedName{Name: clonePrimeName(target), Namespace: "default"}, deleted)
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+
+		event := <-reconciler.recorder.(*record.FakeRecorder).Events
+		Expect(event).To(ContainSubstring(ClonePopulationSucceeded))
+	})
+})
+
+func createPvcWithCloneSourceRef(name, cloneSourceName string, storageClassName *string) *corev1.PersistentVolumeClaim {
+	apiGroup := cdiv1.SchemeGroupVersion.Group
+	pvc := createPvcInStorageClass(name, "default", storageClassName, nil, nil, corev1.ClaimPending)
+	pvc.Spec.DataSourceRef = &corev1.TypedLocalObjectReference{
+		APIGroup: &apiGroup,
+		Kind:     volumeCloneSourceKind,
+		Name:     cloneSourceName,
+	}
+	return pvc
+}
+
+func createCloneSource(name, sourcePvcName string) *cdiv1.VolumeCloneSource {
+	return &cdiv1.VolumeCloneSource{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       cdiv1.VolumeCloneSourceSpec{Source: sourcePvcName},
+	}
+}
+
+func createBoundPrimePvc(target *corev1.PersistentVolumeClaim, volumeName string) *corev1.PersistentVolumeClaim {
+	prime := createPvc(clonePrimeName(target), target.Namespace, map[string]string{
+		AnnCloneRequest: "default/source-pvc",
+		AnnCloneOf:      "true",
+	}, nil)
+	prime.Spec.VolumeName = volumeName
+	prime.Status.Phase = corev1.ClaimBound
+	return prime
+}
+
+func createClonePopulatorReconciler(objects ...runtime.Object) *ClonePopulatorReconciler {
+	objs := []runtime.Object{MakeEmptyCDICR()}
+	objs = append(objs, objects...)
+
+	s := scheme.Scheme
+	cdiv1.AddToScheme(s)
+	snapshotv1.AddToScheme(s)
+	extv1.AddToScheme(s)
+
+	cl := fake.NewFakeClientWithScheme(s, objs...)
+
+	rec := record.NewFakeRecorder(10)
+
+	return &ClonePopulatorReconciler{
+		client:   cl,
+		scheme:   s,
+		log:      clonePopulatorLog,
+		recorder: rec,
+		installerLabels: map[string]string{
+			common.AppKubernetesPartOfLabel:  "testing",
+			common.AppKubernetesVersionLabel: "v0.0.0-tests",
+		},
+	}
+}
@@ -2,8 +2,13 @@ package controller
 
 import (
 	"context"
+	"flag"
+	"fmt"
+	neturl "net/url"
 	"reflect"
 	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/go-logr/logr"
 	ocpconfigv1 "github.com/openshift/api/config/v1"
@@ -29,6 +34,7 @@ import (
 
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	"kubevirt.io/containerized-data-importer/pkg/common"
+	featuregates "kubevirt.io/containerized-data-importer/pkg/feature-gates"
 	"kubevirt.io/containerized-data-importer/pkg/operator"
 	"kubevirt.io/containerized-data-importer/pkg/util"
 )
@@ -40,6 +46,9 @@ const (
 	defaultMemLimit    = "600M"
 	defaultCPURequest  = "100m"
 	defaultMemRequest  = "60M"
+
+	configValidReason   = "ConfigurationValid"
+	configInvalidReason = "ConfigurationInvalid"
 )
 
 // CDIConfigReconciler members
@@ -103,6 +112,10 @@ func (r *CDIConfigReconciler) Reconcile(_ context.Context, req reconcile.Request
 		return reconcile.Result{}, err
 	}
 
+	r.reconcileLogVerbosity(config)
+
+	r.reconcileConditions(config)
+
 	if !reflect.DeepEqual(currentConfigCopy, config) {
 		// Updates have happened, update CDIConfig.
 		log.Info("Updating CDIConfig", "CDIConfig.Name", config.Name, "config", config)
@@ -327,6 +340,89 @@ func validOverhead(overhead cdiv1.Percent) (bool, error) {
 	return regexp.MatchString(`^(0(?:\.\d{1,3})?|1)$`, string(overhead))
 }
 
+// reconcileLogVerbosity applies the LogVerbosity override to the running controller process.
+// Worker pods pick the override up when they are created.
+func (r *CDIConfigReconciler) reconcileLogVerbosity(config *cdiv1.CDIConfig) {
+	if config.Spec.LogVerbosity == nil {
+		return
+	}
+	// the v flag is registered by klog.InitFlags in the controller binary, but not in unit tests
+	if flag.Lookup("v") == nil {
+		return
+	}
+	verbosity := strconv.FormatInt(int64(*config.Spec.LogVerbosity), 10)
+	if flag.Lookup("v").Value.String() == verbosity {
+		return
+	}
+	r.log.Info("Changing log verbosity", "verbosity", verbosity)
+	if err := flag.Set("v", verbosity); err != nil {
+		r.log.Error(err, "Unable to set log verbosity")
+	}
+}
+
+// reconcileConditions reports problems in the CDIConfig spec that the individual reconcile
+// steps silently ignore, such as unparsable import proxy URLs, unknown feature gates or a
+// scratch space storage class that does not resolve to an existing storage class.
+func (r *CDIConfigReconciler) reconcileConditions(config *cdiv1.CDIConfig) {
+	var problems []string
+
+	if config.Spec.ImportProxy != nil {
+		problems = append(problems, validateImportProxyURL("httpProxy", config.Spec.ImportProxy.HTTPProxy)...)
+		problems = append(problems, validateImportProxyURL("httpsProxy", config.Spec.ImportProxy.HTTPSProxy)...)
+	}
+	for _, gate := range config.Spec.FeatureGates {
+		if gate != featuregates.HonorWaitForFirstConsumer {
+			problems = append(problems, fmt.Sprintf("unknown feature gate %s", gate))
+		}
+	}
+	for _, nsGate := range config.Spec.NamespacedFeatureGates {
+		for _, gate := range nsGate.FeatureGates {
+			if gate != featuregates.HonorWaitForFirstConsumer {
+				problems = append(problems, fmt.Sprintf("unknown feature gate %s", gate))
+			}
+		}
+	}
+	if config.Spec.ScratchSpaceStorageClass != nil && config.Status.ScratchSpaceStorageClass != *config.Spec.ScratchSpaceStorageClass {
+		problems = append(problems, fmt.Sprintf("scratch space storage class %s does not exist", *config.Spec.ScratchSpaceStorageClass))
+	}
+
+	if len(problems) > 0 {
+		updateCDIConfigCondition(config, cdiv1.CDIConfigValid, v1.ConditionFalse, strings.Join(problems, "; "), configInvalidReason)
+	} else {
+		updateCDIConfigCondition(config, cdiv1.CDIConfigValid, v1.ConditionTrue, "", configValidReason)
+	}
+}
+
+func validateImportProxyURL(field string, value *string) []string {
+	if value == nil || *value == "" {
+		return nil
+	}
+	if _, err := neturl.ParseRequestURI(*value); err != nil {
+		return []string{fmt.Sprintf("invalid %s URL %s", field, *value)}
+	}
+	return nil
+}
+
+func updateCDIConfigCondition(config *cdiv1.CDIConfig, conditionType cdiv1.CDIConfigConditionType, status v1.ConditionStatus, message, reason string) {
+	if condition := FindCDIConfigConditionByType(config, conditionType); condition != nil {
+		updateConditionState(&condition.ConditionState, status, message, reason)
+	} else {
+		condition = &cdiv1.CDIConfigCondition{Type: conditionType}
+		updateConditionState(&condition.ConditionState, status, message, reason)
+		config.Status.Conditions = append(config.Status.Conditions, *condition)
+	}
+}
+
+// FindCDIConfigConditionByType finds CDIConfigCondition by condition type
+func FindCDIConfigConditionByType(config *cdiv1.CDIConfig, conditionType cdiv1.CDIConfigConditionType) *cdiv1.CDIConfigCondition {
+	for i, condition := range config.Status.Conditions {
+		if condition.Type == conditionType {
+			return &config.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
 // createCDIConfig creates a new instance of the CDIConfig object if it doesn't exist already, and returns the existing one if found.
 // It also sets the operator to be the owner of the CDIConfig object.
 func (r *CDIConfigReconciler) createCDIConfig() (*cdiv1.CDIConfig, error) {
@@ -455,7 +551,7 @@ func NewConfigController(mgr manager.Manager, log logr.Logger, uploadProxyServic
 	}
 
 	configController, err := controller.New("config-controller", mgr, controller.Options{
-		Reconciler: reconciler,
+		Reconciler: NewInstrumentedReconciler("config-controller", reconciler),
 	})
 	if err != nil {
 		return nil, err
@@ -39,6 +39,7 @@ import (
 
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	"kubevirt.io/containerized-data-importer/pkg/common"
+	featuregates "kubevirt.io/containerized-data-importer/pkg/feature-gates"
 	"kubevirt.io/containerized-data-importer/pkg/operator"
 )
 
@@ -398,6 +399,70 @@ var _ = Describe("Controller ImportProxy reconcile loop", func() {
 	})
 })
 
+var _ = Describe("Controller conditions reconcile loop", func() {
+	It("Should set the Valid condition to true on an empty spec", func() {
+		reconciler, cdiConfig := createConfigReconciler()
+		reconciler.reconcileConditions(cdiConfig)
+		condition := FindCDIConfigConditionByType(cdiConfig, cdiv1.CDIConfigValid)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(configValidReason))
+	})
+
+	It("Should set the Valid condition to false on an invalid import proxy URL", func() {
+		reconciler, cdiConfig := createConfigReconciler()
+		cdiConfig.Spec.ImportProxy = createImportProxy("not a proxy url", "", "", "")
+		reconciler.reconcileConditions(cdiConfig)
+		condition := FindCDIConfigConditionByType(cdiConfig, cdiv1.CDIConfigValid)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(configInvalidReason))
+		Expect(condition.Message).To(ContainSubstring("invalid httpProxy URL"))
+	})
+
+	It("Should set the Valid condition to false on an unknown feature gate", func() {
+		reconciler, cdiConfig := createConfigReconciler()
+		cdiConfig.Spec.FeatureGates = []string{featuregates.HonorWaitForFirstConsumer, "NoSuchGate"}
+		reconciler.reconcileConditions(cdiConfig)
+		condition := FindCDIConfigConditionByType(cdiConfig, cdiv1.CDIConfigValid)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+		Expect(condition.Message).To(ContainSubstring("unknown feature gate NoSuchGate"))
+	})
+
+	It("Should set the Valid condition to false when the scratch space storage class does not resolve", func() {
+		reconciler, cdiConfig := createConfigReconciler(createStorageClassList(
+			*createStorageClass("test-default-sc", map[string]string{
+				AnnDefaultStorageClass: "true",
+			}),
+		))
+		override := "invalid"
+		cdiConfig.Spec.ScratchSpaceStorageClass = &override
+		err := reconciler.reconcileStorageClass(cdiConfig)
+		Expect(err).ToNot(HaveOccurred())
+		reconciler.reconcileConditions(cdiConfig)
+		condition := FindCDIConfigConditionByType(cdiConfig, cdiv1.CDIConfigValid)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+		Expect(condition.Message).To(ContainSubstring("scratch space storage class invalid does not exist"))
+	})
+
+	It("Should flip the Valid condition back to true when the problem is fixed", func() {
+		reconciler, cdiConfig := createConfigReconciler()
+		cdiConfig.Spec.FeatureGates = []string{"NoSuchGate"}
+		reconciler.reconcileConditions(cdiConfig)
+		condition := FindCDIConfigConditionByType(cdiConfig, cdiv1.CDIConfigValid)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+
+		cdiConfig.Spec.FeatureGates = nil
+		reconciler.reconcileConditions(cdiConfig)
+		condition = FindCDIConfigConditionByType(cdiConfig, cdiv1.CDIConfigValid)
+		Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+		Expect(cdiConfig.Status.Conditions).To(HaveLen(1))
+	})
+})
+
 var _ = Describe("Controller create CDI config", func() {
 	It("Should return existing cdi config", func() {
 		reconciler, cdiConfig := createConfigReconciler()
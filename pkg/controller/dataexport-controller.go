@@ -0,0 +1,524 @@
+/*
+Copyright 2023 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	sdkapi "kubevirt.io/controller-lifecycle-operator-sdk/api"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/util"
+	"kubevirt.io/containerized-data-importer/pkg/util/naming"
+)
+
+const (
+	dataExportControllerName = "dataexport-controller"
+
+	annCreatedByDataExport = "cdi.kubevirt.io/storage.createdByDataExportController"
+
+	// DataExportSucceededReason is the reason for the event emitted when the image reached the target
+	DataExportSucceededReason = "DataExportSucceeded"
+
+	// DataExportFailedReason is the reason for the event emitted when the exporter pod gave up
+	DataExportFailedReason = "DataExportFailed"
+
+	// ErrInvalidExportTarget is the reason for the event emitted when the export target is invalid
+	ErrInvalidExportTarget = "ErrInvalidExportTarget"
+)
+
+// the exporter pod reports the checksum of the pushed stream in its termination message
+var exportChecksumRegExp = regexp.MustCompile(`checksum=(sha256:[0-9a-f]+)`)
+
+// DataExportReconciler members
+type DataExportReconciler struct {
+	client          client.Client
+	recorder        record.EventRecorder
+	scheme          *runtime.Scheme
+	log             logr.Logger
+	image           string
+	verbose         string
+	pullPolicy      string
+	installerLabels map[string]string
+	shard           ShardConfig
+}
+
+// NewDataExportController creates a new instance of the DataExport controller
+func NewDataExportController(mgr manager.Manager, log logr.Logger, exporterImage, pullPolicy, verbose string, installerLabels map[string]string, shard ShardConfig) (controller.Controller, error) {
+	reconciler := &DataExportReconciler{
+		client:          mgr.GetClient(),
+		recorder:        mgr.GetEventRecorderFor(dataExportControllerName),
+		scheme:          mgr.GetScheme(),
+		log:             log.WithName(dataExportControllerName),
+		image:           exporterImage,
+		verbose:         verbose,
+		pullPolicy:      pullPolicy,
+		installerLabels: installerLabels,
+		shard:           shard,
+	}
+	dataExportController, err := controller.New(dataExportControllerName, mgr, controller.Options{
+		Reconciler: NewInstrumentedReconciler(dataExportControllerName, reconciler),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := addDataExportControllerWatches(mgr, dataExportController); err != nil {
+		return nil, err
+	}
+	log.Info("Initialized DataExport controller")
+	return dataExportController, nil
+}
+
+func addDataExportControllerWatches(mgr manager.Manager, c controller.Controller) error {
+	if err := cdiv1.AddToScheme(mgr.GetScheme()); err != nil {
+		return err
+	}
+	if err := c.Watch(&source.Kind{Type: &cdiv1.DataExport{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+	if err := c.Watch(&source.Kind{Type: &corev1.Pod{}}, &handler.EnqueueRequestForOwner{
+		OwnerType:    &cdiv1.DataExport{},
+		IsController: true,
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Reconcile loop for DataExportReconciler, runs the exporter pod that pushes
+// the source PVC to the target and mirrors the pod outcome into the status
+func (r *DataExportReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	if !r.shard.OwnsNamespace(req.Namespace) {
+		return reconcile.Result{}, nil
+	}
+
+	export := &cdiv1.DataExport{}
+	if err := r.client.Get(ctx, req.NamespacedName, export); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	log := r.log.WithValues("DataExport", req.NamespacedName)
+
+	if export.DeletionTimestamp != nil {
+		// the exporter pod is garbage collected with the export
+		return reconcile.Result{}, nil
+	}
+
+	if export.Status.Phase == cdiv1.DataExportSucceeded || export.Status.Phase == cdiv1.DataExportFailed {
+		return reconcile.Result{}, nil
+	}
+
+	if err := validateExportTarget(export); err != nil {
+		log.V(1).Info("Invalid export target", "error", err.Error())
+		r.recorder.Event(export, corev1.EventTypeWarning, ErrInvalidExportTarget, err.Error())
+		return reconcile.Result{}, nil
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: export.Spec.Source, Namespace: export.Namespace}, pvc); err != nil {
+		if k8serrors.IsNotFound(err) {
+			// source PVC not there yet, pod creation waits for it
+			log.V(1).Info("Source PVC not found", "pvc", export.Spec.Source)
+			return reconcile.Result{RequeueAfter: 5 * time.Second}, r.updateStatus(ctx, export, nil)
+		}
+		return reconcile.Result{}, err
+	}
+
+	pod, err := r.ensureExporterPod(ctx, export, pvc)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err := r.updateStatus(ctx, export, pod); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// poll the pod metrics endpoint for progress while the push runs
+	if pod != nil && pod.Status.Phase == corev1.PodRunning {
+		return reconcile.Result{RequeueAfter: 2 * time.Second}, nil
+	}
+	return reconcile.Result{}, nil
+}
+
+// validateExportTarget checks that exactly one target is configured
+func validateExportTarget(export *cdiv1.DataExport) error {
+	target := export.Spec.Target
+	if (target.S3 == nil) == (target.HTTP == nil) {
+		return errors.New("exactly one of s3 and http must be set as the export target")
+	}
+	return nil
+}
+
+func (r *DataExportReconciler) updateStatus(ctx context.Context, export *cdiv1.DataExport, pod *corev1.Pod) error {
+	exportCopy := export.DeepCopy()
+	if exportCopy.Status.Phase == "" {
+		exportCopy.Status.Phase = cdiv1.DataExportPending
+	}
+
+	if pod != nil {
+		switch pod.Status.Phase {
+		case corev1.PodPending:
+			exportCopy.Status.Phase = cdiv1.DataExportPending
+		case corev1.PodRunning:
+			exportCopy.Status.Phase = cdiv1.DataExportInProgress
+			r.updateProgressFromPod(exportCopy, pod)
+		case corev1.PodSucceeded:
+			r.completeExport(exportCopy, pod)
+		case corev1.PodFailed:
+			exportCopy.Status.Phase = cdiv1.DataExportFailed
+			updateDataExportCondition(exportCopy, cdiv1.DataExportCompleted, corev1.ConditionFalse, DataExportFailedReason, exportTerminationMessage(pod))
+		}
+	}
+
+	if !reflect.DeepEqual(export.Status, exportCopy.Status) {
+		if err := r.client.Status().Update(ctx, exportCopy); err != nil {
+			return err
+		}
+		if exportCopy.Status.Phase != export.Status.Phase {
+			switch exportCopy.Status.Phase {
+			case cdiv1.DataExportSucceeded:
+				r.recorder.Eventf(export, corev1.EventTypeNormal, DataExportSucceededReason, "Export of PVC %s completed, checksum %s", export.Spec.Source, exportCopy.Status.Checksum)
+			case cdiv1.DataExportFailed:
+				r.recorder.Eventf(export, corev1.EventTypeWarning, DataExportFailedReason, "Export of PVC %s failed: %s", export.Spec.Source, exportTerminationMessage(pod))
+			}
+		}
+		export.Status = exportCopy.Status
+	}
+	return nil
+}
+
+// completeExport fills the status from the termination message of the exporter
+// pod, which carries the checksum and the number of bytes pushed
+func (r *DataExportReconciler) completeExport(export *cdiv1.DataExport, pod *corev1.Pod) {
+	message := exportTerminationMessage(pod)
+	export.Status.Phase = cdiv1.DataExportSucceeded
+	export.Status.Progress = cdiv1.DataVolumeProgress("100.0%")
+	if match := exportChecksumRegExp.FindStringSubmatch(message); match != nil {
+		export.Status.Checksum = match[1]
+	}
+	updateDataExportCondition(export, cdiv1.DataExportCompleted, corev1.ConditionTrue, DataExportSucceededReason, message)
+}
+
+// updateProgressFromPod scrapes the exporter pod metrics endpoint, the same
+// way the datavolume controller follows its worker pods
+func (r *DataExportReconciler) updateProgressFromPod(export *cdiv1.DataExport, pod *corev1.Pod) {
+	httpClient := buildHTTPClient()
+	port, err := getPodMetricsPort(pod)
+	if err != nil || pod.Status.PodIP == "" {
+		return
+	}
+
+	url := fmt.Sprintf("https://%s:%d/metrics", pod.Status.PodIP, port)
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return
+	}
+
+	progressReport := fmt.Sprintf("export_progress\\{ownerUID=\"%s\"\\} (\\d{1,3}\\.?\\d*)", export.UID)
+	if match := regexp.MustCompile(progressReport).FindStringSubmatch(string(body)); match != nil {
+		if f, err := strconv.ParseFloat(match[1], 64); err == nil {
+			export.Status.Progress = cdiv1.DataVolumeProgress(fmt.Sprintf("%.2f%%", f))
+		}
+	}
+}
+
+func exportTerminationMessage(pod *corev1.Pod) string {
+	if len(pod.Status.ContainerStatuses) == 0 || pod.Status.ContainerStatuses[0].State.Terminated == nil {
+		return ""
+	}
+	message := pod.Status.ContainerStatuses[0].State.Terminated.Message
+	if termErr := util.TerminationErrorFromMessage(message); termErr != nil {
+		return termErr.Message
+	}
+	return message
+}
+
+func updateDataExportCondition(export *cdiv1.DataExport, conditionType cdiv1.DataExportConditionType, status corev1.ConditionStatus, reason, message string) {
+	for i := range export.Status.Conditions {
+		condition := &export.Status.Conditions[i]
+		if condition.Type != conditionType {
+			continue
+		}
+		if condition.Status != status {
+			condition.LastTransitionTime = metav1.Now()
+		}
+		condition.Status = status
+		condition.Reason = reason
+		condition.Message = message
+		condition.LastHeartbeatTime = metav1.Now()
+		return
+	}
+	export.Status.Conditions = append(export.Status.Conditions, cdiv1.DataExportCondition{
+		Type: conditionType,
+		ConditionState: cdiv1.ConditionState{
+			Status:             status,
+			Reason:             reason,
+			Message:            message,
+			LastTransitionTime: metav1.Now(),
+			LastHeartbeatTime:  metav1.Now(),
+		},
+	})
+}
+
+func (r *DataExportReconciler) ensureExporterPod(ctx context.Context, export *cdiv1.DataExport, pvc *corev1.PersistentVolumeClaim) (*corev1.Pod, error) {
+	podName := exporterResourceName(export)
+
+	pod := &corev1.Pod{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: podName, Namespace: export.Namespace}, pod); err == nil {
+		if !metav1.IsControlledBy(pod, export) {
+			return nil, errors.Errorf("%s pod not controlled by export %s", podName, export.Name)
+		}
+		return pod, nil
+	} else if !k8serrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	return r.createExporterPod(ctx, export, pvc, podName)
+}
+
+// createExporterPod creates the exporter pod manifest and sends it to the server
+func (r *DataExportReconciler) createExporterPod(ctx context.Context, export *cdiv1.DataExport, pvc *corev1.PersistentVolumeClaim, podName string) (*corev1.Pod, error) {
+	podResourceRequirements, err := GetWorkloadPodResourceRequirements(r.client, WorkloadTypeImporter)
+	if err != nil {
+		return nil, err
+	}
+
+	workloadNodePlacement, err := GetWorkloadNodePlacement(r.client)
+	if err != nil {
+		return nil, err
+	}
+
+	image, pullPolicy, err := GetWorkerImageAndPullPolicy(r.client, r.image, r.pullPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	pod := r.makeExporterPodSpec(export, pvc, podName, image, pullPolicy, podResourceRequirements, workloadNodePlacement)
+	util.SetRecommendedLabels(pod, r.installerLabels, "cdi-controller")
+	if err := ApplyWorkloadMetadata(r.client, pod); err != nil {
+		return nil, err
+	}
+	if err := ApplySecurityProfile(r.client, pod); err != nil {
+		return nil, err
+	}
+	if err := ApplyArchitectureAffinity(r.client, pod); err != nil {
+		return nil, err
+	}
+
+	if err := ensureWorkerServiceAccount(r.client, pod.Namespace, common.ExportServerServiceAccountName); err != nil {
+		return nil, err
+	}
+
+	if err := r.client.Create(ctx, pod); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return nil, err
+	}
+
+	r.log.V(1).Info("exporter pod created", "Namespace", pod.Namespace, "Name", pod.Name, "Image name", r.image)
+	return pod, nil
+}
+
+func (r *DataExportReconciler) makeExporterPodSpec(export *cdiv1.DataExport, pvc *corev1.PersistentVolumeClaim, podName, image, pullPolicy string, resourceRequirements *corev1.ResourceRequirements, workloadNodePlacement *sdkapi.NodePlacement) *corev1.Pod {
+	target, endpoint, secretRef := exportTargetParams(export)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      podName,
+			Namespace: export.Namespace,
+			Annotations: map[string]string{
+				annCreatedByDataExport: "yes",
+			},
+			Labels: map[string]string{
+				common.CDILabelKey:       common.CDILabelValue,
+				common.CDIComponentLabel: common.ExporterPodName,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				MakeDataExportOwnerReference(export),
+			},
+		},
+		Spec: corev1.PodSpec{
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsUser: &[]int64{0}[0],
+			},
+			Containers: []corev1.Container{
+				{
+					Name:            common.ExporterPodName,
+					Image:           image,
+					ImagePullPolicy: corev1.PullPolicy(pullPolicy),
+					// the exporter shares the export server image
+					Command: []string{"/usr/bin/cdi-exporter"},
+					Args:    []string{"-v=" + getLogVerbosity(r.client, r.verbose)},
+					Ports: []corev1.ContainerPort{
+						{
+							Name:          "metrics",
+							ContainerPort: 8443,
+							Protocol:      corev1.ProtocolTCP,
+						},
+					},
+					Env: []corev1.EnvVar{
+						{
+							Name:  common.OwnerUID,
+							Value: string(export.UID),
+						},
+						{
+							Name:  common.ExporterTargetVar,
+							Value: target,
+						},
+						{
+							Name:  common.ExporterEndpointVar,
+							Value: endpoint,
+						},
+						{
+							Name:  common.ExportFormatVar,
+							Value: string(export.Spec.Format),
+						},
+					},
+				},
+			},
+			RestartPolicy:      corev1.RestartPolicyOnFailure,
+			ServiceAccountName: common.ExportServerServiceAccountName,
+			Volumes: []corev1.Volume{
+				{
+					Name: DataVolName,
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: pvc.Name,
+							ReadOnly:  true,
+						},
+					},
+				},
+			},
+			NodeSelector: workloadNodePlacement.NodeSelector,
+			Tolerations:  workloadNodePlacement.Tolerations,
+			Affinity:     workloadNodePlacement.Affinity,
+		},
+	}
+
+	if secretRef != "" {
+		// the credentials never appear in the pod spec
+		pod.Spec.Containers[0].Env = append(pod.Spec.Containers[0].Env, corev1.EnvVar{
+			Name: common.ExporterAccessKeyID,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: secretRef,
+					},
+					Key: common.KeyAccess,
+				},
+			},
+		}, corev1.EnvVar{
+			Name: common.ExporterSecretKey,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: secretRef,
+					},
+					Key: common.KeySecret,
+				},
+			},
+		})
+	}
+
+	if resourceRequirements != nil {
+		pod.Spec.Containers[0].Resources = *resourceRequirements
+	}
+
+	if getVolumeMode(pvc) == corev1.PersistentVolumeBlock {
+		pod.Spec.Containers[0].VolumeDevices = []corev1.VolumeDevice{
+			{
+				Name:       DataVolName,
+				DevicePath: common.WriteBlockPath,
+			},
+		}
+		pod.Spec.Containers[0].Env = append(pod.Spec.Containers[0].Env, corev1.EnvVar{
+			Name:  common.ExportSourceVar,
+			Value: common.WriteBlockPath,
+		})
+	} else {
+		pod.Spec.Containers[0].VolumeMounts = []corev1.VolumeMount{
+			{
+				Name:      DataVolName,
+				MountPath: common.ImporterDataDir,
+				ReadOnly:  true,
+			},
+		}
+		pod.Spec.Containers[0].Env = append(pod.Spec.Containers[0].Env, corev1.EnvVar{
+			Name:  common.ExportSourceVar,
+			Value: common.ImporterWritePath,
+		})
+	}
+
+	return pod
+}
+
+// exportTargetParams flattens the target union into the exporter pod env values
+func exportTargetParams(export *cdiv1.DataExport) (target, endpoint, secretRef string) {
+	if s3 := export.Spec.Target.S3; s3 != nil {
+		return common.ExporterTargetS3, s3.URL, s3.SecretRef
+	}
+	if http := export.Spec.Target.HTTP; http != nil {
+		return common.ExporterTargetHTTP, http.URL, http.SecretRef
+	}
+	return "", "", ""
+}
+
+// exporterResourceName returns the name given to exporter pods
+func exporterResourceName(export *cdiv1.DataExport) string {
+	return naming.GetResourceName(common.ExporterPodName, export.Name)
+}
+
+// MakeDataExportOwnerReference makes owner reference from a DataExport
+func MakeDataExportOwnerReference(export *cdiv1.DataExport) metav1.OwnerReference {
+	blockOwnerDeletion := true
+	isController := true
+	return metav1.OwnerReference{
+		APIVersion:         cdiv1.SchemeGroupVersion.String(),
+		Kind:               "DataExport",
+		Name:               export.Name,
+		UID:                export.GetUID(),
+		BlockOwnerDeletion: &blockOwnerDeletion,
+		Controller:         &isController,
+	}
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2023 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+var (
+	dataExportLog = logf.Log.WithName("dataexport-controller-test")
+)
+
+var _ = Describe("Data export controller reconcile loop", func() {
+
+	reconcileDataExport := func(r *DataExportReconciler, name string) {
+		_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: "default"}})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	getDataExport := func(r *DataExportReconciler, name string) *cdiv1.DataExport {
+		export := &cdiv1.DataExport{}
+		err := r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: "default"}, export)
+		Expect(err).ToNot(HaveOccurred())
+		return export
+	}
+
+	It("Should return nil if the export can not be found", func() {
+		reconciler := createDataExportReconciler()
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "testExport", Namespace: "default"}})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("Should emit an event and create nothing on an invalid target", func() {
+		export := createDataExport("testExport", cdiv1.DataExportTarget{})
+		reconciler := createDataExportReconciler(export)
+		reconcileDataExport(reconciler, "testExport")
+
+		pod := &corev1.Pod{}
+		err := reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "exporter-testExport", Namespace: "default"}, pod)
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+
+		event := <-reconciler.recorder.(*record.FakeRecorder).Events
+		Expect(event).To(ContainSubstring(ErrInvalidExportTarget))
+	})
+
+	It("Should stay pending while the source PVC does not exist", func() {
+		export := createDataExport("testExport", cdiv1.DataExportTarget{
+			HTTP: &cdiv1.DataExportTargetHTTP{URL: "https://example.com/disk.img"},
+		})
+		reconciler := createDataExportReconciler(export)
+		reconcileDataExport(reconciler, "testExport")
+
+		updated := getDataExport(reconciler, "testExport")
+		Expect(updated.Status.Phase).To(Equal(cdiv1.DataExportPending))
+	})
+
+	It("Should create the exporter pod with target and credential env vars", func() {
+		export := createDataExport("testExport", cdiv1.DataExportTarget{
+			S3: &cdiv1.DataExportTargetS3{URL: "https://s3.amazonaws.com/bucket/disk.img", SecretRef: "endpoint-secret"},
+		})
+		reconciler := createDataExportReconciler(export, createPvc("testPvc1", "default", nil, nil))
+		reconcileDataExport(reconciler, "testExport")
+
+		pod := &corev1.Pod{}
+		err := reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "exporter-testExport", Namespace: "default"}, pod)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(metav1.IsControlledBy(pod, export)).To(BeTrue())
+		Expect(pod.Spec.Containers[0].Command).To(Equal([]string{"/usr/bin/cdi-exporter"}))
+
+		env := pod.Spec.Containers[0].Env
+		Expect(env).To(ContainElement(corev1.EnvVar{Name: common.ExporterTargetVar, Value: common.ExporterTargetS3}))
+		Expect(env).To(ContainElement(corev1.EnvVar{Name: common.ExporterEndpointVar, Value: "https://s3.amazonaws.com/bucket/disk.img"}))
+		// the credentials are injected from the secret, not inlined
+		for _, e := range env {
+			if e.Name == common.ExporterAccessKeyID || e.Name == common.ExporterSecretKey {
+				Expect(e.Value).To(BeEmpty())
+				Expect(e.ValueFrom.SecretKeyRef.Name).To(Equal("endpoint-secret"))
+			}
+		}
+
+		updated := getDataExport(reconciler, "testExport")
+		Expect(updated.Status.Phase).To(Equal(cdiv1.DataExportPending))
+	})
+
+	It("Should succeed with checksum and condition once the pod terminated", func() {
+		export := createDataExport("testExport", cdiv1.DataExportTarget{
+			HTTP: &cdiv1.DataExportTargetHTTP{URL: "https://example.com/disk.img"},
+		})
+		pod := createExporterPod(export, corev1.PodSucceeded,
+			`{"code": "Completed", "message": "Export Complete; checksum=sha256:abc123", "bytesDone": 1024}`)
+		reconciler := createDataExportReconciler(export, createPvc("testPvc1", "default", nil, nil), pod)
+		reconcileDataExport(reconciler, "testExport")
+
+		updated := getDataExport(reconciler, "testExport")
+		Expect(updated.Status.Phase).To(Equal(cdiv1.DataExportSucceeded))
+		Expect(updated.Status.Checksum).To(Equal("sha256:abc123"))
+		Expect(string(updated.Status.Progress)).To(Equal("100.0%"))
+		Expect(updated.Status.Conditions).To(HaveLen(1))
+		Expect(updated.Status.Conditions[0].Type).To(Equal(cdiv1.DataExportCompleted))
+		Expect(updated.Status.Conditions[0].Status).To(Equal(corev1.ConditionTrue))
+
+		event := <-reconciler.recorder.(*record.FakeRecorder).Events
+		Expect(event).To(ContainSubstring(DataExportSucceededReason))
+	})
+
+	It("Should fail with a false condition when the pod failed", func() {
+		export := createDataExport("testExport", cdiv1.DataExportTarget{
+			HTTP: &cdiv1.DataExportTargetHTTP{URL: "https://example.com/disk.img"},
+		})
+		pod := createExporterPod(export, corev1.PodFailed,
+			`{"code": "ErrConnectionRefused", "message": "connection refused", "retryable": true}`)
+		reconciler := createDataExportReconciler(export, createPvc("testPvc1", "default", nil, nil), pod)
+		reconcileDataExport(reconciler, "testExport")
+
+		updated := getDataExport(reconciler, "testExport")
+		Expect(updated.Status.Phase).To(Equal(cdiv1.DataExportFailed))
+		Expect(updated.Status.Conditions).To(HaveLen(1))
+		Expect(updated.Status.Conditions[0].Status).To(Equal(corev1.ConditionFalse))
+		Expect(updated.Status.Conditions[0].Message).To(ContainSubstring("connection refused"))
+
+		event := <-reconciler.recorder.(*record.FakeRecorder).Events
+		Expect(event).To(ContainSubstring(DataExportFailedReason))
+	})
+})
+
+func createDataExport(name string, target cdiv1.DataExportTarget) *cdiv1.DataExport {
+	return &cdiv1.DataExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			UID:       types.UID("dataexport-uid"),
+		},
+		Spec: cdiv1.DataExportSpec{
+			Source: "testPvc1",
+			Target: target,
+		},
+	}
+}
+
+func createExporterPod(export *cdiv1.DataExport, phase corev1.PodPhase, terminationMessage string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      exporterResourceName(export),
+			Namespace: export.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				MakeDataExportOwnerReference(export),
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: phase,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Message: terminationMessage,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func createDataExportReconciler(objects ...runtime.Object) *DataExportReconciler {
+	objs := []runtime.Object{MakeEmptyCDICR()}
+	objs = append(objs, objects...)
+	cdiConfig := MakeEmptyCDIConfigSpec(common.ConfigName)
+	cdiConfig.Status = cdiv1.CDIConfigStatus{
+		DefaultPodResourceRequirements: createDefaultPodResourceRequirements("", "", "", ""),
+	}
+	objs = append(objs, cdiConfig)
+
+	s := scheme.Scheme
+	cdiv1.AddToScheme(s)
+
+	cl := fake.NewFakeClientWithScheme(s, objs...)
+
+	rec := record.NewFakeRecorder(10)
+
+	return &DataExportReconciler{
+		client:   cl,
+		scheme:   s,
+		log:      dataExportLog,
+		recorder: rec,
+		installerLabels: map[string]string{
+			common.AppKubernetesPartOfLabel:  "testing",
+			common.AppKubernetesVersionLabel: "v0.0.0-tests",
+		},
+	}
+}
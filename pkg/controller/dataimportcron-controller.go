@@ -18,7 +18,10 @@ package controller
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"net/http"
 	"net/url"
 	"reflect"
 	"sort"
@@ -134,7 +137,7 @@ func (r *DataImportCronReconciler) initCron(ctx context.Context, dataImportCron
 		if err := r.client.Create(ctx, job); err != nil {
 			return err
 		}
-	} else if isImageStreamSource(dataImportCron) && dataImportCron.Annotations[AnnNextCronTime] == "" {
+	} else if (isImageStreamSource(dataImportCron) || isHTTPSource(dataImportCron)) && dataImportCron.Annotations[AnnNextCronTime] == "" {
 		AddAnnotation(dataImportCron, AnnNextCronTime, time.Now().Format(time.RFC3339))
 	}
 	return nil
@@ -197,14 +200,20 @@ func splitImageStreamName(imageStreamName string) (string, string, error) {
 	return "", "", errors.Errorf("Illegal ImageStream name %s", imageStreamName)
 }
 
-func (r *DataImportCronReconciler) pollImageStreamDigest(ctx context.Context, dataImportCron *cdiv1.DataImportCron) (reconcile.Result, error) {
+// pollSourceDigest polls sources the controller follows itself, ImageStreams
+// and HTTP URLs, once the next scheduled cron time passed
+func (r *DataImportCronReconciler) pollSourceDigest(ctx context.Context, dataImportCron *cdiv1.DataImportCron) (reconcile.Result, error) {
 	if nextTimeStr := dataImportCron.Annotations[AnnNextCronTime]; nextTimeStr != "" {
 		nextTime, err := time.Parse(time.RFC3339, nextTimeStr)
 		if err != nil {
 			return reconcile.Result{}, err
 		}
 		if nextTime.Before(time.Now()) {
-			if err := r.updateImageStreamDesiredDigest(ctx, dataImportCron); err != nil {
+			if isHTTPSource(dataImportCron) {
+				if err := r.updateHTTPDesiredDigest(ctx, dataImportCron); err != nil {
+					return reconcile.Result{}, err
+				}
+			} else if err := r.updateImageStreamDesiredDigest(ctx, dataImportCron); err != nil {
 				return reconcile.Result{}, err
 			}
 		}
@@ -235,6 +244,11 @@ func isURLSource(dataImportCron *cdiv1.DataImportCron) bool {
 	return err == nil && regSource.URL != nil
 }
 
+func isHTTPSource(dataImportCron *cdiv1.DataImportCron) bool {
+	source := dataImportCron.Spec.Template.Spec.Source
+	return source != nil && source.HTTP != nil
+}
+
 func getCronRegistrySource(cron *cdiv1.DataImportCron) (*cdiv1.DataVolumeSourceRegistry, error) {
 	source := cron.Spec.Template.Spec.Source
 	if source == nil || source.Registry == nil {
@@ -295,8 +309,8 @@ func (r *DataImportCronReconciler) update(ctx context.Context, dataImportCron *c
 
 	// We use the poller returned reconcile.Result for RequeueAfter if needed
 	var err error
-	if isImageStreamSource(dataImportCron) {
-		res, err = r.pollImageStreamDigest(ctx, dataImportCron)
+	if isImageStreamSource(dataImportCron) || isHTTPSource(dataImportCron) {
+		res, err = r.pollSourceDigest(ctx, dataImportCron)
 		if err != nil {
 			return res, err
 		}
@@ -374,6 +388,53 @@ func (r *DataImportCronReconciler) updateImageStreamDesiredDigest(ctx context.Co
 	return nil
 }
 
+// updateHTTPDesiredDigest HEADs the source URL and derives the desired digest
+// from its ETag or Last-Modified header. The header value is hashed into a
+// sha256 pseudo digest, so DataVolume naming and digest comparison work the
+// same as for registry sources.
+func (r *DataImportCronReconciler) updateHTTPDesiredDigest(ctx context.Context, dataImportCron *cdiv1.DataImportCron) error {
+	log := r.log.WithValues("name", dataImportCron.Name).WithValues("uid", dataImportCron.UID)
+	httpSource := dataImportCron.Spec.Template.Spec.Source.HTTP
+
+	req, err := http.NewRequest(http.MethodHead, httpSource.URL, nil)
+	if err != nil {
+		return err
+	}
+	if httpSource.SecretRef != "" {
+		secret := &corev1.Secret{}
+		if err := r.client.Get(ctx, types.NamespacedName{Name: httpSource.SecretRef, Namespace: dataImportCron.Namespace}, secret); err != nil {
+			return err
+		}
+		req.SetBasicAuth(string(secret.Data[common.KeyAccess]), string(secret.Data[common.KeySecret]))
+	}
+
+	// change detection only, the import itself still verifies the server certificate
+	resp, err := buildHTTPClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("HEAD %s returned status %d", httpSource.URL, resp.StatusCode)
+	}
+
+	version := strings.Trim(strings.TrimPrefix(resp.Header.Get("ETag"), "W/"), "\"")
+	if version == "" {
+		version = resp.Header.Get("Last-Modified")
+	}
+	if version == "" {
+		return errors.Errorf("source %s provides neither ETag nor Last-Modified", httpSource.URL)
+	}
+
+	hash := sha256.Sum256([]byte(version))
+	digest := digestPrefix + hex.EncodeToString(hash[:])
+	if dataImportCron.Annotations[AnnSourceDesiredDigest] != digest {
+		log.Info("Updating DataImportCron", "digest", digest, "version", version)
+		AddAnnotation(dataImportCron, AnnSourceDesiredDigest, digest)
+	}
+	return nil
+}
+
 func (r *DataImportCronReconciler) updateDataSource(ctx context.Context, dataImportCron *cdiv1.DataImportCron) error {
 	log := r.log.WithName("updateDataSource")
 	dataSourceName := dataImportCron.Spec.ManagedDataSource
@@ -586,7 +647,7 @@ func NewDataImportCronController(mgr manager.Manager, log logr.Logger, importerI
 		cdiNamespace:    util.GetNamespace(),
 		installerLabels: installerLabels,
 	}
-	dataImportCronController, err := controller.New(dataImportControllerName, mgr, controller.Options{Reconciler: reconciler})
+	dataImportCronController, err := controller.New(dataImportControllerName, mgr, controller.Options{Reconciler: NewInstrumentedReconciler(dataImportControllerName, reconciler)})
 	if err != nil {
 		return nil, err
 	}
@@ -807,12 +868,14 @@ func (r *DataImportCronReconciler) newSourceDataVolume(cron *cdiv1.DataImportCro
 	dv := cron.Spec.Template.DeepCopy()
 	if isURLSource(cron) {
 		digestedURL = untagDigestedDockerURL(*dv.Spec.Source.Registry.URL + "@" + cron.Annotations[AnnSourceDesiredDigest])
+		dv.Spec.Source.Registry.URL = &digestedURL
 	} else if isImageStreamSource(cron) {
 		// No way to import image stream by name when we want speciific digest, so we use its docker reference
 		digestedURL = "docker://" + cron.Annotations[AnnImageStreamDockerRef]
 		dv.Spec.Source.Registry.ImageStream = nil
+		dv.Spec.Source.Registry.URL = &digestedURL
 	}
-	dv.Spec.Source.Registry.URL = &digestedURL
+	// HTTP sources re-import from the template URL as is, the content changed server side
 	dv.Name = dataVolumeName
 	dv.Namespace = cron.Namespace
 	r.setDataImportCronResourceLabels(cron, dv)
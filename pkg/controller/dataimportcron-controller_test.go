@@ -19,6 +19,8 @@ package controller
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"time"
 
 	"github.com/google/uuid"
@@ -396,6 +398,87 @@ var _ = Describe("All DataImportCron Tests", func() {
 			Entry("has tag", imageStreamName+":"+imageStreamTag, 0),
 			Entry("has no tag", imageStreamName, 1),
 		)
+
+		It("Should poll the HTTP source and start an import on ETag change", func() {
+			etag := `"v1"`
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.Method).To(Equal(http.MethodHead))
+				w.Header().Set("ETag", etag)
+			}))
+			defer server.Close()
+			url := server.URL + "/disk.img"
+
+			cron = newDataImportCronWithHTTPSource(cronName, url)
+			reconciler = createDataImportCronReconciler(cron)
+			_, err := reconciler.Reconcile(context.TODO(), cronReq)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = reconciler.client.Get(context.TODO(), cronKey, cron)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cron.Annotations[AnnNextCronTime]).ToNot(BeEmpty())
+
+			pollNow := func() {
+				cron.Annotations[AnnNextCronTime] = time.Now().Format(time.RFC3339)
+				err = reconciler.client.Update(context.TODO(), cron)
+				Expect(err).ToNot(HaveOccurred())
+				_, err = reconciler.Reconcile(context.TODO(), cronReq)
+				Expect(err).ToNot(HaveOccurred())
+				err = reconciler.client.Get(context.TODO(), cronKey, cron)
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			pollNow()
+			digest := cron.Annotations[AnnSourceDesiredDigest]
+			Expect(digest).To(HavePrefix(digestPrefix))
+
+			imports := cron.Status.CurrentImports
+			Expect(imports).ToNot(BeEmpty())
+			Expect(imports[0].Digest).To(Equal(digest))
+
+			dv := &cdiv1.DataVolume{}
+			err = reconciler.client.Get(context.TODO(), dvKey(imports[0].DataVolumeName), dv)
+			Expect(err).ToNot(HaveOccurred())
+			// HTTP sources re-import from the template URL as is
+			Expect(dv.Spec.Source.HTTP.URL).To(Equal(url))
+
+			// the same ETag must not change the desired digest
+			pollNow()
+			Expect(cron.Annotations[AnnSourceDesiredDigest]).To(Equal(digest))
+
+			// a new ETag must
+			etag = `"v2"`
+			pollNow()
+			Expect(cron.Annotations[AnnSourceDesiredDigest]).ToNot(Equal(digest))
+		})
+
+		It("Should fall back to Last-Modified when the HTTP source has no ETag", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Last-Modified", "Wed, 21 Oct 2015 07:28:00 GMT")
+			}))
+			defer server.Close()
+
+			cron = newDataImportCronWithHTTPSource(cronName, server.URL)
+			cron.Annotations[AnnNextCronTime] = time.Now().Format(time.RFC3339)
+			reconciler = createDataImportCronReconciler(cron)
+			_, err := reconciler.Reconcile(context.TODO(), cronReq)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = reconciler.client.Get(context.TODO(), cronKey, cron)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cron.Annotations[AnnSourceDesiredDigest]).To(HavePrefix(digestPrefix))
+		})
+
+		It("Should fail when the HTTP source provides no version header", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+			defer server.Close()
+
+			cron = newDataImportCronWithHTTPSource(cronName, server.URL)
+			cron.Annotations[AnnNextCronTime] = time.Now().Format(time.RFC3339)
+			reconciler = createDataImportCronReconciler(cron)
+			_, err := reconciler.Reconcile(context.TODO(), cronReq)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("neither ETag nor Last-Modified"))
+		})
 	})
 })
 
@@ -438,6 +521,14 @@ func newDataImportCronWithImageStream(dataImportCronName, taggedImageStreamName
 	return cron
 }
 
+func newDataImportCronWithHTTPSource(dataImportCronName, url string) *cdiv1.DataImportCron {
+	cron := newDataImportCron(dataImportCronName)
+	cron.Spec.Template.Spec.Source = &cdiv1.DataVolumeSource{
+		HTTP: &cdiv1.DataVolumeSourceHTTP{URL: url},
+	}
+	return cron
+}
+
 func newImageStream(name string) *imagev1.ImageStream {
 	return &imagev1.ImageStream{
 		TypeMeta: metav1.TypeMeta{APIVersion: imagev1.SchemeGroupVersion.String()},
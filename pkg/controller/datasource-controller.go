@@ -20,10 +20,13 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/go-logr/logr"
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
@@ -48,8 +51,12 @@ type DataSourceReconciler struct {
 }
 
 const (
-	ready = "Ready"
-	noPvc = "NoPvc"
+	ready            = "Ready"
+	noPvc            = "NoPvc"
+	snapshotNotReady = "SnapshotNotReady"
+
+	// there is no watch on VolumeSnapshots, poll until the source snapshot is usable
+	dataSourceSnapshotRequeue = 10 * time.Second
 )
 
 // Reconcile loop for DataSourceReconciler
@@ -64,13 +71,15 @@ func (r *DataSourceReconciler) Reconcile(ctx context.Context, req reconcile.Requ
 	if err := r.update(ctx, dataSource); err != nil {
 		return reconcile.Result{}, err
 	}
+	if dataSource.Spec.Source.Snapshot != nil && !dataSourceIsReady(dataSource) {
+		return reconcile.Result{RequeueAfter: dataSourceSnapshotRequeue}, nil
+	}
 	return reconcile.Result{}, nil
 }
 
 func (r *DataSourceReconciler) update(ctx context.Context, dataSource *cdiv1.DataSource) error {
 	dataSourceCopy := dataSource.DeepCopy()
-	sourcePVC := dataSource.Spec.Source.PVC
-	if sourcePVC != nil {
+	if sourcePVC := dataSource.Spec.Source.PVC; sourcePVC != nil {
 		dv := &cdiv1.DataVolume{}
 		ns := getNamespace(sourcePVC.Namespace, dataSource.Namespace)
 		if err := r.client.Get(ctx, types.NamespacedName{Namespace: ns, Name: sourcePVC.Name}, dv); err != nil {
@@ -85,6 +94,21 @@ func (r *DataSourceReconciler) update(ctx context.Context, dataSource *cdiv1.Dat
 		} else {
 			updateDataSourceCondition(dataSource, cdiv1.DataSourceReady, corev1.ConditionFalse, fmt.Sprintf("Import DataVolume phase %s", dv.Status.Phase), string(dv.Status.Phase))
 		}
+	} else if sourceSnapshot := dataSource.Spec.Source.Snapshot; sourceSnapshot != nil {
+		snapshot := &snapshotv1.VolumeSnapshot{}
+		ns := getNamespace(sourceSnapshot.Namespace, dataSource.Namespace)
+		if err := r.client.Get(ctx, types.NamespacedName{Namespace: ns, Name: sourceSnapshot.Name}, snapshot); err != nil {
+			if k8serrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+				r.log.Info("VolumeSnapshot not found", "name", sourceSnapshot.Name)
+				updateDataSourceCondition(dataSource, cdiv1.DataSourceReady, corev1.ConditionFalse, "VolumeSnapshot not found", notFound)
+			} else {
+				return err
+			}
+		} else if snapshot.Status != nil && snapshot.Status.ReadyToUse != nil && *snapshot.Status.ReadyToUse {
+			updateDataSourceCondition(dataSource, cdiv1.DataSourceReady, corev1.ConditionTrue, "DataSource is ready to be consumed", ready)
+		} else {
+			updateDataSourceCondition(dataSource, cdiv1.DataSourceReady, corev1.ConditionFalse, "VolumeSnapshot not ready", snapshotNotReady)
+		}
 	} else {
 		updateDataSourceCondition(dataSource, cdiv1.DataSourceReady, corev1.ConditionFalse, "No source PVC set", noPvc)
 	}
@@ -96,6 +120,13 @@ func (r *DataSourceReconciler) update(ctx context.Context, dataSource *cdiv1.Dat
 	return nil
 }
 
+// dataSourceIsReady checks the Ready condition published above, consumers wait
+// for it instead of inspecting the source themselves
+func dataSourceIsReady(ds *cdiv1.DataSource) bool {
+	condition := FindDataSourceConditionByType(ds, cdiv1.DataSourceReady)
+	return condition != nil && condition.Status == corev1.ConditionTrue
+}
+
 func updateDataSourceCondition(ds *cdiv1.DataSource, conditionType cdiv1.DataSourceConditionType, status corev1.ConditionStatus, message, reason string) {
 	if condition := FindDataSourceConditionByType(ds, conditionType); condition != nil {
 		updateConditionState(&condition.ConditionState, status, message, reason)
@@ -125,7 +156,7 @@ func NewDataSourceController(mgr manager.Manager, log logr.Logger, installerLabe
 		log:             log.WithName(dataImportControllerName),
 		installerLabels: installerLabels,
 	}
-	DataSourceController, err := controller.New(dataImportControllerName, mgr, controller.Options{Reconciler: reconciler})
+	DataSourceController, err := controller.New(dataImportControllerName, mgr, controller.Options{Reconciler: NewInstrumentedReconciler(dataImportControllerName, reconciler)})
 	if err != nil {
 		return nil, err
 	}
@@ -144,7 +175,7 @@ func addDataSourceControllerWatches(mgr manager.Manager, c controller.Controller
 		predicate.Funcs{
 			CreateFunc: func(e event.CreateEvent) bool { return true },
 			DeleteFunc: func(e event.DeleteEvent) bool { return true },
-			UpdateFunc: func(e event.UpdateEvent) bool { return !sameDataSourcePvc(e.ObjectOld, e.ObjectNew) },
+			UpdateFunc: func(e event.UpdateEvent) bool { return !sameDataSourceSource(e.ObjectOld, e.ObjectNew) },
 		},
 	); err != nil {
 		return err
@@ -197,10 +228,10 @@ func addDataSourceControllerWatches(mgr manager.Manager, c controller.Controller
 	return nil
 }
 
-func sameDataSourcePvc(objOld, objNew client.Object) bool {
+func sameDataSourceSource(objOld, objNew client.Object) bool {
 	dsOld, okOld := objOld.(*cdiv1.DataSource)
 	dsNew, okNew := objNew.(*cdiv1.DataSource)
-	return okOld && okNew && reflect.DeepEqual(dsOld.Spec.Source.PVC, dsNew.Spec.Source.PVC)
+	return okOld && okNew && reflect.DeepEqual(dsOld.Spec.Source, dsNew.Spec.Source)
 }
 
 func getNamespace(namespace, defaultNamespace string) string {
@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -32,8 +33,9 @@ import (
 )
 
 const (
-	dsName  = "test-datasource"
-	pvcName = "test-pvc"
+	dsName   = "test-datasource"
+	pvcName  = "test-pvc"
+	snapName = "test-snapshot"
 )
 
 var _ = Describe("All DataSource Tests", func() {
@@ -93,12 +95,40 @@ var _ = Describe("All DataSource Tests", func() {
 			Expect(err).ToNot(HaveOccurred())
 			verifyConditions("Source DV Deleted", false, notFound)
 		})
+
+		It("Should update Ready condition when DataSource has source snapshot", func() {
+			ds = createDataSource()
+			ds.Spec.Source.Snapshot = &cdiv1.DataVolumeSourceSnapshot{Namespace: metav1.NamespaceDefault, Name: snapName}
+			reconciler = createDataSourceReconciler(ds)
+			verifyConditions("Source snapshot does not exist", false, notFound)
+
+			snapshot := &snapshotv1.VolumeSnapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      snapName,
+					Namespace: metav1.NamespaceDefault,
+				},
+			}
+			err := reconciler.client.Create(context.TODO(), snapshot)
+			Expect(err).ToNot(HaveOccurred())
+			verifyConditions("Source snapshot not ready", false, snapshotNotReady)
+
+			readyToUse := true
+			snapshot.Status = &snapshotv1.VolumeSnapshotStatus{ReadyToUse: &readyToUse}
+			err = reconciler.client.Update(context.TODO(), snapshot)
+			Expect(err).ToNot(HaveOccurred())
+			verifyConditions("Source snapshot ready", true, ready)
+
+			err = reconciler.client.Delete(context.TODO(), snapshot)
+			Expect(err).ToNot(HaveOccurred())
+			verifyConditions("Source snapshot deleted", false, notFound)
+		})
 	})
 })
 
 func createDataSourceReconciler(objects ...runtime.Object) *DataSourceReconciler {
 	s := scheme.Scheme
 	cdiv1.AddToScheme(s)
+	snapshotv1.AddToScheme(s)
 	cl := fake.NewFakeClientWithScheme(s, objects...)
 	r := &DataSourceReconciler{
 		client: cl,
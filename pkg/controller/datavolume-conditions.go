@@ -32,6 +32,12 @@ const (
 	pvcPending      = "Pending"
 	claimLost       = "ClaimLost"
 	notFound        = "NotFound"
+	// accessModeDegraded is the reason for the Degraded condition when requested access modes are unsupported
+	accessModeDegraded = "AccessModeDegraded"
+	// dataSourceNotReady is the reason for the SourceRefNotReady condition while the DataSource cannot be consumed
+	dataSourceNotReady = "DataSourceNotReady"
+	// sourceUnreachable is the reason for the SourceUnreachable condition while the source fails the connectivity pre-check
+	sourceUnreachable = "SourceUnreachable"
 )
 
 func findConditionByType(conditionType cdiv1.DataVolumeConditionType, conditions []cdiv1.DataVolumeCondition) *cdiv1.DataVolumeCondition {
@@ -121,6 +127,42 @@ func updateReadyCondition(conditions []cdiv1.DataVolumeCondition, status corev1.
 	return updateCondition(conditions, cdiv1.DataVolumeReady, status, message, reason)
 }
 
+func updateDegradedCondition(conditions []cdiv1.DataVolumeCondition, degraded bool, message, reason string) []cdiv1.DataVolumeCondition {
+	if !degraded && findConditionByType(cdiv1.DataVolumeDegraded, conditions) == nil {
+		// Do not add the condition to DataVolumes that were never degraded
+		return conditions
+	}
+	status := corev1.ConditionFalse
+	if degraded {
+		status = corev1.ConditionTrue
+	}
+	return updateCondition(conditions, cdiv1.DataVolumeDegraded, status, message, reason)
+}
+
+func updateSourceRefNotReadyCondition(conditions []cdiv1.DataVolumeCondition, notReady bool, message, reason string) []cdiv1.DataVolumeCondition {
+	if !notReady && findConditionByType(cdiv1.DataVolumeSourceRefNotReady, conditions) == nil {
+		// Do not add the condition to DataVolumes that never waited for a DataSource
+		return conditions
+	}
+	status := corev1.ConditionFalse
+	if notReady {
+		status = corev1.ConditionTrue
+	}
+	return updateCondition(conditions, cdiv1.DataVolumeSourceRefNotReady, status, message, reason)
+}
+
+func updateSourceUnreachableCondition(conditions []cdiv1.DataVolumeCondition, unreachable bool, message, reason string) []cdiv1.DataVolumeCondition {
+	if !unreachable && findConditionByType(cdiv1.DataVolumeSourceUnreachable, conditions) == nil {
+		// Do not add the condition to DataVolumes that never failed the pre-check
+		return conditions
+	}
+	status := corev1.ConditionFalse
+	if unreachable {
+		status = corev1.ConditionTrue
+	}
+	return updateCondition(conditions, cdiv1.DataVolumeSourceUnreachable, status, message, reason)
+}
+
 func updateBoundCondition(conditions []cdiv1.DataVolumeCondition, pvc *corev1.PersistentVolumeClaim, reason string) []cdiv1.DataVolumeCondition {
 	if pvc != nil {
 		pvcCondition := getPVCCondition(pvc.GetAnnotations())
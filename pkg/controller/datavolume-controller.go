@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"math"
+	"math/rand"
 	"net/http"
 	"reflect"
 	"regexp"
@@ -33,12 +34,16 @@ import (
 	"github.com/go-logr/logr"
 	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 
+	"golang.org/x/time/rate"
 	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -46,6 +51,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -57,7 +63,9 @@ import (
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	"kubevirt.io/containerized-data-importer/pkg/common"
 	featuregates "kubevirt.io/containerized-data-importer/pkg/feature-gates"
+	"kubevirt.io/containerized-data-importer/pkg/monitoring"
 	"kubevirt.io/containerized-data-importer/pkg/token"
+	"kubevirt.io/containerized-data-importer/pkg/tracing"
 	"kubevirt.io/containerized-data-importer/pkg/util"
 )
 
@@ -76,6 +84,8 @@ const (
 	ErrClaimNotValid = "ErrClaimNotValid"
 	// ErrExceededQuota provides a const to indicate the claim has exceeded the quota
 	ErrExceededQuota = "ErrExceededQuota"
+	// BlockedByQuota provides a const to indicate worker pod creation is blocked by a ResourceQuota or LimitRange
+	BlockedByQuota = "BlockedByQuota"
 	// ErrUnableToClone provides a const to indicate some errors are blocking the clone
 	ErrUnableToClone = "ErrUnableToClone"
 	// DataVolumeFailed provides a const to represent DataVolume failed status
@@ -118,10 +128,26 @@ const (
 	UploadFailed = "UploadFailed"
 	// UploadSucceeded provides a const to indicate upload has succeeded
 	UploadSucceeded = "UploadSucceeded"
+	// PvcOverwritten provides a const to indicate an existing PVC is re-populated
+	PvcOverwritten = "PvcOverwritten"
+	// PvcResumed provides a const to indicate an existing PVC is adopted with its content
+	PvcResumed = "PvcResumed"
+	// TransferPreempted provides a const to indicate a transfer was paused for a higher priority one
+	TransferPreempted = "TransferPreempted"
+	// TransferResumed provides a const to indicate a preempted transfer continues
+	TransferResumed = "TransferResumed"
 	// MessageResourceMarkedForDeletion provides a const to form a resource marked for deletion error message
 	MessageResourceMarkedForDeletion = "Resource %q marked for deletion"
 	// MessageResourceExists provides a const to form a resource exists error message
 	MessageResourceExists = "Resource %q already exists and is not managed by DataVolume"
+	// MessagePvcOverwritten provides a const to form a PVC overwritten message
+	MessagePvcOverwritten = "Re-populating existing PVC %s from the source"
+	// MessagePvcResumed provides a const to form a PVC resumed message
+	MessagePvcResumed = "Adopted existing PVC %s with its current content"
+	// MessageTransferPreempted provides a const to form a transfer preempted message
+	MessageTransferPreempted = "Transfer paused, preempted by higher priority DataVolume %s"
+	// MessageTransferResumed provides a const to form a transfer resumed message
+	MessageTransferResumed = "Transfer into %s resumed, a transfer slot is available again"
 	// MessageResourceDoesntExist provides a const to form a resource doesn't exist error message
 	MessageResourceDoesntExist = "Resource managed by %q doesn't exist"
 	// MessageResourceSynced provides a const to standardize a Resource Synced message
@@ -164,6 +190,18 @@ const (
 	ExpansionInProgress = "ExpansionInProgress"
 	// MessageExpansionInProgress is a const for reporting target expansion
 	MessageExpansionInProgress = "Expanding PersistentVolumeClaim for DataVolume %s/%s"
+	// ExpansionStarted is const representing the expansion pod being started
+	ExpansionStarted = "ExpansionStarted"
+	// MessageExpansionStarted is a const for reporting the expansion pod start
+	MessageExpansionStarted = "Started expansion pod for DataVolume %s/%s"
+	// ConversionStarted is const representing the worker pod starting image conversion
+	ConversionStarted = "ConversionStarted"
+	// MessageConversionStarted is a const for reporting the conversion start
+	MessageConversionStarted = "Conversion of DataVolume %s/%s started"
+	// ConversionCompleted is const representing the worker pod finishing image conversion
+	ConversionCompleted = "ConversionCompleted"
+	// MessageConversionCompleted is a const for reporting the conversion finish
+	MessageConversionCompleted = "Conversion of DataVolume %s/%s completed"
 	// NamespaceTransferInProgress is const representing target PVC transfer
 	NamespaceTransferInProgress = "NamespaceTransferInProgress"
 	// MessageNamespaceTransferInProgress is a const for reporting target transfer
@@ -183,6 +221,13 @@ const (
 	annCloneType = "cdi.kubevirt.io/cloneType"
 
 	dvPhaseField = "status.phase"
+
+	podOwnerUIDField = "cdi.ownerUID"
+
+	// dvBackoffBase is the first retry delay of a failing DataVolume
+	dvBackoffBase = 250 * time.Millisecond
+	// dvBackoffCap is the longest retry delay of a failing DataVolume
+	dvBackoffCap = 5 * time.Minute
 )
 
 type cloneStrategy int
@@ -197,6 +242,84 @@ const (
 
 var httpClient *http.Client
 
+var (
+	// TransferBytesCounter counts the capacity of successfully completed transfers,
+	// so capacity dashboards can be built without scraping the worker pods
+	TransferBytesCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: monitoring.MetricOptsList[monitoring.TransferBytes].Name,
+			Help: monitoring.MetricOptsList[monitoring.TransferBytes].Help,
+		},
+		[]string{"namespace", "source", "storageclass"})
+	// TransferDurationHistogram observes how long successful transfers took from
+	// DataVolume creation to completion
+	TransferDurationHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    monitoring.MetricOptsList[monitoring.TransferDuration].Name,
+			Help:    monitoring.MetricOptsList[monitoring.TransferDuration].Help,
+			Buckets: prometheus.ExponentialBuckets(10, 2, 12),
+		},
+		[]string{"namespace", "source", "storageclass"})
+	// TransferResultsCounter counts completed transfers by final result
+	TransferResultsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: monitoring.MetricOptsList[monitoring.TransferResults].Name,
+			Help: monitoring.MetricOptsList[monitoring.TransferResults].Help,
+		},
+		[]string{"namespace", "source", "storageclass", "result"})
+	// WaitingForConsumerGauge marks DataVolumes sitting in the WaitForFirstConsumer
+	// phase, so an alert can fire when one is stuck there for too long
+	WaitingForConsumerGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: monitoring.MetricOptsList[monitoring.DataVolumeWaitForFirstConsumer].Name,
+			Help: monitoring.MetricOptsList[monitoring.DataVolumeWaitForFirstConsumer].Help,
+		},
+		[]string{"namespace", "name"})
+	// CloneProgressGauge reports the progress of smart and CSI clones, which
+	// have no cloner pod measuring bytes; the value is a coarse percentage
+	// derived from the clone phase
+	CloneProgressGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: monitoring.MetricOptsList[monitoring.CloneProgress].Name,
+			Help: monitoring.MetricOptsList[monitoring.CloneProgress].Help,
+		},
+		[]string{"ownerUID", "phase"})
+	// CheckpointProgressGauge reports the bytes copied for each checkpoint of a
+	// multi-stage import, one series per checkpoint
+	CheckpointProgressGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: monitoring.MetricOptsList[monitoring.CheckpointProgress].Name,
+			Help: monitoring.MetricOptsList[monitoring.CheckpointProgress].Help,
+		},
+		[]string{"ownerUID", "checkpoint"})
+)
+
+// clonePhaseProgress maps the controller driven clone phases to the progress
+// percentage reported while a clone sits in that phase
+var clonePhaseProgress = map[cdiv1.DataVolumePhase]float64{
+	cdiv1.CloneScheduled:                  0,
+	cdiv1.SnapshotForSmartCloneInProgress: 25,
+	cdiv1.SmartClonePVCInProgress:         50,
+	cdiv1.CSICloneInProgress:              50,
+	cdiv1.ExpansionInProgress:             75,
+	cdiv1.Succeeded:                       100,
+}
+
+// updateClonePhaseProgress moves the clone progress gauge of the given owner
+// to the series of the current phase
+func updateClonePhaseProgress(ownerUID string, phase cdiv1.DataVolumePhase) {
+	value, ok := clonePhaseProgress[phase]
+	if !ok {
+		return
+	}
+	for other := range clonePhaseProgress {
+		if other != phase {
+			CloneProgressGauge.DeleteLabelValues(ownerUID, string(other))
+		}
+	}
+	CloneProgressGauge.WithLabelValues(ownerUID, string(phase)).Set(value)
+}
+
 // DataVolumeEvent reoresents event
 type DataVolumeEvent struct {
 	eventType string
@@ -207,6 +330,7 @@ type DataVolumeEvent struct {
 // DatavolumeReconciler members
 type DatavolumeReconciler struct {
 	client          client.Client
+	uncachedClient  client.Client
 	recorder        record.EventRecorder
 	scheme          *runtime.Scheme
 	log             logr.Logger
@@ -217,6 +341,7 @@ type DatavolumeReconciler struct {
 	tokenGenerator  token.Generator
 	installerLabels map[string]string
 	sccs            controllerStarter
+	shard           ShardConfig
 }
 
 type controllerStarter interface {
@@ -301,7 +426,16 @@ func NewDatavolumeController(
 	tokenPublicKey *rsa.PublicKey,
 	tokenPrivateKey *rsa.PrivateKey,
 	installerLabels map[string]string,
+	shard ShardConfig,
+	maxParallelReconciles int,
 ) (controller.Controller, error) {
+	uncachedClient, err := client.New(mgr.GetConfig(), client.Options{
+		Scheme: mgr.GetScheme(),
+		Mapper: mgr.GetRESTMapper(),
+	})
+	if err != nil {
+		return nil, err
+	}
 	client := mgr.GetClient()
 	sccs := &smartCloneControllerStarter{
 		log:                       log,
@@ -311,6 +445,7 @@ func NewDatavolumeController(
 	}
 	reconciler := &DatavolumeReconciler{
 		client:         client,
+		uncachedClient: uncachedClient,
 		scheme:         mgr.GetScheme(),
 		log:            log.WithName("datavolume-controller"),
 		recorder:       mgr.GetEventRecorderFor("datavolume-controller"),
@@ -322,9 +457,12 @@ func NewDatavolumeController(
 		tokenGenerator:  newLongTermCloneTokenGenerator(tokenPrivateKey),
 		installerLabels: installerLabels,
 		sccs:            sccs,
+		shard:           shard,
 	}
 	datavolumeController, err := controller.New("datavolume-controller", mgr, controller.Options{
-		Reconciler: reconciler,
+		MaxConcurrentReconciles: maxParallelReconciles,
+		RateLimiter:             newDatavolumeRateLimiter(),
+		Reconciler:              NewInstrumentedReconciler("datavolume-controller", reconciler),
 	})
 	if err != nil {
 		return nil, err
@@ -337,6 +475,30 @@ func NewDatavolumeController(
 	return datavolumeController, nil
 }
 
+// jitteredRateLimiter spreads the retries of a wrapped per-item rate limiter
+// out by up to a quarter of the computed delay, so persistently failing
+// DataVolumes do not retry in lockstep.
+type jitteredRateLimiter struct {
+	workqueue.RateLimiter
+}
+
+func (r *jitteredRateLimiter) When(item interface{}) time.Duration {
+	delay := r.RateLimiter.When(item)
+	return delay + time.Duration(rand.Int63n(int64(delay)/4+1))
+}
+
+// newDatavolumeRateLimiter returns the workqueue rate limiter used for
+// DataVolume reconciles: capped exponential backoff with jitter per
+// DataVolume, combined with the usual overall bucket limiter, so a
+// persistently failing DataVolume backs off instead of reconciling in a hot
+// loop against the API server and the storage backend.
+func newDatavolumeRateLimiter() workqueue.RateLimiter {
+	return workqueue.NewMaxOfRateLimiter(
+		&jitteredRateLimiter{workqueue.NewItemExponentialFailureRateLimiter(dvBackoffBase, dvBackoffCap)},
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
+	)
+}
+
 func addDatavolumeControllerWatches(mgr manager.Manager, datavolumeController controller.Controller) error {
 	// Add schemes.
 	if err := cdiv1.AddToScheme(mgr.GetScheme()); err != nil {
@@ -348,6 +510,9 @@ func addDatavolumeControllerWatches(mgr manager.Manager, datavolumeController co
 	if err := snapshotv1.AddToScheme(mgr.GetScheme()); err != nil {
 		return err
 	}
+	if err := schedulingv1.AddToScheme(mgr.GetScheme()); err != nil {
+		return err
+	}
 
 	// Setup watches
 	if err := datavolumeController.Watch(&source.Kind{Type: &cdiv1.DataVolume{}}, &handler.EnqueueRequestForObject{}); err != nil {
@@ -389,6 +554,41 @@ func addDatavolumeControllerWatches(mgr manager.Manager, datavolumeController co
 		return err
 	}
 
+	// Index CDI worker pods by the UID of the PVC they work on, so pod lookups
+	// do not have to scan every pod in the cache on each reconcile
+	if err := mgr.GetFieldIndexer().IndexField(context.TODO(), &corev1.Pod{}, podOwnerUIDField, func(obj client.Object) []string {
+		return podOwnerUIDIndexKeys(obj.(*corev1.Pod))
+	}); err != nil {
+		return err
+	}
+
+	// Watch for DataSource updates and reconcile the DVs waiting for a ready DataSource
+	if err := datavolumeController.Watch(&source.Kind{Type: &cdiv1.DataSource{}}, handler.EnqueueRequestsFromMapFunc(
+		func(obj client.Object) (reqs []reconcile.Request) {
+			dvList := &cdiv1.DataVolumeList{}
+			if err := mgr.GetClient().List(context.TODO(), dvList); err != nil {
+				return
+			}
+			for _, dv := range dvList.Items {
+				if dv.Spec.SourceRef == nil || dv.Spec.SourceRef.Name != obj.GetName() {
+					continue
+				}
+				ns := dv.Namespace
+				if dv.Spec.SourceRef.Namespace != nil && *dv.Spec.SourceRef.Namespace != "" {
+					ns = *dv.Spec.SourceRef.Namespace
+				}
+				if ns != obj.GetNamespace() {
+					continue
+				}
+				reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: dv.Name, Namespace: dv.Namespace}})
+			}
+			return
+		},
+	),
+	); err != nil {
+		return err
+	}
+
 	// Watch for SC updates and reconcile the DVs waiting for default SC
 	if err := datavolumeController.Watch(&source.Kind{Type: &storagev1.StorageClass{}}, handler.EnqueueRequestsFromMapFunc(
 		func(obj client.Object) (reqs []reconcile.Request) {
@@ -411,12 +611,26 @@ func addDatavolumeControllerWatches(mgr manager.Manager, datavolumeController co
 
 // Reconcile the reconcile loop for the data volumes.
 func (r *DatavolumeReconciler) Reconcile(_ context.Context, req reconcile.Request) (reconcile.Result, error) {
+	if !r.shard.OwnsNamespace(req.Namespace) {
+		return reconcile.Result{}, nil
+	}
+
 	log := r.log.WithValues("Datavolume", req.NamespacedName)
 
+	paused, err := ReconcilePaused(r.client, req.Namespace)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if paused {
+		log.V(1).Info("Reconciliation is paused, requeueing")
+		return reconcile.Result{RequeueAfter: pausedRequeueDelay}, nil
+	}
+
 	// Get the Datavolume.
 	datavolume := &cdiv1.DataVolume{}
 	if err := r.client.Get(context.TODO(), req.NamespacedName, datavolume); err != nil {
 		if k8serrors.IsNotFound(err) {
+			WaitingForConsumerGauge.DeleteLabelValues(req.Namespace, req.Name)
 			return reconcile.Result{}, nil
 		}
 		return reconcile.Result{}, err
@@ -432,9 +646,13 @@ func (r *DatavolumeReconciler) Reconcile(_ context.Context, req reconcile.Reques
 		return reconcile.Result{}, nil
 	}
 
-	if err := r.populateSourceIfSourceRef(datavolume); err != nil {
+	sourceRefReady, err := r.populateSourceIfSourceRef(datavolume)
+	if err != nil {
 		return reconcile.Result{}, err
 	}
+	if !sourceRefReady {
+		return r.waitForSourceRef(datavolume)
+	}
 
 	pvcPopulated := false
 	// Get the pvc with the name specified in DataVolume.spec
@@ -465,9 +683,24 @@ func (r *DatavolumeReconciler) Reconcile(_ context.Context, req reconcile.Reques
 					return reconcile.Result{}, err
 				}
 			} else {
-				msg := fmt.Sprintf(MessageResourceExists, pvc.Name)
-				r.recorder.Event(datavolume, corev1.EventTypeWarning, ErrResourceExists, msg)
-				return reconcile.Result{}, errors.Errorf(msg)
+				switch datavolume.Spec.OverwritePolicy {
+				case cdiv1.OverwritePolicyResume:
+					// take over the existing volume with its current content
+					if err := r.resumeExistingPvc(datavolume, pvc); err != nil {
+						return reconcile.Result{}, err
+					}
+					pvcPopulated = true
+				case cdiv1.OverwritePolicyWipe:
+					// take over the existing volume and populate it again from the source
+					if err := r.wipeExistingPvc(datavolume, pvc); err != nil {
+						return reconcile.Result{}, err
+					}
+					r.recorder.Eventf(datavolume, corev1.EventTypeNormal, PvcOverwritten, MessagePvcOverwritten, pvc.Name)
+				default:
+					msg := fmt.Sprintf(MessageResourceExists, pvc.Name)
+					r.recorder.Event(datavolume, corev1.EventTypeWarning, ErrResourceExists, msg)
+					return reconcile.Result{}, errors.Errorf(msg)
+				}
 			}
 		}
 		// If the PVC is being deleted, we should log a warning to the event recorder and return to wait the deletion complete
@@ -478,6 +711,23 @@ func (r *DatavolumeReconciler) Reconcile(_ context.Context, req reconcile.Reques
 		}
 	}
 
+	if pvc != nil && pvc.Annotations[AnnPreempted] == "true" {
+		res, err := r.resumePreemptedTransfer(datavolume, pvc)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if res != nil {
+			return *res, nil
+		}
+	}
+
+	if pvc == nil {
+		if _, archived := datavolume.Annotations[AnnArchiveSnapshot]; archived {
+			// the PVC was archived to a snapshot, do not recreate it
+			return reconcile.Result{}, nil
+		}
+	}
+
 	pvcSpec, err := RenderPvcSpec(r.client, r.recorder, r.log, datavolume)
 	if err != nil {
 		return reconcile.Result{}, err
@@ -493,6 +743,42 @@ func (r *DatavolumeReconciler) Reconcile(_ context.Context, req reconcile.Reques
 
 	_, dvPrePopulated := datavolume.Annotations[AnnPrePopulated]
 
+	// Probe the source before provisioning storage when the pre-check is requested
+	if pvc == nil && !dvPrePopulated && datavolume.Annotations[AnnCheckSource] == "true" {
+		if reachable, message := r.checkSourceReachable(datavolume); !reachable {
+			if err := r.updateSourceUnreachable(datavolume, message); err != nil {
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+		}
+	}
+
+	// Enforce the namespace CDIQuota before starting a new transfer
+	if pvc == nil && !dvPrePopulated {
+		allowed, message, err := r.cdiQuotaAllowsTransfer(datavolume)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if !allowed {
+			preempted, err := r.preemptLowerPriorityTransfer(datavolume)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			if preempted {
+				return reconcile.Result{Requeue: true}, nil
+			}
+			if err := r.updateDataVolumeStatusPhaseWithEvent(cdiv1.Pending, datavolume, nil, selectedCloneStrategy,
+				DataVolumeEvent{
+					eventType: corev1.EventTypeWarning,
+					reason:    ErrExceededQuota,
+					message:   message,
+				}); err != nil {
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+		}
+	}
+
 	if selectedCloneStrategy != NoClone {
 		return r.reconcileClone(log, datavolume, pvc, pvcSpec, transferName, dvPrePopulated, pvcPopulated, selectedCloneStrategy)
 	}
@@ -530,6 +816,14 @@ func (r *DatavolumeReconciler) Reconcile(_ context.Context, req reconcile.Reques
 			if err != nil {
 				return reconcile.Result{}, err
 			}
+
+			res, err := r.maybeExpandPvcForSource(log, datavolume, pvc)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			if res != nil {
+				return *res, nil
+			}
 		}
 	}
 
@@ -646,9 +940,10 @@ func (r *DatavolumeReconciler) ensureExtendedToken(pvc *corev1.PersistentVolumeC
 		return err
 	}
 
+	original := pvc.DeepCopy()
 	pvc.Annotations[AnnExtendedCloneToken] = newToken
 
-	if err := r.updatePVC(pvc); err != nil {
+	if err := r.patchPVC(original, pvc); err != nil {
 		return err
 	}
 
@@ -842,12 +1137,13 @@ func (r *DatavolumeReconciler) finishClone(log logr.Logger,
 
 func (r *DatavolumeReconciler) setCloneOfOnPvc(pvc *corev1.PersistentVolumeClaim) error {
 	if v, ok := pvc.Annotations[AnnCloneOf]; !ok || v != "true" {
+		original := pvc.DeepCopy()
 		if pvc.Annotations == nil {
 			pvc.Annotations = make(map[string]string)
 		}
 		pvc.Annotations[AnnCloneOf] = "true"
 
-		return r.updatePVC(pvc)
+		return r.patchPVC(original, pvc)
 	}
 
 	return nil
@@ -894,9 +1190,10 @@ func (r *DatavolumeReconciler) expandPvcAfterCloneFunc(
 
 	if isTemp {
 		// trigger transfer and next reconcile should have pvcExists == true
+		original := pvc.DeepCopy()
 		pvc.Annotations[annReadyForTransfer] = "true"
 		pvc.Annotations[AnnPopulatedFor] = datavolume.Name
-		if err := r.updatePVC(pvc); err != nil {
+		if err := r.patchPVC(original, pvc); err != nil {
 			return reconcile.Result{}, err
 		}
 	}
@@ -1031,7 +1328,7 @@ func (r *DatavolumeReconciler) getVddkAnnotations(dataVolume *cdiv1.DataVolume,
 
 	// only update if something has changed
 	if !reflect.DeepEqual(dataVolume, dataVolumeCopy) {
-		return true, r.updateDataVolume(dataVolumeCopy)
+		return true, r.patchDataVolume(dataVolume, dataVolumeCopy)
 	}
 	return false, nil
 }
@@ -1115,7 +1412,7 @@ func (r *DatavolumeReconciler) setMultistageImportAnnotations(dataVolume *cdiv1.
 
 	// only update if something has changed
 	if !reflect.DeepEqual(pvc, pvcCopy) {
-		return r.updatePVC(pvcCopy)
+		return r.patchPVC(pvc, pvcCopy)
 	}
 	return nil
 }
@@ -1139,7 +1436,7 @@ func (r *DatavolumeReconciler) deleteMultistageImportAnnotations(pvc *corev1.Per
 
 	// only update if something has changed
 	if !reflect.DeepEqual(pvc, pvcCopy) {
-		return r.updatePVC(pvcCopy)
+		return r.patchPVC(pvc, pvcCopy)
 	}
 	return nil
 }
@@ -1216,7 +1513,7 @@ func (r *DatavolumeReconciler) isSourcePVCPopulated(dv *cdiv1.DataVolume) (bool,
 }
 
 func (r *DatavolumeReconciler) sourceInUse(dv *cdiv1.DataVolume, eventReason string) (bool, error) {
-	pods, err := GetPodsUsingPVCs(r.client, dv.Spec.Source.PVC.Namespace, sets.NewString(dv.Spec.Source.PVC.Name), false)
+	pods, err := GetPodsUsingPVCs(r.uncachedClient, dv.Spec.Source.PVC.Namespace, sets.NewString(dv.Spec.Source.PVC.Name), false)
 	if err != nil {
 		return false, err
 	}
@@ -1237,8 +1534,9 @@ func (r *DatavolumeReconciler) initTransfer(log logr.Logger, dv *cdiv1.DataVolum
 	log.Info("Initializing transfer")
 
 	if !HasFinalizer(dv, crossNamespaceFinalizer) {
+		original := dv.DeepCopy()
 		AddFinalizer(dv, crossNamespaceFinalizer)
-		if err := r.updateDataVolume(dv); err != nil {
+		if err := r.patchDataVolume(original, dv); err != nil {
 			return false, err
 		}
 
@@ -1359,8 +1657,9 @@ func (r *DatavolumeReconciler) cleanupTransfer(log logr.Logger, dv *cdiv1.DataVo
 		return fmt.Errorf("waiting for ObjectTransfer %s to delete", name)
 	}
 
+	original := dv.DeepCopy()
 	RemoveFinalizer(dv, crossNamespaceFinalizer)
-	if err := r.updateDataVolume(dv); dv != nil {
+	if err := r.patchDataVolume(original, dv); dv != nil {
 		return err
 	}
 
@@ -1371,6 +1670,62 @@ func expansionPodName(pvc *corev1.PersistentVolumeClaim) string {
 	return "cdi-expand-" + string(pvc.UID)
 }
 
+// sourceTooLargeRegExp extracts the source virtual size from the importer failure message
+var sourceTooLargeRegExp = regexp.MustCompile(`Virtual image size (\d+) is larger than`)
+
+// maybeExpandPvcForSource grows the target PVC when the importer reported that the
+// source virtual size does not fit, the AutoVolumeExpansion feature gate is on and
+// the storage class allows volume expansion. It returns a result while the
+// expansion is still in progress.
+func (r *DatavolumeReconciler) maybeExpandPvcForSource(log logr.Logger, datavolume *cdiv1.DataVolume, pvc *corev1.PersistentVolumeClaim) (*reconcile.Result, error) {
+	match := sourceTooLargeRegExp.FindStringSubmatch(pvc.Annotations[AnnRunningConditionMessage])
+	if match == nil {
+		return nil, nil
+	}
+	enabled, err := r.featureGates.AutoVolumeExpansionEnabled()
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return nil, nil
+	}
+	storageClass, err := GetStorageClassByName(r.client, pvc.Spec.StorageClassName)
+	if err != nil {
+		return nil, err
+	}
+	if storageClass == nil || storageClass.AllowVolumeExpansion == nil || !*storageClass.AllowVolumeExpansion {
+		return nil, nil
+	}
+	virtualSize, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	requiredSize := *resource.NewQuantity(virtualSize, resource.BinarySI)
+	currentSize := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	if currentSize.Cmp(requiredSize) >= 0 {
+		// the request was already grown, the import retries on its own
+		return nil, nil
+	}
+	targetSpec := pvc.Spec.DeepCopy()
+	targetSpec.Resources.Requests[corev1.ResourceStorage] = requiredSize
+	done, err := r.expand(log, datavolume, pvc, targetSpec)
+	if err != nil {
+		return nil, err
+	}
+	if !done {
+		if err := r.updateDataVolumeStatusPhaseWithEvent(cdiv1.ExpansionInProgress, datavolume, pvc, NoClone,
+			DataVolumeEvent{
+				eventType: corev1.EventTypeNormal,
+				reason:    ExpansionInProgress,
+				message:   fmt.Sprintf(MessageExpansionInProgress, datavolume.Namespace, datavolume.Name),
+			}); err != nil {
+			return nil, err
+		}
+		return &reconcile.Result{Requeue: true}, nil
+	}
+	return nil, nil
+}
+
 func (r *DatavolumeReconciler) expand(log logr.Logger,
 	dv *cdiv1.DataVolume,
 	pvc *corev1.PersistentVolumeClaim,
@@ -1392,8 +1747,9 @@ func (r *DatavolumeReconciler) expand(log logr.Logger,
 	log.V(3).Info("Expand sizes", "req", requestedSize, "cur", currentSize, "act", actualSize, "exp", expansionRequired)
 
 	if updateRequestSizeRequired {
+		original := pvc.DeepCopy()
 		pvc.Spec.Resources.Requests[corev1.ResourceStorage] = requestedSize
-		if err := r.updatePVC(pvc); err != nil {
+		if err := r.patchPVC(original, pvc); err != nil {
 			return false, err
 		}
 
@@ -1436,6 +1792,7 @@ func (r *DatavolumeReconciler) expand(log logr.Logger,
 		if err != nil {
 			return false, err
 		}
+		r.recorder.Eventf(dv, corev1.EventTypeNormal, ExpansionStarted, MessageExpansionStarted, dv.Namespace, dv.Name)
 	}
 
 	if pod.Status.Phase == corev1.PodSucceeded {
@@ -1454,7 +1811,7 @@ func (r *DatavolumeReconciler) expand(log logr.Logger,
 }
 
 func (r *DatavolumeReconciler) createExpansionPod(pvc *corev1.PersistentVolumeClaim, dv *cdiv1.DataVolume, podName string) (*corev1.Pod, error) {
-	resourceRequirements, err := GetDefaultPodResourceRequirements(r.client)
+	resourceRequirements, err := GetWorkloadPodResourceRequirements(r.client, WorkloadTypeScratchPrep)
 	if err != nil {
 		return nil, err
 	}
@@ -1588,9 +1945,18 @@ func (r *DatavolumeReconciler) reconcileProgressUpdate(datavolume *cdiv1.DataVol
 			// Avoid long timeouts and error traces from HTTP get when pod is already gone
 			return reconcile.Result{}, nil
 		}
+		prevPhase := ""
+		if datavolume.Status.ProgressDetail != nil {
+			prevPhase = datavolume.Status.ProgressDetail.Phase
+		}
+		updateCheckpointProgress(datavolume, pvc)
 		if err := updateProgressUsingPod(datavolume, pod); err != nil {
 			return reconcile.Result{}, err
 		}
+		if detail := datavolume.Status.ProgressDetail; detail != nil && detail.CurrentCheckpoint != "" {
+			CheckpointProgressGauge.WithLabelValues(string(datavolume.UID), detail.CurrentCheckpoint).Set(float64(detail.BytesDone))
+		}
+		r.emitConversionEvents(datavolume, prevPhase)
 	}
 	// We are not done yet, force a re-reconcile in 2 seconds to get an update.
 	return reconcile.Result{RequeueAfter: 2 * time.Second}, nil
@@ -2010,6 +2376,11 @@ func (r *DatavolumeReconciler) updateCloneStatusPhase(phase cdiv1.DataVolumePhas
 		event.message = fmt.Sprintf(MessageCloneSucceeded, dataVolume.Spec.Source.PVC.Namespace, dataVolume.Spec.Source.PVC.Name, dataVolume.Namespace, dataVolume.Name)
 	}
 
+	// network clones report byte-level progress from the cloner pod
+	if selectedCloneStrategy == SmartClone || selectedCloneStrategy == CsiClone {
+		updateClonePhaseProgress(string(dataVolume.UID), phase)
+	}
+
 	return r.updateDataVolumeStatusPhaseWithEvent(phase, dataVolume, pvc, selectedCloneStrategy, event)
 }
 
@@ -2168,7 +2539,7 @@ func (r *DatavolumeReconciler) reconcileDataVolumeStatus(dataVolume *cdiv1.DataV
 		} else {
 			switch pvc.Status.Phase {
 			case corev1.ClaimPending:
-				honorWaitForFirstConsumerEnabled, err := r.featureGates.HonorWaitForFirstConsumerEnabled()
+				honorWaitForFirstConsumerEnabled, err := r.featureGates.HonorWaitForFirstConsumerEnabledForNamespace(pvc.Namespace)
 				if err != nil {
 					return reconcile.Result{}, err
 				}
@@ -2241,12 +2612,145 @@ func (r *DatavolumeReconciler) reconcileDataVolumeStatus(dataVolume *cdiv1.DataV
 		AddAnnotation(dataVolumeCopy, annCloneType, cloneStrategyToCloneType(selectedCloneStrategy))
 	}
 
+	if curPhase != dataVolumeCopy.Status.Phase &&
+		(dataVolumeCopy.Status.Phase == cdiv1.Succeeded || dataVolumeCopy.Status.Phase == cdiv1.Failed) {
+		recordTransferMetrics(dataVolumeCopy, pvc)
+		r.exportLifecycleTrace(dataVolumeCopy, pvc)
+	}
+
+	if dataVolumeCopy.Status.Phase == cdiv1.WaitForFirstConsumer {
+		WaitingForConsumerGauge.WithLabelValues(dataVolumeCopy.Namespace, dataVolumeCopy.Name).Set(1)
+	} else {
+		WaitingForConsumerGauge.DeleteLabelValues(dataVolumeCopy.Namespace, dataVolumeCopy.Name)
+	}
+
 	currentCond := make([]cdiv1.DataVolumeCondition, len(dataVolumeCopy.Status.Conditions))
 	copy(currentCond, dataVolumeCopy.Status.Conditions)
 	r.updateConditions(dataVolumeCopy, pvc, "")
 	return result, r.emitEvent(dataVolume, dataVolumeCopy, curPhase, currentCond, &event)
 }
 
+// recordTransferMetrics publishes the per transfer metrics when a DataVolume reaches a final phase
+func recordTransferMetrics(dv *cdiv1.DataVolume, pvc *corev1.PersistentVolumeClaim) {
+	sourceType := dataVolumeSourceType(dv)
+	storageClass := ""
+	var capacityBytes float64
+	if pvc != nil {
+		if pvc.Spec.StorageClassName != nil {
+			storageClass = *pvc.Spec.StorageClassName
+		}
+		if capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+			capacityBytes = float64(capacity.Value())
+		}
+	}
+
+	if dv.Status.Phase == cdiv1.Succeeded {
+		TransferResultsCounter.WithLabelValues(dv.Namespace, sourceType, storageClass, "succeeded").Inc()
+		TransferBytesCounter.WithLabelValues(dv.Namespace, sourceType, storageClass).Add(capacityBytes)
+		TransferDurationHistogram.WithLabelValues(dv.Namespace, sourceType, storageClass).Observe(time.Since(dv.CreationTimestamp.Time).Seconds())
+	} else {
+		TransferResultsCounter.WithLabelValues(dv.Namespace, sourceType, storageClass, "failed").Inc()
+	}
+}
+
+// exportLifecycleTrace emits the lifecycle spans of a finished DataVolume to the
+// OTLP endpoint configured in the CDI config. The spans are built retrospectively
+// from the object timestamps and condition transitions, so the whole admission to
+// completion timeline shows up as one trace keyed on the DataVolume UID.
+func (r *DatavolumeReconciler) exportLifecycleTrace(dv *cdiv1.DataVolume, pvc *corev1.PersistentVolumeClaim) {
+	config := &cdiv1.CDIConfig{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, config); err != nil {
+		return
+	}
+	if config.Spec.Tracing == nil || config.Spec.Tracing.OTLPEndpoint == "" {
+		return
+	}
+
+	now := time.Now()
+	traceID := tracing.TraceID(string(dv.UID))
+	rootID := tracing.SpanID(traceID, "datavolume")
+	spans := []tracing.Span{
+		{
+			TraceID: traceID,
+			SpanID:  rootID,
+			Name:    "datavolume",
+			Start:   dv.CreationTimestamp.Time,
+			End:     now,
+			Attributes: map[string]string{
+				"k8s.namespace.name": dv.Namespace,
+				"cdi.datavolume":     dv.Name,
+				"cdi.source":         dataVolumeSourceType(dv),
+				"cdi.phase":          string(dv.Status.Phase),
+			},
+		},
+	}
+
+	if pvc != nil {
+		spans = append(spans, tracing.Span{
+			TraceID:  traceID,
+			SpanID:   tracing.SpanID(traceID, "pvc-create"),
+			ParentID: rootID,
+			Name:     "pvc-create",
+			Start:    dv.CreationTimestamp.Time,
+			End:      pvc.CreationTimestamp.Time,
+		})
+		for _, cond := range dv.Status.Conditions {
+			if cond.Type == cdiv1.DataVolumeBound && cond.Status == corev1.ConditionTrue {
+				spans = append(spans,
+					tracing.Span{
+						TraceID:  traceID,
+						SpanID:   tracing.SpanID(traceID, "pvc-bind"),
+						ParentID: rootID,
+						Name:     "pvc-bind",
+						Start:    pvc.CreationTimestamp.Time,
+						End:      cond.LastTransitionTime.Time,
+					},
+					tracing.Span{
+						TraceID:  traceID,
+						SpanID:   tracing.SpanID(traceID, "transfer"),
+						ParentID: rootID,
+						Name:     "transfer",
+						Start:    cond.LastTransitionTime.Time,
+						End:      now,
+					})
+			}
+		}
+	}
+
+	tracing.NewExporter(config.Spec.Tracing.OTLPEndpoint, "cdi-controller").ExportAsync(spans)
+}
+
+// dataVolumeSourceType returns the source type label value for the transfer metrics
+func dataVolumeSourceType(dv *cdiv1.DataVolume) string {
+	source := dv.Spec.Source
+	switch {
+	case source == nil:
+		return "sourceRef"
+	case source.HTTP != nil:
+		return "http"
+	case source.S3 != nil:
+		return "s3"
+	case source.GCS != nil:
+		return "gcs"
+	case source.OVA != nil:
+		return "ova"
+	case source.Registry != nil:
+		return "registry"
+	case source.PVC != nil:
+		return "pvc"
+	case source.Upload != nil:
+		return "upload"
+	case source.Blank != nil:
+		return "blank"
+	case source.Imageio != nil:
+		return "imageio"
+	case source.VDDK != nil:
+		return "vddk"
+	default:
+		return "unknown"
+	}
+}
+
 func (r *DatavolumeReconciler) updateConditions(dataVolume *cdiv1.DataVolume, pvc *corev1.PersistentVolumeClaim, reason string) {
 	var anno map[string]string
 
@@ -2273,6 +2777,29 @@ func (r *DatavolumeReconciler) updateConditions(dataVolume *cdiv1.DataVolume, pv
 	dataVolume.Status.Conditions = updateBoundCondition(dataVolume.Status.Conditions, pvc, reason)
 	dataVolume.Status.Conditions = updateReadyCondition(dataVolume.Status.Conditions, readyStatus, "", reason)
 	dataVolume.Status.Conditions = updateRunningCondition(dataVolume.Status.Conditions, anno)
+	degraded, message := r.accessModeDegraded(dataVolume, pvc)
+	dataVolume.Status.Conditions = updateDegradedCondition(dataVolume.Status.Conditions, degraded, message, accessModeDegraded)
+	// Getting here means the source was populated, the DataVolume no longer waits for a DataSource
+	dataVolume.Status.Conditions = updateSourceRefNotReadyCondition(dataVolume.Status.Conditions, false, "", dataSourceNotReady)
+	// Getting here also means any earlier source pre-check failure is resolved
+	dataVolume.Status.Conditions = updateSourceUnreachableCondition(dataVolume.Status.Conditions, false, "", sourceUnreachable)
+}
+
+// accessModeDegraded tells whether the DataVolume requests ReadWriteMany access while the
+// target storage profile only supports other access modes, which breaks KubeVirt live migration
+func (r *DatavolumeReconciler) accessModeDegraded(dataVolume *cdiv1.DataVolume, pvc *corev1.PersistentVolumeClaim) (bool, string) {
+	if pvc == nil || pvc.Spec.StorageClassName == nil || !dataVolumeRequestsAccessMode(dataVolume, corev1.ReadWriteMany) {
+		return false, ""
+	}
+	storageProfile := &cdiv1.StorageProfile{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: *pvc.Spec.StorageClassName}, storageProfile); err != nil {
+		return false, ""
+	}
+	if len(storageProfile.Status.ClaimPropertySets) == 0 ||
+		StorageProfileSupportsAccessMode(storageProfile, corev1.ReadWriteMany) {
+		return false, ""
+	}
+	return true, fmt.Sprintf("DataVolume requests ReadWriteMany access, but StorageProfile %s only supports other access modes; the volume will not support live migration", storageProfile.Name)
 }
 
 func (r *DatavolumeReconciler) emitConditionEvent(dataVolume *cdiv1.DataVolume, originalCond []cdiv1.DataVolumeCondition) {
@@ -2298,7 +2825,7 @@ func (r *DatavolumeReconciler) emitFailureConditionEvent(dataVolume *cdiv1.DataV
 	}
 	if curReady.Status == corev1.ConditionFalse && curRunning.Status == corev1.ConditionFalse && curBound.Status == corev1.ConditionTrue {
 		//Bound, not ready, and not running
-		if curRunning.Message != "" && orgRunning.Message != curRunning.Message {
+		if curRunning.Message != "" && (orgRunning == nil || orgRunning.Message != curRunning.Message) {
 			r.recorder.Event(dataVolume, corev1.EventTypeWarning, curRunning.Reason, curRunning.Message)
 		}
 	}
@@ -2307,7 +2834,7 @@ func (r *DatavolumeReconciler) emitFailureConditionEvent(dataVolume *cdiv1.DataV
 func (r *DatavolumeReconciler) emitEvent(dataVolume *cdiv1.DataVolume, dataVolumeCopy *cdiv1.DataVolume, curPhase cdiv1.DataVolumePhase, originalCond []cdiv1.DataVolumeCondition, event *DataVolumeEvent) error {
 	// Only update the object if something actually changed in the status.
 	if !reflect.DeepEqual(dataVolume, dataVolumeCopy) {
-		if err := r.updateDataVolume(dataVolumeCopy); err != nil {
+		if err := r.patchDataVolume(dataVolume, dataVolumeCopy); err != nil {
 			r.log.Error(err, "Unable to update datavolume", "name", dataVolumeCopy.Name)
 			return err
 		}
@@ -2320,12 +2847,29 @@ func (r *DatavolumeReconciler) emitEvent(dataVolume *cdiv1.DataVolume, dataVolum
 	return nil
 }
 
+// podOwnerUIDIndexKeys returns the PVC UIDs a CDI worker pod works on, used as
+// index keys so getPodFromPvc can look pods up without listing them all.
+func podOwnerUIDIndexKeys(pod *corev1.Pod) []string {
+	if _, isCdiPod := pod.Labels[common.PrometheusLabelKey]; !isCdiPod {
+		return nil
+	}
+	var keys []string
+	for _, or := range pod.OwnerReferences {
+		keys = append(keys, string(or.UID))
+	}
+	if val, exists := pod.Labels[CloneUniqueID]; exists {
+		keys = append(keys, strings.TrimSuffix(val, common.ClonerSourcePodNameSuffix))
+	}
+	return keys
+}
+
 // getPodFromPvc determines the pod associated with the pvc passed in.
 func (r *DatavolumeReconciler) getPodFromPvc(namespace string, pvc *corev1.PersistentVolumeClaim) (*corev1.Pod, error) {
 	l, _ := labels.Parse(common.PrometheusLabelKey)
 	pods := &corev1.PodList{}
 	listOptions := client.ListOptions{
 		LabelSelector: l,
+		FieldSelector: fields.OneTermEqualSelector(podOwnerUIDField, string(pvc.GetUID())),
 	}
 	if err := r.client.List(context.TODO(), pods, &listOptions); err != nil {
 		return nil, err
@@ -2352,11 +2896,54 @@ func (r *DatavolumeReconciler) getPodFromPvc(namespace string, pvc *corev1.Persi
 }
 
 func (r *DatavolumeReconciler) addOwnerRef(pvc *corev1.PersistentVolumeClaim, dv *cdiv1.DataVolume) error {
+	original := pvc.DeepCopy()
 	if err := controllerutil.SetControllerReference(dv, pvc, r.scheme); err != nil {
 		return err
 	}
 
-	return r.updatePVC(pvc)
+	return r.patchPVC(original, pvc)
+}
+
+// resumeExistingPvc adopts a pre-existing PVC together with its current content,
+// marking it populated so the DataVolume completes without a transfer
+func (r *DatavolumeReconciler) resumeExistingPvc(dv *cdiv1.DataVolume, pvc *corev1.PersistentVolumeClaim) error {
+	original := pvc.DeepCopy()
+	if err := controllerutil.SetControllerReference(dv, pvc, r.scheme); err != nil {
+		return err
+	}
+	if pvc.Annotations == nil {
+		pvc.Annotations = make(map[string]string)
+	}
+	pvc.Annotations[AnnPopulatedFor] = dv.Name
+	if err := r.patchPVC(original, pvc); err != nil {
+		return err
+	}
+	r.recorder.Eventf(dv, corev1.EventTypeNormal, PvcResumed, MessagePvcResumed, pvc.Name)
+	return nil
+}
+
+// wipeExistingPvc adopts a pre-existing PVC and stamps the source annotations on
+// it, so the worker controllers populate the volume again from scratch
+func (r *DatavolumeReconciler) wipeExistingPvc(dv *cdiv1.DataVolume, pvc *corev1.PersistentVolumeClaim) error {
+	rendered, err := r.newPersistentVolumeClaim(dv, pvc.Spec.DeepCopy(), pvc.Namespace, pvc.Name)
+	if err != nil {
+		return err
+	}
+	original := pvc.DeepCopy()
+	if err := controllerutil.SetControllerReference(dv, pvc, r.scheme); err != nil {
+		return err
+	}
+	if pvc.Annotations == nil {
+		pvc.Annotations = make(map[string]string)
+	}
+	for k, v := range rendered.Annotations {
+		pvc.Annotations[k] = v
+	}
+	// any previous population attempt must not mask the new one
+	delete(pvc.Annotations, AnnPodPhase)
+	delete(pvc.Annotations, AnnImportPod)
+	delete(pvc.Annotations, AnnPopulatedFor)
+	return r.patchPVC(original, pvc)
 }
 
 // If this is a completed pod that was used for one checkpoint of a multi-stage import, it
@@ -2391,19 +2978,115 @@ func updateProgressUsingPod(dataVolumeCopy *cdiv1.DataVolume, pod *corev1.Pod) e
 			return err
 		}
 
+		updateProgressDetail(dataVolumeCopy, pod, string(body))
+
 		match := importRegExp.FindStringSubmatch(string(body))
-		if match == nil {
-			// No match
-			return nil
+		if match != nil {
+			if f, err := strconv.ParseFloat(match[1], 64); err == nil {
+				dataVolumeCopy.Status.Progress = cdiv1.DataVolumeProgress(fmt.Sprintf("%.2f%%", f))
+			}
 		}
-		if f, err := strconv.ParseFloat(match[1], 64); err == nil {
-			dataVolumeCopy.Status.Progress = cdiv1.DataVolumeProgress(fmt.Sprintf("%.2f%%", f))
+
+		// The overall percentage freezes at the transfer result once qemu-img takes
+		// over, show the conversion progress instead while it runs
+		if detail := dataVolumeCopy.Status.ProgressDetail; detail != nil && detail.Phase == convertPhase {
+			if f, ok := matchMetricValue(string(body), "convert_progress\\{ownerUID=\""+string(dataVolumeCopy.UID)+"\"\\} (\\d{1,3}\\.?\\d*)"); ok {
+				dataVolumeCopy.Status.Progress = cdiv1.DataVolumeProgress(fmt.Sprintf("%.2f%%", f))
+			}
 		}
 		return nil
 	}
 	return err
 }
 
+// updateCheckpointProgress records which checkpoint the running stage of a
+// multi-stage import copies, so the byte counters always describe a single
+// delta instead of resetting opaquely when the next stage starts
+func updateCheckpointProgress(dataVolumeCopy *cdiv1.DataVolume, pvc *corev1.PersistentVolumeClaim) {
+	checkpoint := pvc.Annotations[AnnCurrentCheckpoint]
+	if checkpoint == "" {
+		return
+	}
+	detail := dataVolumeCopy.Status.ProgressDetail
+	if detail == nil {
+		detail = &cdiv1.DataVolumeProgressDetail{}
+		dataVolumeCopy.Status.ProgressDetail = detail
+	}
+	if detail.CurrentCheckpoint != checkpoint {
+		// a new stage just started, drop the byte counters of the previous delta
+		detail.BytesDone = 0
+		detail.BytesTotal = 0
+	}
+	detail.CurrentCheckpoint = checkpoint
+	detail.CheckpointIndex = 0
+	for i, cp := range dataVolumeCopy.Spec.Checkpoints {
+		if cp.Current == checkpoint {
+			detail.CheckpointIndex = int32(i + 1)
+			break
+		}
+	}
+}
+
+// convertPhase mirrors importer.ProcessingPhaseConvert as reported by the worker pod metrics
+const convertPhase = "Convert"
+
+// emitConversionEvents emits events when the worker pod enters or leaves the qemu-img
+// conversion phase, based on the phase reported in the structured progress
+func (r *DatavolumeReconciler) emitConversionEvents(datavolume *cdiv1.DataVolume, prevPhase string) {
+	if datavolume.Status.ProgressDetail == nil || datavolume.Status.ProgressDetail.Phase == prevPhase {
+		return
+	}
+	if datavolume.Status.ProgressDetail.Phase == convertPhase {
+		r.recorder.Eventf(datavolume, corev1.EventTypeNormal, ConversionStarted, MessageConversionStarted, datavolume.Namespace, datavolume.Name)
+	} else if prevPhase == convertPhase {
+		r.recorder.Eventf(datavolume, corev1.EventTypeNormal, ConversionCompleted, MessageConversionCompleted, datavolume.Namespace, datavolume.Name)
+	}
+}
+
+// updateProgressDetail fills the structured progress in the DataVolume status from the
+// byte counters and phase gauge the worker pod exposes on its metrics endpoint.
+func updateProgressDetail(dataVolumeCopy *cdiv1.DataVolume, pod *corev1.Pod, metrics string) {
+	uid := string(dataVolumeCopy.UID)
+	bytesDone, doneOk := matchMetricValue(metrics, "progress_bytes_done\\{ownerUID=\""+uid+"\"\\} (\\d+\\.?\\d*(?:e\\+?\\d+)?)")
+	bytesTotal, totalOk := matchMetricValue(metrics, "progress_bytes_total\\{ownerUID=\""+uid+"\"\\} (\\d+\\.?\\d*(?:e\\+?\\d+)?)")
+	phaseRegExp := regexp.MustCompile("progress_phase\\{ownerUID=\"" + uid + "\",phase=\"(\\w+)\"\\} 1")
+	phaseMatch := phaseRegExp.FindStringSubmatch(metrics)
+	if !doneOk && !totalOk && phaseMatch == nil {
+		return
+	}
+
+	detail := dataVolumeCopy.Status.ProgressDetail
+	if detail == nil {
+		detail = &cdiv1.DataVolumeProgressDetail{}
+		if pod.Status.StartTime != nil {
+			detail.StartedAt = *pod.Status.StartTime
+		}
+		dataVolumeCopy.Status.ProgressDetail = detail
+	}
+	if doneOk {
+		detail.BytesDone = int64(bytesDone)
+	}
+	if totalOk {
+		detail.BytesTotal = int64(bytesTotal)
+	}
+	if phaseMatch != nil {
+		detail.Phase = phaseMatch[1]
+	}
+}
+
+// matchMetricValue extracts a single float sample from a prometheus scrape body
+func matchMetricValue(metrics, pattern string) (float64, bool) {
+	match := regexp.MustCompile(pattern).FindStringSubmatch(metrics)
+	if match == nil {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
+
 func errConnectionRefused(err error) bool {
 	return strings.Contains(err.Error(), "connection refused")
 }
@@ -2412,6 +3095,16 @@ func errQuotaExceeded(err error) bool {
 	return strings.Contains(err.Error(), "exceeded quota:")
 }
 
+// errLimitRangeExceeded matches pod rejections produced by the LimitRanger admission plugin
+func errLimitRangeExceeded(err error) bool {
+	return k8serrors.IsForbidden(err) &&
+		(strings.Contains(err.Error(), "usage per Container") || strings.Contains(err.Error(), "usage per Pod"))
+}
+
+func errBlockedByQuota(err error) bool {
+	return errQuotaExceeded(err) || errLimitRangeExceeded(err)
+}
+
 func getPodMetricsPort(pod *corev1.Pod) (int, error) {
 	for _, container := range pod.Spec.Containers {
 		for _, port := range container.Ports {
@@ -2478,6 +3171,9 @@ func (r *DatavolumeReconciler) newPersistentVolumeClaim(dataVolume *cdiv1.DataVo
 		if dataVolume.Spec.Source.HTTP.CertConfigMap != "" {
 			annotations[AnnCertConfigMap] = dataVolume.Spec.Source.HTTP.CertConfigMap
 		}
+		if dataVolume.Spec.Source.HTTP.CertSecret != "" {
+			annotations[AnnCertSecret] = dataVolume.Spec.Source.HTTP.CertSecret
+		}
 		for index, header := range dataVolume.Spec.Source.HTTP.ExtraHeaders {
 			annotations[fmt.Sprintf("%s.%d", AnnExtraHeaders, index)] = header
 		}
@@ -2493,6 +3189,27 @@ func (r *DatavolumeReconciler) newPersistentVolumeClaim(dataVolume *cdiv1.DataVo
 		if dataVolume.Spec.Source.S3.CertConfigMap != "" {
 			annotations[AnnCertConfigMap] = dataVolume.Spec.Source.S3.CertConfigMap
 		}
+		if dataVolume.Spec.Source.S3.CertSecret != "" {
+			annotations[AnnCertSecret] = dataVolume.Spec.Source.S3.CertSecret
+		}
+	} else if dataVolume.Spec.Source.GCS != nil {
+		annotations[AnnEndpoint] = dataVolume.Spec.Source.GCS.URL
+		annotations[AnnSource] = SourceGCS
+		if dataVolume.Spec.Source.GCS.SecretRef != "" {
+			annotations[AnnSecret] = dataVolume.Spec.Source.GCS.SecretRef
+		}
+	} else if dataVolume.Spec.Source.OVA != nil {
+		annotations[AnnEndpoint] = dataVolume.Spec.Source.OVA.URL
+		annotations[AnnSource] = SourceOVA
+		if dataVolume.Spec.Source.OVA.SecretRef != "" {
+			annotations[AnnSecret] = dataVolume.Spec.Source.OVA.SecretRef
+		}
+		if dataVolume.Spec.Source.OVA.CertConfigMap != "" {
+			annotations[AnnCertConfigMap] = dataVolume.Spec.Source.OVA.CertConfigMap
+		}
+		if dataVolume.Spec.Source.OVA.CertSecret != "" {
+			annotations[AnnCertSecret] = dataVolume.Spec.Source.OVA.CertSecret
+		}
 	} else if dataVolume.Spec.Source.Registry != nil {
 		annotations[AnnSource] = SourceRegistry
 		pullMethod := dataVolume.Spec.Source.Registry.PullMethod
@@ -2518,6 +3235,10 @@ func (r *DatavolumeReconciler) newPersistentVolumeClaim(dataVolume *cdiv1.DataVo
 		if certConfigMap != nil && *certConfigMap != "" {
 			annotations[AnnCertConfigMap] = *certConfigMap
 		}
+		certSecret := dataVolume.Spec.Source.Registry.CertSecret
+		if certSecret != nil && *certSecret != "" {
+			annotations[AnnCertSecret] = *certSecret
+		}
 	} else if dataVolume.Spec.Source.PVC != nil {
 		sourceNamespace := dataVolume.Spec.Source.PVC.Namespace
 		if sourceNamespace == "" {
@@ -2529,17 +3250,35 @@ func (r *DatavolumeReconciler) newPersistentVolumeClaim(dataVolume *cdiv1.DataVo
 		}
 		annotations[AnnCloneToken] = token
 		annotations[AnnCloneRequest] = sourceNamespace + "/" + dataVolume.Spec.Source.PVC.Name
+	} else if dataVolume.Spec.Source.Snapshot != nil {
+		if ns := dataVolume.Spec.Source.Snapshot.Namespace; ns != "" && ns != dataVolume.Namespace {
+			return nil, errors.Errorf("cross namespace snapshot source is not supported")
+		}
+		// CSI restores the snapshot into the claim, so the PVC is born populated
+		annotations[AnnPopulatedFor] = dataVolume.Name
 	} else if dataVolume.Spec.Source.Upload != nil {
 		annotations[AnnUploadRequest] = ""
 		annotations[AnnContentType] = string(dataVolume.Spec.ContentType)
 	} else if dataVolume.Spec.Source.Blank != nil {
 		annotations[AnnSource] = SourceNone
 		annotations[AnnContentType] = string(cdiv1.DataVolumeKubeVirt)
+		if blank := dataVolume.Spec.Source.Blank; blank.Filesystem != "" {
+			annotations[AnnBlankFilesystem] = blank.Filesystem
+			if blank.Label != "" {
+				annotations[AnnBlankFilesystemLabel] = blank.Label
+			}
+			if blank.ReservedBlocksPercentage != nil {
+				annotations[AnnBlankReservedBlocks] = strconv.FormatInt(int64(*blank.ReservedBlocksPercentage), 10)
+			}
+		}
 	} else if dataVolume.Spec.Source.Imageio != nil {
 		annotations[AnnEndpoint] = dataVolume.Spec.Source.Imageio.URL
 		annotations[AnnSource] = SourceImageio
 		annotations[AnnSecret] = dataVolume.Spec.Source.Imageio.SecretRef
 		annotations[AnnCertConfigMap] = dataVolume.Spec.Source.Imageio.CertConfigMap
+		if dataVolume.Spec.Source.Imageio.CertSecret != "" {
+			annotations[AnnCertSecret] = dataVolume.Spec.Source.Imageio.CertSecret
+		}
 		annotations[AnnDiskID] = dataVolume.Spec.Source.Imageio.DiskID
 	} else if dataVolume.Spec.Source.VDDK != nil {
 		annotations[AnnEndpoint] = dataVolume.Spec.Source.VDDK.URL
@@ -2551,6 +3290,19 @@ func (r *DatavolumeReconciler) newPersistentVolumeClaim(dataVolume *cdiv1.DataVo
 		if dataVolume.Spec.Source.VDDK.InitImageURL != "" {
 			annotations[AnnVddkInitImageURL] = dataVolume.Spec.Source.VDDK.InitImageURL
 		}
+	} else if dataVolume.Spec.Source.VolumeExport != nil {
+		// the export server speaks plain HTTPS, the paired token and the pinned
+		// serving cert ride along as importer pod inputs
+		annotations[AnnEndpoint] = dataVolume.Spec.Source.VolumeExport.URL
+		annotations[AnnSource] = SourceHTTP
+		annotations[AnnContentType] = string(cdiv1.DataVolumeKubeVirt)
+		annotations[AnnExportTokenSecret] = dataVolume.Spec.Source.VolumeExport.TokenSecretRef
+		if dataVolume.Spec.Source.VolumeExport.CertConfigMap != "" {
+			annotations[AnnCertConfigMap] = dataVolume.Spec.Source.VolumeExport.CertConfigMap
+		}
+		if dataVolume.Spec.Source.VolumeExport.Checksum != "" {
+			annotations[AnnChecksum] = dataVolume.Spec.Source.VolumeExport.Checksum
+		}
 	} else {
 		return nil, errors.Errorf("no source set for datavolume")
 	}
@@ -2569,6 +3321,14 @@ func (r *DatavolumeReconciler) newPersistentVolumeClaim(dataVolume *cdiv1.DataVo
 		Spec: *targetPvcSpec,
 	}
 
+	if snapshot := dataVolume.Spec.Source.Snapshot; snapshot != nil {
+		pvc.Spec.DataSource = &corev1.TypedLocalObjectReference{
+			Name:     snapshot.Name,
+			Kind:     "VolumeSnapshot",
+			APIGroup: &snapshotv1.SchemeGroupVersion.Group,
+		}
+	}
+
 	if pvc.Namespace == dataVolume.Namespace {
 		pvc.OwnerReferences = []metav1.OwnerReference{
 			*metav1.NewControllerRef(dataVolume, schema.GroupVersionKind{
@@ -2587,13 +3347,15 @@ func (r *DatavolumeReconciler) newPersistentVolumeClaim(dataVolume *cdiv1.DataVo
 	return pvc, nil
 }
 
-// If sourceRef is set, populate spec.Source with data from the DataSource
-func (r *DatavolumeReconciler) populateSourceIfSourceRef(dv *cdiv1.DataVolume) error {
+// If sourceRef is set, populate spec.Source with data from the DataSource.
+// Returns false when the referenced DataSource cannot be consumed yet, reconciliation
+// then waits for the DataSource to become ready instead of failing.
+func (r *DatavolumeReconciler) populateSourceIfSourceRef(dv *cdiv1.DataVolume) (bool, error) {
 	if dv.Spec.SourceRef == nil {
-		return nil
+		return true, nil
 	}
 	if dv.Spec.SourceRef.Kind != cdiv1.DataVolumeDataSource {
-		return errors.Errorf("Unsupported sourceRef kind %s, currently only %s is supported", dv.Spec.SourceRef.Kind, cdiv1.DataVolumeDataSource)
+		return false, errors.Errorf("Unsupported sourceRef kind %s, currently only %s is supported", dv.Spec.SourceRef.Kind, cdiv1.DataVolumeDataSource)
 	}
 	ns := dv.Namespace
 	if dv.Spec.SourceRef.Namespace != nil && *dv.Spec.SourceRef.Namespace != "" {
@@ -2601,24 +3363,258 @@ func (r *DatavolumeReconciler) populateSourceIfSourceRef(dv *cdiv1.DataVolume) e
 	}
 	dataSource := &cdiv1.DataSource{}
 	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: dv.Spec.SourceRef.Name, Namespace: ns}, dataSource); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if !dataSourceIsReady(dataSource) {
+		return false, nil
+	}
+	if dataSource.Spec.Source.PVC != nil {
+		dv.Spec.Source = &cdiv1.DataVolumeSource{
+			PVC: dataSource.Spec.Source.PVC,
+		}
+		return true, nil
+	}
+	if dataSource.Spec.Source.Snapshot != nil {
+		dv.Spec.Source = &cdiv1.DataVolumeSource{
+			Snapshot: dataSource.Spec.Source.Snapshot,
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// cdiQuotaAllowsTransfer checks the CDIQuotas in the DataVolume namespace before a new
+// transfer starts, returning false and a message when a limit would be exceeded
+func (r *DatavolumeReconciler) cdiQuotaAllowsTransfer(dv *cdiv1.DataVolume) (bool, string, error) {
+	quotas := &cdiv1.CDIQuotaList{}
+	if err := r.client.List(context.TODO(), quotas, client.InNamespace(dv.Namespace)); err != nil {
+		return false, "", err
+	}
+	for i := range quotas.Items {
+		quota := &quotas.Items[i]
+		if max := quota.Spec.MaxConcurrentTransfers; max != nil {
+			active, err := r.countActiveTransfers(dv)
+			if err != nil {
+				return false, "", err
+			}
+			if active >= *max {
+				return false, fmt.Sprintf("CDIQuota %s exceeded: %d transfers already running, at most %d allowed", quota.Name, active, *max), nil
+			}
+		}
+		if max := quota.Spec.MaxImportedBytesPerDay; max != nil && DataVolumeIsImport(dv) {
+			imported := ImportedBytesToday(quota)
+			if imported.Cmp(*max) >= 0 {
+				return false, fmt.Sprintf("CDIQuota %s exceeded: %s already imported today, at most %s allowed per day", quota.Name, imported.String(), max.String()), nil
+			}
+		}
+	}
+	return true, "", nil
+}
+
+// countActiveTransfers counts the DataVolumes in the namespace that currently run a transfer
+func (r *DatavolumeReconciler) countActiveTransfers(dv *cdiv1.DataVolume) (int32, error) {
+	dvList := &cdiv1.DataVolumeList{}
+	if err := r.client.List(context.TODO(), dvList, client.InNamespace(dv.Namespace)); err != nil {
+		return 0, err
+	}
+	active := int32(0)
+	for i := range dvList.Items {
+		other := &dvList.Items[i]
+		if other.Name != dv.Name && DataVolumeIsActiveTransfer(other) {
+			active++
+		}
+	}
+	return active, nil
+}
+
+// dataVolumePriority resolves the priority class of a DataVolume, treating a
+// missing or unresolvable class as the default priority of zero
+func (r *DatavolumeReconciler) dataVolumePriority(dv *cdiv1.DataVolume) int32 {
+	if dv.Spec.PriorityClassName == "" {
+		return 0
+	}
+	priorityClass := &schedulingv1.PriorityClass{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: dv.Spec.PriorityClassName}, priorityClass); err != nil {
+		return 0
+	}
+	return priorityClass.Value
+}
+
+// preemptLowerPriorityTransfer tries to free a transfer slot for dv by pausing
+// the lowest priority running transfer in the namespace, returning true when a
+// transfer was preempted
+func (r *DatavolumeReconciler) preemptLowerPriorityTransfer(dv *cdiv1.DataVolume) (bool, error) {
+	priority := r.dataVolumePriority(dv)
+	dvList := &cdiv1.DataVolumeList{}
+	if err := r.client.List(context.TODO(), dvList, client.InNamespace(dv.Namespace)); err != nil {
+		return false, err
+	}
+	var victim *cdiv1.DataVolume
+	victimPriority := priority
+	for i := range dvList.Items {
+		other := &dvList.Items[i]
+		if other.Name == dv.Name || !DataVolumeIsActiveTransfer(other) {
+			continue
+		}
+		if otherPriority := r.dataVolumePriority(other); otherPriority < victimPriority {
+			victim = other
+			victimPriority = otherPriority
+		}
+	}
+	if victim == nil {
+		return false, nil
+	}
+	if err := r.pausePreemptedTransfer(dv, victim); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// pausePreemptedTransfer pauses the import of victim so dv can use its transfer
+// slot, the victim resumes once the quota allows another transfer
+func (r *DatavolumeReconciler) pausePreemptedTransfer(dv, victim *cdiv1.DataVolume) error {
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: victim.Namespace, Name: GetDataVolumeClaimName(victim)}, pvc); err != nil {
 		return err
 	}
-	dv.Spec.Source = &cdiv1.DataVolumeSource{
-		PVC: dataSource.Spec.Source.PVC,
+	original := pvc.DeepCopy()
+	if pvc.Annotations == nil {
+		pvc.Annotations = make(map[string]string)
 	}
+	pvc.Annotations[AnnImportPaused] = "true"
+	pvc.Annotations[AnnPreempted] = "true"
+	if err := r.patchPVC(original, pvc); err != nil {
+		return err
+	}
+	if pod, err := r.getPodFromPvc(pvc.Namespace, pvc); err == nil {
+		if err := r.client.Delete(context.TODO(), pod); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+	victimCopy := victim.DeepCopy()
+	victimCopy.Status.Phase = cdiv1.Paused
+	if err := r.patchDataVolume(victim, victimCopy); err != nil {
+		return err
+	}
+	r.recorder.Eventf(victim, corev1.EventTypeWarning, TransferPreempted, MessageTransferPreempted, dv.Name)
 	return nil
 }
 
+// resumePreemptedTransfer lifts the pause from a preempted import once the
+// quota allows another transfer, returning a result to requeue while it does not
+func (r *DatavolumeReconciler) resumePreemptedTransfer(dv *cdiv1.DataVolume, pvc *corev1.PersistentVolumeClaim) (*reconcile.Result, error) {
+	allowed, _, err := r.cdiQuotaAllowsTransfer(dv)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return &reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+	original := pvc.DeepCopy()
+	delete(pvc.Annotations, AnnImportPaused)
+	delete(pvc.Annotations, AnnPreempted)
+	if err := r.patchPVC(original, pvc); err != nil {
+		return nil, err
+	}
+	r.recorder.Eventf(dv, corev1.EventTypeNormal, TransferResumed, MessageTransferResumed, pvc.Name)
+	return nil, nil
+}
+
+// importSourceCheckURL returns the endpoint to probe for the source pre-check,
+// or an empty string when the source has nothing to connect to
+func importSourceCheckURL(dv *cdiv1.DataVolume) string {
+	source := dv.Spec.Source
+	if source == nil {
+		return ""
+	}
+	switch {
+	case source.HTTP != nil:
+		return source.HTTP.URL
+	case source.S3 != nil:
+		return source.S3.URL
+	case source.OVA != nil:
+		// s3 backed OVAs cannot be probed with a plain HEAD request
+		if !strings.HasPrefix(source.OVA.URL, "s3://") {
+			return source.OVA.URL
+		}
+	case source.Registry != nil && source.Registry.URL != nil:
+		// ping the registry API root, the manifest itself may need credentials
+		if name := strings.TrimPrefix(*source.Registry.URL, "docker://"); name != *source.Registry.URL {
+			return "https://" + strings.SplitN(name, "/", 2)[0] + "/v2/"
+		}
+	}
+	return ""
+}
+
+// checkSourceReachable probes the import source endpoint with a HEAD request so
+// a doomed import fails fast before any storage is provisioned
+func (r *DatavolumeReconciler) checkSourceReachable(dv *cdiv1.DataVolume) (bool, string) {
+	url := importSourceCheckURL(dv)
+	if url == "" {
+		return true, ""
+	}
+	req, err := http.NewRequest(http.MethodHead, url, nil)
+	if err != nil {
+		return false, fmt.Sprintf("invalid source URL %s: %v", url, err)
+	}
+	resp, err := buildHTTPClient().Do(req)
+	if err != nil {
+		return false, fmt.Sprintf("could not connect to %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+	// an unauthorized answer still proves connectivity, the transfer pod brings the credentials
+	if resp.StatusCode >= http.StatusBadRequest && resp.StatusCode != http.StatusUnauthorized {
+		return false, fmt.Sprintf("%s responded with status %q", url, resp.Status)
+	}
+	return true, ""
+}
+
+// updateSourceUnreachable parks the DataVolume with a SourceUnreachable condition
+// instead of provisioning storage for an import that cannot start
+func (r *DatavolumeReconciler) updateSourceUnreachable(dv *cdiv1.DataVolume, message string) error {
+	dataVolumeCopy := dv.DeepCopy()
+	dataVolumeCopy.Status.Phase = cdiv1.Pending
+	dataVolumeCopy.Status.Conditions = updateSourceUnreachableCondition(dataVolumeCopy.Status.Conditions, true, message, sourceUnreachable)
+	if !reflect.DeepEqual(dv.Status, dataVolumeCopy.Status) {
+		r.recorder.Event(dv, corev1.EventTypeWarning, sourceUnreachable, message)
+		return r.patchDataVolume(dv, dataVolumeCopy)
+	}
+	return nil
+}
+
+// waitForSourceRef flags the DataVolume as waiting for its DataSource,
+// reconciliation resumes once the referenced DataSource becomes ready
+func (r *DatavolumeReconciler) waitForSourceRef(dv *cdiv1.DataVolume) (reconcile.Result, error) {
+	dataVolumeCopy := dv.DeepCopy()
+	message := fmt.Sprintf("DataSource %s is not ready", dv.Spec.SourceRef.Name)
+	dataVolumeCopy.Status.Conditions = updateSourceRefNotReadyCondition(dataVolumeCopy.Status.Conditions, true, message, dataSourceNotReady)
+	if !reflect.DeepEqual(dv.Status, dataVolumeCopy.Status) {
+		r.recorder.Event(dv, corev1.EventTypeWarning, dataSourceNotReady, message)
+		if err := r.patchDataVolume(dv, dataVolumeCopy); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{}, nil
+}
+
+// patchDataVolume sends only the difference between original and modified as a
+// merge patch. Full object updates bump the resourceVersion on every write and
+// run into conflict retries when hundreds of DataVolumes import at once.
 // Whenever the controller updates a DV, we must make sure to nil out spec.source when spec.sourceRef is set
-func (r *DatavolumeReconciler) updateDataVolume(dv *cdiv1.DataVolume) error {
-	if dv.Spec.SourceRef != nil {
-		dv.Spec.Source = nil
+func (r *DatavolumeReconciler) patchDataVolume(original, modified *cdiv1.DataVolume) error {
+	if modified.Spec.SourceRef != nil {
+		modified.Spec.Source = nil
 	}
-	return r.client.Update(context.TODO(), dv)
+	return r.client.Patch(context.TODO(), modified, client.MergeFrom(original))
 }
 
-func (r *DatavolumeReconciler) updatePVC(pvc *corev1.PersistentVolumeClaim) error {
-	return r.client.Update(context.TODO(), pvc)
+// patchPVC coalesces the annotation and metadata changes a reconcile made into
+// a single merge patch carrying just the touched fields
+func (r *DatavolumeReconciler) patchPVC(original, modified *corev1.PersistentVolumeClaim) error {
+	return r.client.Patch(context.TODO(), modified, client.MergeFrom(original))
 }
 
 func getName(storageClass *storagev1.StorageClass) string {
@@ -2663,11 +3659,10 @@ func (r *DatavolumeReconciler) garbageCollect(dataVolume *cdiv1.DataVolume, pvc
 	if dataVolume.Status.Phase != cdiv1.Succeeded {
 		return nil, nil
 	}
-	cdiConfig := &cdiv1.CDIConfig{}
-	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, cdiConfig); err != nil {
+	dvTTL, err := r.getDataVolumeTTLSeconds(dataVolume, log)
+	if err != nil {
 		return nil, err
 	}
-	dvTTL := cdiConfig.Spec.DataVolumeTTLSeconds
 	if dvTTL == nil || *dvTTL < 0 {
 		return nil, nil
 	}
@@ -2681,6 +3676,29 @@ func (r *DatavolumeReconciler) garbageCollect(dataVolume *cdiv1.DataVolume, pvc
 	return &reconcile.Result{}, nil
 }
 
+// getDataVolumeTTLSeconds returns the TTL for the given DV, preferring a
+// Namespace annotation override to the global CDIConfig DataVolumeTTLSeconds
+func (r *DatavolumeReconciler) getDataVolumeTTLSeconds(dataVolume *cdiv1.DataVolume, log logr.Logger) (*int32, error) {
+	ns := &corev1.Namespace{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: dataVolume.Namespace}, ns); err != nil {
+		return nil, err
+	}
+	if ttlAnn, ok := ns.Annotations[AnnDataVolumeTTLSeconds]; ok {
+		ttl, err := strconv.ParseInt(ttlAnn, 10, 32)
+		if err != nil {
+			log.V(1).Info("Ignoring unparsable namespace TTL annotation", "namespace", ns.Name, "value", ttlAnn)
+		} else {
+			ttl32 := int32(ttl)
+			return &ttl32, nil
+		}
+	}
+	cdiConfig := &cdiv1.CDIConfig{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, cdiConfig); err != nil {
+		return nil, err
+	}
+	return cdiConfig.Spec.DataVolumeTTLSeconds, nil
+}
+
 func (r *DatavolumeReconciler) detachPvcDeleteDv(pvc *corev1.PersistentVolumeClaim, dv *cdiv1.DataVolume, log logr.Logger) error {
 	if !IsSucceeded(pvc) {
 		return nil
@@ -2693,9 +3711,10 @@ func (r *DatavolumeReconciler) detachPvcDeleteDv(pvc *corev1.PersistentVolumeCla
 	if dvDelete != "true" {
 		return nil
 	}
+	original := pvc.DeepCopy()
 	updatePvcOwnerRefs(pvc, dv)
 	delete(pvc.Annotations, AnnPopulatedFor)
-	if err := r.updatePVC(pvc); err != nil {
+	if err := r.patchPVC(original, pvc); err != nil {
 		return err
 	}
 	if err := r.client.Delete(context.TODO(), dv); err != nil {
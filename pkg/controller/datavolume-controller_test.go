@@ -31,7 +31,10 @@ import (
 	. "github.com/onsi/gomega"
 
 	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -98,6 +101,86 @@ var _ = Describe("All DataVolume Tests", func() {
 			Expect(pvc.Labels[common.KubePersistentVolumeFillingUpSuppressLabelKey]).To(Equal(common.KubePersistentVolumeFillingUpSuppressLabelValue))
 		})
 
+		It("Should wait with SourceRefNotReady condition when the referenced DataSource does not exist", func() {
+			dv := newImportDataVolume("test-dv")
+			dv.Spec.Source = nil
+			dv.Spec.SourceRef = &cdiv1.DataVolumeSourceRef{
+				Kind: cdiv1.DataVolumeDataSource,
+				Name: "missing-datasource",
+			}
+			reconciler = createDatavolumeReconciler(dv)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, dv)
+			Expect(err).ToNot(HaveOccurred())
+			cond := findConditionByType(cdiv1.DataVolumeSourceRefNotReady, dv.Status.Conditions)
+			Expect(cond).ToNot(BeNil())
+			Expect(cond.Status).To(Equal(corev1.ConditionTrue))
+			// No PVC is created while the DataSource is missing
+			pvc := &corev1.PersistentVolumeClaim{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should wait with SourceRefNotReady condition until the referenced DataSource is ready", func() {
+			dv := newImportDataVolume("test-dv")
+			dv.Spec.Source = nil
+			dv.Spec.SourceRef = &cdiv1.DataVolumeSourceRef{
+				Kind: cdiv1.DataVolumeDataSource,
+				Name: "snapshot-datasource",
+			}
+			ds := &cdiv1.DataSource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "snapshot-datasource",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: cdiv1.DataSourceSpec{
+					Source: cdiv1.DataSourceSource{
+						Snapshot: &cdiv1.DataVolumeSourceSnapshot{Name: "source-snapshot"},
+					},
+				},
+			}
+			reconciler = createDatavolumeReconciler(dv, ds)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, dv)
+			Expect(err).ToNot(HaveOccurred())
+			cond := findConditionByType(cdiv1.DataVolumeSourceRefNotReady, dv.Status.Conditions)
+			Expect(cond).ToNot(BeNil())
+			Expect(cond.Status).To(Equal(corev1.ConditionTrue))
+		})
+
+		It("Should restore the snapshot of a ready snapshot DataSource into the PVC", func() {
+			dv := newImportDataVolume("test-dv")
+			dv.Spec.Source = nil
+			dv.Spec.SourceRef = &cdiv1.DataVolumeSourceRef{
+				Kind: cdiv1.DataVolumeDataSource,
+				Name: "snapshot-datasource",
+			}
+			ds := &cdiv1.DataSource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "snapshot-datasource",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: cdiv1.DataSourceSpec{
+					Source: cdiv1.DataSourceSource{
+						Snapshot: &cdiv1.DataVolumeSourceSnapshot{Name: "source-snapshot"},
+					},
+				},
+			}
+			updateDataSourceCondition(ds, cdiv1.DataSourceReady, corev1.ConditionTrue, "DataSource is ready to be consumed", ready)
+			reconciler = createDatavolumeReconciler(dv, ds)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			pvc := &corev1.PersistentVolumeClaim{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvc.Spec.DataSource).ToNot(BeNil())
+			Expect(pvc.Spec.DataSource.Kind).To(Equal("VolumeSnapshot"))
+			Expect(pvc.Spec.DataSource.Name).To(Equal("source-snapshot"))
+			Expect(pvc.Annotations[AnnPopulatedFor]).To(Equal("test-dv"))
+		})
+
 		It("Should set params on a PVC from import DV.PVC", func() {
 			importDataVolume := newImportDataVolume("test-dv")
 			importDataVolume.Spec.PVC.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
@@ -482,6 +565,66 @@ var _ = Describe("All DataVolume Tests", func() {
 			Expect(pvc.GetAnnotations()[AnnPriorityClassName]).To(Equal("p0-s3"))
 		})
 
+		It("Should set up the import annotations for a GCS source", func() {
+			dv := newGCSImportDataVolume("test-dv")
+			reconciler = createDatavolumeReconciler(dv)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			pvc := &corev1.PersistentVolumeClaim{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvc.GetAnnotations()[AnnSource]).To(Equal(SourceGCS))
+			Expect(pvc.GetAnnotations()[AnnEndpoint]).To(Equal("gs://bucket/disk.img"))
+			Expect(pvc.GetAnnotations()[AnnSecret]).To(Equal("gcs-secret"))
+		})
+
+		It("Should set up the import annotations for an OVA source", func() {
+			dv := newOVAImportDataVolume("test-dv")
+			reconciler = createDatavolumeReconciler(dv)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			pvc := &corev1.PersistentVolumeClaim{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvc.GetAnnotations()[AnnSource]).To(Equal(SourceOVA))
+			Expect(pvc.GetAnnotations()[AnnEndpoint]).To(Equal("https://example.com/appliance.ova"))
+			Expect(pvc.GetAnnotations()[AnnSecret]).To(Equal("ova-secret"))
+			Expect(pvc.GetAnnotations()[AnnCertConfigMap]).To(Equal("ova-certs"))
+		})
+
+		It("Should set up the import annotations for a VolumeExport source", func() {
+			dv := newVolumeExportImportDataVolume("test-dv")
+			reconciler = createDatavolumeReconciler(dv)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			pvc := &corev1.PersistentVolumeClaim{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvc.GetAnnotations()[AnnSource]).To(Equal(SourceHTTP))
+			Expect(pvc.GetAnnotations()[AnnEndpoint]).To(Equal("https://cdi-export-source.other.svc/volume"))
+			Expect(pvc.GetAnnotations()[AnnExportTokenSecret]).To(Equal("export-token"))
+			Expect(pvc.GetAnnotations()[AnnCertConfigMap]).To(Equal("export-cert"))
+			Expect(pvc.GetAnnotations()[AnnChecksum]).To(Equal("sha256:1234"))
+		})
+
+		It("Should set up the filesystem annotations for a blank source", func() {
+			dv := newBlankImageDataVolume("test-dv")
+			reservedBlocks := int32(5)
+			dv.Spec.Source.Blank.Filesystem = "ext4"
+			dv.Spec.Source.Blank.Label = "data"
+			dv.Spec.Source.Blank.ReservedBlocksPercentage = &reservedBlocks
+			reconciler = createDatavolumeReconciler(dv)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			pvc := &corev1.PersistentVolumeClaim{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvc.GetAnnotations()[AnnSource]).To(Equal(SourceNone))
+			Expect(pvc.GetAnnotations()[AnnBlankFilesystem]).To(Equal("ext4"))
+			Expect(pvc.GetAnnotations()[AnnBlankFilesystemLabel]).To(Equal("data"))
+			Expect(pvc.GetAnnotations()[AnnBlankReservedBlocks]).To(Equal("5"))
+		})
+
 		It("Should follow the phase of the created PVC", func() {
 			reconciler = createDatavolumeReconciler(newImportDataVolume("test-dv"))
 			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
@@ -545,6 +688,200 @@ var _ = Describe("All DataVolume Tests", func() {
 			Expect(event).To(ContainSubstring("Resource \"test-dv\" already exists and is not managed by DataVolume"))
 		})
 
+		It("Should adopt an existing PVC with its content on the resume overwrite policy", func() {
+			pvc := createPvc("test-dv", metav1.NamespaceDefault, map[string]string{}, nil)
+			pvc.Status.Phase = corev1.ClaimBound
+			dv := newImportDataVolume("test-dv")
+			dv.Spec.OverwritePolicy = cdiv1.OverwritePolicyResume
+			reconciler = createDatavolumeReconciler(pvc, dv)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvc.OwnerReferences).To(HaveLen(1))
+			Expect(pvc.OwnerReferences[0].Kind).To(Equal("DataVolume"))
+			Expect(pvc.Annotations[AnnPopulatedFor]).To(Equal("test-dv"))
+			event := <-reconciler.recorder.(*record.FakeRecorder).Events
+			Expect(event).To(ContainSubstring("Adopted existing PVC test-dv"))
+		})
+
+		It("Should re-populate an existing PVC on the wipe overwrite policy", func() {
+			pvc := createPvc("test-dv", metav1.NamespaceDefault, map[string]string{AnnPodPhase: string(corev1.PodSucceeded)}, nil)
+			pvc.Status.Phase = corev1.ClaimBound
+			dv := newImportDataVolume("test-dv")
+			dv.Spec.OverwritePolicy = cdiv1.OverwritePolicyWipe
+			reconciler = createDatavolumeReconciler(pvc, dv)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvc.OwnerReferences).To(HaveLen(1))
+			Expect(pvc.Annotations[AnnSource]).To(Equal(SourceHTTP))
+			Expect(pvc.Annotations[AnnEndpoint]).To(Equal("http://example.com/data"))
+			Expect(pvc.Annotations).ToNot(HaveKey(AnnPodPhase))
+			event := <-reconciler.recorder.(*record.FakeRecorder).Events
+			Expect(event).To(ContainSubstring("Re-populating existing PVC test-dv"))
+		})
+
+		It("Should preempt a lower priority transfer when the quota blocks a higher priority DataVolume", func() {
+			maxTransfers := int32(1)
+			quota := createCDIQuota()
+			quota.Spec.MaxConcurrentTransfers = &maxTransfers
+			lowPriority := &schedulingv1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: "low"}, Value: 10}
+			highPriority := &schedulingv1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: "high"}, Value: 100}
+			victim := newImportDataVolume("victim-dv")
+			victim.Spec.PriorityClassName = "low"
+			victim.Status.Phase = cdiv1.ImportInProgress
+			victimPvc := createPvc("victim-dv", metav1.NamespaceDefault, nil, nil)
+			victimPvc.Status.Phase = corev1.ClaimBound
+			dv := newImportDataVolume("test-dv")
+			dv.Spec.PriorityClassName = "high"
+			reconciler = createDatavolumeReconciler(quota, lowPriority, highPriority, victim, victimPvc, dv)
+			result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Requeue).To(BeTrue())
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "victim-dv", Namespace: metav1.NamespaceDefault}, victimPvc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(victimPvc.Annotations[AnnImportPaused]).To(Equal("true"))
+			Expect(victimPvc.Annotations[AnnPreempted]).To(Equal("true"))
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "victim-dv", Namespace: metav1.NamespaceDefault}, victim)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(victim.Status.Phase).To(Equal(cdiv1.Paused))
+			event := <-reconciler.recorder.(*record.FakeRecorder).Events
+			Expect(event).To(ContainSubstring("preempted by higher priority DataVolume test-dv"))
+		})
+
+		It("Should resume a preempted transfer once the quota allows it", func() {
+			maxTransfers := int32(1)
+			quota := createCDIQuota()
+			quota.Spec.MaxConcurrentTransfers = &maxTransfers
+			dv := newImportDataVolume("test-dv")
+			dv.Status.Phase = cdiv1.Paused
+			annotations := map[string]string{AnnImportPaused: "true", AnnPreempted: "true"}
+			pvc := createPvc("test-dv", metav1.NamespaceDefault, annotations, nil)
+			pvc.Status.Phase = corev1.ClaimBound
+			controller := true
+			pvc.OwnerReferences = append(pvc.OwnerReferences, metav1.OwnerReference{
+				Kind:       "DataVolume",
+				Controller: &controller,
+				Name:       "test-dv",
+				UID:        dv.UID,
+			})
+			reconciler = createDatavolumeReconciler(quota, dv, pvc)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvc.Annotations).ToNot(HaveKey(AnnImportPaused))
+			Expect(pvc.Annotations).ToNot(HaveKey(AnnPreempted))
+			event := <-reconciler.recorder.(*record.FakeRecorder).Events
+			Expect(event).To(ContainSubstring("Transfer into test-dv resumed"))
+		})
+
+		It("Should park the DataVolume with a SourceUnreachable condition when the source pre-check fails", func() {
+			ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			}))
+			defer ts.Close()
+			dv := newImportDataVolume("test-dv")
+			dv.Annotations = map[string]string{AnnCheckSource: "true"}
+			dv.Spec.Source.HTTP.URL = ts.URL
+			reconciler = createDatavolumeReconciler(dv)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			pvc := &corev1.PersistentVolumeClaim{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).To(HaveOccurred())
+			Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, dv)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dv.Status.Phase).To(Equal(cdiv1.Pending))
+			cond := findConditionByType(cdiv1.DataVolumeSourceUnreachable, dv.Status.Conditions)
+			Expect(cond).ToNot(BeNil())
+			Expect(cond.Status).To(Equal(corev1.ConditionTrue))
+			Expect(cond.Message).To(ContainSubstring("404"))
+			event := <-reconciler.recorder.(*record.FakeRecorder).Events
+			Expect(event).To(ContainSubstring("SourceUnreachable"))
+		})
+
+		It("Should create the PVC when the source pre-check passes", func() {
+			ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer ts.Close()
+			dv := newImportDataVolume("test-dv")
+			dv.Annotations = map[string]string{AnnCheckSource: "true"}
+			dv.Spec.Source.HTTP.URL = ts.URL
+			reconciler = createDatavolumeReconciler(dv)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			pvc := &corev1.PersistentVolumeClaim{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, dv)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(findConditionByType(cdiv1.DataVolumeSourceUnreachable, dv.Status.Conditions)).To(BeNil())
+		})
+
+		It("Should grow the PVC when the source does not fit and auto expansion is enabled", func() {
+			scName := "expandable"
+			allowExpansion := true
+			sc := createStorageClass(scName, nil)
+			sc.AllowVolumeExpansion = &allowExpansion
+			annotations := map[string]string{
+				AnnRunningConditionMessage: "Unable to process data: Virtual image size 2147483648 is larger than the reported available storage 1000000000. A larger PVC is required.",
+			}
+			pvc := createPvcInStorageClass("test-dv", metav1.NamespaceDefault, &scName, annotations, nil, corev1.ClaimBound)
+			dv := newImportDataVolume("test-dv")
+			controller := true
+			pvc.OwnerReferences = append(pvc.OwnerReferences, metav1.OwnerReference{
+				Kind:       "DataVolume",
+				Controller: &controller,
+				Name:       "test-dv",
+				UID:        dv.UID,
+			})
+			cdiConfig := MakeEmptyCDIConfigSpec(common.ConfigName)
+			cdiConfig.Status = cdiv1.CDIConfigStatus{ScratchSpaceStorageClass: testStorageClass}
+			cdiConfig.Spec.FeatureGates = []string{featuregates.HonorWaitForFirstConsumer, featuregates.AutoVolumeExpansion}
+			reconciler = createDatavolumeReconcilerWithoutConfig(sc, pvc, dv, cdiConfig)
+			result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Requeue).To(BeTrue())
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			requested := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+			Expect(requested.Value()).To(Equal(int64(2147483648)))
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, dv)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dv.Status.Phase).To(Equal(cdiv1.ExpansionInProgress))
+		})
+
+		It("Should not grow the PVC for a too small source without the AutoVolumeExpansion feature gate", func() {
+			scName := "expandable"
+			allowExpansion := true
+			sc := createStorageClass(scName, nil)
+			sc.AllowVolumeExpansion = &allowExpansion
+			annotations := map[string]string{
+				AnnRunningConditionMessage: "Unable to process data: Virtual image size 2147483648 is larger than the reported available storage 1000000000. A larger PVC is required.",
+			}
+			pvc := createPvcInStorageClass("test-dv", metav1.NamespaceDefault, &scName, annotations, nil, corev1.ClaimBound)
+			dv := newImportDataVolume("test-dv")
+			controller := true
+			pvc.OwnerReferences = append(pvc.OwnerReferences, metav1.OwnerReference{
+				Kind:       "DataVolume",
+				Controller: &controller,
+				Name:       "test-dv",
+				UID:        dv.UID,
+			})
+			reconciler = createDatavolumeReconciler(sc, pvc, dv)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			requested := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+			Expect(requested.Value()).To(Equal(int64(1000000000)))
+		})
+
 		It("Should add owner to pre populated PVC", func() {
 			annotations := map[string]string{"cdi.kubevirt.io/storage.populatedFor": "test-dv"}
 			pvc := createPvc("test-dv", metav1.NamespaceDefault, annotations, nil)
@@ -1691,6 +2028,21 @@ var _ = Describe("All DataVolume Tests", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(foundPod).ToNot(BeNil())
 		})
+
+		It("Should index CDI worker pods by the UID of the PVC", func() {
+			pod := createImporterTestPod(pvc, "test-dv", nil)
+			pod.SetLabels(make(map[string]string))
+			pod.GetLabels()[common.PrometheusLabelKey] = common.PrometheusLabelValue
+			pod.GetLabels()[CloneUniqueID] = string(pvc.GetUID()) + common.ClonerSourcePodNameSuffix
+			keys := podOwnerUIDIndexKeys(pod)
+			Expect(keys).To(ContainElement(string(pvc.GetUID())))
+		})
+
+		It("Should not index pods without the CDI label", func() {
+			pod := createImporterTestPod(pvc, "test-dv", nil)
+			pod.SetLabels(make(map[string]string))
+			Expect(podOwnerUIDIndexKeys(pod)).To(BeEmpty())
+		})
 	})
 
 	var _ = Describe("Update Progress from pod", func() {
@@ -1902,6 +2254,7 @@ func createDatavolumeReconcilerWithoutConfig(objects ...runtime.Object) *Datavol
 	// Create a ReconcileMemcached object with the scheme and fake client.
 	r := &DatavolumeReconciler{
 		client:         cl,
+		uncachedClient: cl,
 		scheme:         s,
 		log:            dvLog,
 		recorder:       rec,
@@ -1917,6 +2270,216 @@ func createDatavolumeReconcilerWithoutConfig(objects ...runtime.Object) *Datavol
 	return r
 }
 
+var _ = Describe("recordTransferMetrics", func() {
+	It("Should record completed transfers labeled by source type and storage class", func() {
+		scName := "metrics-sc"
+		dv := newImportDataVolume("metrics-dv")
+		dv.Status.Phase = cdiv1.Succeeded
+		pvc := createPvcInStorageClass("metrics-pvc", metav1.NamespaceDefault, &scName, nil, nil, corev1.ClaimBound)
+		pvc.Status.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1G")}
+		recordTransferMetrics(dv, pvc)
+
+		metric := &dto.Metric{}
+		err := TransferResultsCounter.WithLabelValues(dv.Namespace, "http", scName, "succeeded").Write(metric)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(metric.Counter.GetValue()).To(Equal(float64(1)))
+
+		err = TransferBytesCounter.WithLabelValues(dv.Namespace, "http", scName).Write(metric)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(metric.Counter.GetValue()).To(Equal(float64(1000000000)))
+	})
+
+	It("Should count failed transfers under the failed result", func() {
+		scName := "metrics-sc"
+		dv := newImportDataVolume("metrics-dv-failed")
+		dv.Status.Phase = cdiv1.Failed
+		pvc := createPvcInStorageClass("metrics-pvc", metav1.NamespaceDefault, &scName, nil, nil, corev1.ClaimBound)
+		recordTransferMetrics(dv, pvc)
+
+		metric := &dto.Metric{}
+		err := TransferResultsCounter.WithLabelValues(dv.Namespace, "http", scName, "failed").Write(metric)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(metric.Counter.GetValue()).To(Equal(float64(1)))
+	})
+})
+
+var _ = Describe("DataVolume phase collector", func() {
+	It("Should count DataVolumes by namespace and phase", func() {
+		dv1 := newImportDataVolume("dv1")
+		dv1.Status.Phase = cdiv1.ImportInProgress
+		dv2 := newImportDataVolume("dv2")
+		dv2.Status.Phase = cdiv1.ImportInProgress
+		dv3 := newImportDataVolume("dv3")
+		dv3.Status.Phase = cdiv1.Failed
+
+		registry := prometheus.NewPedanticRegistry()
+		registry.MustRegister(NewDataVolumePhaseCollector(createClient(dv1, dv2, dv3)))
+		families, err := registry.Gather()
+		Expect(err).ToNot(HaveOccurred())
+
+		counts := map[string]float64{}
+		for _, family := range families {
+			if family.GetName() != "kubevirt_cdi_datavolumes" {
+				continue
+			}
+			for _, metric := range family.Metric {
+				var phase string
+				for _, label := range metric.Label {
+					if label.GetName() == "phase" {
+						phase = label.GetValue()
+					}
+				}
+				counts[phase] = metric.Gauge.GetValue()
+			}
+		}
+		Expect(counts).To(Equal(map[string]float64{
+			"ImportInProgress": 2,
+			"Failed":           1,
+		}))
+	})
+
+	It("Should emit an info series per DataVolume", func() {
+		scName := "info-sc"
+		dv := newImportDataVolume("info-dv")
+		dv.Status.Phase = cdiv1.ImportInProgress
+		dv.Spec.PVC = &corev1.PersistentVolumeClaimSpec{StorageClassName: &scName}
+		dv.Annotations = map[string]string{AnnPrePopulated: "info-pvc"}
+
+		registry := prometheus.NewPedanticRegistry()
+		registry.MustRegister(NewDataVolumePhaseCollector(createClient(dv)))
+		families, err := registry.Gather()
+		Expect(err).ToNot(HaveOccurred())
+
+		labels := map[string]string{}
+		for _, family := range families {
+			if family.GetName() != "kubevirt_cdi_datavolume_info" {
+				continue
+			}
+			Expect(family.Metric).To(HaveLen(1))
+			for _, label := range family.Metric[0].Label {
+				labels[label.GetName()] = label.GetValue()
+			}
+		}
+		Expect(labels).To(Equal(map[string]string{
+			"namespace":    metav1.NamespaceDefault,
+			"name":         "info-dv",
+			"source":       "http",
+			"storageclass": scName,
+			"phase":        "ImportInProgress",
+			"prepopulated": "true",
+		}))
+	})
+})
+
+var _ = Describe("updateProgressDetail", func() {
+	It("Should fill structured progress from the worker pod metrics", func() {
+		dv := newImportDataVolume("detail-dv")
+		dv.UID = "1111-2222"
+		startTime := metav1.Now()
+		pod := &corev1.Pod{Status: corev1.PodStatus{StartTime: &startTime}}
+		metrics := "import_progress{ownerUID=\"1111-2222\"} 13.45\n" +
+			"progress_bytes_done{ownerUID=\"1111-2222\"} 134500000\n" +
+			"progress_bytes_total{ownerUID=\"1111-2222\"} 1e+09\n" +
+			"progress_phase{ownerUID=\"1111-2222\",phase=\"Convert\"} 1\n"
+		updateProgressDetail(dv, pod, metrics)
+
+		detail := dv.Status.ProgressDetail
+		Expect(detail).ToNot(BeNil())
+		Expect(detail.BytesDone).To(Equal(int64(134500000)))
+		Expect(detail.BytesTotal).To(Equal(int64(1000000000)))
+		Expect(detail.Phase).To(Equal("Convert"))
+		Expect(detail.StartedAt).To(Equal(startTime))
+	})
+
+	It("Should leave the status untouched when the pod exposes no byte counters", func() {
+		dv := newImportDataVolume("detail-dv")
+		dv.UID = "1111-2222"
+		updateProgressDetail(dv, &corev1.Pod{}, "import_progress{ownerUID=\"1111-2222\"} 13.45\n")
+		Expect(dv.Status.ProgressDetail).To(BeNil())
+	})
+})
+
+var _ = Describe("updateCheckpointProgress", func() {
+	newMultiStageDv := func() *cdiv1.DataVolume {
+		dv := newImportDataVolume("multi-stage-dv")
+		dv.Spec.Checkpoints = []cdiv1.DataVolumeCheckpoint{
+			{Previous: "", Current: "snap1"},
+			{Previous: "snap1", Current: "snap2"},
+		}
+		return dv
+	}
+
+	newCheckpointPvc := func(checkpoint string) *corev1.PersistentVolumeClaim {
+		pvc := createPvc("multi-stage-dv", metav1.NamespaceDefault, nil, nil)
+		pvc.Annotations = map[string]string{AnnCurrentCheckpoint: checkpoint}
+		return pvc
+	}
+
+	It("Should record the current checkpoint and its index", func() {
+		dv := newMultiStageDv()
+		updateCheckpointProgress(dv, newCheckpointPvc("snap2"))
+		detail := dv.Status.ProgressDetail
+		Expect(detail).ToNot(BeNil())
+		Expect(detail.CurrentCheckpoint).To(Equal("snap2"))
+		Expect(detail.CheckpointIndex).To(Equal(int32(2)))
+	})
+
+	It("Should drop the byte counters of the previous delta when the stage changes", func() {
+		dv := newMultiStageDv()
+		dv.Status.ProgressDetail = &cdiv1.DataVolumeProgressDetail{
+			CurrentCheckpoint: "snap1",
+			BytesDone:         1000,
+			BytesTotal:        2000,
+		}
+		updateCheckpointProgress(dv, newCheckpointPvc("snap2"))
+		detail := dv.Status.ProgressDetail
+		Expect(detail.CurrentCheckpoint).To(Equal("snap2"))
+		Expect(detail.CheckpointIndex).To(Equal(int32(2)))
+		Expect(detail.BytesDone).To(BeZero())
+		Expect(detail.BytesTotal).To(BeZero())
+	})
+
+	It("Should keep the byte counters while the stage stays the same", func() {
+		dv := newMultiStageDv()
+		dv.Status.ProgressDetail = &cdiv1.DataVolumeProgressDetail{
+			CurrentCheckpoint: "snap1",
+			CheckpointIndex:   1,
+			BytesDone:         1000,
+			BytesTotal:        2000,
+		}
+		updateCheckpointProgress(dv, newCheckpointPvc("snap1"))
+		detail := dv.Status.ProgressDetail
+		Expect(detail.CheckpointIndex).To(Equal(int32(1)))
+		Expect(detail.BytesDone).To(Equal(int64(1000)))
+	})
+
+	It("Should do nothing without a current checkpoint annotation", func() {
+		dv := newMultiStageDv()
+		pvc := createPvc("multi-stage-dv", metav1.NamespaceDefault, nil, nil)
+		updateCheckpointProgress(dv, pvc)
+		Expect(dv.Status.ProgressDetail).To(BeNil())
+	})
+})
+
+var _ = Describe("Datavolume rate limiter", func() {
+	It("Should back off exponentially with bounded jitter and cap the delay", func() {
+		limiter := newDatavolumeRateLimiter()
+		item := "default/failing-dv"
+		var previous time.Duration
+		for i := 0; i < 20; i++ {
+			delay := limiter.When(item)
+			Expect(delay).To(BeNumerically("<=", dvBackoffCap+dvBackoffCap/4))
+			if i > 0 && previous < dvBackoffCap/2 {
+				Expect(delay).To(BeNumerically(">", previous))
+			}
+			previous = delay
+		}
+		By("Forgetting the item resets the backoff")
+		limiter.Forget(item)
+		Expect(limiter.When(item)).To(BeNumerically("<=", dvBackoffBase+dvBackoffBase/4))
+	})
+})
+
 func newImportDataVolumeWithPvc(name string, pvc *corev1.PersistentVolumeClaimSpec) *cdiv1.DataVolume {
 	return &cdiv1.DataVolume{
 		TypeMeta: metav1.TypeMeta{APIVersion: cdiv1.SchemeGroupVersion.String()},
@@ -1980,6 +2543,75 @@ func newS3ImportDataVolume(name string) *cdiv1.DataVolume {
 	}
 }
 
+func newGCSImportDataVolume(name string) *cdiv1.DataVolume {
+	return &cdiv1.DataVolume{
+		TypeMeta: metav1.TypeMeta{APIVersion: cdiv1.SchemeGroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: metav1.NamespaceDefault,
+			UID:       types.UID(metav1.NamespaceDefault + "-" + name),
+		},
+		Spec: cdiv1.DataVolumeSpec{
+			Source: &cdiv1.DataVolumeSource{
+				GCS: &cdiv1.DataVolumeSourceGCS{
+					URL:       "gs://bucket/disk.img",
+					SecretRef: "gcs-secret",
+				},
+			},
+			PVC: &corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			},
+		},
+	}
+}
+
+func newOVAImportDataVolume(name string) *cdiv1.DataVolume {
+	return &cdiv1.DataVolume{
+		TypeMeta: metav1.TypeMeta{APIVersion: cdiv1.SchemeGroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: metav1.NamespaceDefault,
+			UID:       types.UID(metav1.NamespaceDefault + "-" + name),
+		},
+		Spec: cdiv1.DataVolumeSpec{
+			Source: &cdiv1.DataVolumeSource{
+				OVA: &cdiv1.DataVolumeSourceOVA{
+					URL:           "https://example.com/appliance.ova",
+					SecretRef:     "ova-secret",
+					CertConfigMap: "ova-certs",
+				},
+			},
+			PVC: &corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			},
+		},
+	}
+}
+
+func newVolumeExportImportDataVolume(name string) *cdiv1.DataVolume {
+	return &cdiv1.DataVolume{
+		TypeMeta: metav1.TypeMeta{APIVersion: cdiv1.SchemeGroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: metav1.NamespaceDefault,
+			UID:       types.UID(metav1.NamespaceDefault + "-" + name),
+		},
+		Spec: cdiv1.DataVolumeSpec{
+			Source: &cdiv1.DataVolumeSource{
+				VolumeExport: &cdiv1.DataVolumeSourceVolumeExport{
+					URL:            "https://cdi-export-source.other.svc/volume",
+					TokenSecretRef: "export-token",
+					CertConfigMap:  "export-cert",
+					Checksum:       "sha256:1234",
+				},
+			},
+			PVC: &corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			},
+		},
+	}
+}
+
 func newCloneDataVolume(name string) *cdiv1.DataVolume {
 	return newCloneDataVolumeWithPVCNS(name, "default")
 }
@@ -0,0 +1,724 @@
+/*
+Copyright 2023 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	sdkapi "kubevirt.io/controller-lifecycle-operator-sdk/api"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/util"
+	"kubevirt.io/containerized-data-importer/pkg/util/cert/generator"
+	"kubevirt.io/containerized-data-importer/pkg/util/naming"
+)
+
+const (
+	volumeExportControllerName = "volumeexport-controller"
+
+	annCreatedByExport = "cdi.kubevirt.io/storage.createdByExportController"
+
+	defaultExportServerCertDuration = 365 * 24 * time.Hour
+
+	exportTokenLength = 32
+
+	// ExportReady is the reason for the event emitted when an export becomes downloadable
+	ExportReady = "ExportReady"
+
+	// ExportExpired is the reason for the event emitted when an export TTL ran out
+	ExportExpired = "ExportExpired"
+
+	// ErrInvalidExportSource is the reason for the event emitted when the export source is invalid
+	ErrInvalidExportSource = "ErrInvalidExportSource"
+)
+
+// VolumeExportReconciler members
+type VolumeExportReconciler struct {
+	client              client.Client
+	recorder            record.EventRecorder
+	scheme              *runtime.Scheme
+	log                 logr.Logger
+	image               string
+	verbose             string
+	pullPolicy          string
+	serverCertGenerator generator.CertGenerator
+	installerLabels     map[string]string
+	shard               ShardConfig
+}
+
+// NewVolumeExportController creates a new instance of the VolumeExport controller
+func NewVolumeExportController(mgr manager.Manager, log logr.Logger, exportImage, pullPolicy, verbose string, serverCertGenerator generator.CertGenerator, installerLabels map[string]string, shard ShardConfig) (controller.Controller, error) {
+	reconciler := &VolumeExportReconciler{
+		client:              mgr.GetClient(),
+		recorder:            mgr.GetEventRecorderFor(volumeExportControllerName),
+		scheme:              mgr.GetScheme(),
+		log:                 log.WithName(volumeExportControllerName),
+		image:               exportImage,
+		verbose:             verbose,
+		pullPolicy:          pullPolicy,
+		serverCertGenerator: serverCertGenerator,
+		installerLabels:     installerLabels,
+		shard:               shard,
+	}
+	volumeExportController, err := controller.New(volumeExportControllerName, mgr, controller.Options{
+		Reconciler: NewInstrumentedReconciler(volumeExportControllerName, reconciler),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := addVolumeExportControllerWatches(mgr, volumeExportController); err != nil {
+		return nil, err
+	}
+	log.Info("Initialized VolumeExport controller")
+	return volumeExportController, nil
+}
+
+func addVolumeExportControllerWatches(mgr manager.Manager, c controller.Controller) error {
+	if err := cdiv1.AddToScheme(mgr.GetScheme()); err != nil {
+		return err
+	}
+	if err := c.Watch(&source.Kind{Type: &cdiv1.VolumeExport{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+	for _, t := range []client.Object{&corev1.Pod{}, &corev1.Service{}, &corev1.Secret{}, &corev1.PersistentVolumeClaim{}} {
+		if err := c.Watch(&source.Kind{Type: t}, &handler.EnqueueRequestForOwner{
+			OwnerType:    &cdiv1.VolumeExport{},
+			IsController: true,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reconcile loop for VolumeExportReconciler, keeps the export server workload
+// in sync with the VolumeExport and tears it down when the TTL runs out
+func (r *VolumeExportReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	if !r.shard.OwnsNamespace(req.Namespace) {
+		return reconcile.Result{}, nil
+	}
+
+	export := &cdiv1.VolumeExport{}
+	if err := r.client.Get(ctx, req.NamespacedName, export); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	log := r.log.WithValues("VolumeExport", req.NamespacedName)
+
+	if export.DeletionTimestamp != nil {
+		// owned resources are garbage collected with the export
+		return reconcile.Result{}, nil
+	}
+
+	if exportIsExpired(export) {
+		return reconcile.Result{}, r.expireExport(ctx, export, log)
+	}
+
+	pvcName, err := r.getSourcePvcName(ctx, export)
+	if err != nil {
+		log.V(1).Info("Cannot resolve export source", "error", err.Error())
+		r.recorder.Event(export, corev1.EventTypeWarning, ErrInvalidExportSource, err.Error())
+		return reconcile.Result{}, nil
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: pvcName, Namespace: export.Namespace}, pvc); err != nil {
+		if k8serrors.IsNotFound(err) {
+			// source PVC not there yet, pod creation waits for it
+			log.V(1).Info("Source PVC not found", "pvc", pvcName)
+			return reconcile.Result{}, r.updateStatus(ctx, export, nil, "")
+		}
+		return reconcile.Result{}, err
+	}
+
+	tokenSecretName, err := r.ensureTokenSecret(ctx, export)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	pod, err := r.ensureExportPod(ctx, export, pvc, tokenSecretName)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if _, err := r.ensureExportService(ctx, export); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err := r.updateStatus(ctx, export, pod, tokenSecretName); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// wake up to tear the export down once the TTL runs out
+	if export.Status.ExpiresAt != nil {
+		return reconcile.Result{RequeueAfter: time.Until(export.Status.ExpiresAt.Time)}, nil
+	}
+	return reconcile.Result{}, nil
+}
+
+func exportIsExpired(export *cdiv1.VolumeExport) bool {
+	return export.Status.ExpiresAt != nil && time.Now().After(export.Status.ExpiresAt.Time)
+}
+
+// expireExport deletes the export server workload and moves the export to the
+// Expired phase, the token secret is kept so the phase transition is auditable
+func (r *VolumeExportReconciler) expireExport(ctx context.Context, export *cdiv1.VolumeExport, log logr.Logger) error {
+	resourceName := exportResourceName(export)
+
+	pod := &corev1.Pod{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: resourceName, Namespace: export.Namespace}, pod); err == nil {
+		if pod.DeletionTimestamp == nil {
+			if err := r.client.Delete(ctx, pod); IgnoreNotFound(err) != nil {
+				return err
+			}
+		}
+	} else if !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	service := &corev1.Service{}
+	svcName := naming.GetServiceNameFromResourceName(resourceName)
+	if err := r.client.Get(ctx, types.NamespacedName{Name: svcName, Namespace: export.Namespace}, service); err == nil {
+		if service.DeletionTimestamp == nil {
+			if err := r.client.Delete(ctx, service); IgnoreNotFound(err) != nil {
+				return err
+			}
+		}
+	} else if !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	if export.Status.Phase != cdiv1.VolumeExportExpired {
+		exportCopy := export.DeepCopy()
+		exportCopy.Status.Phase = cdiv1.VolumeExportExpired
+		exportCopy.Status.URL = ""
+		if err := r.client.Status().Update(ctx, exportCopy); err != nil {
+			return err
+		}
+		log.V(1).Info("Export expired")
+		r.recorder.Event(export, corev1.EventTypeNormal, ExportExpired, "Export TTL ran out, the volume is no longer served")
+	}
+	return nil
+}
+
+func (r *VolumeExportReconciler) updateStatus(ctx context.Context, export *cdiv1.VolumeExport, pod *corev1.Pod, tokenSecretName string) error {
+	exportCopy := export.DeepCopy()
+	exportCopy.Status.Phase = cdiv1.VolumeExportPending
+	exportCopy.Status.TokenSecretRef = tokenSecretName
+
+	if pod != nil && pod.Status.Phase == corev1.PodRunning && isPodReady(pod) {
+		svcName := naming.GetServiceNameFromResourceName(exportResourceName(export))
+		exportCopy.Status.Phase = cdiv1.VolumeExportReady
+		exportCopy.Status.URL = fmt.Sprintf("https://%s.%s.svc%s", svcName, export.Namespace, common.ExportServerVolumePath)
+		cert, err := r.getServerCert(ctx, export)
+		if err != nil {
+			return err
+		}
+		exportCopy.Status.Cert = cert
+		if export.Spec.TTLDuration != nil && exportCopy.Status.ExpiresAt == nil {
+			expiresAt := metav1.NewTime(time.Now().Add(export.Spec.TTLDuration.Duration))
+			exportCopy.Status.ExpiresAt = &expiresAt
+		}
+	}
+
+	if !reflect.DeepEqual(export.Status, exportCopy.Status) {
+		if err := r.client.Status().Update(ctx, exportCopy); err != nil {
+			return err
+		}
+		if exportCopy.Status.Phase == cdiv1.VolumeExportReady && export.Status.Phase != cdiv1.VolumeExportReady {
+			r.recorder.Eventf(export, corev1.EventTypeNormal, ExportReady, "Volume is available for download at %s", exportCopy.Status.URL)
+		}
+		export.Status = exportCopy.Status
+	}
+	return nil
+}
+
+// getServerCert reads back the serving certificate so it can be published in
+// the export status, consumers in other clusters pin it to authenticate the
+// server before presenting the token
+func (r *VolumeExportReconciler) getServerCert(ctx context.Context, export *cdiv1.VolumeExport) (string, error) {
+	secret := &corev1.Secret{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: exportResourceName(export), Namespace: export.Namespace}, secret); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return string(secret.Data["tls.crt"]), nil
+}
+
+// getSourcePvcName resolves the export source to the name of the PVC the
+// export server pod mounts, restoring snapshot sources into a PVC first
+func (r *VolumeExportReconciler) getSourcePvcName(ctx context.Context, export *cdiv1.VolumeExport) (string, error) {
+	source := export.Spec.Source
+	if (source.PVC == "") == (source.Snapshot == "") {
+		return "", errors.New("exactly one of pvc and snapshot must be set as the export source")
+	}
+	if source.PVC != "" {
+		return source.PVC, nil
+	}
+	return r.ensureSnapshotRestorePvc(ctx, export)
+}
+
+// ensureSnapshotRestorePvc creates a PVC restoring the source snapshot, owned
+// by the export so it is garbage collected with it
+func (r *VolumeExportReconciler) ensureSnapshotRestorePvc(ctx context.Context, export *cdiv1.VolumeExport) (string, error) {
+	name := naming.GetResourceName(exportResourceName(export), "source")
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: export.Namespace}, pvc); err == nil {
+		if !metav1.IsControlledBy(pvc, export) {
+			return "", errors.Errorf("%s restore PVC not controlled by export %s", name, export.Name)
+		}
+		return name, nil
+	} else if !k8serrors.IsNotFound(err) {
+		return "", err
+	}
+
+	snapshot := &snapshotv1.VolumeSnapshot{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: export.Spec.Source.Snapshot, Namespace: export.Namespace}, snapshot); err != nil {
+		return "", errors.Wrap(err, "error getting source snapshot")
+	}
+	if snapshot.Status == nil || snapshot.Status.RestoreSize == nil {
+		return "", errors.Errorf("snapshot %s has no RestoreSize yet", snapshot.Name)
+	}
+
+	pvc = &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: export.Namespace,
+			Annotations: map[string]string{
+				annCreatedByExport: "yes",
+			},
+			Labels: map[string]string{
+				common.CDILabelKey:       common.CDILabelValue,
+				common.CDIComponentLabel: common.ExportServerCDILabel,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				MakeVolumeExportOwnerReference(export),
+			},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			DataSource: &corev1.TypedLocalObjectReference{
+				Name:     snapshot.Name,
+				Kind:     "VolumeSnapshot",
+				APIGroup: &snapshotv1.SchemeGroupVersion.Group,
+			},
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: *snapshot.Status.RestoreSize,
+				},
+			},
+		},
+	}
+	util.SetRecommendedLabels(pvc, r.installerLabels, "cdi-controller")
+
+	if err := r.client.Create(ctx, pvc); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return "", errors.Wrap(err, "error creating snapshot restore PVC")
+	}
+	return name, nil
+}
+
+// ensureTokenSecret creates the secret holding the download bearer token,
+// users read the token from the secret named in the export status
+func (r *VolumeExportReconciler) ensureTokenSecret(ctx context.Context, export *cdiv1.VolumeExport) (string, error) {
+	name := naming.GetResourceName(exportResourceName(export), "token")
+
+	secret := &corev1.Secret{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: export.Namespace}, secret); err == nil {
+		if !metav1.IsControlledBy(secret, export) {
+			return "", errors.Errorf("%s token secret not controlled by export %s", name, export.Name)
+		}
+		return name, nil
+	} else if !k8serrors.IsNotFound(err) {
+		return "", err
+	}
+
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: export.Namespace,
+			Annotations: map[string]string{
+				annCreatedByExport: "yes",
+			},
+			Labels: map[string]string{
+				common.CDILabelKey:       common.CDILabelValue,
+				common.CDIComponentLabel: common.ExportServerCDILabel,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				MakeVolumeExportOwnerReference(export),
+			},
+		},
+		Data: map[string][]byte{
+			common.ExportServerTokenKey: []byte(util.RandAlphaNum(exportTokenLength)),
+		},
+	}
+	util.SetRecommendedLabels(secret, r.installerLabels, "cdi-controller")
+
+	if err := r.client.Create(ctx, secret); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return "", errors.Wrap(err, "error creating export token secret")
+	}
+	return name, nil
+}
+
+func (r *VolumeExportReconciler) ensureExportPod(ctx context.Context, export *cdiv1.VolumeExport, pvc *corev1.PersistentVolumeClaim, tokenSecretName string) (*corev1.Pod, error) {
+	podName := exportResourceName(export)
+
+	pod := &corev1.Pod{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: podName, Namespace: export.Namespace}, pod); err == nil {
+		if !metav1.IsControlledBy(pod, export) {
+			return nil, errors.Errorf("%s pod not controlled by export %s", podName, export.Name)
+		}
+		return pod, nil
+	} else if !k8serrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	if err := r.ensureCertSecret(ctx, export, podName); err != nil {
+		return nil, err
+	}
+
+	pod, err := r.createExportPod(ctx, export, pvc, podName, tokenSecretName)
+	if err != nil {
+		return nil, err
+	}
+	return pod, nil
+}
+
+// ensureCertSecret creates the secret with the export server tls key/cert,
+// named after the pod that mounts it
+func (r *VolumeExportReconciler) ensureCertSecret(ctx context.Context, export *cdiv1.VolumeExport, podName string) error {
+	certDuration, err := r.getExportServerCertDuration()
+	if err != nil {
+		return err
+	}
+
+	serverCert, serverKey, err := r.serverCertGenerator.MakeServerCert(export.Namespace, naming.GetServiceNameFromResourceName(podName), certDuration)
+	if err != nil {
+		return err
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      podName,
+			Namespace: export.Namespace,
+			Annotations: map[string]string{
+				annCreatedByExport: "yes",
+			},
+			Labels: map[string]string{
+				common.CDILabelKey:       common.CDILabelValue,
+				common.CDIComponentLabel: common.ExportServerCDILabel,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				MakeVolumeExportOwnerReference(export),
+			},
+		},
+		Data: map[string][]byte{
+			"tls.key": serverKey,
+			"tls.crt": serverCert,
+		},
+	}
+	util.SetRecommendedLabels(secret, r.installerLabels, "cdi-controller")
+
+	if err := r.client.Create(ctx, secret); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return errors.Wrap(err, "error creating cert secret")
+	}
+	return nil
+}
+
+func (r *VolumeExportReconciler) getExportServerCertDuration() (time.Duration, error) {
+	cr, err := GetActiveCDI(r.client)
+	if err != nil {
+		return 0, err
+	}
+
+	if cr == nil || cr.Spec.CertConfig == nil || cr.Spec.CertConfig.Server == nil || cr.Spec.CertConfig.Server.Duration == nil {
+		return defaultExportServerCertDuration, nil
+	}
+
+	return cr.Spec.CertConfig.Server.Duration.Duration, nil
+}
+
+func (r *VolumeExportReconciler) ensureExportService(ctx context.Context, export *cdiv1.VolumeExport) (*corev1.Service, error) {
+	name := naming.GetServiceNameFromResourceName(exportResourceName(export))
+
+	service := &corev1.Service{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: export.Namespace}, service); err == nil {
+		if !metav1.IsControlledBy(service, export) {
+			return nil, errors.Errorf("%s service not controlled by export %s", name, export.Name)
+		}
+		return service, nil
+	} else if !k8serrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	service = r.makeExportServiceSpec(export, name)
+	util.SetRecommendedLabels(service, r.installerLabels, "cdi-controller")
+
+	if err := r.client.Create(ctx, service); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return nil, errors.Wrap(err, "export service API create errored")
+	}
+	r.log.V(1).Info("export service created", "Namespace", service.Namespace, "Name", service.Name)
+	return service, nil
+}
+
+func (r *VolumeExportReconciler) makeExportServiceSpec(export *cdiv1.VolumeExport, name string) *corev1.Service {
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Service",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: export.Namespace,
+			Annotations: map[string]string{
+				annCreatedByExport: "yes",
+			},
+			Labels: map[string]string{
+				common.CDILabelKey:       common.CDILabelValue,
+				common.CDIComponentLabel: common.ExportServerCDILabel,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				MakeVolumeExportOwnerReference(export),
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Protocol: "TCP",
+					Port:     443,
+					TargetPort: intstr.IntOrString{
+						Type:   intstr.Int,
+						IntVal: 8443,
+					},
+				},
+			},
+			Selector: map[string]string{
+				common.ExportServerServiceLabel: name,
+			},
+		},
+	}
+}
+
+// createExportPod creates the export server pod manifest and sends it to the server
+func (r *VolumeExportReconciler) createExportPod(ctx context.Context, export *cdiv1.VolumeExport, pvc *corev1.PersistentVolumeClaim, podName, tokenSecretName string) (*corev1.Pod, error) {
+	podResourceRequirements, err := GetWorkloadPodResourceRequirements(r.client, WorkloadTypeUploadServer)
+	if err != nil {
+		return nil, err
+	}
+
+	workloadNodePlacement, err := GetWorkloadNodePlacement(r.client)
+	if err != nil {
+		return nil, err
+	}
+
+	image, pullPolicy, err := GetWorkerImageAndPullPolicy(r.client, r.image, r.pullPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	pod := r.makeExportPodSpec(export, pvc, podName, tokenSecretName, image, pullPolicy, podResourceRequirements, workloadNodePlacement)
+	util.SetRecommendedLabels(pod, r.installerLabels, "cdi-controller")
+	if err := ApplyWorkloadMetadata(r.client, pod); err != nil {
+		return nil, err
+	}
+	if err := ApplySecurityProfile(r.client, pod); err != nil {
+		return nil, err
+	}
+	if err := ApplyArchitectureAffinity(r.client, pod); err != nil {
+		return nil, err
+	}
+
+	if err := ensureWorkerServiceAccount(r.client, pod.Namespace, common.ExportServerServiceAccountName); err != nil {
+		return nil, err
+	}
+
+	if err := r.client.Create(ctx, pod); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return nil, err
+	}
+
+	r.log.V(1).Info("export pod created", "Namespace", pod.Namespace, "Name", pod.Name, "Image name", r.image)
+	return pod, nil
+}
+
+func (r *VolumeExportReconciler) makeExportPodSpec(export *cdiv1.VolumeExport, pvc *corev1.PersistentVolumeClaim, podName, tokenSecretName, image, pullPolicy string, resourceRequirements *corev1.ResourceRequirements, workloadNodePlacement *sdkapi.NodePlacement) *corev1.Pod {
+	serviceName := naming.GetServiceNameFromResourceName(podName)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      podName,
+			Namespace: export.Namespace,
+			Annotations: map[string]string{
+				annCreatedByExport: "yes",
+			},
+			Labels: map[string]string{
+				common.CDILabelKey:              common.CDILabelValue,
+				common.CDIComponentLabel:        common.ExportServerCDILabel,
+				common.ExportServerServiceLabel: serviceName,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				MakeVolumeExportOwnerReference(export),
+			},
+		},
+		Spec: corev1.PodSpec{
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsUser: &[]int64{0}[0],
+			},
+			Containers: []corev1.Container{
+				{
+					Name:            common.ExportServerPodname,
+					Image:           image,
+					ImagePullPolicy: corev1.PullPolicy(pullPolicy),
+					Env: []corev1.EnvVar{
+						{
+							Name:  "TLS_CERT_DIR",
+							Value: common.ExportServerCertDir,
+						},
+						{
+							Name:  common.ExportFormatVar,
+							Value: string(export.Spec.Format),
+						},
+						{
+							// the token never appears in the pod spec
+							Name: common.ExportTokenVar,
+							ValueFrom: &corev1.EnvVarSource{
+								SecretKeyRef: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: tokenSecretName,
+									},
+									Key: common.ExportServerTokenKey,
+								},
+							},
+						},
+					},
+					Args: []string{"-v=" + getLogVerbosity(r.client, r.verbose)},
+					ReadinessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							HTTPGet: &corev1.HTTPGetAction{
+								Path: "/healthz",
+								Port: intstr.IntOrString{
+									Type:   intstr.Int,
+									IntVal: 8080,
+								},
+							},
+						},
+						InitialDelaySeconds: 2,
+						PeriodSeconds:       5,
+					},
+				},
+			},
+			RestartPolicy:      corev1.RestartPolicyOnFailure,
+			ServiceAccountName: common.ExportServerServiceAccountName,
+			Volumes: []corev1.Volume{
+				{
+					Name: DataVolName,
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: pvc.Name,
+							ReadOnly:  true,
+						},
+					},
+				},
+				{
+					Name: ServerCertVolName,
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName: podName,
+						},
+					},
+				},
+			},
+			NodeSelector: workloadNodePlacement.NodeSelector,
+			Tolerations:  workloadNodePlacement.Tolerations,
+			Affinity:     workloadNodePlacement.Affinity,
+		},
+	}
+
+	if resourceRequirements != nil {
+		pod.Spec.Containers[0].Resources = *resourceRequirements
+	}
+
+	if getVolumeMode(pvc) == corev1.PersistentVolumeBlock {
+		pod.Spec.Containers[0].VolumeDevices = []corev1.VolumeDevice{
+			{
+				Name:       DataVolName,
+				DevicePath: common.WriteBlockPath,
+			},
+		}
+		pod.Spec.Containers[0].Env = append(pod.Spec.Containers[0].Env, corev1.EnvVar{
+			Name:  common.ExportSourceVar,
+			Value: common.WriteBlockPath,
+		})
+	} else {
+		pod.Spec.Containers[0].VolumeMounts = []corev1.VolumeMount{
+			{
+				Name:      DataVolName,
+				MountPath: common.ImporterDataDir,
+				ReadOnly:  true,
+			},
+		}
+	}
+
+	pod.Spec.Containers[0].VolumeMounts = append(pod.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      ServerCertVolName,
+		MountPath: common.ExportServerCertDir,
+	})
+
+	return pod
+}
+
+// exportResourceName returns the name given to export server resources
+func exportResourceName(export *cdiv1.VolumeExport) string {
+	return naming.GetResourceName(common.ExportPodName, export.Name)
+}
+
+// MakeVolumeExportOwnerReference makes owner reference from a VolumeExport
+func MakeVolumeExportOwnerReference(export *cdiv1.VolumeExport) metav1.OwnerReference {
+	blockOwnerDeletion := true
+	isController := true
+	return metav1.OwnerReference{
+		APIVersion:         cdiv1.SchemeGroupVersion.String(),
+		Kind:               "VolumeExport",
+		Name:               export.Name,
+		UID:                export.GetUID(),
+		BlockOwnerDeletion: &blockOwnerDeletion,
+		Controller:         &isController,
+	}
+}
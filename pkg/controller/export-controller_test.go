@@ -0,0 +1,207 @@
+/*
+Copyright 2023 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+var (
+	exportLog = logf.Log.WithName("export-controller-test")
+)
+
+var _ = Describe("Volume export controller reconcile loop", func() {
+
+	reconcileExport := func(r *VolumeExportReconciler, name string) {
+		_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: "default"}})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	getExport := func(r *VolumeExportReconciler, name string) *cdiv1.VolumeExport {
+		export := &cdiv1.VolumeExport{}
+		err := r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: "default"}, export)
+		Expect(err).ToNot(HaveOccurred())
+		return export
+	}
+
+	It("Should return nil if the export can not be found", func() {
+		reconciler := createVolumeExportReconciler()
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "testExport", Namespace: "default"}})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("Should emit an event and create nothing on an invalid source", func() {
+		export := createVolumeExport("testExport", cdiv1.VolumeExportSource{})
+		reconciler := createVolumeExportReconciler(export)
+		reconcileExport(reconciler, "testExport")
+
+		pod := &corev1.Pod{}
+		err := reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "cdi-export-testExport", Namespace: "default"}, pod)
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+
+		event := <-reconciler.recorder.(*record.FakeRecorder).Events
+		Expect(event).To(ContainSubstring(ErrInvalidExportSource))
+	})
+
+	It("Should create token secret, pod and service for a PVC source", func() {
+		export := createVolumeExport("testExport", cdiv1.VolumeExportSource{PVC: "testPvc1"})
+		reconciler := createVolumeExportReconciler(export, createPvc("testPvc1", "default", nil, nil))
+		reconcileExport(reconciler, "testExport")
+
+		tokenSecret := &corev1.Secret{}
+		err := reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "cdi-export-testExport-token", Namespace: "default"}, tokenSecret)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tokenSecret.Data[common.ExportServerTokenKey]).ToNot(BeEmpty())
+
+		certSecret := &corev1.Secret{}
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "cdi-export-testExport", Namespace: "default"}, certSecret)
+		Expect(err).ToNot(HaveOccurred())
+
+		pod := &corev1.Pod{}
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "cdi-export-testExport", Namespace: "default"}, pod)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pod.Labels[common.ExportServerServiceLabel]).To(Equal("cdi-export-testExport"))
+		Expect(metav1.IsControlledBy(pod, export)).To(BeTrue())
+
+		service := &corev1.Service{}
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "cdi-export-testExport", Namespace: "default"}, service)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(metav1.IsControlledBy(service, export)).To(BeTrue())
+
+		updated := getExport(reconciler, "testExport")
+		Expect(updated.Status.Phase).To(Equal(cdiv1.VolumeExportPending))
+		Expect(updated.Status.TokenSecretRef).To(Equal("cdi-export-testExport-token"))
+	})
+
+	It("Should become ready with URL, cert and expiration once the pod is running", func() {
+		export := createVolumeExport("testExport", cdiv1.VolumeExportSource{PVC: "testPvc1"})
+		export.Spec.TTLDuration = &metav1.Duration{Duration: time.Hour}
+		pod := createExportServerPod(export)
+		certSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cdi-export-testExport",
+				Namespace: "default",
+			},
+			Data: map[string][]byte{
+				"tls.crt": []byte("server cert"),
+			},
+		}
+		reconciler := createVolumeExportReconciler(export, createPvc("testPvc1", "default", nil, nil), pod, certSecret)
+		reconcileExport(reconciler, "testExport")
+
+		updated := getExport(reconciler, "testExport")
+		Expect(updated.Status.Phase).To(Equal(cdiv1.VolumeExportReady))
+		Expect(updated.Status.URL).To(Equal("https://cdi-export-testExport.default.svc/volume"))
+		Expect(updated.Status.Cert).To(Equal("server cert"))
+		Expect(updated.Status.ExpiresAt).ToNot(BeNil())
+		Expect(updated.Status.ExpiresAt.Time).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+	})
+
+	It("Should tear down the workload and expire the export after the TTL", func() {
+		export := createVolumeExport("testExport", cdiv1.VolumeExportSource{PVC: "testPvc1"})
+		expiresAt := metav1.NewTime(time.Now().Add(-time.Minute))
+		export.Status.ExpiresAt = &expiresAt
+		pod := createExportServerPod(export)
+		reconciler := createVolumeExportReconciler(export, createPvc("testPvc1", "default", nil, nil), pod)
+		reconcileExport(reconciler, "testExport")
+
+		err := reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "cdi-export-testExport", Namespace: "default"}, &corev1.Pod{})
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+
+		updated := getExport(reconciler, "testExport")
+		Expect(updated.Status.Phase).To(Equal(cdiv1.VolumeExportExpired))
+		Expect(updated.Status.URL).To(BeEmpty())
+	})
+})
+
+func createVolumeExport(name string, source cdiv1.VolumeExportSource) *cdiv1.VolumeExport {
+	return &cdiv1.VolumeExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			UID:       types.UID("export-uid"),
+		},
+		Spec: cdiv1.VolumeExportSpec{
+			Source: source,
+		},
+	}
+}
+
+func createExportServerPod(export *cdiv1.VolumeExport) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      exportResourceName(export),
+			Namespace: export.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				MakeVolumeExportOwnerReference(export),
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Ready: true},
+			},
+		},
+	}
+}
+
+func createVolumeExportReconciler(objects ...runtime.Object) *VolumeExportReconciler {
+	objs := []runtime.Object{MakeEmptyCDICR()}
+	objs = append(objs, objects...)
+	cdiConfig := MakeEmptyCDIConfigSpec(common.ConfigName)
+	cdiConfig.Status = cdiv1.CDIConfigStatus{
+		DefaultPodResourceRequirements: createDefaultPodResourceRequirements("", "", "", ""),
+	}
+	objs = append(objs, cdiConfig)
+
+	s := scheme.Scheme
+	cdiv1.AddToScheme(s)
+
+	cl := fake.NewFakeClientWithScheme(s, objs...)
+
+	rec := record.NewFakeRecorder(10)
+
+	return &VolumeExportReconciler{
+		client:              cl,
+		scheme:              s,
+		log:                 exportLog,
+		serverCertGenerator: &fakeCertGenerator{},
+		recorder:            rec,
+		installerLabels: map[string]string{
+			common.AppKubernetesPartOfLabel:  "testing",
+			common.AppKubernetesVersionLabel: "v0.0.0-tests",
+		},
+	}
+}
@@ -3,6 +3,7 @@ package controller
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 	"path"
 	"reflect"
@@ -41,6 +42,10 @@ const (
 	SourceHTTP = "http"
 	// SourceS3 is the source type S3
 	SourceS3 = "s3"
+	// SourceGCS is the source type Google Cloud Storage
+	SourceGCS = "gcs"
+	// SourceOVA is the source type OVA archive
+	SourceOVA = "ova"
 	// SourceGlance is the source type of glance
 	SourceGlance = "glance"
 	// SourceNone means there is no source.
@@ -58,8 +63,14 @@ const (
 	AnnEndpoint = AnnAPIGroup + "/storage.import.endpoint"
 	// AnnSecret provides a const for our PVC secretName annotation
 	AnnSecret = AnnAPIGroup + "/storage.import.secretName"
+	// AnnSecretProviderClass is the name of a secrets-store CSI SecretProviderClass delivering
+	// the endpoint credentials from an external secret store
+	AnnSecretProviderClass = AnnAPIGroup + "/storage.import.secretProviderClass"
 	// AnnCertConfigMap is the name of a configmap containing tls certs
 	AnnCertConfigMap = AnnAPIGroup + "/storage.import.certConfigMap"
+
+	// AnnCertSecret is the name of a secret containing tls certs in PEM format
+	AnnCertSecret = AnnAPIGroup + "/storage.import.certSecret"
 	// AnnContentType provides a const for the PVC content-type
 	AnnContentType = AnnAPIGroup + "/storage.contentType"
 	// AnnRegistryImportMethod provides a const for registry import method annotation
@@ -68,6 +79,12 @@ const (
 	AnnRegistryImageStream = AnnAPIGroup + "/storage.import.registryImageStream"
 	// AnnImportPod provides a const for our PVC importPodName annotation
 	AnnImportPod = AnnAPIGroup + "/storage.import.importPodName"
+	// AnnImportPaused is set by the transfer controller to keep the importer
+	// pod down while the PVC moves to another namespace
+	AnnImportPaused = AnnAPIGroup + "/storage.import.paused"
+	// AnnPreempted marks an import paused because a higher priority transfer took
+	// its slot, the datavolume controller resumes it once capacity frees up
+	AnnPreempted = AnnAPIGroup + "/storage.import.preempted"
 	// AnnRequiresScratch provides a const for our PVC requires scratch annotation
 	AnnRequiresScratch = AnnAPIGroup + "/storage.import.requiresScratch"
 	// AnnDiskID provides a const for our PVC diskId annotation
@@ -84,6 +101,20 @@ const (
 	AnnExtraHeaders = AnnAPIGroup + "/storage.import.extraHeaders"
 	// AnnSecretExtraHeaders provides a const for our PVC secretExtraHeaders annotation
 	AnnSecretExtraHeaders = AnnAPIGroup + "/storage.import.secretExtraHeaders"
+	// AnnSourceETag provides a const for our PVC sourceETag annotation, recording the
+	// ETag (or Last-Modified) the endpoint reported when the import succeeded
+	AnnSourceETag = AnnAPIGroup + "/storage.import.sourceETag"
+	// AnnExportTokenSecret names a secret holding the token of a VolumeExport the import pulls from
+	AnnExportTokenSecret = AnnAPIGroup + "/storage.import.exportTokenSecret"
+	// AnnChecksum provides a const for our PVC expected checksum annotation, the import fails on a mismatch
+	AnnChecksum = AnnAPIGroup + "/storage.import.checksum"
+
+	// AnnBlankFilesystem is the filesystem to create inside a blank image
+	AnnBlankFilesystem = AnnAPIGroup + "/storage.import.blankFilesystem"
+	// AnnBlankFilesystemLabel is the label of the filesystem created inside a blank image
+	AnnBlankFilesystemLabel = AnnAPIGroup + "/storage.import.blankFilesystemLabel"
+	// AnnBlankReservedBlocks is the reserved blocks percentage of the filesystem created inside a blank image
+	AnnBlankReservedBlocks = AnnAPIGroup + "/storage.import.blankReservedBlocks"
 
 	//LabelImportPvc is a pod label used to find the import pod that was created by the relevant PVC
 	LabelImportPvc = AnnAPIGroup + "/storage.import.importPvcName"
@@ -100,10 +131,18 @@ const (
 
 	// creatingScratch provides a const to indicate scratch is being created.
 	creatingScratch = "CreatingScratchSpace"
+	// ScratchSpaceRequested is reason for event created when a scratch PVC is requested for a transfer
+	ScratchSpaceRequested = "ScratchSpaceRequested"
+	// ScratchSpaceBound is reason for event created when the requested scratch PVC becomes bound
+	ScratchSpaceBound = "ScratchSpaceBound"
 
 	// ImportTargetInUse is reason for event created when an import pvc is in use
 	ImportTargetInUse = "ImportTargetInUse"
 
+	// SkippedUnchangedSource is reason for event created when an import is skipped
+	// because the source did not change since the previous import
+	SkippedUnchangedSource = "SkippedUnchangedSource"
+
 	// importPodImageStreamFinalizer ensures image stream import pod is deleted when pvc is deleted,
 	// as in this case pod has no pvc OwnerReference
 	importPodImageStreamFinalizer = "cdi.kubevirt.io/importImageStream"
@@ -122,33 +161,47 @@ type ImportReconciler struct {
 	filesystemOverhead string
 	featureGates       featuregates.FeatureGates
 	installerLabels    map[string]string
+	shard              ShardConfig
 }
 
 type importPodEnvVar struct {
-	ep                 string
-	secretName         string
-	source             string
-	contentType        string
-	imageSize          string
-	certConfigMap      string
-	diskID             string
-	uuid               string
-	readyFile          string
-	doneFile           string
-	backingFile        string
-	thumbprint         string
-	filesystemOverhead string
-	insecureTLS        bool
-	currentCheckpoint  string
-	previousCheckpoint string
-	finalCheckpoint    string
-	preallocation      bool
-	httpProxy          string
-	httpsProxy         string
-	noProxy            string
-	certConfigMapProxy string
-	extraHeaders       []string
-	secretExtraHeaders []string
+	ep                  string
+	secretName          string
+	secretProviderClass string
+	authFileSecret      bool
+	source              string
+	contentType         string
+	imageSize           string
+	certConfigMap       string
+	certSecret          string
+	diskID              string
+	uuid                string
+	readyFile           string
+	doneFile            string
+	backingFile         string
+	thumbprint          string
+	filesystemOverhead  string
+	cacheMode           string
+	layerCacheHostPath  string
+	insecureTLS         bool
+	currentCheckpoint   string
+	previousCheckpoint  string
+	finalCheckpoint     string
+	preallocation       bool
+	httpProxy           string
+	httpsProxy          string
+	noProxy             string
+	certConfigMapProxy  string
+	proxySecret         string
+	tlsMinVersion       string
+	tlsCiphers          string
+	extraHeaders        []string
+	secretExtraHeaders  []string
+	exportTokenSecret   string
+	checksum            string
+	blankFilesystem     string
+	blankFsLabel        string
+	blankReservedBlocks string
 }
 
 type importerPodArgs struct {
@@ -163,10 +216,11 @@ type importerPodArgs struct {
 	workloadNodePlacement   *sdkapi.NodePlacement
 	vddkImageName           *string
 	priorityClassName       string
+	rootless                bool
 }
 
 // NewImportController creates a new instance of the import controller.
-func NewImportController(mgr manager.Manager, log logr.Logger, importerImage, pullPolicy, verbose string, installerLabels map[string]string) (controller.Controller, error) {
+func NewImportController(mgr manager.Manager, log logr.Logger, importerImage, pullPolicy, verbose string, installerLabels map[string]string, shard ShardConfig) (controller.Controller, error) {
 	uncachedClient, err := client.New(mgr.GetConfig(), client.Options{
 		Scheme: mgr.GetScheme(),
 		Mapper: mgr.GetRESTMapper(),
@@ -183,9 +237,10 @@ func NewImportController(mgr manager.Manager, log logr.Logger, importerImage, pu
 		recorder:        mgr.GetEventRecorderFor("import-controller"),
 		featureGates:    featuregates.NewFeatureGates(client),
 		installerLabels: installerLabels,
+		shard:           shard,
 	}
 	importController, err := controller.New("import-controller", mgr, controller.Options{
-		Reconciler: reconciler,
+		Reconciler: NewInstrumentedReconciler("import-controller", reconciler),
 	})
 	if err != nil {
 		return nil, err
@@ -207,6 +262,14 @@ func addImportControllerWatches(mgr manager.Manager, importController controller
 	}); err != nil {
 		return err
 	}
+	// requeue imports parked with the BlockedByQuota condition when the quota changes
+	if err := importController.Watch(&source.Kind{Type: &corev1.ResourceQuota{}}, handler.EnqueueRequestsFromMapFunc(
+		func(obj client.Object) []reconcile.Request {
+			return enqueueQuotaBlockedPvcs(mgr.GetClient(), obj.GetNamespace())
+		},
+	)); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -214,7 +277,7 @@ func addImportControllerWatches(mgr manager.Manager, importController controller
 func (r *ImportReconciler) shouldReconcilePVC(pvc *corev1.PersistentVolumeClaim,
 	log logr.Logger) (bool, error) {
 	_, isImmediateBindingRequested := pvc.Annotations[AnnImmediateBinding]
-	waitForFirstConsumerEnabled, err := isWaitForFirstConsumerEnabled(isImmediateBindingRequested, r.featureGates)
+	waitForFirstConsumerEnabled, err := isWaitForFirstConsumerEnabled(isImmediateBindingRequested, pvc.Namespace, r.featureGates)
 
 	if err != nil {
 		return false, err
@@ -223,6 +286,7 @@ func (r *ImportReconciler) shouldReconcilePVC(pvc *corev1.PersistentVolumeClaim,
 	multiStageAlreadyDone := metav1.HasAnnotation(pvc.ObjectMeta, AnnMultiStageImportDone)
 
 	return (!isPVCComplete(pvc) || (isPVCComplete(pvc) && multiStageImport && !multiStageAlreadyDone)) &&
+			pvc.Annotations[AnnImportPaused] != "true" &&
 			(checkPVC(pvc, AnnEndpoint, log) || checkPVC(pvc, AnnSource, log)) &&
 			shouldHandlePvc(pvc, waitForFirstConsumerEnabled, log),
 		nil
@@ -239,6 +303,10 @@ func isImageStream(pvc *corev1.PersistentVolumeClaim) bool {
 
 // Reconcile the reconcile loop for the CDIConfig object.
 func (r *ImportReconciler) Reconcile(_ context.Context, req reconcile.Request) (reconcile.Result, error) {
+	if !r.shard.OwnsNamespace(req.Namespace) {
+		return reconcile.Result{}, nil
+	}
+
 	log := r.log.WithValues("PVC", req.NamespacedName)
 	log.V(1).Info("reconciling Import PVCs")
 
@@ -312,7 +380,7 @@ func (r *ImportReconciler) reconcilePvc(pvc *corev1.PersistentVolumeClaim, log l
 			// Don't create the POD if the PVC is completed already
 			log.V(1).Info("PVC is already complete")
 		} else if pvc.DeletionTimestamp == nil {
-			podsUsingPVC, err := GetPodsUsingPVCs(r.client, pvc.Namespace, sets.NewString(pvc.Name), false)
+			podsUsingPVC, err := GetPodsUsingPVCs(r.uncachedClient, pvc.Namespace, sets.NewString(pvc.Name), false)
 			if err != nil {
 				return reconcile.Result{}, err
 			}
@@ -329,10 +397,21 @@ func (r *ImportReconciler) reconcilePvc(pvc *corev1.PersistentVolumeClaim, log l
 			}
 
 			if _, ok := pvc.Annotations[AnnImportPod]; ok {
-				// Create importer pod, make sure the PVC owns it.
-				if err := r.createImporterPod(pvc); err != nil {
+				skipped, err := r.skipUnchangedSource(pvc, log)
+				if err != nil {
 					return reconcile.Result{}, err
 				}
+				if !skipped {
+					// Create importer pod, make sure the PVC owns it.
+					if err := r.createImporterPod(pvc); err != nil {
+						if errBlockedByQuota(err) {
+							// park the import instead of erroring in a tight loop, the
+							// ResourceQuota watch requeues the PVC when the quota changes
+							return reconcile.Result{}, r.setQuotaBlocked(pvc, err)
+						}
+						return reconcile.Result{}, err
+					}
+				}
 			} else {
 				// Create importer pod Name and store in PVC?
 				if err := r.initPvcPodName(pvc, log); err != nil {
@@ -363,6 +442,20 @@ func (r *ImportReconciler) reconcilePvc(pvc *corev1.PersistentVolumeClaim, log l
 	return reconcile.Result{}, nil
 }
 
+// setQuotaBlocked marks the PVC as blocked by quota, which surfaces as a
+// BlockedByQuota running condition on the owning DataVolume
+func (r *ImportReconciler) setQuotaBlocked(pvc *corev1.PersistentVolumeClaim, cause error) error {
+	r.recorder.Event(pvc, corev1.EventTypeWarning, ErrExceededQuota, cause.Error())
+	anno := pvc.GetAnnotations()
+	if anno[AnnRunningConditionReason] == BlockedByQuota {
+		return nil
+	}
+	anno[AnnRunningCondition] = "false"
+	anno[AnnRunningConditionMessage] = cause.Error()
+	anno[AnnRunningConditionReason] = BlockedByQuota
+	return r.updatePVC(pvc, r.log)
+}
+
 func (r *ImportReconciler) initPvcPodName(pvc *corev1.PersistentVolumeClaim, log logr.Logger) error {
 	currentPvcCopy := pvc.DeepCopyObject()
 
@@ -388,6 +481,7 @@ func (r *ImportReconciler) initPvcPodName(pvc *corev1.PersistentVolumeClaim, log
 func (r *ImportReconciler) updatePvcFromPod(pvc *corev1.PersistentVolumeClaim, pod *corev1.Pod, log logr.Logger) error {
 	// Keep a copy of the original for comparison later.
 	currentPvcCopy := pvc.DeepCopyObject()
+	wasSucceeded := podSucceededFromPVC(pvc)
 
 	log.V(1).Info("Updating PVC from pod")
 	anno := pvc.GetAnnotations()
@@ -411,11 +505,27 @@ func (r *ImportReconciler) updatePvcFromPod(pvc *corev1.PersistentVolumeClaim, p
 		anno[AnnCurrentPodID] = string(pod.ObjectMeta.UID)
 	}
 
+	restartLimitExceeded := false
+	if !scratchExitCode {
+		var err error
+		restartLimitExceeded, err = ExceedsRestartLimit(r.client, pod)
+		if err != nil {
+			return err
+		}
+		if restartLimitExceeded {
+			log.V(1).Info("Import pod exceeded the restart limit, marking import failed", "pod.Name", pod.Name)
+			r.recorder.Event(pvc, corev1.EventTypeWarning, ErrImportFailedPVC, "Import pod exceeded the configured restart limit")
+		}
+	}
+
 	anno[AnnImportPod] = string(pod.Name)
 	if !scratchExitCode {
 		// No scratch exit code, update the phase based on the pod. If we do have scratch exit code we don't want to update the
 		// phase, because the pod might terminate cleanly and mistakenly mark the import complete.
 		anno[AnnPodPhase] = string(pod.Status.Phase)
+		if restartLimitExceeded {
+			anno[AnnPodPhase] = string(corev1.PodFailed)
+		}
 	}
 
 	// Check if the POD is waiting for scratch space, if so create some.
@@ -439,6 +549,14 @@ func (r *ImportReconciler) updatePvcFromPod(pvc *corev1.PersistentVolumeClaim, p
 		pvc.GetLabels()[common.CDILabelKey] = common.CDILabelValue
 	}
 
+	// Remember the source ETag so a future re-import of the same PVC can be skipped
+	// when the source did not change in the meantime.
+	if !wasSucceeded && podSucceededFromPVC(pvc) && getSource(pvc) == SourceHTTP {
+		if etag := getEndpointETag(anno[AnnEndpoint]); etag != "" {
+			anno[AnnSourceETag] = etag
+		}
+	}
+
 	if !reflect.DeepEqual(currentPvcCopy, pvc) {
 		if err := r.updatePVC(pvc, log); err != nil {
 			return err
@@ -446,6 +564,11 @@ func (r *ImportReconciler) updatePvcFromPod(pvc *corev1.PersistentVolumeClaim, p
 		log.V(1).Info("Updated PVC", "pvc.anno.Phase", anno[AnnPodPhase], "pvc.anno.Restarts", anno[AnnPodRestarts])
 	}
 
+	if restartLimitExceeded {
+		log.V(1).Info("Deleting pod that exceeded the restart limit", "pod.Name", pod.Name)
+		return r.cleanup(pvc, pod, log)
+	}
+
 	if isPVCComplete(pvc) || scratchExitCode {
 		if !scratchExitCode {
 			r.recorder.Event(pvc, corev1.EventTypeNormal, ImportSucceededPVC, "Import Successful")
@@ -482,6 +605,53 @@ func (r *ImportReconciler) updatePVC(pvc *corev1.PersistentVolumeClaim, log logr
 	return nil
 }
 
+// skipUnchangedSource completes an http import without a transfer when the endpoint
+// reports the same ETag as the previous import of this PVC.
+func (r *ImportReconciler) skipUnchangedSource(pvc *corev1.PersistentVolumeClaim, log logr.Logger) (bool, error) {
+	anno := pvc.GetAnnotations()
+	previousETag := anno[AnnSourceETag]
+	if previousETag == "" || getSource(pvc) != SourceHTTP {
+		return false, nil
+	}
+	if etag := getEndpointETag(anno[AnnEndpoint]); etag == "" || etag != previousETag {
+		return false, nil
+	}
+	log.V(1).Info("Source unchanged since previous import, skipping transfer", "pvc.Name", pvc.Name)
+	anno[AnnPodPhase] = string(corev1.PodSucceeded)
+	anno[AnnRunningCondition] = "false"
+	anno[AnnRunningConditionMessage] = "Import skipped, source unchanged"
+	anno[AnnRunningConditionReason] = SkippedUnchangedSource
+	if err := r.updatePVC(pvc, log); err != nil {
+		return false, err
+	}
+	r.recorder.Eventf(pvc, corev1.EventTypeNormal, SkippedUnchangedSource,
+		"Source of PersistentVolumeClaim %s is unchanged, skipping import", pvc.Name)
+	return true, nil
+}
+
+// getEndpointETag returns the ETag an http(s) endpoint reports for its content,
+// falling back to Last-Modified, or the empty string when the endpoint cannot be
+// reached or reports neither.
+func getEndpointETag(endpoint string) string {
+	ep, err := url.Parse(endpoint)
+	if err != nil || (ep.Scheme != "http" && ep.Scheme != "https") {
+		return ""
+	}
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+	resp, err := httpClient.Head(endpoint)
+	if err != nil {
+		return ""
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return ""
+	}
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		return etag
+	}
+	return resp.Header.Get("Last-Modified")
+}
+
 func (r *ImportReconciler) createImporterPod(pvc *corev1.PersistentVolumeClaim) error {
 	r.log.V(1).Info("Creating importer POD for PVC", "pvc.Name", pvc.Name)
 	var scratchPvcName *string
@@ -520,16 +690,25 @@ func (r *ImportReconciler) createImporterPod(pvc *corev1.PersistentVolumeClaim)
 	if err != nil {
 		return err
 	}
+	image, pullPolicy, err := GetWorkerImageAndPullPolicy(r.client, r.image, r.pullPolicy)
+	if err != nil {
+		return err
+	}
+	rootless, err := r.featureGates.RootlessWorkloadsEnabled()
+	if err != nil {
+		return err
+	}
 	// all checks passed, let's create the importer pod!
 	podArgs := &importerPodArgs{
-		image:             r.image,
-		verbose:           r.verbose,
-		pullPolicy:        r.pullPolicy,
+		image:             image,
+		verbose:           getLogVerbosity(r.client, r.verbose),
+		pullPolicy:        pullPolicy,
 		podEnvVar:         podEnvVar,
 		pvc:               pvc,
 		scratchPvcName:    scratchPvcName,
 		vddkImageName:     vddkImageName,
 		priorityClassName: getPriorityClass(pvc),
+		rootless:          rootless,
 	}
 
 	pod, err := createImporterPod(r.log, r.client, podArgs, r.installerLabels)
@@ -538,6 +717,18 @@ func (r *ImportReconciler) createImporterPod(pvc *corev1.PersistentVolumeClaim)
 	}
 	r.log.V(1).Info("Created POD", "pod.Name", pod.Name)
 
+	if podEnvVar.proxySecret != "" {
+		if err := r.ensureProxyCredentialsSecret(podEnvVar.proxySecret, pod); err != nil {
+			return err
+		}
+	}
+
+	if GetManageNetworkPolicies(r.client) {
+		if err := ensureNetworkPolicy(r.client, MakeImporterNetworkPolicy(pvc, podEnvVar.ep)); err != nil {
+			return err
+		}
+	}
+
 	// If importing from image stream, add finalizer. Note we don't watch the importer pod in this case,
 	// so to prevent a deadlock we add finalizer only if the pod is not retained after completion.
 	if isImageStream(pvc) && pvc.GetAnnotations()[AnnPodRetainAfterCompletion] != "true" {
@@ -570,6 +761,13 @@ func (r *ImportReconciler) createImportEnvVar(pvc *corev1.PersistentVolumeClaim)
 		if podEnvVar.secretName == "" {
 			r.log.V(2).Info("no secret will be supplied to endpoint", "endPoint", podEnvVar.ep)
 		}
+		podEnvVar.secretProviderClass = getValueFromAnnotation(pvc, AnnSecretProviderClass)
+		if podEnvVar.source == SourceRegistry && podEnvVar.secretName != "" {
+			podEnvVar.authFileSecret, err = r.isDockerConfigSecret(pvc.Namespace, podEnvVar.secretName)
+			if err != nil {
+				return nil, err
+			}
+		}
 		//get the CDIConfig to extract the proxy configuration to be used to import an image
 		cdiConfig := &cdiv1.CDIConfig{}
 		r.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, cdiConfig)
@@ -577,10 +775,23 @@ func (r *ImportReconciler) createImportEnvVar(pvc *corev1.PersistentVolumeClaim)
 		if err != nil {
 			return nil, err
 		}
+		if podEnvVar.certConfigMap == "" {
+			podEnvVar.certConfigMap, err = getRegistryCertConfigMap(podEnvVar.ep, cdiConfig)
+			if err != nil {
+				return nil, err
+			}
+		}
+		podEnvVar.certSecret = getValueFromAnnotation(pvc, AnnCertSecret)
 		podEnvVar.insecureTLS, err = r.isInsecureTLS(pvc, cdiConfig)
 		if err != nil {
 			return nil, err
 		}
+		tlsMinVersion, tlsCiphers := ResolveTLSSecurityProfile(GetTLSSecurityProfile(cdiConfig))
+		podEnvVar.tlsMinVersion = tlsMinVersion
+		podEnvVar.tlsCiphers = strings.Join(tlsCiphers, ",")
+		if podEnvVar.source == SourceRegistry && cdiConfig.Spec.RegistryLayerCacheHostPath != nil {
+			podEnvVar.layerCacheHostPath = *cdiConfig.Spec.RegistryLayerCacheHostPath
+		}
 		podEnvVar.diskID = getValueFromAnnotation(pvc, AnnDiskID)
 		podEnvVar.backingFile = getValueFromAnnotation(pvc, AnnBackingFile)
 		podEnvVar.uuid = getValueFromAnnotation(pvc, AnnUUID)
@@ -588,6 +799,8 @@ func (r *ImportReconciler) createImportEnvVar(pvc *corev1.PersistentVolumeClaim)
 		podEnvVar.previousCheckpoint = getValueFromAnnotation(pvc, AnnPreviousCheckpoint)
 		podEnvVar.currentCheckpoint = getValueFromAnnotation(pvc, AnnCurrentCheckpoint)
 		podEnvVar.finalCheckpoint = getValueFromAnnotation(pvc, AnnFinalCheckpoint)
+		podEnvVar.exportTokenSecret = getValueFromAnnotation(pvc, AnnExportTokenSecret)
+		podEnvVar.checksum = getValueFromAnnotation(pvc, AnnChecksum)
 
 		for annotation, value := range pvc.Annotations {
 			if strings.HasPrefix(annotation, AnnExtraHeaders) {
@@ -599,22 +812,26 @@ func (r *ImportReconciler) createImportEnvVar(pvc *corev1.PersistentVolumeClaim)
 		}
 
 		var field string
-		if field, err = GetImportProxyConfig(cdiConfig, common.ImportProxyHTTP); err != nil {
+		if field, err = GetImportProxyConfigForNamespace(r.client, cdiConfig, pvc.Namespace, common.ImportProxyHTTP); err != nil {
 			r.log.V(3).Info("no proxy http url will be supplied:", err.Error())
 		}
 		podEnvVar.httpProxy = field
-		if field, err = GetImportProxyConfig(cdiConfig, common.ImportProxyHTTPS); err != nil {
+		if field, err = GetImportProxyConfigForNamespace(r.client, cdiConfig, pvc.Namespace, common.ImportProxyHTTPS); err != nil {
 			r.log.V(3).Info("no proxy https url will be supplied:", err.Error())
 		}
 		podEnvVar.httpsProxy = field
-		if field, err = GetImportProxyConfig(cdiConfig, common.ImportProxyNoProxy); err != nil {
+		if field, err = GetImportProxyConfigForNamespace(r.client, cdiConfig, pvc.Namespace, common.ImportProxyNoProxy); err != nil {
 			r.log.V(3).Info("the noProxy field will not be supplied:", err.Error())
 		}
 		podEnvVar.noProxy = field
-		if field, err = GetImportProxyConfig(cdiConfig, common.ImportProxyConfigMapName); err != nil {
+		if field, err = GetImportProxyConfigForNamespace(r.client, cdiConfig, pvc.Namespace, common.ImportProxyConfigMapName); err != nil {
 			r.log.V(3).Info("no proxy CA certiticate will be supplied:", err.Error())
 		}
 		podEnvVar.certConfigMapProxy = field
+		if field, err = GetImportProxyConfigForNamespace(r.client, cdiConfig, pvc.Namespace, common.ImportProxyCredentialsSecret); err != nil {
+			r.log.V(3).Info("no proxy credentials will be supplied:", err.Error())
+		}
+		podEnvVar.proxySecret = field
 	}
 
 	fsOverhead, err := GetFilesystemOverhead(r.client, pvc)
@@ -623,10 +840,19 @@ func (r *ImportReconciler) createImportEnvVar(pvc *corev1.PersistentVolumeClaim)
 	}
 	podEnvVar.filesystemOverhead = string(fsOverhead)
 
+	podEnvVar.cacheMode, err = GetDataImportCacheMode(r.client, pvc)
+	if err != nil {
+		return nil, err
+	}
+
 	if preallocation, err := strconv.ParseBool(getValueFromAnnotation(pvc, AnnPreallocationRequested)); err == nil {
 		podEnvVar.preallocation = preallocation
 	} // else use the default "false"
 
+	podEnvVar.blankFilesystem = getValueFromAnnotation(pvc, AnnBlankFilesystem)
+	podEnvVar.blankFsLabel = getValueFromAnnotation(pvc, AnnBlankFilesystemLabel)
+	podEnvVar.blankReservedBlocks = getValueFromAnnotation(pvc, AnnBlankReservedBlocks)
+
 	//get the requested image size.
 	podEnvVar.imageSize, err = getRequestedImageSize(pvc)
 	if err != nil {
@@ -654,6 +880,13 @@ func IsInsecureTLS(ep string, cdiConfig *cdiv1.CDIConfig, client client.Client,
 		return false, nil
 	}
 
+	for _, registry := range cdiConfig.Spec.RegistryTLSConfigs {
+		if registry.Host == url.Host {
+			log.V(1).Info("Found registry TLS config for host", "host", url.Host, "insecure", registry.Insecure)
+			return registry.Insecure, nil
+		}
+	}
+
 	for _, value := range cdiConfig.Spec.InsecureRegistries {
 		log.V(1).Info("Checking host against value", "host", url.Host, "value", value)
 		if value == url.Host {
@@ -684,6 +917,27 @@ func IsInsecureTLS(ep string, cdiConfig *cdiv1.CDIConfig, client client.Client,
 	return false, nil
 }
 
+// getRegistryCertConfigMap returns the CA bundle ConfigMap name the CDIConfig associates
+// with the registry host of the given endpoint
+func getRegistryCertConfigMap(ep string, cdiConfig *cdiv1.CDIConfig) (string, error) {
+	url, err := url.Parse(ep)
+	if err != nil {
+		return "", err
+	}
+
+	if url.Scheme != "docker" {
+		return "", nil
+	}
+
+	for _, registry := range cdiConfig.Spec.RegistryTLSConfigs {
+		if registry.Host == url.Host && registry.CertConfigMap != nil {
+			return *registry.CertConfigMap, nil
+		}
+	}
+
+	return "", nil
+}
+
 func (r *ImportReconciler) getCertConfigMap(pvc *corev1.PersistentVolumeClaim) (string, error) {
 	value, ok := pvc.Annotations[AnnCertConfigMap]
 	if !ok || value == "" {
@@ -703,6 +957,59 @@ func (r *ImportReconciler) getCertConfigMap(pvc *corev1.PersistentVolumeClaim) (
 	return value, nil
 }
 
+// isDockerConfigSecret tells whether the endpoint secret is a standard
+// kubernetes.io/dockerconfigjson pull secret, which is mounted into the
+// importer pod instead of being injected as accessKeyId/secretKey env vars.
+func (r *ImportReconciler) isDockerConfigSecret(namespace, name string) (bool, error) {
+	secret := &corev1.Secret{}
+	if err := r.uncachedClient.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, secret); err != nil {
+		if k8serrors.IsNotFound(err) {
+			r.log.V(1).Info("Secret does not exist, pod will not start until it does", "secretName", name)
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return secret.Type == corev1.SecretTypeDockerConfigJson, nil
+}
+
+// ensureProxyCredentialsSecret copies the import proxy credentials secret from the cdi namespace
+// into the importer pod namespace, named after and owned by the pod, so the pod can consume the
+// credentials through a SecretKeyRef without them ever appearing in the proxy URLs.
+func (r *ImportReconciler) ensureProxyCredentialsSecret(secretName string, pod *corev1.Pod) error {
+	source := &corev1.Secret{}
+	if err := r.uncachedClient.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: util.GetNamespace()}, source); err != nil {
+		return errors.Wrapf(err, "error getting proxy credentials secret %s", secretName)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+			Annotations: map[string]string{
+				AnnCreatedBy: "yes",
+			},
+			Labels: map[string]string{
+				common.CDILabelKey:       common.CDILabelValue,
+				common.CDIComponentLabel: common.ImporterPodName,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				MakePodOwnerReference(pod),
+			},
+		},
+		Data: source.Data,
+	}
+
+	util.SetRecommendedLabels(secret, r.installerLabels, "cdi-controller")
+
+	if err := r.client.Create(context.TODO(), secret); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return errors.Wrap(err, "error creating proxy credentials secret")
+	}
+
+	return nil
+}
+
 // returns the name of the secret containing endpoint credentials consumed by the importer pod.
 // A value of "" implies there are no credentials for the endpoint being used. A returned error
 // causes processNextItem() to stop.
@@ -782,6 +1089,9 @@ func (r *ImportReconciler) createScratchPvcForPod(pvc *corev1.PersistentVolumeCl
 			}
 			return fmt.Errorf("terminating scratch space found, deleting pod %s", pod.Name)
 		}
+		if scratchPvc.Status.Phase == corev1.ClaimBound && anno[AnnBoundConditionReason] == creatingScratch {
+			r.recorder.Eventf(pvc, corev1.EventTypeNormal, ScratchSpaceBound, "Scratch space PVC %s is bound", scratchPvc.Name)
+		}
 		setBoundConditionFromPVC(anno, AnnBoundCondition, scratchPvc)
 	}
 	anno[AnnRequiresScratch] = "false"
@@ -818,6 +1128,8 @@ func getSource(pvc *corev1.PersistentVolumeClaim) string {
 	case
 		SourceHTTP,
 		SourceS3,
+		SourceGCS,
+		SourceOVA,
 		SourceGlance,
 		SourceNone,
 		SourceRegistry,
@@ -908,7 +1220,7 @@ func createImportPodNameFromPvc(pvc *corev1.PersistentVolumeClaim) string {
 // importer pod.
 func createImporterPod(log logr.Logger, client client.Client, args *importerPodArgs, installerLabels map[string]string) (*corev1.Pod, error) {
 	var err error
-	args.podResourceRequirements, err = GetDefaultPodResourceRequirements(client)
+	args.podResourceRequirements, err = GetWorkloadPodResourceRequirements(client, WorkloadTypeImporter)
 	if err != nil {
 		return nil, err
 	}
@@ -930,6 +1242,22 @@ func createImporterPod(log logr.Logger, client client.Client, args *importerPodA
 	}
 
 	util.SetRecommendedLabels(pod, installerLabels, "cdi-controller")
+	if err := ApplyWorkloadMetadata(client, pod); err != nil {
+		return nil, err
+	}
+	if err := ApplyTracingEnv(client, pod); err != nil {
+		return nil, err
+	}
+	if err := ApplySecurityProfile(client, pod); err != nil {
+		return nil, err
+	}
+	if err := ApplyArchitectureAffinity(client, pod); err != nil {
+		return nil, err
+	}
+
+	if err := ensureWorkerServiceAccount(client, pod.Namespace, common.ImporterServiceAccountName); err != nil {
+		return nil, err
+	}
 
 	if err = client.Create(context.TODO(), pod); err != nil {
 		return nil, err
@@ -979,6 +1307,7 @@ func makeNodeImporterPodSpec(args *importerPodArgs) *corev1.Pod {
 				common.CDILabelKey:        common.CDILabelValue,
 				common.CDIComponentLabel:  common.ImporterPodName,
 				common.PrometheusLabelKey: common.PrometheusLabelValue,
+				LabelImportPvc:            naming.GetLabelNameFromResourceName(args.pvc.Name),
 			},
 		},
 		Spec: corev1.PodSpec{
@@ -1011,12 +1340,13 @@ func makeNodeImporterPodSpec(args *importerPodArgs) *corev1.Pod {
 					},
 				},
 			},
-			RestartPolicy:     corev1.RestartPolicyOnFailure,
-			Volumes:           volumes,
-			NodeSelector:      args.workloadNodePlacement.NodeSelector,
-			Tolerations:       args.workloadNodePlacement.Tolerations,
-			Affinity:          args.workloadNodePlacement.Affinity,
-			PriorityClassName: args.priorityClassName,
+			RestartPolicy:      corev1.RestartPolicyOnFailure,
+			ServiceAccountName: common.ImporterServiceAccountName,
+			Volumes:            volumes,
+			NodeSelector:       args.workloadNodePlacement.NodeSelector,
+			Tolerations:        args.workloadNodePlacement.Tolerations,
+			Affinity:           args.workloadNodePlacement.Affinity,
+			PriorityClassName:  args.priorityClassName,
 		},
 	}
 
@@ -1107,6 +1437,7 @@ func makeImporterPodSpec(args *importerPodArgs) *corev1.Pod {
 				common.CDILabelKey:        common.CDILabelValue,
 				common.CDIComponentLabel:  common.ImporterPodName,
 				common.PrometheusLabelKey: common.PrometheusLabelValue,
+				LabelImportPvc:            naming.GetLabelNameFromResourceName(args.pvc.Name),
 			},
 			OwnerReferences: []metav1.OwnerReference{
 				{
@@ -1123,17 +1454,22 @@ func makeImporterPodSpec(args *importerPodArgs) *corev1.Pod {
 			Containers: []corev1.Container{
 				*importerContainer,
 			},
-			RestartPolicy:     corev1.RestartPolicyOnFailure,
-			Volumes:           volumes,
-			NodeSelector:      args.workloadNodePlacement.NodeSelector,
-			Tolerations:       args.workloadNodePlacement.Tolerations,
-			Affinity:          args.workloadNodePlacement.Affinity,
-			PriorityClassName: args.priorityClassName,
+			RestartPolicy:      corev1.RestartPolicyOnFailure,
+			ServiceAccountName: common.ImporterServiceAccountName,
+			Volumes:            volumes,
+			NodeSelector:       args.workloadNodePlacement.NodeSelector,
+			Tolerations:        args.workloadNodePlacement.Tolerations,
+			Affinity:           args.workloadNodePlacement.Affinity,
+			PriorityClassName:  args.priorityClassName,
 		},
 	}
 
 	setImporterPodCommons(pod, args.podEnvVar, args.pvc, args.podResourceRequirements)
 
+	if args.rootless {
+		SetRootlessSecurityContext(pod, getVolumeMode(args.pvc) == corev1.PersistentVolumeBlock)
+	}
+
 	if args.scratchPvcName != nil {
 		pod.Spec.Containers[0].VolumeMounts = append(pod.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
 			Name:      ScratchVolName,
@@ -1141,6 +1477,23 @@ func makeImporterPodSpec(args *importerPodArgs) *corev1.Pod {
 		})
 	}
 
+	if args.podEnvVar.layerCacheHostPath != "" {
+		hostPathType := corev1.HostPathDirectoryOrCreate
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name: LayerCacheVolName,
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: args.podEnvVar.layerCacheHostPath,
+					Type: &hostPathType,
+				},
+			},
+		})
+		pod.Spec.Containers[0].VolumeMounts = append(pod.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      LayerCacheVolName,
+			MountPath: common.LayerCacheDataDir,
+		})
+	}
+
 	if args.vddkImageName != nil {
 		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
 			Name: "vddk-vol-mount",
@@ -1185,6 +1538,69 @@ func makeImporterPodSpec(args *importerPodArgs) *corev1.Pod {
 		pod.Spec.Volumes = append(pod.Spec.Volumes, vol)
 	}
 
+	if args.podEnvVar.certSecret != "" {
+		vm := corev1.VolumeMount{
+			Name:      CertVolName,
+			MountPath: common.ImporterCertDir,
+		}
+
+		vol := corev1.Volume{
+			Name: CertVolName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: args.podEnvVar.certSecret,
+				},
+			},
+		}
+
+		pod.Spec.Containers[0].VolumeMounts = append(pod.Spec.Containers[0].VolumeMounts, vm)
+		pod.Spec.Volumes = append(pod.Spec.Volumes, vol)
+	}
+
+	if args.podEnvVar.authFileSecret {
+		vm := corev1.VolumeMount{
+			Name:      AuthVolName,
+			MountPath: common.ImporterAuthDir,
+		}
+
+		vol := corev1.Volume{
+			Name: AuthVolName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: args.podEnvVar.secretName,
+				},
+			},
+		}
+
+		pod.Spec.Containers[0].VolumeMounts = append(pod.Spec.Containers[0].VolumeMounts, vm)
+		pod.Spec.Volumes = append(pod.Spec.Volumes, vol)
+	}
+
+	if args.podEnvVar.secretProviderClass != "" {
+		readOnly := true
+		vm := corev1.VolumeMount{
+			Name:      SecretProviderVolName,
+			MountPath: common.ImporterCredentialsDir,
+			ReadOnly:  true,
+		}
+
+		vol := corev1.Volume{
+			Name: SecretProviderVolName,
+			VolumeSource: corev1.VolumeSource{
+				CSI: &corev1.CSIVolumeSource{
+					Driver:   "secrets-store.csi.k8s.io",
+					ReadOnly: &readOnly,
+					VolumeAttributes: map[string]string{
+						"secretProviderClass": args.podEnvVar.secretProviderClass,
+					},
+				},
+			},
+		}
+
+		pod.Spec.Containers[0].VolumeMounts = append(pod.Spec.Containers[0].VolumeMounts, vm)
+		pod.Spec.Volumes = append(pod.Spec.Volumes, vol)
+	}
+
 	if args.podEnvVar.certConfigMapProxy != "" {
 		vm := corev1.VolumeMount{
 			Name:      ProxyCertVolName,
@@ -1194,6 +1610,31 @@ func makeImporterPodSpec(args *importerPodArgs) *corev1.Pod {
 		pod.Spec.Volumes = append(pod.Spec.Volumes, createProxyConfigMapVolume(ProxyCertVolName, args.podEnvVar.certConfigMapProxy))
 	}
 
+	if args.podEnvVar.proxySecret != "" {
+		// the credentials secret is copied into the pod namespace under the pod name
+		pod.Spec.Containers[0].Env = append(pod.Spec.Containers[0].Env, corev1.EnvVar{
+			Name: common.ImporterProxyUsernameVar,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: podName,
+					},
+					Key: common.KeyAccess,
+				},
+			},
+		}, corev1.EnvVar{
+			Name: common.ImporterProxyPasswordVar,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: podName,
+					},
+					Key: common.KeySecret,
+				},
+			},
+		})
+	}
+
 	for index, header := range args.podEnvVar.secretExtraHeaders {
 		vm := corev1.VolumeMount{
 			Name:      fmt.Sprintf(SecretExtraHeadersVolumeName, index),
@@ -1374,7 +1815,19 @@ func makeImportEnv(podEnvVar *importPodEnvVar, uid types.UID) []corev1.EnvVar {
 			Value: strconv.FormatBool(podEnvVar.preallocation),
 		},
 	}
-	if podEnvVar.secretName != "" {
+	if podEnvVar.secretName != "" && podEnvVar.source == SourceGCS {
+		env = append(env, corev1.EnvVar{
+			Name: common.ImporterGoogleCredentials,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: podEnvVar.secretName,
+					},
+					Key: common.KeyGoogleCredentials,
+				},
+			},
+		})
+	} else if podEnvVar.secretName != "" && !podEnvVar.authFileSecret {
 		env = append(env, corev1.EnvVar{
 			Name: common.ImporterAccessKeyID,
 			ValueFrom: &corev1.EnvVarSource{
@@ -1398,12 +1851,80 @@ func makeImportEnv(podEnvVar *importPodEnvVar, uid types.UID) []corev1.EnvVar {
 		})
 
 	}
-	if podEnvVar.certConfigMap != "" {
+	if podEnvVar.authFileSecret {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterAuthFileVar,
+			Value: common.ImporterAuthDir + "/" + corev1.DockerConfigJsonKey,
+		})
+	}
+	if podEnvVar.exportTokenSecret != "" {
+		// the export token never appears in the pod spec
+		env = append(env, corev1.EnvVar{
+			Name: common.ImporterExportTokenVar,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: podEnvVar.exportTokenSecret,
+					},
+					Key: common.ExportServerTokenKey,
+				},
+			},
+		})
+	}
+	if podEnvVar.checksum != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterChecksumVar,
+			Value: podEnvVar.checksum,
+		})
+	}
+	if podEnvVar.blankFilesystem != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterBlankFilesystem,
+			Value: podEnvVar.blankFilesystem,
+		}, corev1.EnvVar{
+			Name:  common.ImporterBlankFilesystemLabel,
+			Value: podEnvVar.blankFsLabel,
+		}, corev1.EnvVar{
+			Name:  common.ImporterBlankReservedBlocks,
+			Value: podEnvVar.blankReservedBlocks,
+		})
+	}
+	if podEnvVar.secretProviderClass != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterCredentialsDirVar,
+			Value: common.ImporterCredentialsDir,
+		})
+	}
+	if podEnvVar.certConfigMap != "" || podEnvVar.certSecret != "" {
 		env = append(env, corev1.EnvVar{
 			Name:  common.ImporterCertDirVar,
 			Value: common.ImporterCertDir,
 		})
 	}
+	if podEnvVar.tlsMinVersion != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterTLSMinVersionVar,
+			Value: podEnvVar.tlsMinVersion,
+		})
+	}
+	if podEnvVar.tlsCiphers != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterTLSCiphersVar,
+			Value: podEnvVar.tlsCiphers,
+		})
+	}
+	if podEnvVar.cacheMode != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  common.QemuCacheMode,
+			Value: podEnvVar.cacheMode,
+		})
+	}
+	if podEnvVar.layerCacheHostPath != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterLayerCacheDirVar,
+			Value: common.LayerCacheDataDir,
+		})
+	}
 	if podEnvVar.certConfigMapProxy != "" {
 		env = append(env, corev1.EnvVar{
 			Name:  common.ImporterProxyCertDirVar,
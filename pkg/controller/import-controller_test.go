@@ -18,6 +18,8 @@ package controller
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"strconv"
 	"strings"
@@ -241,6 +243,52 @@ var _ = Describe("ImportConfig Controller reconcile loop", func() {
 		}
 		Expect(found).To(BeTrue())
 	})
+	It("Should skip the import and mark the PVC complete if the source ETag is unchanged", func() {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("ETag", "\"abc\"")
+		}))
+		defer ts.Close()
+		pvc := createPvc("testPvc1", "default", map[string]string{
+			AnnEndpoint: ts.URL, AnnSource: SourceHTTP, AnnImportPod: "importer-testPvc1", AnnSourceETag: "\"abc\""}, nil)
+		pvc.Status.Phase = v1.ClaimBound
+		reconciler := createImportReconciler(pvc)
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "testPvc1", Namespace: "default"}})
+		Expect(err).ToNot(HaveOccurred())
+		resPvc := &corev1.PersistentVolumeClaim{}
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testPvc1", Namespace: "default"}, resPvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resPvc.GetAnnotations()[AnnPodPhase]).To(Equal(string(corev1.PodSucceeded)))
+		pod := &corev1.Pod{}
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "importer-testPvc1", Namespace: "default"}, pod)
+		Expect(err).To(HaveOccurred())
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		By("Checking events recorded")
+		close(reconciler.recorder.(*record.FakeRecorder).Events)
+		found := false
+		for event := range reconciler.recorder.(*record.FakeRecorder).Events {
+			if strings.Contains(event, "SkippedUnchangedSource") {
+				found = true
+			}
+		}
+		Expect(found).To(BeTrue())
+	})
+
+	It("Should create a POD if the source ETag changed since the previous import", func() {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("ETag", "\"def\"")
+		}))
+		defer ts.Close()
+		pvc := createPvc("testPvc1", "default", map[string]string{
+			AnnEndpoint: ts.URL, AnnSource: SourceHTTP, AnnImportPod: "importer-testPvc1", AnnSourceETag: "\"abc\""}, nil)
+		pvc.Status.Phase = v1.ClaimBound
+		reconciler := createImportReconciler(pvc)
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "testPvc1", Namespace: "default"}})
+		Expect(err).ToNot(HaveOccurred())
+		pod := &corev1.Pod{}
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "importer-testPvc1", Namespace: "default"}, pod)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
 	It("Should create a POD if target pvc no longer in use (pod using the PVC failed)", func() {
 		pvc := createPvc("testPvc1", "default", map[string]string{AnnEndpoint: testEndPoint, AnnImportPod: "importer-testPvc1"}, nil)
 		pvc.Status.Phase = v1.ClaimBound
@@ -527,6 +575,49 @@ var _ = Describe("Update PVC from POD", func() {
 
 	})
 
+	It("Should mark the import failed and delete the pod when the restart limit is exceeded", func() {
+		pvc := createPvcInStorageClass("testPvc1", "default", &testStorageClass, map[string]string{AnnEndpoint: testEndPoint, AnnPodPhase: string(corev1.PodRunning)}, nil, corev1.ClaimBound)
+		pod := createImporterTestPod(pvc, "testPvc1", nil)
+		pod.Status = corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					RestartCount: 3,
+					State: v1.ContainerState{
+						Waiting: &corev1.ContainerStateWaiting{
+							Reason: "CrashLoopBackOff",
+						},
+					},
+				},
+			},
+		}
+		reconciler = createImportReconciler(pvc, pod)
+		cdiConfig := &cdiv1.CDIConfig{}
+		err := reconciler.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, cdiConfig)
+		Expect(err).ToNot(HaveOccurred())
+		maxRestarts := int32(2)
+		cdiConfig.Spec.WorkerRetryPolicy = &cdiv1.WorkerRetryPolicy{MaxRestarts: &maxRestarts}
+		err = reconciler.client.Update(context.TODO(), cdiConfig)
+		Expect(err).ToNot(HaveOccurred())
+
+		err = reconciler.updatePvcFromPod(pvc, pod, reconciler.log)
+		Expect(err).ToNot(HaveOccurred())
+		By("Checking pvc phase has been marked failed")
+		resPvc := &corev1.PersistentVolumeClaim{}
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testPvc1", Namespace: "default"}, resPvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resPvc.GetAnnotations()[AnnPodPhase]).To(BeEquivalentTo(corev1.PodFailed))
+		Expect(resPvc.GetAnnotations()[AnnPodRestarts]).To(Equal("3"))
+		By("Checking error event recorded")
+		event := <-reconciler.recorder.(*record.FakeRecorder).Events
+		Expect(event).To(ContainSubstring("restart limit"))
+		By("Checking the pod has been deleted")
+		resPod := &corev1.Pod{}
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}, resPod)
+		Expect(err).To(HaveOccurred())
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
 	// TODO: Update me to stay in progress if we were in progress already, its a pod failure and it will get restarted.
 	It("Should update phase on PVC, if pod exited with error state that is NOT scratchspace exit", func() {
 		pvc := createPvcInStorageClass("testPvc1", "default", &testStorageClass, map[string]string{AnnEndpoint: testEndPoint, AnnPodPhase: string(corev1.PodRunning)}, nil, corev1.ClaimBound)
@@ -814,6 +905,127 @@ var _ = Describe("Create Importer Pod", func() {
 		table.Entry("should create pod with block volume mode and scratchspace", createBlockPvc("testBlockPvc1", "default", map[string]string{AnnEndpoint: testEndPoint, AnnPodPhase: string(corev1.PodPending), AnnImportPod: "podName", AnnPriorityClassName: "p0"}, nil), &scratchPvcName),
 	)
 
+	It("should mount a dockerconfigjson pull secret instead of injecting credential env vars", func() {
+		pvc := createPvc("testPvc1", "default", map[string]string{AnnEndpoint: testEndPoint, AnnImportPod: "podName"}, nil)
+		reconciler := createImportReconciler(pvc)
+		podEnvVar := &importPodEnvVar{
+			source:             SourceRegistry,
+			secretName:         "pull-secret",
+			authFileSecret:     true,
+			imageSize:          "1G",
+			filesystemOverhead: "0.055",
+		}
+		podArgs := &importerPodArgs{
+			image:      testImage,
+			verbose:    "5",
+			pullPolicy: testPullPolicy,
+			podEnvVar:  podEnvVar,
+			pvc:        pvc,
+		}
+		pod, err := createImporterPod(reconciler.log, reconciler.client, podArgs, map[string]string{})
+		Expect(err).ToNot(HaveOccurred())
+		foundVolume := false
+		for _, vol := range pod.Spec.Volumes {
+			if vol.Name == AuthVolName {
+				foundVolume = true
+				Expect(vol.Secret.SecretName).To(Equal("pull-secret"))
+			}
+		}
+		Expect(foundVolume).To(BeTrue())
+		foundMount := false
+		for _, vm := range pod.Spec.Containers[0].VolumeMounts {
+			if vm.Name == AuthVolName {
+				foundMount = true
+				Expect(vm.MountPath).To(Equal(common.ImporterAuthDir))
+			}
+		}
+		Expect(foundMount).To(BeTrue())
+		foundEnv := false
+		for _, env := range pod.Spec.Containers[0].Env {
+			Expect(env.Name).ToNot(Equal(common.ImporterAccessKeyID))
+			Expect(env.Name).ToNot(Equal(common.ImporterSecretKey))
+			if env.Name == common.ImporterAuthFileVar {
+				foundEnv = true
+				Expect(env.Value).To(Equal(common.ImporterAuthDir + "/" + corev1.DockerConfigJsonKey))
+			}
+		}
+		Expect(foundEnv).To(BeTrue())
+	})
+
+	It("should take proxy credentials from the copied secret instead of the proxy url", func() {
+		pvc := createPvc("testPvc1", "default", map[string]string{AnnEndpoint: testEndPoint, AnnImportPod: "podName"}, nil)
+		reconciler := createImportReconciler(pvc)
+		podEnvVar := &importPodEnvVar{
+			ep:                 testEndPoint,
+			httpProxy:          "http://www.myproxy.com",
+			proxySecret:        "proxy-credentials",
+			imageSize:          "1G",
+			filesystemOverhead: "0.055",
+		}
+		podArgs := &importerPodArgs{
+			image:      testImage,
+			verbose:    "5",
+			pullPolicy: testPullPolicy,
+			podEnvVar:  podEnvVar,
+			pvc:        pvc,
+		}
+		pod, err := createImporterPod(reconciler.log, reconciler.client, podArgs, map[string]string{})
+		Expect(err).ToNot(HaveOccurred())
+		foundUsername := false
+		foundPassword := false
+		for _, env := range pod.Spec.Containers[0].Env {
+			if env.Name == common.ImporterProxyUsernameVar {
+				foundUsername = true
+				Expect(env.ValueFrom.SecretKeyRef.Name).To(Equal(pod.Name))
+				Expect(env.ValueFrom.SecretKeyRef.Key).To(Equal(common.KeyAccess))
+			}
+			if env.Name == common.ImporterProxyPasswordVar {
+				foundPassword = true
+				Expect(env.ValueFrom.SecretKeyRef.Name).To(Equal(pod.Name))
+				Expect(env.ValueFrom.SecretKeyRef.Key).To(Equal(common.KeySecret))
+			}
+		}
+		Expect(foundUsername).To(BeTrue())
+		Expect(foundPassword).To(BeTrue())
+	})
+
+	It("should mount the secrets-store CSI volume when a secret provider class is set", func() {
+		pvc := createPvc("testPvc1", "default", map[string]string{AnnEndpoint: testEndPoint, AnnImportPod: "podName"}, nil)
+		reconciler := createImportReconciler(pvc)
+		podEnvVar := &importPodEnvVar{
+			ep:                  testEndPoint,
+			secretProviderClass: "vault-endpoint-creds",
+			imageSize:           "1G",
+			filesystemOverhead:  "0.055",
+		}
+		podArgs := &importerPodArgs{
+			image:      testImage,
+			verbose:    "5",
+			pullPolicy: testPullPolicy,
+			podEnvVar:  podEnvVar,
+			pvc:        pvc,
+		}
+		pod, err := createImporterPod(reconciler.log, reconciler.client, podArgs, map[string]string{})
+		Expect(err).ToNot(HaveOccurred())
+		foundVolume := false
+		for _, vol := range pod.Spec.Volumes {
+			if vol.Name == SecretProviderVolName {
+				foundVolume = true
+				Expect(vol.CSI.Driver).To(Equal("secrets-store.csi.k8s.io"))
+				Expect(vol.CSI.VolumeAttributes).To(HaveKeyWithValue("secretProviderClass", "vault-endpoint-creds"))
+			}
+		}
+		Expect(foundVolume).To(BeTrue())
+		foundEnv := false
+		for _, env := range pod.Spec.Containers[0].Env {
+			if env.Name == common.ImporterCredentialsDirVar {
+				foundEnv = true
+				Expect(env.Value).To(Equal(common.ImporterCredentialsDir))
+			}
+		}
+		Expect(foundEnv).To(BeTrue())
+	})
+
 	table.DescribeTable("should append current checkpoint name to importer pod", func(pvcName, checkpointID string) {
 		pvc := createPvc(pvcName, "default", map[string]string{AnnCurrentCheckpoint: checkpointID, AnnEndpoint: testEndPoint}, nil)
 		pvc.Status.Phase = v1.ClaimBound
@@ -968,6 +1180,46 @@ var _ = Describe("getInsecureTLS", func() {
 		table.Entry("return false on endpoint with no port, and blank host", endpointNoPort, "", false),
 		table.Entry("return false on blank endpoint, and host defined", "", host, false),
 	)
+
+	table.DescribeTable("should honor the registry TLS config", func(endpoint string, registryHost string, insecure bool, isInsecure bool) {
+		pvc := createPvc("testPVC", "default", map[string]string{AnnEndpoint: endpoint}, nil)
+		reconciler := createImportReconciler(pvc)
+
+		cdiConfig := &cdiv1.CDIConfig{}
+		err := reconciler.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, cdiConfig)
+		Expect(err).ToNot(HaveOccurred())
+
+		cdiConfig.Spec.RegistryTLSConfigs = []cdiv1.RegistryTLSConfig{
+			{Host: registryHost, Insecure: insecure},
+		}
+
+		result, err := reconciler.isInsecureTLS(pvc, cdiConfig)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(isInsecure))
+	},
+		table.Entry("return true on matching insecure registry config", endpointNoPort, host, true, true),
+		table.Entry("return false on matching secure registry config", endpointNoPort, host, false, false),
+		table.Entry("return false when no registry config matches", endpointNoPort, hostWithPort, true, false),
+	)
+})
+
+var _ = Describe("getRegistryCertConfigMap", func() {
+	host := "myregistry"
+	certConfigMap := "myregistry-certs"
+
+	table.DescribeTable("should", func(endpoint string, registryHost string, expected string) {
+		cdiConfig := MakeEmptyCDIConfigSpec(common.ConfigName)
+		cdiConfig.Spec.RegistryTLSConfigs = []cdiv1.RegistryTLSConfig{
+			{Host: registryHost, CertConfigMap: &certConfigMap},
+		}
+		cm, err := getRegistryCertConfigMap(endpoint, cdiConfig)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cm).To(Equal(expected))
+	},
+		table.Entry("return the configured ConfigMap for a matching registry host", "docker://"+host, host, certConfigMap),
+		table.Entry("return blank when no registry config matches", "docker://"+host, host+":5000", ""),
+		table.Entry("return blank for a non registry endpoint", "http://"+host, host, ""),
+	)
 })
 
 var _ = Describe("GetContentType", func() {
@@ -1318,8 +1570,22 @@ func createImporterTestPod(pvc *corev1.PersistentVolumeClaim, dvname string, scr
 
 type FakeFeatureGates struct {
 	honorWaitForFirstConsumerEnabled bool
+	rootlessWorkloadsEnabled         bool
+	autoVolumeExpansionEnabled       bool
 }
 
 func (f *FakeFeatureGates) HonorWaitForFirstConsumerEnabled() (bool, error) {
 	return f.honorWaitForFirstConsumerEnabled, nil
 }
+
+func (f *FakeFeatureGates) HonorWaitForFirstConsumerEnabledForNamespace(namespace string) (bool, error) {
+	return f.honorWaitForFirstConsumerEnabled, nil
+}
+
+func (f *FakeFeatureGates) RootlessWorkloadsEnabled() (bool, error) {
+	return f.rootlessWorkloadsEnabled, nil
+}
+
+func (f *FakeFeatureGates) AutoVolumeExpansionEnabled() (bool, error) {
+	return f.autoVolumeExpansionEnabled, nil
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2022 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net"
+	"net/url"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/util/naming"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const dnsPort = 53
+
+// GetManageNetworkPolicies tells whether the controller should create
+// NetworkPolicies restricting worker pods to their declared endpoints.
+func GetManageNetworkPolicies(c client.Client) bool {
+	config := &cdiv1.CDIConfig{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, config); err != nil {
+		return false
+	}
+	return config.Spec.ManageNetworkPolicies != nil && *config.Spec.ManageNetworkPolicies
+}
+
+// ensureNetworkPolicy creates the given NetworkPolicy unless it already exists.
+func ensureNetworkPolicy(c client.Client, policy *networkingv1.NetworkPolicy) error {
+	if err := c.Create(context.TODO(), policy); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// MakeImporterNetworkPolicy returns the NetworkPolicy for an importer pod:
+// all ingress is denied and egress is limited to DNS and the import endpoint.
+// When the endpoint host is a literal IP the destination is pinned to it,
+// otherwise only the port can be restricted since NetworkPolicies match on
+// IP blocks, not on hostnames.
+func MakeImporterNetworkPolicy(pvc *corev1.PersistentVolumeClaim, endpoint string) *networkingv1.NetworkPolicy {
+	blockOwnerDeletion := true
+	isController := true
+
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pvc.Annotations[AnnImportPod],
+			Namespace: pvc.Namespace,
+			Labels: map[string]string{
+				common.CDILabelKey: common.CDILabelValue,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         "v1",
+					Kind:               "PersistentVolumeClaim",
+					Name:               pvc.Name,
+					UID:                pvc.GetUID(),
+					BlockOwnerDeletion: &blockOwnerDeletion,
+					Controller:         &isController,
+				},
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					LabelImportPvc: naming.GetLabelNameFromResourceName(pvc.Name),
+				},
+			},
+			PolicyTypes: []networkingv1.PolicyType{
+				networkingv1.PolicyTypeIngress,
+				networkingv1.PolicyTypeEgress,
+			},
+			Egress: []networkingv1.NetworkPolicyEgressRule{
+				dnsEgressRule(),
+			},
+		},
+	}
+
+	if rule, ok := endpointEgressRule(endpoint); ok {
+		policy.Spec.Egress = append(policy.Spec.Egress, rule)
+	}
+
+	return policy
+}
+
+// MakeUploadServerNetworkPolicy returns the NetworkPolicy for an upload server
+// pod: ingress is limited to the upload proxy and clone source pods talking to
+// the upload port, plus the readiness probe port, and egress is denied.
+func MakeUploadServerNetworkPolicy(pvc *corev1.PersistentVolumeClaim, podName, serviceName string) *networkingv1.NetworkPolicy {
+	blockOwnerDeletion := true
+	isController := true
+	tcp := corev1.ProtocolTCP
+	uploadPort := intstr.FromInt(8443)
+	probePort := intstr.FromInt(8080)
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      podName,
+			Namespace: pvc.Namespace,
+			Labels: map[string]string{
+				common.CDILabelKey: common.CDILabelValue,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         "v1",
+					Kind:               "PersistentVolumeClaim",
+					Name:               pvc.Name,
+					UID:                pvc.GetUID(),
+					BlockOwnerDeletion: &blockOwnerDeletion,
+					Controller:         &isController,
+				},
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					common.UploadServerServiceLabel: serviceName,
+				},
+			},
+			PolicyTypes: []networkingv1.PolicyType{
+				networkingv1.PolicyTypeIngress,
+				networkingv1.PolicyTypeEgress,
+			},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{
+					From: []networkingv1.NetworkPolicyPeer{
+						{
+							NamespaceSelector: &metav1.LabelSelector{},
+							PodSelector: &metav1.LabelSelector{
+								MatchExpressions: []metav1.LabelSelectorRequirement{
+									{
+										Key:      common.CDIComponentLabel,
+										Operator: metav1.LabelSelectorOpIn,
+										Values:   []string{"cdi-uploadproxy", common.ClonerSourcePodName},
+									},
+								},
+							},
+						},
+					},
+					Ports: []networkingv1.NetworkPolicyPort{
+						{Protocol: &tcp, Port: &uploadPort},
+					},
+				},
+				{
+					// kubelet readiness probes originate from the node, which
+					// cannot be matched by a pod selector
+					Ports: []networkingv1.NetworkPolicyPort{
+						{Protocol: &tcp, Port: &probePort},
+					},
+				},
+			},
+		},
+	}
+}
+
+// dnsEgressRule allows name resolution to any destination.
+func dnsEgressRule() networkingv1.NetworkPolicyEgressRule {
+	tcp := corev1.ProtocolTCP
+	udp := corev1.ProtocolUDP
+	port := intstr.FromInt(dnsPort)
+	return networkingv1.NetworkPolicyEgressRule{
+		Ports: []networkingv1.NetworkPolicyPort{
+			{Protocol: &udp, Port: &port},
+			{Protocol: &tcp, Port: &port},
+		},
+	}
+}
+
+// endpointEgressRule allows traffic to the import endpoint, pinning the
+// destination IP when the endpoint host is a literal IP.
+func endpointEgressRule(endpoint string) (networkingv1.NetworkPolicyEgressRule, bool) {
+	ep, err := url.Parse(endpoint)
+	if err != nil || ep.Host == "" {
+		return networkingv1.NetworkPolicyEgressRule{}, false
+	}
+
+	tcp := corev1.ProtocolTCP
+	rule := networkingv1.NetworkPolicyEgressRule{}
+
+	portNum := 443
+	if ep.Scheme == "http" {
+		portNum = 80
+	}
+	if p := ep.Port(); p != "" {
+		port := intstr.Parse(p)
+		portNum = port.IntValue()
+	}
+	port := intstr.FromInt(portNum)
+	rule.Ports = []networkingv1.NetworkPolicyPort{
+		{Protocol: &tcp, Port: &port},
+	}
+
+	if ip := net.ParseIP(ep.Hostname()); ip != nil {
+		cidr := ip.String() + "/32"
+		if ip.To4() == nil {
+			cidr = ip.String() + "/128"
+		}
+		rule.To = []networkingv1.NetworkPolicyPeer{
+			{IPBlock: &networkingv1.IPBlock{CIDR: cidr}},
+		}
+	}
+
+	return rule, true
+}
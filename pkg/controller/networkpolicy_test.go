@@ -0,0 +1,73 @@
+/*
+Copyright 2022 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+var _ = Describe("Worker pod network policies", func() {
+	It("Should be disabled by default", func() {
+		client := createClient(MakeEmptyCDIConfigSpec(common.ConfigName))
+		Expect(GetManageNetworkPolicies(client)).To(BeFalse())
+	})
+
+	It("Should be enabled by the CDIConfig flag", func() {
+		config := MakeEmptyCDIConfigSpec(common.ConfigName)
+		config.Spec.ManageNetworkPolicies = &[]bool{true}[0]
+		client := createClient(config)
+		Expect(GetManageNetworkPolicies(client)).To(BeTrue())
+	})
+
+	It("Should deny importer ingress and pin egress to a literal IP endpoint", func() {
+		pvc := createPvc("testPvc1", "default", map[string]string{AnnImportPod: "importer-testPvc1"}, nil)
+		policy := MakeImporterNetworkPolicy(pvc, "https://192.168.1.1/disk.img")
+		Expect(policy.Name).To(Equal("importer-testPvc1"))
+		Expect(policy.Spec.PolicyTypes).To(ContainElement(networkingv1.PolicyTypeIngress))
+		Expect(policy.Spec.Ingress).To(BeEmpty())
+		Expect(policy.Spec.PodSelector.MatchLabels).To(HaveKeyWithValue(LabelImportPvc, "testPvc1"))
+		Expect(policy.Spec.Egress).To(HaveLen(2))
+		endpointRule := policy.Spec.Egress[1]
+		Expect(endpointRule.To).To(HaveLen(1))
+		Expect(endpointRule.To[0].IPBlock.CIDR).To(Equal("192.168.1.1/32"))
+		Expect(endpointRule.Ports[0].Port.IntValue()).To(Equal(443))
+	})
+
+	It("Should only restrict the egress port for hostname endpoints", func() {
+		pvc := createPvc("testPvc1", "default", map[string]string{AnnImportPod: "importer-testPvc1"}, nil)
+		policy := MakeImporterNetworkPolicy(pvc, "http://www.example.com:8080/disk.img")
+		endpointRule := policy.Spec.Egress[1]
+		Expect(endpointRule.To).To(BeEmpty())
+		Expect(endpointRule.Ports[0].Port.IntValue()).To(Equal(8080))
+	})
+
+	It("Should limit upload server ingress to the upload proxy and clone source pods", func() {
+		pvc := createPvc("testPvc1", "default", nil, nil)
+		policy := MakeUploadServerNetworkPolicy(pvc, "cdi-upload-testPvc1", "cdi-upload-testPvc1")
+		Expect(policy.Spec.PodSelector.MatchLabels).To(HaveKeyWithValue(common.UploadServerServiceLabel, "cdi-upload-testPvc1"))
+		Expect(policy.Spec.Ingress).To(HaveLen(2))
+		peers := policy.Spec.Ingress[0].From
+		Expect(peers).To(HaveLen(1))
+		Expect(peers[0].PodSelector.MatchExpressions[0].Values).To(ContainElement(common.ClonerSourcePodName))
+		Expect(policy.Spec.Ingress[0].Ports[0].Port.IntValue()).To(Equal(8443))
+	})
+})
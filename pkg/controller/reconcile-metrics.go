@@ -0,0 +1,90 @@
+/*
+Copyright 2022 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"kubevirt.io/containerized-data-importer/pkg/monitoring"
+)
+
+var (
+	// ReconcileDurationHistogram observes how long each reconcile loop took,
+	// labeled by controller and result
+	ReconcileDurationHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    monitoring.MetricOptsList[monitoring.ReconcileDuration].Name,
+			Help:    monitoring.MetricOptsList[monitoring.ReconcileDuration].Help,
+			Buckets: prometheus.ExponentialBuckets(0.001, 4, 8),
+		},
+		[]string{"controller", "result"})
+	// ReconcileErrorsCounter counts reconcile errors, labeled by controller and error reason
+	ReconcileErrorsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: monitoring.MetricOptsList[monitoring.ReconcileErrors].Name,
+			Help: monitoring.MetricOptsList[monitoring.ReconcileErrors].Help,
+		},
+		[]string{"controller", "reason"})
+)
+
+type instrumentedReconciler struct {
+	name  string
+	inner reconcile.Reconciler
+}
+
+// NewInstrumentedReconciler wraps a reconciler so its loop duration and errors
+// are published under the given controller name
+func NewInstrumentedReconciler(name string, inner reconcile.Reconciler) reconcile.Reconciler {
+	return &instrumentedReconciler{name: name, inner: inner}
+}
+
+func (r *instrumentedReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	start := time.Now()
+	result, err := r.inner.Reconcile(ctx, req)
+	ReconcileDurationHistogram.WithLabelValues(r.name, reconcileResult(result, err)).Observe(time.Since(start).Seconds())
+	if err != nil {
+		ReconcileErrorsCounter.WithLabelValues(r.name, errorReason(err)).Inc()
+	}
+	return result, err
+}
+
+// reconcileResult maps the outcome of a reconcile loop to a low cardinality
+// label value
+func reconcileResult(result reconcile.Result, err error) string {
+	switch {
+	case err != nil:
+		return "error"
+	case result.Requeue || result.RequeueAfter > 0:
+		return "requeue"
+	default:
+		return "success"
+	}
+}
+
+// errorReason maps an error to a low cardinality label value
+func errorReason(err error) string {
+	if reason := k8serrors.ReasonForError(err); reason != metav1.StatusReasonUnknown {
+		return string(reason)
+	}
+	return "Other"
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2022 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+// AnnAutomountServiceAccountToken re-enables service account token automount for the
+// CDI worker pods in the annotated namespace
+const AnnAutomountServiceAccountToken = AnnAPIGroup + "/automountServiceAccountToken"
+
+// ensureWorkerServiceAccount makes sure the dedicated ServiceAccount the worker pods run
+// under exists in the given namespace. The ServiceAccount carries no API permissions and
+// does not automount its token, unless the namespace opts back in with the
+// AnnAutomountServiceAccountToken annotation.
+func ensureWorkerServiceAccount(c client.Client, namespace, name string) error {
+	ns := &corev1.Namespace{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: namespace}, ns); err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	automount := ns.Annotations[AnnAutomountServiceAccountToken] == "true"
+
+	sa := &corev1.ServiceAccount{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, sa); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+		sa = &corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels: map[string]string{
+					common.CDILabelKey:       common.CDILabelValue,
+					common.CDIComponentLabel: name,
+				},
+			},
+			AutomountServiceAccountToken: &automount,
+		}
+		if err := c.Create(context.TODO(), sa); err != nil && !k8serrors.IsAlreadyExists(err) {
+			return err
+		}
+		return nil
+	}
+
+	if sa.AutomountServiceAccountToken == nil || *sa.AutomountServiceAccountToken != automount {
+		sa.AutomountServiceAccountToken = &automount
+		return c.Update(context.TODO(), sa)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2022 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+var _ = Describe("Worker pod service accounts", func() {
+	createNamespace := func(name string, annotations map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Annotations: annotations,
+			},
+		}
+	}
+
+	getServiceAccount := func(c client.Client, namespace, name string) *corev1.ServiceAccount {
+		sa := &corev1.ServiceAccount{}
+		err := c.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, sa)
+		Expect(err).ToNot(HaveOccurred())
+		return sa
+	}
+
+	It("Should create the service account with token automount disabled", func() {
+		client := createClient(createNamespace("default", nil))
+		err := ensureWorkerServiceAccount(client, "default", common.ImporterServiceAccountName)
+		Expect(err).ToNot(HaveOccurred())
+		sa := getServiceAccount(client, "default", common.ImporterServiceAccountName)
+		Expect(sa.AutomountServiceAccountToken).ToNot(BeNil())
+		Expect(*sa.AutomountServiceAccountToken).To(BeFalse())
+		Expect(sa.Labels[common.CDIComponentLabel]).To(Equal(common.ImporterServiceAccountName))
+	})
+
+	It("Should honor the namespace automount opt-in annotation", func() {
+		annotations := map[string]string{AnnAutomountServiceAccountToken: "true"}
+		client := createClient(createNamespace("default", annotations))
+		err := ensureWorkerServiceAccount(client, "default", common.UploadServerServiceAccountName)
+		Expect(err).ToNot(HaveOccurred())
+		sa := getServiceAccount(client, "default", common.UploadServerServiceAccountName)
+		Expect(sa.AutomountServiceAccountToken).ToNot(BeNil())
+		Expect(*sa.AutomountServiceAccountToken).To(BeTrue())
+	})
+
+	It("Should update an existing service account when the namespace opt-in changes", func() {
+		ns := createNamespace("default", nil)
+		client := createClient(ns)
+		err := ensureWorkerServiceAccount(client, "default", common.ClonerServiceAccountName)
+		Expect(err).ToNot(HaveOccurred())
+
+		ns.Annotations = map[string]string{AnnAutomountServiceAccountToken: "true"}
+		err = client.Update(context.TODO(), ns)
+		Expect(err).ToNot(HaveOccurred())
+
+		err = ensureWorkerServiceAccount(client, "default", common.ClonerServiceAccountName)
+		Expect(err).ToNot(HaveOccurred())
+		sa := getServiceAccount(client, "default", common.ClonerServiceAccountName)
+		Expect(sa.AutomountServiceAccountToken).ToNot(BeNil())
+		Expect(*sa.AutomountServiceAccountToken).To(BeTrue())
+	})
+})
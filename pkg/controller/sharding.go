@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"fmt"
+	"hash/fnv"
+	"os"
+	"strconv"
+)
+
+const (
+	// ShardIndexVar provides a constant to capture our env variable "SHARD_INDEX"
+	ShardIndexVar = "SHARD_INDEX"
+	// ShardCountVar provides a constant to capture our env variable "SHARD_COUNT"
+	ShardCountVar = "SHARD_COUNT"
+)
+
+// ShardConfig partitions namespaced objects between cdi-controller replicas.
+// Namespaces are assigned to shards by hash, so each replica owns a stable
+// subset of the DataVolumes in the cluster. The zero value disables sharding.
+type ShardConfig struct {
+	// Index is the shard handled by this replica
+	Index int
+	// Count is the total number of shards
+	Count int
+}
+
+// NewShardConfigFromEnv returns the ShardConfig for this replica based on the
+// SHARD_INDEX and SHARD_COUNT environment variables
+func NewShardConfigFromEnv() (ShardConfig, error) {
+	config := ShardConfig{}
+
+	countVal := os.Getenv(ShardCountVar)
+	if countVal == "" {
+		return config, nil
+	}
+
+	count, err := strconv.Atoi(countVal)
+	if err != nil {
+		return config, fmt.Errorf("invalid %s %q: %v", ShardCountVar, countVal, err)
+	}
+
+	index, err := strconv.Atoi(os.Getenv(ShardIndexVar))
+	if err != nil {
+		return config, fmt.Errorf("invalid %s %q: %v", ShardIndexVar, os.Getenv(ShardIndexVar), err)
+	}
+
+	if count < 1 || index < 0 || index >= count {
+		return config, fmt.Errorf("shard index %d out of range for %d shards", index, count)
+	}
+
+	config.Index = index
+	config.Count = count
+	return config, nil
+}
+
+// Primary returns true for the shard running the singleton controllers
+func (s ShardConfig) Primary() bool {
+	return s.Index == 0
+}
+
+// OwnsNamespace returns true when objects in the given namespace are
+// reconciled by this shard
+func (s ShardConfig) OwnsNamespace(namespace string) bool {
+	if s.Count <= 1 {
+		return true
+	}
+	h := fnv.New32a()
+	h.Write([]byte(namespace))
+	return int(h.Sum32()%uint32(s.Count)) == s.Index
+}
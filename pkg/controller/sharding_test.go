@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"fmt"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ShardConfig", func() {
+
+	It("Should own every namespace when sharding is disabled", func() {
+		config := ShardConfig{}
+		Expect(config.OwnsNamespace("default")).To(BeTrue())
+		Expect(config.OwnsNamespace("other")).To(BeTrue())
+		Expect(config.Primary()).To(BeTrue())
+	})
+
+	It("Should assign each namespace to exactly one shard", func() {
+		shards := []ShardConfig{
+			{Index: 0, Count: 3},
+			{Index: 1, Count: 3},
+			{Index: 2, Count: 3},
+		}
+		for i := 0; i < 100; i++ {
+			namespace := fmt.Sprintf("namespace-%d", i)
+			owners := 0
+			for _, shard := range shards {
+				if shard.OwnsNamespace(namespace) {
+					owners++
+				}
+			}
+			Expect(owners).To(Equal(1))
+		}
+	})
+
+	It("Should assign namespaces consistently", func() {
+		shard := ShardConfig{Index: 1, Count: 4}
+		owned := shard.OwnsNamespace("default")
+		for i := 0; i < 10; i++ {
+			Expect(shard.OwnsNamespace("default")).To(Equal(owned))
+		}
+	})
+})
+
+var _ = Describe("NewShardConfigFromEnv", func() {
+
+	AfterEach(func() {
+		os.Unsetenv(ShardIndexVar)
+		os.Unsetenv(ShardCountVar)
+	})
+
+	It("Should disable sharding when the variables are not set", func() {
+		config, err := NewShardConfigFromEnv()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(config.Count).To(Equal(0))
+	})
+
+	It("Should parse a valid shard configuration", func() {
+		os.Setenv(ShardCountVar, "3")
+		os.Setenv(ShardIndexVar, "2")
+		config, err := NewShardConfigFromEnv()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(config.Index).To(Equal(2))
+		Expect(config.Count).To(Equal(3))
+		Expect(config.Primary()).To(BeFalse())
+	})
+
+	It("Should reject an out of range shard index", func() {
+		os.Setenv(ShardCountVar, "3")
+		os.Setenv(ShardIndexVar, "3")
+		_, err := NewShardConfigFromEnv()
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Should reject a non numeric shard count", func() {
+		os.Setenv(ShardCountVar, "many")
+		os.Setenv(ShardIndexVar, "0")
+		_, err := NewShardConfigFromEnv()
+		Expect(err).To(HaveOccurred())
+	})
+})
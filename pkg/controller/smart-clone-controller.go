@@ -52,7 +52,7 @@ func NewSmartCloneController(mgr manager.Manager, log logr.Logger, installerLabe
 		installerLabels: installerLabels,
 	}
 	smartCloneController, err := controller.New("smartclone-controller", mgr, controller.Options{
-		Reconciler: reconciler,
+		Reconciler: NewInstrumentedReconciler("smartclone-controller", reconciler),
 	})
 	if err != nil {
 		return nil, err
@@ -243,6 +243,8 @@ func (r *SmartCloneReconciler) reconcileSnapshot(log logr.Logger, snapshot *snap
 		r.emitEvent(snapshot, event)
 	}
 
+	updateClonePhaseProgress(string(dataVolume.UID), cdiv1.SmartClonePVCInProgress)
+
 	log.V(3).Info("Creating PVC from snapshot", "pvc.Namespace", newPvc.Namespace, "pvc.Name", newPvc.Name)
 	if err := r.client.Create(context.TODO(), newPvc); err != nil {
 		if errQuotaExceeded(err) {
@@ -30,13 +30,24 @@ import (
 	"kubevirt.io/containerized-data-importer/pkg/util"
 )
 
+const (
+	// reasonNoClaimPropertySets is the incomplete profile reason when no claim property sets are reported at all
+	reasonNoClaimPropertySets = "NoClaimPropertySets"
+	// reasonMissingAccessModes is the incomplete profile reason when a claim property set has no access modes
+	reasonMissingAccessModes = "MissingAccessModes"
+	// reasonMissingVolumeMode is the incomplete profile reason when a claim property set has no volume mode
+	reasonMissingVolumeMode = "MissingVolumeMode"
+)
+
 var (
-	// IncompleteProfileGauge is the metric we use to alert about incomplete storage profiles
-	IncompleteProfileGauge = prometheus.NewGauge(
+	// IncompleteProfileGauge is the metric we use to alert about incomplete storage profiles,
+	// labeled per profile so alerts can point at the storage class that needs attention
+	IncompleteProfileGauge = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: monitoring.MetricOptsList[monitoring.IncompleteProfile].Name,
 			Help: monitoring.MetricOptsList[monitoring.IncompleteProfile].Help,
-		})
+		},
+		[]string{"storageclass", "provisioner", "reason"})
 )
 
 // StorageProfileReconciler members
@@ -54,6 +65,15 @@ func (r *StorageProfileReconciler) Reconcile(_ context.Context, req reconcile.Re
 	log := r.log.WithValues("StorageProfile", req.NamespacedName)
 	log.Info("reconciling StorageProfile")
 
+	paused, err := ReconcilePaused(r.client, "")
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if paused {
+		log.V(1).Info("Reconciliation is paused, requeueing")
+		return reconcile.Result{RequeueAfter: pausedRequeueDelay}, nil
+	}
+
 	storageClass := &storagev1.StorageClass{}
 	if err := r.client.Get(context.TODO(), req.NamespacedName, storageClass); err != nil {
 		if k8serrors.IsNotFound(err) {
@@ -83,6 +103,8 @@ func (r *StorageProfileReconciler) reconcileStorageProfile(sc *storagev1.Storage
 	storageProfile.Status.StorageClass = &sc.Name
 	storageProfile.Status.Provisioner = &sc.Provisioner
 	storageProfile.Status.CloneStrategy = r.reconcileCloneStrategy(sc, storageProfile.Spec.CloneStrategy)
+	storageProfile.Status.DataImportCacheMode = storageProfile.Spec.DataImportCacheMode
+	storageProfile.Status.SupportsReflink = storageProfile.Spec.SupportsReflink
 
 	var claimPropertySets []cdiv1.ClaimPropertySet
 
@@ -100,6 +122,8 @@ func (r *StorageProfileReconciler) reconcileStorageProfile(sc *storagev1.Storage
 	}
 
 	storageProfile.Status.ClaimPropertySets = claimPropertySets
+	supportsRWXBlock := claimPropertySetsSupportRWXBlock(claimPropertySets)
+	storageProfile.Status.SupportsRWXBlock = &supportsRWXBlock
 
 	util.SetRecommendedLabels(storageProfile, r.installerLabels, "cdi-controller")
 	if err := r.updateStorageProfile(prevStorageProfile, storageProfile, log); err != nil {
@@ -210,17 +234,24 @@ func isNoProvisioner(name string, cl client.Client) bool {
 }
 
 func (r *StorageProfileReconciler) checkIncompleteProfiles() error {
-	numIncomplete := 0
 	storageProfileList := &cdiv1.StorageProfileList{}
 	if err := r.client.List(context.TODO(), storageProfileList); err != nil {
 		return err
 	}
+	IncompleteProfileGauge.Reset()
 	for _, profile := range storageProfileList.Items {
-		if isIncomplete(profile.Status.ClaimPropertySets) {
-			numIncomplete++
+		if reason := incompleteProfileReason(profile.Status.ClaimPropertySets); reason != "" {
+			storageClass := ""
+			if profile.Status.StorageClass != nil {
+				storageClass = *profile.Status.StorageClass
+			}
+			provisioner := ""
+			if profile.Status.Provisioner != nil {
+				provisioner = *profile.Status.Provisioner
+			}
+			IncompleteProfileGauge.WithLabelValues(storageClass, provisioner, reason).Set(1)
 		}
 	}
-	IncompleteProfileGauge.Set(float64(numIncomplete))
 
 	return nil
 }
@@ -262,7 +293,7 @@ func NewStorageProfileController(mgr manager.Manager, log logr.Logger, installer
 	storageProfileController, err := controller.New(
 		"storageprofile-controller",
 		mgr,
-		controller.Options{Reconciler: reconciler})
+		controller.Options{Reconciler: NewInstrumentedReconciler("storageprofile-controller", reconciler)})
 	if err != nil {
 		return nil, err
 	}
@@ -310,16 +341,40 @@ func scName(obj client.Object) string {
 	return obj.(*v1.PersistentVolume).Spec.StorageClassName
 }
 
-func isIncomplete(sets []cdiv1.ClaimPropertySet) bool {
-	if len(sets) > 0 {
-		for _, cps := range sets {
-			if len(cps.AccessModes) == 0 || cps.VolumeMode == nil {
+// claimPropertySetsSupportRWXBlock tells whether the claim property sets include
+// ReadWriteMany access with block volume mode, needed by KubeVirt for live migration
+func claimPropertySetsSupportRWXBlock(sets []cdiv1.ClaimPropertySet) bool {
+	for _, cps := range sets {
+		if cps.VolumeMode == nil || *cps.VolumeMode != v1.PersistentVolumeBlock {
+			continue
+		}
+		for _, accessMode := range cps.AccessModes {
+			if accessMode == v1.ReadWriteMany {
 				return true
 			}
 		}
-	} else {
-		return true
 	}
-
 	return false
 }
+
+func isIncomplete(sets []cdiv1.ClaimPropertySet) bool {
+	return incompleteProfileReason(sets) != ""
+}
+
+// incompleteProfileReason returns the reason the claim property sets are incomplete,
+// or an empty string when they are complete
+func incompleteProfileReason(sets []cdiv1.ClaimPropertySet) string {
+	if len(sets) == 0 {
+		return reasonNoClaimPropertySets
+	}
+	for _, cps := range sets {
+		if len(cps.AccessModes) == 0 {
+			return reasonMissingAccessModes
+		}
+		if cps.VolumeMode == nil {
+			return reasonMissingVolumeMode
+		}
+	}
+
+	return ""
+}
@@ -23,6 +23,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	dto "github.com/prometheus/client_model/go"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -106,6 +107,18 @@ var _ = Describe("Storage profile controller reconcile loop", func() {
 		Expect(incomplete).To(BeFalse())
 	})
 
+	It("Should set the incomplete profile metric labeled with the storage class and reason", func() {
+		scProvisioner := "unknown-provisioner"
+		reconciler := createStorageProfileReconciler(createStorageClassWithProvisioner(storageClassName, map[string]string{AnnDefaultStorageClass: "true"}, map[string]string{}, scProvisioner))
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: storageClassName}})
+		Expect(err).ToNot(HaveOccurred())
+
+		metric := &dto.Metric{}
+		err = IncompleteProfileGauge.WithLabelValues(storageClassName, scProvisioner, reasonNoClaimPropertySets).Write(metric)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(metric.Gauge.GetValue()).To(Equal(float64(1)))
+	})
+
 	It("Should delete storage profile when corresponding storage class gets deleted", func() {
 		storageClass := createStorageClass(storageClassName, map[string]string{AnnDefaultStorageClass: "true"})
 		reconciler := createStorageProfileReconciler(storageClass)
@@ -161,6 +174,25 @@ var _ = Describe("Storage profile controller reconcile loop", func() {
 		Expect(sp.Status.ClaimPropertySets).To(Equal(claimPropertySets))
 	})
 
+	table.DescribeTable("Should report RWX-Block support in the storage profile status", func(scProvisioner string, expectedSupport bool) {
+		reconciler := createStorageProfileReconciler(createStorageClassWithProvisioner(storageClassName, map[string]string{AnnDefaultStorageClass: "true"}, map[string]string{}, scProvisioner))
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: storageClassName}})
+		Expect(err).ToNot(HaveOccurred())
+		storageProfileList := &cdiv1.StorageProfileList{}
+		err = reconciler.client.List(context.TODO(), storageProfileList, &client.ListOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(storageProfileList.Items).To(HaveLen(1))
+		sp := storageProfileList.Items[0]
+		Expect(sp.Status.SupportsRWXBlock).ToNot(BeNil())
+		Expect(*sp.Status.SupportsRWXBlock).To(Equal(expectedSupport))
+	},
+		table.Entry("ceph-rbd", "rook-ceph.rbd.csi.ceph.com", true),
+		table.Entry("portworx", "pxd.openstorage.org", true),
+		table.Entry("linstor", "linstor.csi.linbit.com", true),
+		table.Entry("hostpath-provisioner", "kubevirt.io/hostpath-provisioner", false),
+		table.Entry("unknown provisioner", "unknown-provisioner", false),
+	)
+
 	It("Should find storage capabilities for no-provisioner LSO storage class", func() {
 		storageClass := createStorageClassWithProvisioner(storageClassName, map[string]string{AnnDefaultStorageClass: "true"}, lsoLabels, "kubernetes.io/no-provisioner")
 		pv := CreatePv("my-pv", storageClassName)
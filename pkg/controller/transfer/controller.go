@@ -39,6 +39,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	cdicontroller "kubevirt.io/containerized-data-importer/pkg/controller"
 )
 
 const (
@@ -62,6 +63,7 @@ type objectTransferHandler struct {
 // ObjectTransferReconciler members
 type ObjectTransferReconciler struct {
 	Client          client.Client
+	UncachedClient  client.Client
 	Recorder        record.EventRecorder
 	Scheme          *runtime.Scheme
 	Log             logr.Logger
@@ -85,11 +87,19 @@ func getTransferTargetNamespace(ot *cdiv1.ObjectTransfer) string {
 }
 
 // NewObjectTransferController creates a new instance of the ObjectTransfer controller.
-func NewObjectTransferController(mgr manager.Manager, log logr.Logger, installerLabels map[string]string) (controller.Controller, error) {
+func NewObjectTransferController(mgr manager.Manager, log logr.Logger, installerLabels map[string]string, maxParallelReconciles int) (controller.Controller, error) {
 	name := "transfer-controller"
+	uncachedClient, err := client.New(mgr.GetConfig(), client.Options{
+		Scheme: mgr.GetScheme(),
+		Mapper: mgr.GetRESTMapper(),
+	})
+	if err != nil {
+		return nil, err
+	}
 	client := mgr.GetClient()
 	reconciler := &ObjectTransferReconciler{
 		Client:          client,
+		UncachedClient:  uncachedClient,
 		Scheme:          mgr.GetScheme(),
 		Log:             log.WithName(name),
 		Recorder:        mgr.GetEventRecorderFor(name),
@@ -97,7 +107,8 @@ func NewObjectTransferController(mgr manager.Manager, log logr.Logger, installer
 	}
 
 	ctrl, err := controller.New(name, mgr, controller.Options{
-		Reconciler: reconciler,
+		MaxConcurrentReconciles: maxParallelReconciles,
+		Reconciler:              cdicontroller.NewInstrumentedReconciler(name, reconciler),
 	})
 	if err != nil {
 		return nil, err
@@ -123,6 +134,13 @@ func (r *ObjectTransferReconciler) logger(ot *cdiv1.ObjectTransfer) logr.Logger
 
 // Reconcile the reconcile loop for the data volumes.
 func (r *ObjectTransferReconciler) Reconcile(_ context.Context, req reconcile.Request) (reconcile.Result, error) {
+	if paused, err := cdicontroller.ReconcilePaused(r.Client, req.Namespace); err != nil {
+		return reconcile.Result{}, err
+	} else if paused {
+		r.Log.V(1).Info("Reconciliation is paused, requeueing", "objectTransfer", req.Name)
+		return reconcile.Result{RequeueAfter: time.Minute}, nil
+	}
+
 	ot := &cdiv1.ObjectTransfer{}
 	if err := r.Client.Get(context.TODO(), req.NamespacedName, ot); err != nil {
 		if errors.IsNotFound(err) {
@@ -65,10 +65,11 @@ func createReconciler(objects ...client.Object) *transfer.ObjectTransferReconcil
 	cl := fake.NewFakeClientWithScheme(s, runtimeObjects...)
 
 	return &transfer.ObjectTransferReconciler{
-		Client:   cl,
-		Scheme:   s,
-		Log:      logf.Log.WithName("transfer-controller-test"),
-		Recorder: record.NewFakeRecorder(10),
+		Client:         cl,
+		UncachedClient: cl,
+		Scheme:         s,
+		Log:            logf.Log.WithName("transfer-controller-test"),
+		Recorder:       record.NewFakeRecorder(10),
 		InstallerLabels: map[string]string{
 			common.AppKubernetesPartOfLabel:  "testing",
 			common.AppKubernetesVersionLabel: "v0.0.0-tests",
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 
@@ -35,21 +36,73 @@ func (h *dataVolumeTransferHandler) ReconcilePending(ot *cdiv1.ObjectTransfer) (
 		return 0, nil
 	}
 
+	resume := false
 	if dv.Status.Phase != cdiv1.Succeeded {
-		// will reconcile again when dv is updated
-		if err := h.reconciler.setAndUpdateCompleteCondition(ot, corev1.ConditionFalse, "Source not populated", ""); err != nil {
-			return 0, err
+		if !isResumableImport(dv) {
+			// will reconcile again when dv is updated
+			if err := h.reconciler.setAndUpdateCompleteCondition(ot, corev1.ConditionFalse, "Source not populated", ""); err != nil {
+				return 0, err
+			}
+
+			return 0, nil
 		}
+		resume = true
+	}
 
-		return 0, nil
+	pvcName := cdicontroller.GetDataVolumeClaimName(dv)
+
+	if resume {
+		pvc := &corev1.PersistentVolumeClaim{}
+		pvcExists, err := h.reconciler.getResource(dv.Namespace, pvcName, pvc)
+		if err != nil {
+			return 0, h.reconciler.setCompleteConditionError(ot, err)
+		}
+
+		if !pvcExists {
+			if err := h.reconciler.setAndUpdateCompleteCondition(ot, corev1.ConditionFalse, "Source DV has no PVC", ""); err != nil {
+				return 0, err
+			}
+
+			return 0, nil
+		}
+
+		if pvc.Annotations[cdicontroller.AnnImportPaused] != "true" {
+			if pvc.Annotations == nil {
+				pvc.Annotations = make(map[string]string)
+			}
+			pvc.Annotations[cdicontroller.AnnImportPaused] = "true"
+			if err := h.reconciler.updateResource(ot, pvc); err != nil {
+				return 0, h.reconciler.setCompleteConditionError(ot, err)
+			}
+		}
 	}
 
-	pods, err := cdicontroller.GetPodsUsingPVCs(h.reconciler.Client, dv.Namespace, sets.NewString(cdicontroller.GetDataVolumeClaimName(dv)), false)
+	pods, err := cdicontroller.GetPodsUsingPVCs(h.reconciler.UncachedClient, dv.Namespace, sets.NewString(pvcName), false)
 	if err != nil {
 		return 0, h.reconciler.setCompleteConditionError(ot, err)
 	}
 
 	if len(pods) > 0 {
+		if resume {
+			// stop the paused worker pods so the PVC can be moved,
+			// the import resumes in the target namespace
+			for i := range pods {
+				pod := &pods[i]
+				if pod.DeletionTimestamp != nil || pod.Labels[common.CDILabelKey] != common.CDILabelValue {
+					continue
+				}
+				if err := h.reconciler.Client.Delete(context.TODO(), pod); err != nil && !k8serrors.IsNotFound(err) {
+					return 0, h.reconciler.setCompleteConditionError(ot, err)
+				}
+			}
+
+			if err := h.reconciler.setAndUpdateCompleteCondition(ot, corev1.ConditionFalse, "Waiting for importer pod to stop", ""); err != nil {
+				return 0, err
+			}
+
+			return defaultRequeue, nil
+		}
+
 		if err := h.reconciler.setAndUpdateCompleteCondition(ot, corev1.ConditionFalse, "Pods using DataVolume PVC", ""); err != nil {
 			return 0, err
 		}
@@ -60,12 +113,25 @@ func (h *dataVolumeTransferHandler) ReconcilePending(ot *cdiv1.ObjectTransfer) (
 	dv2 := dv.DeepCopy()
 	dv2.Status = cdiv1.DataVolumeStatus{}
 	data := map[string]string{
-		"pvcName": cdicontroller.GetDataVolumeClaimName(dv),
+		"pvcName": pvcName,
+	}
+	if resume {
+		data["resumeImport"] = "true"
 	}
 
 	return 0, h.reconciler.pendingHelper(ot, dv2, data)
 }
 
+// isResumableImport tells whether the import of the DataVolume can be paused
+// and resumed in the target namespace
+func isResumableImport(dv *cdiv1.DataVolume) bool {
+	switch dv.Status.Phase {
+	case cdiv1.ImportScheduled, cdiv1.ImportInProgress:
+		return true
+	}
+	return false
+}
+
 func (h *dataVolumeTransferHandler) ReconcileRunning(ot *cdiv1.ObjectTransfer) (time.Duration, error) {
 	dv := &cdiv1.DataVolume{}
 	dvExists, err := h.reconciler.getSourceResource(ot, dv)
@@ -92,6 +158,7 @@ func (h *dataVolumeTransferHandler) ReconcileRunning(ot *cdiv1.ObjectTransfer) (
 	}
 
 	pvcName := ot.Status.Data["pvcName"]
+	resume := ot.Status.Data["resumeImport"] == "true"
 
 	if !targetExists && !pvcTransferExists {
 		targetNamespace := getTransferTargetNamespace(ot)
@@ -152,8 +219,10 @@ func (h *dataVolumeTransferHandler) ReconcileRunning(ot *cdiv1.ObjectTransfer) (
 			return 0, nil
 		}
 
-		if err := h.addPopulatedAnnotation(ot, pvc); err != nil {
-			return 0, h.reconciler.setCompleteConditionError(ot, err)
+		if !resume {
+			if err := h.addPopulatedAnnotation(ot, pvc); err != nil {
+				return 0, h.reconciler.setCompleteConditionError(ot, err)
+			}
 		}
 	}
 
@@ -164,6 +233,14 @@ func (h *dataVolumeTransferHandler) ReconcileRunning(ot *cdiv1.ObjectTransfer) (
 		}
 	}
 
+	if resume {
+		// the transferred PVC keeps its import progress annotations, handing
+		// it to the target DataVolume lets the import pick up where it left off
+		if err := h.resumeTargetImport(ot, target); err != nil {
+			return 0, h.reconciler.setCompleteConditionError(ot, err)
+		}
+	}
+
 	if target.Status.Phase != cdiv1.Succeeded {
 		ot.Status.Phase = cdiv1.ObjectTransferRunning
 		if err := h.reconciler.setAndUpdateCompleteCondition(ot, corev1.ConditionFalse, "Waiting for target DataVolume", ""); err != nil {
@@ -238,6 +315,43 @@ func (h *dataVolumeTransferHandler) deleteDataVolume(ot *cdiv1.ObjectTransfer, d
 	return 0, h.reconciler.setCompleteConditionRunning(ot)
 }
 
+// resumeTargetImport hands the transferred PVC to the target DataVolume and
+// unpauses the import
+func (h *dataVolumeTransferHandler) resumeTargetImport(ot *cdiv1.ObjectTransfer, target *cdiv1.DataVolume) error {
+	pvc := &corev1.PersistentVolumeClaim{}
+	pvcExists, err := h.reconciler.getResource(getTransferTargetNamespace(ot), getTransferTargetName(ot), pvc)
+	if err != nil {
+		return err
+	}
+	if !pvcExists {
+		return fmt.Errorf("transferred PVC does not exist")
+	}
+
+	changed := false
+	if !metav1.IsControlledBy(pvc, target) {
+		t := true
+		pvc.OwnerReferences = append(pvc.OwnerReferences, metav1.OwnerReference{
+			APIVersion:         cdiv1.SchemeGroupVersion.String(),
+			Kind:               "DataVolume",
+			Name:               target.Name,
+			UID:                target.UID,
+			Controller:         &t,
+			BlockOwnerDeletion: &t,
+		})
+		changed = true
+	}
+
+	if _, ok := pvc.Annotations[cdicontroller.AnnImportPaused]; ok {
+		delete(pvc.Annotations, cdicontroller.AnnImportPaused)
+		changed = true
+	}
+
+	if changed {
+		return h.reconciler.updateResource(ot, pvc)
+	}
+	return nil
+}
+
 func (h *dataVolumeTransferHandler) addPopulatedAnnotation(ot *cdiv1.ObjectTransfer, pvc *corev1.PersistentVolumeClaim) error {
 	dvName := getTransferTargetName(ot)
 
@@ -22,6 +22,7 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -79,6 +80,81 @@ var _ = Describe("DataVolume Transfer Tests", func() {
 			checkCompleteFalse(xfer, "Pods using DataVolume PVC", "")
 		})
 
+		It("Should pause a running import", func() {
+			xfer := dvTransfer(cdiv1.ObjectTransferPending)
+			dv := createImportingDV()
+			pvc := createBoundPVC()
+			pvc.Name = dv.Name
+			pod := createPod(pvc.Name)
+			pod.Labels = map[string]string{common.CDILabelKey: common.CDILabelValue}
+
+			r := createReconciler(xfer, dv, pvc, pod)
+			_, err := r.Reconcile(context.TODO(), rr(xfer.Name))
+			Expect(err).ToNot(HaveOccurred())
+
+			err = getResource(r.Client, "", xfer.Name, xfer)
+			Expect(err).ToNot(HaveOccurred())
+			err = getResource(r.Client, pvc.Namespace, pvc.Name, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			err = getResource(r.Client, pod.Namespace, pod.Name, &corev1.Pod{})
+			Expect(err).To(HaveOccurred())
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+
+			Expect(pvc.Annotations["cdi.kubevirt.io/storage.import.paused"]).To(Equal("true"))
+			Expect(xfer.Status.Phase).To(Equal(cdiv1.ObjectTransferPending))
+			checkCompleteFalse(xfer, "Waiting for importer pod to stop", "")
+		})
+
+		It("Should become running for a paused import", func() {
+			xfer := dvTransfer(cdiv1.ObjectTransferPending)
+			dv := createImportingDV()
+			pvc := createBoundPVC()
+			pvc.Name = dv.Name
+			pvc.Annotations = map[string]string{
+				"cdi.kubevirt.io/storage.import.paused": "true",
+			}
+
+			r := createReconciler(xfer, dv, pvc)
+			_, err := r.Reconcile(context.TODO(), rr(xfer.Name))
+			Expect(err).ToNot(HaveOccurred())
+
+			err = getResource(r.Client, "", xfer.Name, xfer)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(xfer.Status.Phase).To(Equal(cdiv1.ObjectTransferRunning))
+			Expect(xfer.Status.Data["resumeImport"]).To(Equal("true"))
+			checkCompleteFalse(xfer, "Running", "")
+		})
+
+		It("Should resume the import on the target DataVolume", func() {
+			xfer := dvTransferResumeRunning()
+			pvcTransfer := internalPVCTransfer(xfer)
+			pvc := createBoundPVC()
+			pvc.Namespace = "target-ns"
+			pvc.Name = "target-dv"
+			pvc.Annotations = map[string]string{
+				"cdi.kubevirt.io/storage.import.paused": "true",
+			}
+			dv := &cdiv1.DataVolume{}
+
+			r := createReconciler(xfer, pvcTransfer, pvc)
+			_, err := r.Reconcile(context.TODO(), rr(xfer.Name))
+			Expect(err).ToNot(HaveOccurred())
+
+			err = getResource(r.Client, "", xfer.Name, xfer)
+			Expect(err).ToNot(HaveOccurred())
+			err = getResource(r.Client, pvc.Namespace, pvc.Name, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			err = getResource(r.Client, pvc.Namespace, pvc.Name, dv)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(pvc.Annotations).ToNot(HaveKey("cdi.kubevirt.io/storage.import.paused"))
+			Expect(pvc.Annotations).ToNot(HaveKey("cdi.kubevirt.io/storage.populatedFor"))
+			Expect(metav1.IsControlledBy(pvc, dv)).To(BeTrue())
+			Expect(xfer.Status.Phase).To(Equal(cdiv1.ObjectTransferRunning))
+			checkCompleteFalse(xfer, "Waiting for target DataVolume", "")
+		})
+
 		It("Should become running", func() {
 			xfer := dvTransfer(cdiv1.ObjectTransferPending)
 
@@ -269,6 +345,18 @@ func createPopulatedDV() *cdiv1.DataVolume {
 	return dv
 }
 
+func createImportingDV() *cdiv1.DataVolume {
+	dv := createUnpopulatedDV()
+	dv.Status.Phase = cdiv1.ImportInProgress
+	return dv
+}
+
+func dvTransferResumeRunning() *cdiv1.ObjectTransfer {
+	t := dvTransferRunning()
+	t.Status.Data["resumeImport"] = "true"
+	return t
+}
+
 func dvTransferRunning() *cdiv1.ObjectTransfer {
 	t := dvTransfer(cdiv1.ObjectTransferRunning)
 	dv := createPopulatedDV()
@@ -48,6 +48,7 @@ import (
 
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/util/cert"
 	"kubevirt.io/containerized-data-importer/pkg/util/cert/fetcher"
 	"kubevirt.io/containerized-data-importer/pkg/util/cert/generator"
 	"kubevirt.io/containerized-data-importer/pkg/util/naming"
@@ -67,7 +68,7 @@ const (
 
 	uploadServerClientName = "client.upload-server.cdi.kubevirt.io"
 
-	uploadServerCertDuration = 365 * 24 * time.Hour
+	defaultUploadServerCertDuration = 365 * 24 * time.Hour
 
 	// UploadSucceededPVC provides a const to indicate an import to the PVC failed
 	UploadSucceededPVC = "UploadSucceeded"
@@ -79,6 +80,7 @@ const (
 // UploadReconciler members
 type UploadReconciler struct {
 	client                 client.Client
+	uncachedClient         client.Client
 	recorder               record.EventRecorder
 	scheme                 *runtime.Scheme
 	log                    logr.Logger
@@ -90,6 +92,7 @@ type UploadReconciler struct {
 	clientCAFetcher        fetcher.CertBundleFetcher
 	featureGates           featuregates.FeatureGates
 	installerLabels        map[string]string
+	shard                  ShardConfig
 }
 
 // UploadPodArgs are the parameters required to create an upload pod
@@ -99,12 +102,18 @@ type UploadPodArgs struct {
 	ScratchPVCName                  string
 	ClientName                      string
 	FilesystemOverhead              string
+	CacheMode                       string
 	ServerCert, ServerKey, ClientCA []byte
 	Preallocation                   string
+	Rootless                        bool
 }
 
 // Reconcile the reconcile loop for the CDIConfig object.
 func (r *UploadReconciler) Reconcile(_ context.Context, req reconcile.Request) (reconcile.Result, error) {
+	if !r.shard.OwnsNamespace(req.Namespace) {
+		return reconcile.Result{}, nil
+	}
+
 	log := r.log.WithValues("PVC", req.NamespacedName)
 	log.V(1).Info("reconciling Upload PVCs")
 
@@ -148,12 +157,13 @@ func (r *UploadReconciler) Reconcile(_ context.Context, req reconcile.Request) (
 
 func (r *UploadReconciler) shouldReconcile(isUpload bool, isCloneTarget bool, pvc *v1.PersistentVolumeClaim, log logr.Logger) (bool, error) {
 	_, isImmediateBindingRequested := pvc.Annotations[AnnImmediateBinding]
-	waitForFirstConsumerEnabled, err := isWaitForFirstConsumerEnabled(isImmediateBindingRequested, r.featureGates)
+	waitForFirstConsumerEnabled, err := isWaitForFirstConsumerEnabled(isImmediateBindingRequested, pvc.Namespace, r.featureGates)
 	if err != nil {
 		return false, err
 	}
 
 	return (isUpload || isCloneTarget) &&
+			pvc.Annotations[AnnReflinkClone] != "true" &&
 			shouldHandlePvc(pvc, waitForFirstConsumerEnabled, log),
 		nil
 }
@@ -189,8 +199,10 @@ func (r *UploadReconciler) reconcilePVC(log logr.Logger, pvc *corev1.PersistentV
 		return reconcile.Result{}, err
 	}
 
+	var requeueAfter time.Duration
+
 	if pod == nil {
-		podsUsingPVC, err := GetPodsUsingPVCs(r.client, pvc.Namespace, sets.NewString(pvc.Name), false)
+		podsUsingPVC, err := GetPodsUsingPVCs(r.uncachedClient, pvc.Namespace, sets.NewString(pvc.Name), false)
 		if err != nil {
 			return reconcile.Result{}, err
 		}
@@ -217,6 +229,16 @@ func (r *UploadReconciler) reconcilePVC(log logr.Logger, pvc *corev1.PersistentV
 			return reconcile.Result{Requeue: true}, nil
 		}
 		pod, err = r.createUploadPodForPvc(pvc, podName, scratchPVCName, uploadClientName)
+		if err != nil {
+			if errBlockedByQuota(err) {
+				// park the upload instead of erroring in a tight loop, the
+				// ResourceQuota watch requeues the PVC when the quota changes
+				return reconcile.Result{}, r.setQuotaBlocked(pvcCopy, err)
+			}
+			return reconcile.Result{}, err
+		}
+	} else if pod.Status.Phase == v1.PodRunning {
+		requeueAfter, err = r.renewCertSecretIfNeeded(pod)
 		if err != nil {
 			return reconcile.Result{}, err
 		}
@@ -252,7 +274,21 @@ func (r *UploadReconciler) reconcilePVC(log logr.Logger, pvc *corev1.PersistentV
 		}
 	}
 
-	return reconcile.Result{}, nil
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// setQuotaBlocked marks the PVC as blocked by quota, which surfaces as a
+// BlockedByQuota running condition on the owning DataVolume
+func (r *UploadReconciler) setQuotaBlocked(pvc *v1.PersistentVolumeClaim, cause error) error {
+	r.recorder.Event(pvc, corev1.EventTypeWarning, ErrExceededQuota, cause.Error())
+	anno := pvc.GetAnnotations()
+	if anno[AnnRunningConditionReason] == BlockedByQuota {
+		return nil
+	}
+	anno[AnnRunningCondition] = "false"
+	anno[AnnRunningConditionMessage] = cause.Error()
+	anno[AnnRunningConditionReason] = BlockedByQuota
+	return r.updatePVC(pvc)
 }
 
 func (r *UploadReconciler) updatePvcPodName(pvc *v1.PersistentVolumeClaim, podName string, log logr.Logger) error {
@@ -350,7 +386,12 @@ func (r *UploadReconciler) findUploadPodForPvc(pvc *v1.PersistentVolumeClaim, lo
 }
 
 func (r *UploadReconciler) createUploadPodForPvc(pvc *v1.PersistentVolumeClaim, podName, scratchPVCName, clientName string) (*v1.Pod, error) {
-	serverCert, serverKey, err := r.serverCertGenerator.MakeServerCert(pvc.Namespace, naming.GetServiceNameFromResourceName(podName), uploadServerCertDuration)
+	certDuration, err := r.getUploadServerCertDuration()
+	if err != nil {
+		return nil, err
+	}
+
+	serverCert, serverKey, err := r.serverCertGenerator.MakeServerCert(pvc.Namespace, naming.GetServiceNameFromResourceName(podName), certDuration)
 	if err != nil {
 		return nil, err
 	}
@@ -365,21 +406,33 @@ func (r *UploadReconciler) createUploadPodForPvc(pvc *v1.PersistentVolumeClaim,
 		return nil, err
 	}
 
+	cacheMode, err := GetDataImportCacheMode(r.client, pvc)
+	if err != nil {
+		return nil, err
+	}
+
 	preallocationRequested := false
 	if preallocation, err := strconv.ParseBool(getValueFromAnnotation(pvc, AnnPreallocationRequested)); err == nil {
 		preallocationRequested = preallocation
 	}
 
+	rootless, err := r.featureGates.RootlessWorkloadsEnabled()
+	if err != nil {
+		return nil, err
+	}
+
 	args := UploadPodArgs{
 		Name:               podName,
 		PVC:                pvc,
 		ScratchPVCName:     scratchPVCName,
 		ClientName:         clientName,
 		FilesystemOverhead: string(fsOverhead),
+		CacheMode:          cacheMode,
 		ServerCert:         serverCert,
 		ServerKey:          serverKey,
 		ClientCA:           clientCA,
 		Preallocation:      strconv.FormatBool(preallocationRequested),
+		Rootless:           rootless,
 	}
 
 	r.log.V(3).Info("Creating upload pod")
@@ -392,6 +445,13 @@ func (r *UploadReconciler) createUploadPodForPvc(pvc *v1.PersistentVolumeClaim,
 		return nil, err
 	}
 
+	if GetManageNetworkPolicies(r.client) {
+		serviceName := naming.GetServiceNameFromResourceName(podName)
+		if err := ensureNetworkPolicy(r.client, MakeUploadServerNetworkPolicy(pvc, podName, serviceName)); err != nil {
+			return nil, err
+		}
+	}
+
 	return pod, nil
 }
 
@@ -533,7 +593,7 @@ func (r *UploadReconciler) makeUploadServiceSpec(name string, pvc *v1.Persistent
 func (r *UploadReconciler) createUploadPod(args UploadPodArgs) (*v1.Pod, error) {
 	ns := args.PVC.Namespace
 
-	podResourceRequirements, err := GetDefaultPodResourceRequirements(r.client)
+	podResourceRequirements, err := GetWorkloadPodResourceRequirements(r.client, WorkloadTypeUploadServer)
 	if err != nil {
 		return nil, err
 	}
@@ -543,8 +603,29 @@ func (r *UploadReconciler) createUploadPod(args UploadPodArgs) (*v1.Pod, error)
 		return nil, err
 	}
 
-	pod := r.makeUploadPodSpec(args, podResourceRequirements, workloadNodePlacement)
+	image, pullPolicy, err := GetWorkerImageAndPullPolicy(r.client, r.image, r.pullPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	pod := r.makeUploadPodSpec(args, image, pullPolicy, podResourceRequirements, workloadNodePlacement)
 	util.SetRecommendedLabels(pod, r.installerLabels, "cdi-controller")
+	if err := ApplyWorkloadMetadata(r.client, pod); err != nil {
+		return nil, err
+	}
+	if err := ApplyTracingEnv(r.client, pod); err != nil {
+		return nil, err
+	}
+	if err := ApplySecurityProfile(r.client, pod); err != nil {
+		return nil, err
+	}
+	if err := ApplyArchitectureAffinity(r.client, pod); err != nil {
+		return nil, err
+	}
+
+	if err := ensureWorkerServiceAccount(r.client, pod.Namespace, common.UploadServerServiceAccountName); err != nil {
+		return nil, err
+	}
 
 	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: args.Name, Namespace: ns}, pod); err != nil {
 		if !k8serrors.IsNotFound(err) {
@@ -595,11 +676,80 @@ func (r *UploadReconciler) ensureCertSecret(args UploadPodArgs, pod *v1.Pod) err
 	return nil
 }
 
+// getUploadServerCertDuration returns the lifetime for upload server certs,
+// honoring the server cert config in the CDI CR when set
+func (r *UploadReconciler) getUploadServerCertDuration() (time.Duration, error) {
+	cr, err := GetActiveCDI(r.client)
+	if err != nil {
+		return 0, err
+	}
+
+	if cr == nil || cr.Spec.CertConfig == nil || cr.Spec.CertConfig.Server == nil || cr.Spec.CertConfig.Server.Duration == nil {
+		return defaultUploadServerCertDuration, nil
+	}
+
+	return cr.Spec.CertConfig.Server.Duration.Duration, nil
+}
+
+// renewCertSecretIfNeeded regenerates the upload server cert secret once the
+// current cert is past the halfway point of its lifetime. The secret is
+// mounted in the upload server pod, which serves the new cert on subsequent
+// TLS handshakes without dropping connections that are already established.
+// Returns the time until the next renewal is due.
+func (r *UploadReconciler) renewCertSecretIfNeeded(pod *v1.Pod) (time.Duration, error) {
+	secret := &corev1.Secret{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}, secret); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	certs, err := cert.ParseCertsPEM(secret.Data["tls.crt"])
+	if err != nil {
+		return 0, errors.Wrap(err, "error parsing upload server cert")
+	}
+
+	now := time.Now()
+	renewTime := certs[0].NotBefore.Add(certs[0].NotAfter.Sub(certs[0].NotBefore) / 2)
+	if now.Before(renewTime) {
+		return renewTime.Sub(now), nil
+	}
+
+	certDuration, err := r.getUploadServerCertDuration()
+	if err != nil {
+		return 0, err
+	}
+
+	serverCert, serverKey, err := r.serverCertGenerator.MakeServerCert(pod.Namespace, naming.GetServiceNameFromResourceName(pod.Name), certDuration)
+	if err != nil {
+		return 0, err
+	}
+
+	secret.Data["tls.key"] = serverKey
+	secret.Data["tls.crt"] = serverCert
+
+	if err := r.client.Update(context.TODO(), secret); err != nil {
+		return 0, errors.Wrap(err, "error updating cert secret")
+	}
+
+	r.log.V(1).Info("renewed upload server cert", "Namespace", pod.Namespace, "Name", pod.Name)
+	return certDuration / 2, nil
+}
+
 // NewUploadController creates a new instance of the upload controller.
-func NewUploadController(mgr manager.Manager, log logr.Logger, uploadImage, pullPolicy, verbose string, serverCertGenerator generator.CertGenerator, clientCAFetcher fetcher.CertBundleFetcher, installerLabels map[string]string) (controller.Controller, error) {
+func NewUploadController(mgr manager.Manager, log logr.Logger, uploadImage, pullPolicy, verbose string, serverCertGenerator generator.CertGenerator, clientCAFetcher fetcher.CertBundleFetcher, installerLabels map[string]string, shard ShardConfig) (controller.Controller, error) {
+	uncachedClient, err := client.New(mgr.GetConfig(), client.Options{
+		Scheme: mgr.GetScheme(),
+		Mapper: mgr.GetRESTMapper(),
+	})
+	if err != nil {
+		return nil, err
+	}
 	client := mgr.GetClient()
 	reconciler := &UploadReconciler{
 		client:              client,
+		uncachedClient:      uncachedClient,
 		scheme:              mgr.GetScheme(),
 		log:                 log.WithName("upload-controller"),
 		image:               uploadImage,
@@ -610,9 +760,10 @@ func NewUploadController(mgr manager.Manager, log logr.Logger, uploadImage, pull
 		clientCAFetcher:     clientCAFetcher,
 		featureGates:        featuregates.NewFeatureGates(client),
 		installerLabels:     installerLabels,
+		shard:               shard,
 	}
 	uploadController, err := controller.New("upload-controller", mgr, controller.Options{
-		Reconciler: reconciler,
+		Reconciler: NewInstrumentedReconciler("upload-controller", reconciler),
 	})
 	if err != nil {
 		return nil, err
@@ -641,6 +792,14 @@ func addUploadControllerWatches(mgr manager.Manager, uploadController controller
 	}); err != nil {
 		return err
 	}
+	// requeue uploads parked with the BlockedByQuota condition when the quota changes
+	if err := uploadController.Watch(&source.Kind{Type: &corev1.ResourceQuota{}}, handler.EnqueueRequestsFromMapFunc(
+		func(obj client.Object) []reconcile.Request {
+			return enqueueQuotaBlockedPvcs(mgr.GetClient(), obj.GetNamespace())
+		},
+	)); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -689,7 +848,7 @@ func createUploadServiceNameFromPvcName(pvc string) string {
 	return naming.GetServiceNameFromResourceName(createUploadResourceName(pvc))
 }
 
-func (r *UploadReconciler) makeUploadPodSpec(args UploadPodArgs, resourceRequirements *v1.ResourceRequirements, workloadNodePlacement *sdkapi.NodePlacement) *v1.Pod {
+func (r *UploadReconciler) makeUploadPodSpec(args UploadPodArgs, image, pullPolicy string, resourceRequirements *v1.ResourceRequirements, workloadNodePlacement *sdkapi.NodePlacement) *v1.Pod {
 	requestImageSize, _ := getRequestedImageSize(args.PVC)
 	serviceName := naming.GetServiceNameFromResourceName(args.Name)
 	fsGroup := common.QemuSubGid
@@ -717,30 +876,12 @@ func (r *UploadReconciler) makeUploadPodSpec(args UploadPodArgs, resourceRequire
 			Containers: []v1.Container{
 				{
 					Name:            common.UploadServerPodname,
-					Image:           r.image,
-					ImagePullPolicy: v1.PullPolicy(r.pullPolicy),
+					Image:           image,
+					ImagePullPolicy: v1.PullPolicy(pullPolicy),
 					Env: []v1.EnvVar{
 						{
-							Name: "TLS_KEY",
-							ValueFrom: &v1.EnvVarSource{
-								SecretKeyRef: &v1.SecretKeySelector{
-									LocalObjectReference: v1.LocalObjectReference{
-										Name: args.Name,
-									},
-									Key: "tls.key",
-								},
-							},
-						},
-						{
-							Name: "TLS_CERT",
-							ValueFrom: &v1.EnvVarSource{
-								SecretKeyRef: &v1.SecretKeySelector{
-									LocalObjectReference: v1.LocalObjectReference{
-										Name: args.Name,
-									},
-									Key: "tls.crt",
-								},
-							},
+							Name:  "TLS_CERT_DIR",
+							Value: common.UploadServerCertDir,
 						},
 						{
 							Name:  "CLIENT_CERT",
@@ -763,7 +904,7 @@ func (r *UploadReconciler) makeUploadPodSpec(args UploadPodArgs, resourceRequire
 							Value: args.Preallocation,
 						},
 					},
-					Args: []string{"-v=" + r.verbose},
+					Args: []string{"-v=" + getLogVerbosity(r.client, r.verbose)},
 					ReadinessProbe: &v1.Probe{
 						ProbeHandler: v1.ProbeHandler{
 							HTTPGet: &v1.HTTPGetAction{
@@ -779,7 +920,8 @@ func (r *UploadReconciler) makeUploadPodSpec(args UploadPodArgs, resourceRequire
 					},
 				},
 			},
-			RestartPolicy: v1.RestartPolicyOnFailure,
+			RestartPolicy:      v1.RestartPolicyOnFailure,
+			ServiceAccountName: common.UploadServerServiceAccountName,
 			Volumes: []v1.Volume{
 				{
 					Name: DataVolName,
@@ -790,6 +932,14 @@ func (r *UploadReconciler) makeUploadPodSpec(args UploadPodArgs, resourceRequire
 						},
 					},
 				},
+				{
+					Name: ServerCertVolName,
+					VolumeSource: v1.VolumeSource{
+						Secret: &v1.SecretVolumeSource{
+							SecretName: args.Name,
+						},
+					},
+				},
 			},
 			NodeSelector:      workloadNodePlacement.NodeSelector,
 			Tolerations:       workloadNodePlacement.Tolerations,
@@ -806,6 +956,13 @@ func (r *UploadReconciler) makeUploadPodSpec(args UploadPodArgs, resourceRequire
 		pod.Spec.Containers[0].Resources = *resourceRequirements
 	}
 
+	if args.CacheMode != "" {
+		pod.Spec.Containers[0].Env = append(pod.Spec.Containers[0].Env, v1.EnvVar{
+			Name:  common.QemuCacheMode,
+			Value: args.CacheMode,
+		})
+	}
+
 	if getVolumeMode(args.PVC) == v1.PersistentVolumeBlock {
 		pod.Spec.Containers[0].VolumeDevices = []v1.VolumeDevice{
 			{
@@ -826,6 +983,11 @@ func (r *UploadReconciler) makeUploadPodSpec(args UploadPodArgs, resourceRequire
 		}
 	}
 
+	pod.Spec.Containers[0].VolumeMounts = append(pod.Spec.Containers[0].VolumeMounts, v1.VolumeMount{
+		Name:      ServerCertVolName,
+		MountPath: common.UploadServerCertDir,
+	})
+
 	if args.ScratchPVCName != "" {
 		pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
 			Name: ScratchVolName,
@@ -842,6 +1004,10 @@ func (r *UploadReconciler) makeUploadPodSpec(args UploadPodArgs, resourceRequire
 			MountPath: common.ScratchDataDir,
 		})
 	}
+	if args.Rootless {
+		SetRootlessSecurityContext(pod, getVolumeMode(args.PVC) == v1.PersistentVolumeBlock)
+	}
+
 	SetPodPvcAnnotations(pod, args.PVC)
 	return pod
 }
@@ -18,6 +18,12 @@ package controller
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
 	"strings"
 	"time"
 
@@ -529,6 +535,64 @@ var _ = Describe("Update PVC", func() {
 	})
 })
 
+var _ = Describe("Upload server cert renewal", func() {
+
+	It("Should renew the cert secret when the cert is past half its lifetime", func() {
+		testPvc := createPvc("testPvc1", "default",
+			map[string]string{AnnUploadRequest: "", AnnPodPhase: string(corev1.PodRunning)}, nil)
+		pod := createUploadPod(testPvc)
+		pod.Status.Phase = corev1.PodRunning
+		secret := createUploadServerCertSecret(testPvc)
+		secret.Data["tls.crt"] = createCertPEM(time.Now().Add(-2*time.Hour), time.Now().Add(30*time.Minute))
+		reconciler := createUploadReconciler(testPvc, pod, createUploadService(testPvc), secret)
+
+		result, err := reconciler.reconcilePVC(reconciler.log, testPvc, false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+		By("Verifying the secret contains the regenerated cert")
+		updatedSecret := &corev1.Secret{}
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: secret.Name, Namespace: "default"}, updatedSecret)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updatedSecret.Data["tls.crt"]).To(Equal([]byte("foo")))
+		Expect(updatedSecret.Data["tls.key"]).To(Equal([]byte("bar")))
+	})
+
+	It("Should leave the cert secret alone before the halfway point", func() {
+		testPvc := createPvc("testPvc1", "default",
+			map[string]string{AnnUploadRequest: "", AnnPodPhase: string(corev1.PodRunning)}, nil)
+		pod := createUploadPod(testPvc)
+		pod.Status.Phase = corev1.PodRunning
+		secret := createUploadServerCertSecret(testPvc)
+		certBytes := createCertPEM(time.Now().Add(-30*time.Minute), time.Now().Add(10*time.Hour))
+		secret.Data["tls.crt"] = certBytes
+		reconciler := createUploadReconciler(testPvc, pod, createUploadService(testPvc), secret)
+
+		result, err := reconciler.reconcilePVC(reconciler.log, testPvc, false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+		updatedSecret := &corev1.Secret{}
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: secret.Name, Namespace: "default"}, updatedSecret)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updatedSecret.Data["tls.crt"]).To(Equal(certBytes))
+	})
+})
+
+func createCertPEM(notBefore, notAfter time.Time) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	Expect(err).ToNot(HaveOccurred())
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "cdi-upload-server"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	Expect(err).ToNot(HaveOccurred())
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
 func createUploadReconciler(objects ...runtime.Object) *UploadReconciler {
 	objs := []runtime.Object{}
 	objs = append(objs, objects...)
@@ -553,6 +617,7 @@ func createUploadReconciler(objects ...runtime.Object) *UploadReconciler {
 	// Create a ReconcileMemcached object with the scheme and fake client.
 	r := &UploadReconciler{
 		client:              cl,
+		uncachedClient:      cl,
 		scheme:              s,
 		log:                 uploadLog,
 		serverCertGenerator: &fakeCertGenerator{},
@@ -623,15 +688,15 @@ func createUploadClonePod(pvc *corev1.PersistentVolumeClaim, clientName string)
 							Name:      DataVolName,
 							MountPath: "/data",
 						},
-					},
-					Env: []corev1.EnvVar{
 						{
-							Name:  "TLS_KEY",
-							Value: "bar",
+							Name:      ServerCertVolName,
+							MountPath: common.UploadServerCertDir,
 						},
+					},
+					Env: []corev1.EnvVar{
 						{
-							Name:  "TLS_CERT",
-							Value: "foo",
+							Name:  "TLS_CERT_DIR",
+							Value: common.UploadServerCertDir,
 						},
 						{
 							Name:  "CLIENT_CERT",
@@ -673,6 +738,14 @@ func createUploadClonePod(pvc *corev1.PersistentVolumeClaim, clientName string)
 						},
 					},
 				},
+				{
+					Name: ServerCertVolName,
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName: name,
+						},
+					},
+				},
 			},
 		},
 	}
@@ -0,0 +1,172 @@
+/*
+Copyright 2023 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+)
+
+const (
+	uploadPopulatorControllerName = "upload-populator-controller"
+
+	// volumeUploadSourceKind is the dataSourceRef kind handled by this controller
+	volumeUploadSourceKind = "VolumeUploadSource"
+
+	// annPopulatedByUploadSource names the VolumeUploadSource that turned the PVC into an upload target
+	annPopulatedByUploadSource = "cdi.kubevirt.io/storage.populatedByUploadSource"
+
+	// UploadPopulationRequested is the event reason emitted once a PVC got handed over to the upload controller
+	UploadPopulationRequested = "UploadPopulationRequested"
+)
+
+// UploadPopulatorReconciler turns PVCs referencing a VolumeUploadSource into upload targets
+type UploadPopulatorReconciler struct {
+	client   client.Client
+	recorder record.EventRecorder
+	scheme   *runtime.Scheme
+	log      logr.Logger
+	shard    ShardConfig
+}
+
+// NewUploadPopulatorController creates a new instance of the upload populator controller
+func NewUploadPopulatorController(mgr manager.Manager, log logr.Logger, shard ShardConfig) (controller.Controller, error) {
+	reconciler := &UploadPopulatorReconciler{
+		client:   mgr.GetClient(),
+		scheme:   mgr.GetScheme(),
+		log:      log.WithName(uploadPopulatorControllerName),
+		recorder: mgr.GetEventRecorderFor(uploadPopulatorControllerName),
+		shard:    shard,
+	}
+	uploadPopulatorController, err := controller.New(uploadPopulatorControllerName, mgr, controller.Options{
+		Reconciler: NewInstrumentedReconciler(uploadPopulatorControllerName, reconciler),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := addUploadPopulatorControllerWatches(mgr, uploadPopulatorController); err != nil {
+		return nil, err
+	}
+	return uploadPopulatorController, nil
+}
+
+func addUploadPopulatorControllerWatches(mgr manager.Manager, uploadPopulatorController controller.Controller) error {
+	if err := uploadPopulatorController.Watch(&source.Kind{Type: &corev1.PersistentVolumeClaim{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+	// a VolumeUploadSource may show up after the PVCs referencing it
+	if err := uploadPopulatorController.Watch(&source.Kind{Type: &cdiv1.VolumeUploadSource{}}, handler.EnqueueRequestsFromMapFunc(
+		func(obj client.Object) []reconcile.Request {
+			return enqueuePvcsForUploadSource(mgr.GetClient(), obj.GetNamespace(), obj.GetName())
+		},
+	)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func enqueuePvcsForUploadSource(c client.Client, namespace, name string) []reconcile.Request {
+	pvcList := &corev1.PersistentVolumeClaimList{}
+	if err := c.List(context.TODO(), pvcList, &client.ListOptions{Namespace: namespace}); err != nil {
+		return nil
+	}
+	var reqs []reconcile.Request
+	for i := range pvcList.Items {
+		pvc := &pvcList.Items[i]
+		if isVolumeUploadSourceRef(pvc) && pvc.Spec.DataSourceRef.Name == name {
+			reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: pvc.Namespace, Name: pvc.Name}})
+		}
+	}
+	return reqs
+}
+
+// Reconcile the reconcile loop for PVCs populated by a VolumeUploadSource
+func (r *UploadPopulatorReconciler) Reconcile(_ context.Context, req reconcile.Request) (reconcile.Result, error) {
+	if !r.shard.OwnsNamespace(req.Namespace) {
+		return reconcile.Result{}, nil
+	}
+
+	log := r.log.WithValues("PVC", req.NamespacedName)
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := r.client.Get(context.TODO(), req.NamespacedName, pvc); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	if pvc.DeletionTimestamp != nil || !isVolumeUploadSourceRef(pvc) {
+		return reconcile.Result{}, nil
+	}
+	if _, ok := pvc.Annotations[AnnUploadRequest]; ok {
+		// already handed over to the upload controller
+		return reconcile.Result{}, nil
+	}
+
+	uploadSource := &cdiv1.VolumeUploadSource{}
+	sourceKey := types.NamespacedName{Name: pvc.Spec.DataSourceRef.Name, Namespace: pvc.Namespace}
+	if err := r.client.Get(context.TODO(), sourceKey, uploadSource); err != nil {
+		if k8serrors.IsNotFound(err) {
+			// wait for the VolumeUploadSource watch to requeue the PVC
+			log.V(1).Info("VolumeUploadSource not found", "name", sourceKey.Name)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	log.V(1).Info("Requesting upload for populated PVC", "uploadSource", uploadSource.Name)
+	AddAnnotation(pvc, AnnUploadRequest, "")
+	AddAnnotation(pvc, annPopulatedByUploadSource, uploadSource.Name)
+	// the upload server pod is the first consumer of the claim
+	AddAnnotation(pvc, AnnImmediateBinding, "")
+	if uploadSource.Spec.ContentType != "" {
+		AddAnnotation(pvc, AnnContentType, string(uploadSource.Spec.ContentType))
+	}
+	if uploadSource.Spec.Preallocation != nil {
+		AddAnnotation(pvc, AnnPreallocationRequested, strconv.FormatBool(*uploadSource.Spec.Preallocation))
+	}
+	if err := r.client.Update(context.TODO(), pvc); err != nil {
+		return reconcile.Result{}, err
+	}
+	r.recorder.Eventf(pvc, corev1.EventTypeNormal, UploadPopulationRequested,
+		"Upload into %s requested by VolumeUploadSource %s", pvc.Name, uploadSource.Name)
+	return reconcile.Result{}, nil
+}
+
+// isVolumeUploadSourceRef tells whether the PVC asks to be populated by a VolumeUploadSource
+func isVolumeUploadSourceRef(pvc *corev1.PersistentVolumeClaim) bool {
+	ref := pvc.Spec.DataSourceRef
+	return ref != nil &&
+		ref.APIGroup != nil && *ref.APIGroup == cdiv1.SchemeGroupVersion.Group &&
+		ref.Kind == volumeUploadSourceKind &&
+		ref.Name != ""
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2023 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+)
+
+var (
+	uploadPopulatorLog = logf.Log.WithName("upload-populator-controller-test")
+)
+
+var _ = Describe("Upload populator reconcile loop", func() {
+
+	reconcilePvc := func(r *UploadPopulatorReconciler, name string) {
+		_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: "default"}})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	getPvc := func(r *UploadPopulatorReconciler, name string) *corev1.PersistentVolumeClaim {
+		pvc := &corev1.PersistentVolumeClaim{}
+		err := r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: "default"}, pvc)
+		Expect(err).ToNot(HaveOccurred())
+		return pvc
+	}
+
+	It("Should return nil if the PVC can not be found", func() {
+		reconciler := createUploadPopulatorReconciler()
+		reconcilePvc(reconciler, "testPvc1")
+	})
+
+	It("Should ignore PVCs without a VolumeUploadSource dataSourceRef", func() {
+		reconciler := createUploadPopulatorReconciler(createPvc("testPvc1", "default", nil, nil))
+		reconcilePvc(reconciler, "testPvc1")
+
+		pvc := getPvc(reconciler, "testPvc1")
+		_, found := pvc.Annotations[AnnUploadRequest]
+		Expect(found).To(BeFalse())
+	})
+
+	It("Should wait until the referenced VolumeUploadSource exists", func() {
+		reconciler := createUploadPopulatorReconciler(createPvcWithUploadSourceRef("testPvc1", "upload-source"))
+		reconcilePvc(reconciler, "testPvc1")
+
+		pvc := getPvc(reconciler, "testPvc1")
+		_, found := pvc.Annotations[AnnUploadRequest]
+		Expect(found).To(BeFalse())
+	})
+
+	It("Should request upload once the VolumeUploadSource exists", func() {
+		preallocation := true
+		uploadSource := &cdiv1.VolumeUploadSource{
+			ObjectMeta: metav1.ObjectMeta{Name: "upload-source", Namespace: "default"},
+			Spec: cdiv1.VolumeUploadSourceSpec{
+				ContentType:   cdiv1.DataVolumeArchive,
+				Preallocation: &preallocation,
+			},
+		}
+		reconciler := createUploadPopulatorReconciler(createPvcWithUploadSourceRef("testPvc1", "upload-source"), uploadSource)
+		reconcilePvc(reconciler, "testPvc1")
+
+		pvc := getPvc(reconciler, "testPvc1")
+		_, found := pvc.Annotations[AnnUploadRequest]
+		Expect(found).To(BeTrue())
+		Expect(pvc.Annotations[annPopulatedByUploadSource]).To(Equal("upload-source"))
+		Expect(pvc.Annotations[AnnContentType]).To(Equal(string(cdiv1.DataVolumeArchive)))
+		Expect(pvc.Annotations[AnnPreallocationRequested]).To(Equal("true"))
+		_, found = pvc.Annotations[AnnImmediateBinding]
+		Expect(found).To(BeTrue())
+
+		event := <-reconciler.recorder.(*record.FakeRecorder).Events
+		Expect(event).To(ContainSubstring(UploadPopulationRequested))
+	})
+
+	It("Should enqueue the PVCs referencing a VolumeUploadSource", func() {
+		reconciler := createUploadPopulatorReconciler(
+			createPvcWithUploadSourceRef("testPvc1", "upload-source"),
+			createPvc("testPvc2", "default", nil, nil))
+
+		reqs := enqueuePvcsForUploadSource(reconciler.client, "default", "upload-source")
+		Expect(reqs).To(HaveLen(1))
+		Expect(reqs[0].Name).To(Equal("testPvc1"))
+	})
+})
+
+func createPvcWithUploadSourceRef(name, uploadSourceName string) *corev1.PersistentVolumeClaim {
+	apiGroup := cdiv1.SchemeGroupVersion.Group
+	pvc := createPvc(name, "default", nil, nil)
+	pvc.Spec.DataSourceRef = &corev1.TypedLocalObjectReference{
+		APIGroup: &apiGroup,
+		Kind:     volumeUploadSourceKind,
+		Name:     uploadSourceName,
+	}
+	return pvc
+}
+
+func createUploadPopulatorReconciler(objects ...runtime.Object) *UploadPopulatorReconciler {
+	objs := []runtime.Object{MakeEmptyCDICR()}
+	objs = append(objs, objects...)
+
+	s := scheme.Scheme
+	cdiv1.AddToScheme(s)
+
+	cl := fake.NewFakeClientWithScheme(s, objs...)
+
+	rec := record.NewFakeRecorder(10)
+
+	return &UploadPopulatorReconciler{
+		client:   cl,
+		scheme:   s,
+		log:      uploadPopulatorLog,
+		recorder: rec,
+	}
+}
@@ -5,13 +5,17 @@ import (
 	"crypto/rsa"
 	"encoding/json"
 	"fmt"
+	"math"
 	"regexp"
+	"runtime"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
 	ocpconfigv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/library-go/pkg/crypto"
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
@@ -19,6 +23,7 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
@@ -28,11 +33,13 @@ import (
 	"kubevirt.io/containerized-data-importer/pkg/common"
 	featuregates "kubevirt.io/containerized-data-importer/pkg/feature-gates"
 	"kubevirt.io/containerized-data-importer/pkg/token"
+	"kubevirt.io/containerized-data-importer/pkg/tracing"
 	"kubevirt.io/containerized-data-importer/pkg/util"
 	"kubevirt.io/containerized-data-importer/pkg/util/cert"
 	"kubevirt.io/containerized-data-importer/pkg/util/naming"
 	sdkapi "kubevirt.io/controller-lifecycle-operator-sdk/api"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 const (
@@ -42,9 +49,21 @@ const (
 	// CertVolName is the name of the volumecontaining certs
 	CertVolName = "cdi-cert-vol"
 
+	// AuthVolName is the name of the volume containing a dockerconfigjson pull secret
+	AuthVolName = "cdi-auth-vol"
+
+	// SecretProviderVolName is the name of the secrets-store CSI volume delivering endpoint credentials
+	SecretProviderVolName = "cdi-secret-provider-vol"
+
 	// ScratchVolName provides a const to use for creating scratch pvc volumes in pod specs
 	ScratchVolName = "cdi-scratch-vol"
 
+	// LayerCacheVolName provides a const to use for creating the node-local layer cache volume in importer pod specs
+	LayerCacheVolName = "cdi-layer-cache-vol"
+
+	// ServerCertVolName is the name of the volume containing the server tls key/cert
+	ServerCertVolName = "cdi-server-cert-vol"
+
 	// ImagePathName provides a const to use for creating volumes in pod specs
 	ImagePathName  = "image-path"
 	socketPathName = "socket-path"
@@ -69,6 +88,13 @@ const (
 	AnnPriorityClassName = AnnAPIGroup + "/storage.pod.priorityclassname"
 	// AnnDeleteAfterCompletion is PVC annotation for deleting DV after completion
 	AnnDeleteAfterCompletion = AnnAPIGroup + "/storage.deleteAfterCompletion"
+	// AnnCheckSource is a DataVolume annotation requesting a connectivity pre-check of the import source before storage is provisioned
+	AnnCheckSource = AnnAPIGroup + "/storage.checkSource"
+	// AnnDataVolumeTTLSeconds is a Namespace annotation overriding the CDIConfig DataVolumeTTLSeconds for DVs in that namespace
+	AnnDataVolumeTTLSeconds = AnnAPIGroup + "/dataVolumeTTLSeconds"
+
+	// AnnPaused pauses reconciliation while set to "true" on the CDI resource or on a Namespace
+	AnnPaused = AnnAPIGroup + "/paused"
 
 	// AnnPodRetainAfterCompletion is PVC annotation for retaining transfer pods after completion
 	AnnPodRetainAfterCompletion = AnnAPIGroup + "/storage.pod.retainAfterCompletion"
@@ -179,10 +205,10 @@ func checkPVC(pvc *v1.PersistentVolumeClaim, annotation string, log logr.Logger)
 	return true
 }
 
-func isWaitForFirstConsumerEnabled(isImmediateBindingRequested bool, gates featuregates.FeatureGates) (bool, error) {
+func isWaitForFirstConsumerEnabled(isImmediateBindingRequested bool, namespace string, gates featuregates.FeatureGates) (bool, error) {
 	// when PVC requests immediateBinding it cannot honor wffc logic
 	pvcHonorWaitForFirstConsumer := !isImmediateBindingRequested
-	globalHonorWaitForFirstConsumer, err := gates.HonorWaitForFirstConsumerEnabled()
+	globalHonorWaitForFirstConsumer, err := gates.HonorWaitForFirstConsumerEnabledForNamespace(namespace)
 	if err != nil {
 		return false, err
 	}
@@ -278,6 +304,12 @@ func newScratchPersistentVolumeClaimSpec(pvc *v1.PersistentVolumeClaim, pod *v1.
 func CreateScratchPersistentVolumeClaim(client client.Client, pvc *v1.PersistentVolumeClaim, pod *v1.Pod, name, storageClassName string, installerLabels map[string]string, recorder record.EventRecorder) (*v1.PersistentVolumeClaim, error) {
 	scratchPvcSpec := newScratchPersistentVolumeClaimSpec(pvc, pod, name, storageClassName)
 	util.SetRecommendedLabels(scratchPvcSpec, installerLabels, "cdi-controller")
+	if err := applyScratchSpaceSizing(client, scratchPvcSpec); err != nil {
+		return nil, err
+	}
+	if err := ApplyWorkloadMetadata(client, scratchPvcSpec); err != nil {
+		return nil, err
+	}
 	if err := client.Create(context.TODO(), scratchPvcSpec); err != nil {
 		if errQuotaExceeded(err) {
 			recorder.Event(pvc, v1.EventTypeWarning, ErrExceededQuota, err.Error())
@@ -292,9 +324,74 @@ func CreateScratchPersistentVolumeClaim(client client.Client, pvc *v1.Persistent
 		return nil, err
 	}
 	klog.V(3).Infof("scratch PVC \"%s/%s\" created\n", scratchPvc.Namespace, scratchPvc.Name)
+	recorder.Eventf(pvc, v1.EventTypeNormal, ScratchSpaceRequested, "Created scratch space PVC %s", scratchPvc.Name)
 	return scratchPvc, nil
 }
 
+// enqueueQuotaBlockedPvcs returns reconcile requests for PVCs in the given
+// namespace that were parked with the BlockedByQuota condition
+func enqueueQuotaBlockedPvcs(c client.Client, namespace string) []reconcile.Request {
+	pvcList := &v1.PersistentVolumeClaimList{}
+	if err := c.List(context.TODO(), pvcList, &client.ListOptions{Namespace: namespace}); err != nil {
+		return nil
+	}
+	var reqs []reconcile.Request
+	for i := range pvcList.Items {
+		pvc := &pvcList.Items[i]
+		if pvc.Annotations[AnnRunningConditionReason] == BlockedByQuota {
+			reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: pvc.Namespace, Name: pvc.Name}})
+		}
+	}
+	return reqs
+}
+
+// getLogVerbosity returns the klog verbosity worker pods should run with, preferring the CDIConfig LogVerbosity override
+func getLogVerbosity(client client.Client, defaultVerbosity string) string {
+	cdiConfig := &cdiv1.CDIConfig{}
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, cdiConfig); err != nil {
+		return defaultVerbosity
+	}
+	if cdiConfig.Spec.LogVerbosity != nil {
+		return strconv.FormatInt(int64(*cdiConfig.Spec.LogVerbosity), 10)
+	}
+	return defaultVerbosity
+}
+
+// applyScratchSpaceSizing adjusts the scratch PVC requested size per the CDIConfig size factor and cap
+func applyScratchSpaceSizing(client client.Client, scratchPvc *v1.PersistentVolumeClaim) error {
+	cdiConfig := &cdiv1.CDIConfig{}
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, cdiConfig); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	requested, ok := scratchPvc.Spec.Resources.Requests[v1.ResourceStorage]
+	if !ok {
+		return nil
+	}
+	adjusted := false
+	if factorStr := cdiConfig.Spec.ScratchSpaceSizeFactor; factorStr != nil {
+		factor, err := strconv.ParseFloat(*factorStr, 64)
+		if err != nil || factor <= 0 {
+			klog.V(1).Infof("Ignoring invalid scratchSpaceSizeFactor %q", *factorStr)
+		} else {
+			scaled := int64(math.Ceil(float64(requested.Value()) * factor))
+			requested = *resource.NewQuantity(util.RoundUp(scaled, util.DefaultAlignBlockSize), resource.BinarySI)
+			adjusted = true
+		}
+	}
+	if maxSize := cdiConfig.Spec.ScratchSpaceMaxSize; maxSize != nil && requested.Cmp(*maxSize) > 0 {
+		requested = *maxSize
+		adjusted = true
+	}
+	if adjusted {
+		// the requests map is shared with the target PVC, so replace it instead of mutating it
+		scratchPvc.Spec.Resources.Requests = v1.ResourceList{v1.ResourceStorage: requested}
+	}
+	return nil
+}
+
 // GetStorageClassByName looks up the storage class based on the name. If no storage class is found returns nil
 func GetStorageClassByName(client client.Client, name *string) (*storagev1.StorageClass, error) {
 	// look up storage class by name
@@ -327,6 +424,25 @@ func GetDefaultStorageClass(client client.Client) (*storagev1.StorageClass, erro
 }
 
 // GetFilesystemOverhead determines the filesystem overhead defined in CDIConfig for this PVC's volumeMode and storageClass.
+// GetDataImportCacheMode returns the qemu cache mode the StorageProfile of the
+// PVC storage class requests, or an empty string when there is no override
+func GetDataImportCacheMode(client client.Client, pvc *v1.PersistentVolumeClaim) (string, error) {
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+		return "", nil
+	}
+	storageProfile := &cdiv1.StorageProfile{}
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: *pvc.Spec.StorageClassName}, storageProfile); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	if storageProfile.Status.DataImportCacheMode == nil {
+		return "", nil
+	}
+	return string(*storageProfile.Status.DataImportCacheMode), nil
+}
+
 func GetFilesystemOverhead(client client.Client, pvc *v1.PersistentVolumeClaim) (cdiv1.Percent, error) {
 	if getVolumeMode(pvc) != v1.PersistentVolumeFilesystem {
 		return "0", nil
@@ -415,6 +531,80 @@ func GetDefaultPodResourceRequirements(client client.Client) (*v1.ResourceRequir
 	return cdiconfig.Status.DefaultPodResourceRequirements, nil
 }
 
+// CDIWorkloadType distinguishes the CDI worker pod flavors for per workload resource overrides
+type CDIWorkloadType string
+
+const (
+	// WorkloadTypeImporter is for importer pods
+	WorkloadTypeImporter CDIWorkloadType = "importer"
+	// WorkloadTypeCloneSource is for clone source pods
+	WorkloadTypeCloneSource CDIWorkloadType = "cloneSource"
+	// WorkloadTypeUploadServer is for upload server pods
+	WorkloadTypeUploadServer CDIWorkloadType = "uploadServer"
+	// WorkloadTypeScratchPrep is for pods preparing volumes, such as the PVC expansion pod
+	WorkloadTypeScratchPrep CDIWorkloadType = "scratchPrep"
+)
+
+// GetWorkloadPodResourceRequirements gets pod resource requirements for the given workload type,
+// applying any per workload override from cdi config spec on top of the defaults from cdi config status
+func GetWorkloadPodResourceRequirements(client client.Client, workloadType CDIWorkloadType) (*v1.ResourceRequirements, error) {
+	cdiconfig := &cdiv1.CDIConfig{}
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, cdiconfig); err != nil {
+		klog.Errorf("Unable to find CDI configuration, %v\n", err)
+		return nil, err
+	}
+
+	requirements := cdiconfig.Status.DefaultPodResourceRequirements
+	byWorkload := cdiconfig.Spec.PodResourceRequirementsByWorkload
+	if byWorkload == nil {
+		return requirements, nil
+	}
+
+	var override *v1.ResourceRequirements
+	switch workloadType {
+	case WorkloadTypeImporter:
+		override = byWorkload.Importer
+	case WorkloadTypeCloneSource:
+		override = byWorkload.CloneSource
+	case WorkloadTypeUploadServer:
+		override = byWorkload.UploadServer
+	case WorkloadTypeScratchPrep:
+		override = byWorkload.ScratchPrep
+	}
+	if override == nil {
+		return requirements, nil
+	}
+
+	return overrideResourceRequirements(requirements, override), nil
+}
+
+// overrideResourceRequirements applies the cpu and memory values from override on top of defaults,
+// mirroring the filtering reconcileDefaultPodResourceRequirements does for the global spec value
+func overrideResourceRequirements(defaults, override *v1.ResourceRequirements) *v1.ResourceRequirements {
+	merged := defaults.DeepCopy()
+	if merged == nil {
+		merged = &v1.ResourceRequirements{}
+	}
+	if merged.Limits == nil {
+		merged.Limits = v1.ResourceList{}
+	}
+	if merged.Requests == nil {
+		merged.Requests = v1.ResourceList{}
+	}
+
+	for _, name := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+		if quantity, exist := override.Limits[name]; exist {
+			merged.Limits[name] = quantity
+		}
+
+		if quantity, exist := override.Requests[name]; exist {
+			merged.Requests[name] = quantity
+		}
+	}
+
+	return merged
+}
+
 // this is being called for pods using PV with block volume mode
 func addVolumeDevices() []v1.VolumeDevice {
 	volumeDevices := []v1.VolumeDevice{
@@ -457,6 +647,30 @@ func DecodePublicKey(keyBytes []byte) (*rsa.PublicKey, error) {
 	return key, nil
 }
 
+// DecodePublicKeys turns a PEM bundle into the public keys it contains.
+// The bundle may hold several keys while the signing key is being rotated
+func DecodePublicKeys(keyBytes []byte) ([]*rsa.PublicKey, error) {
+	keys, err := cert.ParsePublicKeysPEM(keyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("PEM does not contain any keys")
+	}
+
+	rsaKeys := make([]*rsa.PublicKey, 0, len(keys))
+	for _, key := range keys {
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return nil, errors.New("PEM does not contain RSA key")
+		}
+		rsaKeys = append(rsaKeys, rsaKey)
+	}
+
+	return rsaKeys, nil
+}
+
 // MakePVCOwnerReference makes owner reference from a PVC
 func MakePVCOwnerReference(pvc *v1.PersistentVolumeClaim) metav1.OwnerReference {
 	blockOwnerDeletion := true
@@ -561,8 +775,16 @@ func setAnnotationsFromPodWithPrefix(anno map[string]string, pod *v1.Pod, prefix
 			anno[prefix+".message"] = simplifyKnownMessage(containerState.Waiting.Message)
 			anno[prefix+".reason"] = containerState.Waiting.Reason
 		} else if containerState.Terminated != nil {
-			anno[prefix+".message"] = simplifyKnownMessage(containerState.Terminated.Message)
-			anno[prefix+".reason"] = containerState.Terminated.Reason
+			if termErr := util.TerminationErrorFromMessage(containerState.Terminated.Message); termErr != nil {
+				anno[prefix+".message"] = simplifyKnownMessage(termErr.Message)
+				anno[prefix+".reason"] = termErr.Code
+				if termErr.Code != util.ErrCodeCompleted {
+					anno[prefix+".retryable"] = strconv.FormatBool(termErr.Retryable)
+				}
+			} else {
+				anno[prefix+".message"] = simplifyKnownMessage(containerState.Terminated.Message)
+				anno[prefix+".reason"] = containerState.Terminated.Reason
+			}
 			if strings.Contains(containerState.Terminated.Message, common.PreallocationApplied) {
 				anno[AnnPreallocationApplied] = "true"
 			}
@@ -585,6 +807,10 @@ func setVddkAnnotations(anno map[string]string, pod *v1.Pod) {
 		return
 	}
 	terminationMessage := pod.Status.ContainerStatuses[0].State.Terminated.Message
+	if termErr := util.TerminationErrorFromMessage(terminationMessage); termErr != nil {
+		// The VDDK info is embedded in the message of a structured termination message.
+		terminationMessage = termErr.Message
+	}
 	klog.V(1).Info("Saving VDDK annotations from pod status message: ", "message", terminationMessage)
 
 	var terminationInfo string
@@ -644,10 +870,11 @@ func createScratchNameFromPvc(pvc *v1.PersistentVolumeClaim) string {
 	return naming.GetResourceName(pvc.Name, common.ScratchNameSuffix)
 }
 
-// GetPodsUsingPVCs returns Pods currently using PVCs
-func GetPodsUsingPVCs(c client.Client, namespace string, names sets.String, allowReadOnly bool) ([]v1.Pod, error) {
+// GetPodsUsingPVCs returns Pods currently using PVCs. The reader has to see
+// all pods in the namespace; the manager cache only holds CDI worker pods, so
+// callers pass an uncached client.
+func GetPodsUsingPVCs(c client.Reader, namespace string, names sets.String, allowReadOnly bool) ([]v1.Pod, error) {
 	pl := &v1.PodList{}
-	// hopefully using cached client here
 	err := c.List(context.TODO(), pl, &client.ListOptions{Namespace: namespace})
 	if err != nil {
 		return nil, err
@@ -710,6 +937,36 @@ func GetActiveCDI(c client.Client) (*cdiv1.CDI, error) {
 	return &activeResources[0], nil
 }
 
+// pausedRequeueDelay is how often paused controllers recheck the pause annotation
+const pausedRequeueDelay = time.Minute
+
+// ReconcilePaused returns true if reconciliation is paused by the pause annotation
+// on the active CDI resource, or on the namespace of the object being reconciled
+func ReconcilePaused(c client.Client, namespace string) (bool, error) {
+	cr, err := GetActiveCDI(c)
+	if err != nil {
+		return false, err
+	}
+
+	if cr != nil && cr.Annotations[AnnPaused] == "true" {
+		return true, nil
+	}
+
+	if namespace == "" {
+		return false, nil
+	}
+
+	ns := &v1.Namespace{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: namespace}, ns); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return ns.Annotations[AnnPaused] == "true", nil
+}
+
 // IsPopulated returns if the passed in PVC has been populated according to the rules outlined in pkg/apis/core/<version>/utils.go
 func IsPopulated(pvc *v1.PersistentVolumeClaim, c client.Client) (bool, error) {
 	return cdiv1utils.IsPopulated(pvc, func(name, namespace string) (*cdiv1.DataVolume, error) {
@@ -745,6 +1002,98 @@ func SetPodPvcAnnotations(pod *v1.Pod, pvc *v1.PersistentVolumeClaim) {
 	}
 }
 
+// SetRootlessSecurityContext makes the worker pod comply with the restricted pod
+// security standard: it runs with a fixed non-root uid and gid, drops all
+// capabilities and uses the runtime default seccomp profile. Filesystem PVCs get
+// the qemu fsGroup so the non-root process can write to the volume, block PVCs
+// get the qemu group as a supplemental group for access to the device.
+func SetRootlessSecurityContext(pod *v1.Pod, isBlock bool) {
+	nonRoot := true
+	uid := common.RootlessUID
+	gid := common.QemuSubGid
+	pod.Spec.SecurityContext = &v1.PodSecurityContext{
+		RunAsNonRoot: &nonRoot,
+		RunAsUser:    &uid,
+		RunAsGroup:   &gid,
+		SeccompProfile: &v1.SeccompProfile{
+			Type: v1.SeccompProfileTypeRuntimeDefault,
+		},
+	}
+	if isBlock {
+		pod.Spec.SecurityContext.SupplementalGroups = []int64{common.QemuSubGid}
+	} else {
+		pod.Spec.SecurityContext.FSGroup = &gid
+	}
+	allowPrivilegeEscalation := false
+	for i := range pod.Spec.Containers {
+		pod.Spec.Containers[i].SecurityContext = &v1.SecurityContext{
+			AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+			Capabilities: &v1.Capabilities{
+				Drop: []v1.Capability{"ALL"},
+			},
+		}
+	}
+}
+
+// FIPSTLSProfile is the TLS policy applied to worker pods when fipsMode is
+// enabled and no explicit TLSSecurityProfile is configured: TLS 1.2 with the
+// FIPS-approved AES-GCM subset of the intermediate profile ciphers.
+var FIPSTLSProfile = &ocpconfigv1.TLSSecurityProfile{
+	Type: ocpconfigv1.TLSProfileCustomType,
+	Custom: &ocpconfigv1.CustomTLSProfile{
+		TLSProfileSpec: ocpconfigv1.TLSProfileSpec{
+			MinTLSVersion: ocpconfigv1.VersionTLS12,
+			Ciphers: []string{
+				"ECDHE-ECDSA-AES128-GCM-SHA256",
+				"ECDHE-RSA-AES128-GCM-SHA256",
+				"ECDHE-ECDSA-AES256-GCM-SHA384",
+				"ECDHE-RSA-AES256-GCM-SHA384",
+			},
+		},
+	},
+}
+
+// GetTLSSecurityProfile returns the TLS policy worker pods should enforce,
+// falling back to the FIPS baseline when fipsMode is enabled without an
+// explicit profile.
+func GetTLSSecurityProfile(config *cdiv1.CDIConfig) *ocpconfigv1.TLSSecurityProfile {
+	if config.Spec.TLSSecurityProfile != nil {
+		return config.Spec.TLSSecurityProfile
+	}
+	if config.Spec.FIPSMode != nil && *config.Spec.FIPSMode {
+		return FIPSTLSProfile
+	}
+	return nil
+}
+
+// ResolveTLSSecurityProfile returns the minimum TLS version and the IANA names
+// of the allowed ciphers for the given profile. An empty version and a nil
+// cipher list mean no policy is enforced. TLS 1.3 cipher suites are dropped
+// from the list because golang does not allow restricting them
+func ResolveTLSSecurityProfile(profile *ocpconfigv1.TLSSecurityProfile) (string, []string) {
+	if profile == nil {
+		return "", nil
+	}
+	var spec *ocpconfigv1.TLSProfileSpec
+	if profile.Type == ocpconfigv1.TLSProfileCustomType {
+		if profile.Custom != nil {
+			spec = &profile.Custom.TLSProfileSpec
+		}
+	} else {
+		spec = ocpconfigv1.TLSProfiles[profile.Type]
+	}
+	if spec == nil {
+		return "", nil
+	}
+	var ciphers []string
+	for _, name := range crypto.OpenSSLToIANACipherSuites(spec.Ciphers) {
+		if _, err := crypto.CipherSuite(name); err == nil {
+			ciphers = append(ciphers, name)
+		}
+	}
+	return string(spec.MinTLSVersion), ciphers
+}
+
 // GetPreallocation retuns the preallocation setting for DV, falling back to StorageClass and global setting (in this order)
 func GetPreallocation(client client.Client, dataVolume *cdiv1.DataVolume) bool {
 	// First, the DV's preallocation
@@ -798,6 +1147,10 @@ func GetImportProxyConfig(config *cdiv1.CDIConfig, field string) (string, error)
 		if config.Status.ImportProxy.TrustedCAProxy != nil {
 			return *config.Status.ImportProxy.TrustedCAProxy, nil
 		}
+	case common.ImportProxyCredentialsSecret:
+		if config.Status.ImportProxy.CredentialsSecret != nil {
+			return *config.Status.ImportProxy.CredentialsSecret, nil
+		}
 	default:
 		return "", errors.Errorf("CDIConfig ImportProxy does not have the field: %s\n", field)
 	}
@@ -806,6 +1159,225 @@ func GetImportProxyConfig(config *cdiv1.CDIConfig, field string) (string, error)
 	return "", nil
 }
 
+// GetImportProxyConfigForNamespace resolves the effective import proxy setting for importer pods
+// in the given namespace, applying any namespace scoped overrides from the CDIConfig.
+func GetImportProxyConfigForNamespace(c client.Client, config *cdiv1.CDIConfig, namespace, field string) (string, error) {
+	value, err := GetImportProxyConfig(config, field)
+	if err != nil {
+		return "", err
+	}
+	if len(config.Status.ImportProxy.NamespaceOverrides) == 0 {
+		return value, nil
+	}
+	ns := &v1.Namespace{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: namespace}, ns); err != nil {
+		return "", err
+	}
+	for i := range config.Status.ImportProxy.NamespaceOverrides {
+		override := &config.Status.ImportProxy.NamespaceOverrides[i]
+		if override.NamespaceSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(override.NamespaceSelector)
+			if err != nil {
+				return "", err
+			}
+			if !selector.Matches(labels.Set(ns.Labels)) {
+				continue
+			}
+		}
+		switch field {
+		case common.ImportProxyHTTP:
+			if override.HTTPProxy != nil {
+				value = *override.HTTPProxy
+			}
+		case common.ImportProxyHTTPS:
+			if override.HTTPSProxy != nil {
+				value = *override.HTTPSProxy
+			}
+		case common.ImportProxyNoProxy:
+			if override.NoProxy != nil {
+				value = *override.NoProxy
+			}
+		case common.ImportProxyConfigMapName:
+			if override.TrustedCAProxy != nil {
+				value = *override.TrustedCAProxy
+			}
+		}
+	}
+	return value, nil
+}
+
+// GetWorkerImageAndPullPolicy applies the CDIConfig worker image overrides to the image
+// and pull policy the controller was deployed with.
+func GetWorkerImageAndPullPolicy(c client.Client, image, pullPolicy string) (string, string, error) {
+	config := &cdiv1.CDIConfig{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, config); err != nil {
+		return "", "", err
+	}
+	overrides := config.Spec.WorkerImageOverrides
+	if overrides == nil {
+		return image, pullPolicy, nil
+	}
+	if overrides.Registry != nil {
+		if index := strings.Index(image, "/"); index >= 0 {
+			image = *overrides.Registry + image[index:]
+		} else {
+			image = *overrides.Registry + "/" + image
+		}
+	}
+	if overrides.Tag != nil {
+		if index := strings.LastIndex(image, ":"); index > strings.LastIndex(image, "/") {
+			image = image[:index]
+		}
+		image = image + ":" + *overrides.Tag
+	}
+	if overrides.PullPolicy != nil {
+		pullPolicy = string(*overrides.PullPolicy)
+	}
+	return image, pullPolicy, nil
+}
+
+// ExceedsRestartLimit tells whether the worker pod was restarted more times than the
+// CDIConfig workerRetryPolicy allows.
+func ExceedsRestartLimit(c client.Client, pod *v1.Pod) (bool, error) {
+	config := &cdiv1.CDIConfig{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, config); err != nil {
+		return false, err
+	}
+	policy := config.Spec.WorkerRetryPolicy
+	if policy == nil || policy.MaxRestarts == nil || pod.Status.ContainerStatuses == nil {
+		return false, nil
+	}
+	return pod.Status.ContainerStatuses[0].RestartCount > *policy.MaxRestarts, nil
+}
+
+// ApplyWorkloadMetadata adds the CDIConfig workloadMetadata labels and annotations to an
+// object CDI creates, keeping any values the controller already set.
+func ApplyWorkloadMetadata(c client.Client, obj metav1.Object) error {
+	config := &cdiv1.CDIConfig{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, config); err != nil {
+		return err
+	}
+	metadata := config.Spec.WorkloadMetadata
+	if metadata == nil {
+		return nil
+	}
+	objLabels := obj.GetLabels()
+	for key, value := range metadata.Labels {
+		if _, ok := objLabels[key]; !ok {
+			if objLabels == nil {
+				objLabels = make(map[string]string)
+			}
+			objLabels[key] = value
+		}
+	}
+	obj.SetLabels(objLabels)
+	objAnnotations := obj.GetAnnotations()
+	for key, value := range metadata.Annotations {
+		if _, ok := objAnnotations[key]; !ok {
+			if objAnnotations == nil {
+				objAnnotations = make(map[string]string)
+			}
+			objAnnotations[key] = value
+		}
+	}
+	obj.SetAnnotations(objAnnotations)
+	return nil
+}
+
+// ApplyTracingEnv adds the OTLP collector endpoint configured in the CDIConfig to the
+// containers of a worker pod, so workers emit their own spans into the transfer trace.
+func ApplyTracingEnv(c client.Client, pod *v1.Pod) error {
+	config := &cdiv1.CDIConfig{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, config); err != nil {
+		return err
+	}
+	if config.Spec.Tracing == nil || config.Spec.Tracing.OTLPEndpoint == "" {
+		return nil
+	}
+	for i := range pod.Spec.Containers {
+		pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, v1.EnvVar{
+			Name:  tracing.EndpointEnvVar,
+			Value: config.Spec.Tracing.OTLPEndpoint,
+		})
+	}
+	return nil
+}
+
+// ApplySecurityProfile sets the seccomp and AppArmor profiles configured in the CDIConfig
+// on a worker pod, for clusters enforcing custom runtime profiles.
+func ApplySecurityProfile(c client.Client, pod *v1.Pod) error {
+	config := &cdiv1.CDIConfig{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, config); err != nil {
+		return err
+	}
+	profile := config.Spec.WorkerSecurityProfile
+	if profile == nil {
+		return nil
+	}
+	if profile.SeccompProfile != nil {
+		if pod.Spec.SecurityContext == nil {
+			pod.Spec.SecurityContext = &v1.PodSecurityContext{}
+		}
+		pod.Spec.SecurityContext.SeccompProfile = profile.SeccompProfile.DeepCopy()
+	}
+	if profile.AppArmorProfile != nil {
+		if pod.Annotations == nil {
+			pod.Annotations = make(map[string]string)
+		}
+		for _, container := range pod.Spec.InitContainers {
+			pod.Annotations[v1.AppArmorBetaContainerAnnotationKeyPrefix+container.Name] = *profile.AppArmorProfile
+		}
+		for _, container := range pod.Spec.Containers {
+			pod.Annotations[v1.AppArmorBetaContainerAnnotationKeyPrefix+container.Name] = *profile.AppArmorProfile
+		}
+	}
+	return nil
+}
+
+// ApplyArchitectureAffinity pins a worker pod to nodes matching the architecture CDI
+// runs on, so in heterogeneous clusters workers always land on nodes the published
+// worker images support. Homogeneous clusters are left alone.
+func ApplyArchitectureAffinity(c client.Client, pod *v1.Pod) error {
+	nodes := &v1.NodeList{}
+	if err := c.List(context.TODO(), nodes); err != nil {
+		return err
+	}
+	heterogeneous := false
+	for _, node := range nodes.Items {
+		if arch := node.Status.NodeInfo.Architecture; arch != "" && arch != runtime.GOARCH {
+			heterogeneous = true
+			break
+		}
+	}
+	if !heterogeneous {
+		return nil
+	}
+	requirement := v1.NodeSelectorRequirement{
+		Key:      v1.LabelArchStable,
+		Operator: v1.NodeSelectorOpIn,
+		Values:   []string{runtime.GOARCH},
+	}
+	if pod.Spec.Affinity == nil {
+		pod.Spec.Affinity = &v1.Affinity{}
+	}
+	if pod.Spec.Affinity.NodeAffinity == nil {
+		pod.Spec.Affinity.NodeAffinity = &v1.NodeAffinity{}
+	}
+	nodeAffinity := pod.Spec.Affinity.NodeAffinity
+	if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &v1.NodeSelector{}
+	}
+	selector := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(selector.NodeSelectorTerms) == 0 {
+		selector.NodeSelectorTerms = append(selector.NodeSelectorTerms, v1.NodeSelectorTerm{})
+	}
+	// Terms are ORed, so the requirement has to be added to every one of them.
+	for i := range selector.NodeSelectorTerms {
+		selector.NodeSelectorTerms[i].MatchExpressions = append(selector.NodeSelectorTerms[i].MatchExpressions, requirement)
+	}
+	return nil
+}
+
 func getPriorityClass(pvc *v1.PersistentVolumeClaim) string {
 	anno := pvc.GetAnnotations()
 	return anno[AnnPriorityClassName]
@@ -1090,6 +1662,35 @@ func getDefaultAccessModes(c client.Client, storageClass *storagev1.StorageClass
 	return nil, errors.Errorf("no accessMode defined on StorageProfile for %s StorageClass", storageClass.Name)
 }
 
+// StorageProfileSupportsAccessMode tells whether any of the claim property sets reported
+// by the storage profile include the given access mode
+func StorageProfileSupportsAccessMode(storageProfile *cdiv1.StorageProfile, accessMode v1.PersistentVolumeAccessMode) bool {
+	for _, cps := range storageProfile.Status.ClaimPropertySets {
+		for _, mode := range cps.AccessModes {
+			if mode == accessMode {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// dataVolumeRequestsAccessMode tells whether the DataVolume explicitly requests the given access mode
+func dataVolumeRequestsAccessMode(dv *cdiv1.DataVolume, accessMode v1.PersistentVolumeAccessMode) bool {
+	var accessModes []v1.PersistentVolumeAccessMode
+	if dv.Spec.PVC != nil {
+		accessModes = dv.Spec.PVC.AccessModes
+	} else if dv.Spec.Storage != nil {
+		accessModes = dv.Spec.Storage.AccessModes
+	}
+	for _, mode := range accessModes {
+		if mode == accessMode {
+			return true
+		}
+	}
+	return false
+}
+
 func volumeSize(c client.Client, storage *cdiv1.StorageSpec, volumeMode *v1.PersistentVolumeMode) (*resource.Quantity, error) {
 	// resources.requests[storage] - just copy it to pvc,
 	requestedSize, found := storage.Resources.Requests[v1.ResourceStorage]
@@ -0,0 +1,379 @@
+/*
+ * This file is part of the CDI project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package exporter
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/system"
+	"kubevirt.io/containerized-data-importer/pkg/util"
+)
+
+const (
+	s3FolderSep = "/"
+	httpScheme  = "http"
+)
+
+// S3Client is the interface to the used S3 client.
+type S3Client interface {
+	PutObject(input *s3.PutObjectInput) (*s3.PutObjectOutput, error)
+}
+
+// may be overridden in tests
+var newClientFunc = getS3Client
+var qemuExecFunction = system.ExecWithLimits
+
+// Exporter pushes the disk image of a volume to an S3 or HTTP target, the
+// mirror image of the import data sources
+type Exporter struct {
+	source    string
+	target    string
+	endpoint  string
+	accessKey string
+	secKey    string
+	format    string
+
+	// WrapReader optionally wraps the upload stream, the exporter pod uses it
+	// to publish progress to prometheus
+	WrapReader func(r io.ReadCloser, total uint64) io.ReadCloser
+}
+
+// Result reports what the exporter pushed to the target
+type Result struct {
+	// Checksum is the sha256 of the pushed stream
+	Checksum string
+	// BytesDone is the number of bytes pushed
+	BytesDone int64
+}
+
+// NewExporter creates a new instance of the Exporter
+func NewExporter(source, target, endpoint, accessKey, secKey, format string) *Exporter {
+	return &Exporter{
+		source:    source,
+		target:    target,
+		endpoint:  endpoint,
+		accessKey: accessKey,
+		secKey:    secKey,
+		format:    format,
+	}
+}
+
+// Export prepares the image in the requested format, computes its checksum in
+// a first pass and pushes it to the target in a second
+func (e *Exporter) Export() (*Result, error) {
+	file, size, cleanup, err := e.prepareSource()
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+	defer file.Close()
+
+	checksum, err := computeChecksum(file, size)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, errors.Wrap(err, "error rewinding source for upload")
+	}
+
+	var body io.Reader = io.LimitReader(file, size)
+	if e.WrapReader != nil {
+		body = e.WrapReader(ioutil.NopCloser(body), uint64(size))
+	}
+
+	switch e.target {
+	case common.ExporterTargetS3:
+		err = e.uploadS3(file, body, size)
+	case common.ExporterTargetHTTP:
+		err = e.uploadHTTP(body, size)
+	default:
+		err = errors.Errorf("unknown export target %q", e.target)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{Checksum: checksum, BytesDone: size}, nil
+}
+
+// prepareSource opens the exported volume, which is either a mounted disk
+// image file or a block device, converting it into a scratch file first when a
+// non raw format was requested
+func (e *Exporter) prepareSource() (*os.File, int64, func(), error) {
+	noop := func() {}
+
+	switch cdiv1.VolumeExportFormat(e.format) {
+	case cdiv1.VolumeExportRaw, "":
+		file, size, err := openSource(e.source)
+		return file, size, noop, err
+	case cdiv1.VolumeExportGzip:
+		return prepareGzip(e.source)
+	case cdiv1.VolumeExportQcow2:
+		return prepareQcow2(e.source)
+	default:
+		return nil, 0, noop, errors.Errorf("unknown export format %q", e.format)
+	}
+}
+
+func openSource(source string) (*os.File, int64, error) {
+	size, err := util.GetAvailableSpaceBlock(source)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "error determining if source %q is a block device", source)
+	}
+
+	file, err := os.Open(source)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "error opening source %q", source)
+	}
+
+	if size < 0 {
+		fi, err := file.Stat()
+		if err != nil {
+			file.Close()
+			return nil, 0, errors.Wrapf(err, "error stating source %q", source)
+		}
+		size = fi.Size()
+	}
+
+	return file, size, nil
+}
+
+func prepareGzip(source string) (*os.File, int64, func(), error) {
+	noop := func() {}
+
+	file, size, err := openSource(source)
+	if err != nil {
+		return nil, 0, noop, err
+	}
+	defer file.Close()
+
+	tmpDir, err := ioutil.TempDir("", "exporter-gzip")
+	if err != nil {
+		return nil, 0, noop, errors.Wrap(err, "error creating scratch dir")
+	}
+	cleanup := func() { os.RemoveAll(tmpDir) }
+
+	dest := filepath.Join(tmpDir, "disk.gz")
+	out, err := os.Create(dest)
+	if err != nil {
+		cleanup()
+		return nil, 0, noop, errors.Wrap(err, "error creating scratch file")
+	}
+
+	gzw := gzip.NewWriter(out)
+	if _, err := io.Copy(gzw, io.LimitReader(file, size)); err != nil {
+		out.Close()
+		cleanup()
+		return nil, 0, noop, errors.Wrap(err, "error compressing source")
+	}
+	if err := gzw.Close(); err != nil {
+		out.Close()
+		cleanup()
+		return nil, 0, noop, errors.Wrap(err, "error finishing compressed source")
+	}
+	out.Close()
+
+	return openScratch(dest, cleanup)
+}
+
+// prepareQcow2 converts the volume to qcow2 in a scratch file, qemu-img cannot
+// write qcow2 to a pipe because the format is not sequential
+func prepareQcow2(source string) (*os.File, int64, func(), error) {
+	noop := func() {}
+
+	tmpDir, err := ioutil.TempDir("", "exporter-qcow2")
+	if err != nil {
+		return nil, 0, noop, errors.Wrap(err, "error creating scratch dir")
+	}
+	cleanup := func() { os.RemoveAll(tmpDir) }
+
+	dest := filepath.Join(tmpDir, "disk.qcow2")
+	if _, err := qemuExecFunction(nil, nil, "qemu-img", "convert", "-f", "raw", "-O", "qcow2", source, dest); err != nil {
+		cleanup()
+		return nil, 0, noop, errors.Wrap(err, "error converting source to qcow2")
+	}
+
+	return openScratch(dest, cleanup)
+}
+
+func openScratch(path string, cleanup func()) (*os.File, int64, func(), error) {
+	file, err := os.Open(path)
+	if err != nil {
+		cleanup()
+		return nil, 0, func() {}, errors.Wrap(err, "error opening scratch file")
+	}
+
+	fi, err := file.Stat()
+	if err != nil {
+		file.Close()
+		cleanup()
+		return nil, 0, func() {}, errors.Wrap(err, "error stating scratch file")
+	}
+
+	return file, fi.Size(), cleanup, nil
+}
+
+func computeChecksum(file *os.File, size int64) (string, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, io.LimitReader(file, size)); err != nil {
+		return "", errors.Wrap(err, "error computing source checksum")
+	}
+	return fmt.Sprintf("sha256:%s", hex.EncodeToString(hash.Sum(nil))), nil
+}
+
+// seekableBody reads the upload stream through the progress wrapper while
+// keeping the underlying file seekable, the S3 client requires a ReadSeeker
+type seekableBody struct {
+	reader io.Reader
+	file   *os.File
+}
+
+func (sb *seekableBody) Read(p []byte) (int, error) {
+	return sb.reader.Read(p)
+}
+
+func (sb *seekableBody) Seek(offset int64, whence int) (int64, error) {
+	return sb.file.Seek(offset, whence)
+}
+
+func (e *Exporter) uploadS3(file *os.File, body io.Reader, size int64) error {
+	klog.V(3).Infoln("Using S3 client to push data")
+
+	ep, err := url.Parse(e.endpoint)
+	if err != nil {
+		return errors.Wrapf(err, "unable to parse endpoint %q", e.endpoint)
+	}
+
+	path := strings.Trim(ep.Path, "/")
+	bucket, object := extractBucketAndObject(path)
+
+	klog.V(1).Infof("bucket %s", bucket)
+	klog.V(1).Infof("object %s", object)
+	svc, err := newClientFunc(ep.Host, e.accessKey, e.secKey, ep.Scheme)
+	if err != nil {
+		return errors.Wrapf(err, "could not build s3 client for %q", ep.Host)
+	}
+
+	objInput := &s3.PutObjectInput{
+		Bucket:        aws.String(bucket),
+		Key:           aws.String(object),
+		Body:          &seekableBody{reader: body, file: file},
+		ContentLength: aws.Int64(size),
+	}
+	if _, err := svc.PutObject(objInput); err != nil {
+		return errors.Wrapf(err, "could not put s3 object: \"%s/%s\"", bucket, object)
+	}
+	return nil
+}
+
+func (e *Exporter) uploadHTTP(body io.Reader, size int64) error {
+	klog.V(3).Infoln("Using HTTP PUT to push data")
+
+	req, err := http.NewRequest(http.MethodPut, e.endpoint, body)
+	if err != nil {
+		return errors.Wrapf(err, "error creating request for %q", e.endpoint)
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", "application/octet-stream")
+	if e.accessKey != "" || e.secKey != "" {
+		req.SetBasicAuth(e.accessKey, e.secKey)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "error putting to %q", e.endpoint)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return errors.Errorf("unexpected status code %d putting to %q", resp.StatusCode, e.endpoint)
+	}
+	return nil
+}
+
+func getS3Client(endpoint, accessKey, secKey string, urlScheme string) (S3Client, error) {
+	creds := credentials.NewStaticCredentials(accessKey, secKey, "")
+	region := extractRegion(endpoint)
+	disableSSL := false
+	// Disable SSL for http endpoint. This should cause the s3 client to create http requests.
+	if urlScheme == httpScheme {
+		disableSSL = true
+	}
+
+	sess, err := session.NewSession(&aws.Config{
+		Region:           aws.String(region),
+		Endpoint:         aws.String(endpoint),
+		Credentials:      creds,
+		S3ForcePathStyle: aws.Bool(true),
+		DisableSSL:       &disableSSL,
+		// the body is hashed by the exporter itself and streams through a
+		// progress reader that must not be consumed twice for signing
+		DisableComputeChecksums: aws.Bool(true),
+	},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	svc := s3.New(sess)
+	return svc, nil
+}
+
+func extractRegion(s string) string {
+	var region string
+	r, _ := regexp.Compile("s3\\.(.+)\\.amazonaws\\.com")
+	if matches := r.FindStringSubmatch(s); matches != nil {
+		region = matches[1]
+	} else {
+		region = strings.Split(s, ".")[0]
+	}
+
+	return region
+}
+
+func extractBucketAndObject(s string) (string, string) {
+	pathSplit := strings.Split(s, s3FolderSep)
+	bucket := pathSplit[0]
+	object := strings.Join(pathSplit[1:], s3FolderSep)
+	return bucket, object
+}
@@ -0,0 +1,206 @@
+/*
+ * This file is part of the CDI project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package exporter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/system"
+)
+
+var _ = Describe("Exporter", func() {
+	var (
+		tmpDir  string
+		source  string
+		content = []byte("exported disk image contents")
+	)
+
+	checksumOf := func(data []byte) string {
+		hash := sha256.Sum256(data)
+		return fmt.Sprintf("sha256:%s", hex.EncodeToString(hash[:]))
+	}
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "exporter-test")
+		Expect(err).ToNot(HaveOccurred())
+		source = filepath.Join(tmpDir, "disk.img")
+		err = ioutil.WriteFile(source, content, 0600)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	It("Should PUT the raw image to an HTTP target with credentials", func() {
+		var received []byte
+		var method, user, pass string
+		var hasAuth bool
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			method = r.Method
+			user, pass, hasAuth = r.BasicAuth()
+			received, _ = ioutil.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+		}))
+		defer server.Close()
+
+		exp := NewExporter(source, common.ExporterTargetHTTP, server.URL+"/disk.img", "user", "pass", "raw")
+		result, err := exp.Export()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(method).To(Equal(http.MethodPut))
+		Expect(hasAuth).To(BeTrue())
+		Expect(user).To(Equal("user"))
+		Expect(pass).To(Equal("pass"))
+		Expect(received).To(Equal(content))
+		Expect(result.Checksum).To(Equal(checksumOf(content)))
+		Expect(result.BytesDone).To(Equal(int64(len(content))))
+	})
+
+	It("Should fail when the HTTP target rejects the upload", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		exp := NewExporter(source, common.ExporterTargetHTTP, server.URL, "", "", "raw")
+		_, err := exp.Export()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("unexpected status code 403"))
+	})
+
+	It("Should push the gzip compressed image", func() {
+		var received []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received, _ = ioutil.ReadAll(r.Body)
+		}))
+		defer server.Close()
+
+		exp := NewExporter(source, common.ExporterTargetHTTP, server.URL, "", "", "gzip")
+		result, err := exp.Export()
+		Expect(err).ToNot(HaveOccurred())
+
+		gzr, err := gzip.NewReader(bytes.NewReader(received))
+		Expect(err).ToNot(HaveOccurred())
+		decompressed, err := ioutil.ReadAll(gzr)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(decompressed).To(Equal(content))
+		// the checksum covers the pushed stream, not the raw source
+		Expect(result.Checksum).To(Equal(checksumOf(received)))
+	})
+
+	It("Should push the converted qcow2 image", func() {
+		// fake qemu-img by copying the source to the destination
+		replaceExecFunction(func(_ *system.ProcessLimitValues, _ func(string), _ string, args ...string) ([]byte, error) {
+			Expect(args[0]).To(Equal("convert"))
+			src, dst := args[len(args)-2], args[len(args)-1]
+			data, err := ioutil.ReadFile(src)
+			Expect(err).ToNot(HaveOccurred())
+			return nil, ioutil.WriteFile(dst, data, 0600)
+		})
+		defer replaceExecFunction(system.ExecWithLimits)
+
+		var received []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received, _ = ioutil.ReadAll(r.Body)
+		}))
+		defer server.Close()
+
+		exp := NewExporter(source, common.ExporterTargetHTTP, server.URL, "", "", "qcow2")
+		result, err := exp.Export()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(received).To(Equal(content))
+		Expect(result.Checksum).To(Equal(checksumOf(content)))
+	})
+
+	It("Should put the image as an S3 object", func() {
+		client := &fakeS3Client{}
+		replaceClientFunc(func(endpoint, accessKey, secKey, urlScheme string) (S3Client, error) {
+			Expect(endpoint).To(Equal("s3.example.com"))
+			Expect(accessKey).To(Equal("user"))
+			Expect(secKey).To(Equal("pass"))
+			return client, nil
+		})
+		defer replaceClientFunc(getS3Client)
+
+		exp := NewExporter(source, common.ExporterTargetS3, "https://s3.example.com/bucket/folder/disk.img", "user", "pass", "raw")
+		result, err := exp.Export()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(*client.input.Bucket).To(Equal("bucket"))
+		Expect(*client.input.Key).To(Equal("folder/disk.img"))
+		Expect(*client.input.ContentLength).To(Equal(int64(len(content))))
+		Expect(client.received).To(Equal(content))
+		Expect(result.Checksum).To(Equal(checksumOf(content)))
+	})
+
+	It("Should error out on an unknown target", func() {
+		exp := NewExporter(source, "ftp", "ftp://example.com", "", "", "raw")
+		_, err := exp.Export()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("unknown export target"))
+	})
+
+	It("Should error out on an unknown format", func() {
+		exp := NewExporter(source, common.ExporterTargetHTTP, "http://example.com", "", "", "vdi")
+		_, err := exp.Export()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("unknown export format"))
+	})
+})
+
+type fakeS3Client struct {
+	input    *s3.PutObjectInput
+	received []byte
+}
+
+func (f *fakeS3Client) PutObject(input *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+	f.input = input
+	data, err := ioutil.ReadAll(input.Body)
+	if err != nil {
+		return nil, err
+	}
+	f.received = data
+	return &s3.PutObjectOutput{}, nil
+}
+
+func replaceExecFunction(replacement func(*system.ProcessLimitValues, func(string), string, ...string) ([]byte, error)) {
+	qemuExecFunction = replacement
+}
+
+func replaceClientFunc(replacement func(endpoint, accessKey, secKey, urlScheme string) (S3Client, error)) {
+	newClientFunc = replacement
+}
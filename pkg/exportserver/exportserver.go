@@ -0,0 +1,296 @@
+/*
+ * This file is part of the CDI project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package exportserver
+
+import (
+	"compress/gzip"
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/system"
+	"kubevirt.io/containerized-data-importer/pkg/util"
+)
+
+const (
+	healthzPort = 8080
+	healthzPath = "/healthz"
+)
+
+// may be overridden in tests
+var qemuExecFunction = system.ExecWithLimits
+
+// ExportServer is the interface to exportServerApp
+type ExportServer interface {
+	Run() error
+}
+
+type exportServerApp struct {
+	bindAddress string
+	bindPort    int
+	source      string
+	certDir     string
+	token       string
+	format      string
+	keyFile     string
+	certFile    string
+	mux         *http.ServeMux
+	errChan     chan error
+}
+
+// NewExportServer returns a new instance of exportServerApp
+func NewExportServer(bindAddress string, bindPort int, source, certDir, token, format string) ExportServer {
+	server := &exportServerApp{
+		bindAddress: bindAddress,
+		bindPort:    bindPort,
+		source:      source,
+		certDir:     certDir,
+		token:       token,
+		format:      format,
+		mux:         http.NewServeMux(),
+		errChan:     make(chan error),
+	}
+
+	server.mux.HandleFunc(common.ExportServerVolumePath, server.volumeHandler)
+
+	return server
+}
+
+// Run serves the volume until the server errors out; the export pod is torn
+// down by the controller when the export expires or is deleted
+func (app *exportServerApp) Run() error {
+	exportServer, err := app.createExportServer()
+	if err != nil {
+		return errors.Wrap(err, "Error creating export http server")
+	}
+
+	healthzServer := &http.Server{
+		Handler: http.HandlerFunc(app.healthzHandler),
+	}
+
+	exportListener, err := net.Listen("tcp", fmt.Sprintf("%s:%d", app.bindAddress, app.bindPort))
+	if err != nil {
+		return errors.Wrap(err, "Error creating export listener")
+	}
+
+	healthzListener, err := net.Listen("tcp", fmt.Sprintf(":%d", healthzPort))
+	if err != nil {
+		return errors.Wrap(err, "Error creating healthz listener")
+	}
+
+	go func() {
+		defer exportListener.Close()
+
+		// maybe bind port was 0 (unit tests) assign port here
+		app.bindPort = exportListener.Addr().(*net.TCPAddr).Port
+
+		if app.keyFile != "" && app.certFile != "" {
+			app.errChan <- exportServer.ServeTLS(exportListener, app.certFile, app.keyFile)
+			return
+		}
+
+		// only used by unit tests, export pods always serve TLS
+		app.errChan <- exportServer.Serve(exportListener)
+	}()
+
+	go func() {
+		defer healthzServer.Close()
+
+		app.errChan <- healthzServer.Serve(healthzListener)
+	}()
+
+	err = <-app.errChan
+	klog.Errorf("HTTP server returned error %s", err.Error())
+	return err
+}
+
+func (app *exportServerApp) createExportServer() (*http.Server, error) {
+	server := &http.Server{
+		Handler: app.mux,
+	}
+
+	if app.certDir != "" {
+		app.keyFile = filepath.Join(app.certDir, "tls.key")
+		app.certFile = filepath.Join(app.certDir, "tls.crt")
+	}
+
+	return server, nil
+}
+
+func (app *exportServerApp) healthzHandler(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != healthzPath {
+		http.NotFound(w, r)
+		return
+	}
+	io.WriteString(w, "OK")
+}
+
+// authorized accepts the export token either as an Authorization bearer token
+// or in the dedicated export token header
+func (app *exportServerApp) authorized(r *http.Request) bool {
+	if app.token == "" {
+		return false
+	}
+
+	token := r.Header.Get(common.ExportServerTokenHeader)
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		token = strings.TrimPrefix(auth, "Bearer ")
+	}
+
+	return subtle.ConstantTimeCompare([]byte(token), []byte(app.token)) == 1
+}
+
+func (app *exportServerApp) volumeHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !app.authorized(r) {
+		klog.V(1).Infof("Rejecting unauthorized download request from %s", r.RemoteAddr)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch cdiv1.VolumeExportFormat(app.format) {
+	case cdiv1.VolumeExportRaw, "":
+		app.serveRaw(w)
+	case cdiv1.VolumeExportGzip:
+		app.serveGzip(w)
+	case cdiv1.VolumeExportQcow2:
+		app.serveQcow2(w)
+	default:
+		klog.Errorf("Unknown export format %q", app.format)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// openSource opens the exported volume, which is either a mounted disk image
+// file or a block device, and returns its size
+func (app *exportServerApp) openSource() (*os.File, int64, error) {
+	size, err := util.GetAvailableSpaceBlock(app.source)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "error determining if source %q is a block device", app.source)
+	}
+
+	file, err := os.Open(app.source)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "error opening source %q", app.source)
+	}
+
+	if size < 0 {
+		fi, err := file.Stat()
+		if err != nil {
+			file.Close()
+			return nil, 0, errors.Wrapf(err, "error stating source %q", app.source)
+		}
+		size = fi.Size()
+	}
+
+	return file, size, nil
+}
+
+func (app *exportServerApp) serveRaw(w http.ResponseWriter) {
+	file, size, err := app.openSource()
+	if err != nil {
+		klog.Errorf("%+v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	defer file.Close()
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Length", fmt.Sprintf("%d", size))
+
+	if _, err := io.Copy(w, io.LimitReader(file, size)); err != nil {
+		klog.Errorf("Error streaming raw volume: %+v", err)
+	}
+}
+
+func (app *exportServerApp) serveGzip(w http.ResponseWriter) {
+	file, size, err := app.openSource()
+	if err != nil {
+		klog.Errorf("%+v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	defer file.Close()
+
+	w.Header().Set("Content-Type", "application/gzip")
+
+	gzw := gzip.NewWriter(w)
+	defer gzw.Close()
+
+	if _, err := io.Copy(gzw, io.LimitReader(file, size)); err != nil {
+		klog.Errorf("Error streaming gzip volume: %+v", err)
+	}
+}
+
+// serveQcow2 converts the volume to qcow2 in a scratch file and streams that,
+// qemu-img cannot write qcow2 to a pipe because the format is not sequential
+func (app *exportServerApp) serveQcow2(w http.ResponseWriter) {
+	tmpDir, err := ioutil.TempDir("", "exportserver-qcow2")
+	if err != nil {
+		klog.Errorf("Error creating scratch dir: %+v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	defer os.RemoveAll(tmpDir)
+
+	dest := filepath.Join(tmpDir, "disk.qcow2")
+	if _, err := qemuExecFunction(nil, nil, "qemu-img", "convert", "-f", "raw", "-O", "qcow2", app.source, dest); err != nil {
+		klog.Errorf("Error converting volume to qcow2: %+v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	file, err := os.Open(dest)
+	if err != nil {
+		klog.Errorf("Error opening converted volume: %+v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	defer file.Close()
+
+	fi, err := file.Stat()
+	if err != nil {
+		klog.Errorf("Error stating converted volume: %+v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Length", fmt.Sprintf("%d", fi.Size()))
+
+	if _, err := io.Copy(w, file); err != nil {
+		klog.Errorf("Error streaming qcow2 volume: %+v", err)
+	}
+}
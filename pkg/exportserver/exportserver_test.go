@@ -0,0 +1,147 @@
+/*
+ * This file is part of the CDI project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package exportserver
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/system"
+)
+
+const testToken = "sssshhhhh"
+
+var _ = Describe("Export server", func() {
+	var (
+		tmpDir  string
+		content = []byte("exported disk image contents")
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "exportserver-test")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	createApp := func(format string) *exportServerApp {
+		source := filepath.Join(tmpDir, "disk.img")
+		err := ioutil.WriteFile(source, content, 0600)
+		Expect(err).ToNot(HaveOccurred())
+		return NewExportServer("127.0.0.1", 0, source, "", testToken, format).(*exportServerApp)
+	}
+
+	doRequest := func(app *exportServerApp, setAuth func(*http.Request)) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, common.ExportServerVolumePath, nil)
+		if setAuth != nil {
+			setAuth(req)
+		}
+		rr := httptest.NewRecorder()
+		app.volumeHandler(rr, req)
+		return rr
+	}
+
+	bearerAuth := func(req *http.Request) {
+		req.Header.Set("Authorization", "Bearer "+testToken)
+	}
+
+	It("Should reject requests without a token", func() {
+		rr := doRequest(createApp("raw"), nil)
+		Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("Should reject requests with a wrong token", func() {
+		rr := doRequest(createApp("raw"), func(req *http.Request) {
+			req.Header.Set("Authorization", "Bearer letmein")
+		})
+		Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("Should reject non GET requests", func() {
+		app := createApp("raw")
+		req := httptest.NewRequest(http.MethodPost, common.ExportServerVolumePath, nil)
+		bearerAuth(req)
+		rr := httptest.NewRecorder()
+		app.volumeHandler(rr, req)
+		Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+	})
+
+	It("Should stream the raw volume with a bearer token", func() {
+		rr := doRequest(createApp("raw"), bearerAuth)
+		Expect(rr.Code).To(Equal(http.StatusOK))
+		Expect(rr.Body.Bytes()).To(Equal(content))
+		Expect(rr.Header().Get("Content-Length")).To(Equal("28"))
+	})
+
+	It("Should accept the token in the export token header", func() {
+		rr := doRequest(createApp("raw"), func(req *http.Request) {
+			req.Header.Set(common.ExportServerTokenHeader, testToken)
+		})
+		Expect(rr.Code).To(Equal(http.StatusOK))
+		Expect(rr.Body.Bytes()).To(Equal(content))
+	})
+
+	It("Should stream the volume gzip compressed", func() {
+		rr := doRequest(createApp("gzip"), bearerAuth)
+		Expect(rr.Code).To(Equal(http.StatusOK))
+		Expect(rr.Header().Get("Content-Type")).To(Equal("application/gzip"))
+		gzr, err := gzip.NewReader(rr.Body)
+		Expect(err).ToNot(HaveOccurred())
+		decompressed, err := ioutil.ReadAll(gzr)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(decompressed).To(Equal(content))
+	})
+
+	It("Should stream the converted qcow2 volume", func() {
+		// fake qemu-img by copying the source to the destination
+		replaceExecFunction(func(_ *system.ProcessLimitValues, _ func(string), _ string, args ...string) ([]byte, error) {
+			Expect(args[0]).To(Equal("convert"))
+			src, dst := args[len(args)-2], args[len(args)-1]
+			data, err := ioutil.ReadFile(src)
+			Expect(err).ToNot(HaveOccurred())
+			return nil, ioutil.WriteFile(dst, data, 0600)
+		})
+		defer replaceExecFunction(system.ExecWithLimits)
+
+		rr := doRequest(createApp("qcow2"), bearerAuth)
+		Expect(rr.Code).To(Equal(http.StatusOK))
+		Expect(rr.Body.Bytes()).To(Equal(content))
+	})
+
+	It("Should error out on an unknown format", func() {
+		rr := doRequest(createApp("vdi"), bearerAuth)
+		Expect(rr.Code).To(Equal(http.StatusInternalServerError))
+	})
+})
+
+func replaceExecFunction(replacement func(*system.ProcessLimitValues, func(string), string, ...string) ([]byte, error)) {
+	qemuExecFunction = replacement
+}
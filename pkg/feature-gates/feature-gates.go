@@ -4,6 +4,9 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -14,12 +17,28 @@ import (
 const (
 	// HonorWaitForFirstConsumer - if enabled will not schedule worker pods on a storage with WaitForFirstConsumer binding mode
 	HonorWaitForFirstConsumer = "HonorWaitForFirstConsumer"
+
+	// RootlessWorkloads - if enabled worker pods run with a fixed non-root uid, no added
+	// capabilities and a restricted security context
+	RootlessWorkloads = "RootlessWorkloads"
+
+	// AutoVolumeExpansion - if enabled a target PVC that is too small for the source
+	// virtual size is grown instead of failing the import, provided the storage class
+	// allows volume expansion
+	AutoVolumeExpansion = "AutoVolumeExpansion"
 )
 
 // FeatureGates is a util for determining whether an optional feature is enabled or not.
 type FeatureGates interface {
 	// HonorWaitForFirstConsumerEnabled - see the HonorWaitForFirstConsumer const
 	HonorWaitForFirstConsumerEnabled() (bool, error)
+	// HonorWaitForFirstConsumerEnabledForNamespace is like HonorWaitForFirstConsumerEnabled,
+	// but also honors namespace-scoped gates for the given namespace
+	HonorWaitForFirstConsumerEnabledForNamespace(namespace string) (bool, error)
+	// RootlessWorkloadsEnabled - see the RootlessWorkloads const
+	RootlessWorkloadsEnabled() (bool, error)
+	// AutoVolumeExpansionEnabled - see the AutoVolumeExpansion const
+	AutoVolumeExpansionEnabled() (bool, error)
 }
 
 // CDIConfigFeatureGates is a util for determining whether an optional feature is enabled or not.
@@ -33,12 +52,12 @@ func NewFeatureGates(c client.Client) *CDIConfigFeatureGates {
 }
 
 func (f *CDIConfigFeatureGates) isFeatureGateEnabled(featureGate string) (bool, error) {
-	featureGates, err := f.getConfig()
+	config, err := f.getConfig()
 	if err != nil {
 		return false, errors.Wrap(err, "error getting CDIConfig")
 	}
 
-	for _, fg := range featureGates {
+	for _, fg := range config.Spec.FeatureGates {
 		if fg == featureGate {
 			return true, nil
 		}
@@ -46,16 +65,81 @@ func (f *CDIConfigFeatureGates) isFeatureGateEnabled(featureGate string) (bool,
 	return false, nil
 }
 
-func (f *CDIConfigFeatureGates) getConfig() ([]string, error) {
+// isFeatureGateEnabledForNamespace checks the global gates first, then the
+// namespace-scoped gates matching the given namespace by name or label selector
+func (f *CDIConfigFeatureGates) isFeatureGateEnabledForNamespace(featureGate, namespace string) (bool, error) {
+	enabled, err := f.isFeatureGateEnabled(featureGate)
+	if err != nil || enabled {
+		return enabled, err
+	}
+
+	config, err := f.getConfig()
+	if err != nil {
+		return false, errors.Wrap(err, "error getting CDIConfig")
+	}
+
+	var ns *corev1.Namespace
+	for _, nsGate := range config.Spec.NamespacedFeatureGates {
+		if !containsString(nsGate.FeatureGates, featureGate) {
+			continue
+		}
+		if containsString(nsGate.Namespaces, namespace) {
+			return true, nil
+		}
+		if nsGate.NamespaceSelector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(nsGate.NamespaceSelector)
+		if err != nil {
+			continue
+		}
+		if ns == nil {
+			ns = &corev1.Namespace{}
+			if err := f.client.Get(context.TODO(), types.NamespacedName{Name: namespace}, ns); err != nil {
+				return false, errors.Wrap(err, "error getting namespace")
+			}
+		}
+		if selector.Matches(labels.Set(ns.Labels)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *CDIConfigFeatureGates) getConfig() (*cdiv1.CDIConfig, error) {
 	config := &cdiv1.CDIConfig{}
 	if err := f.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, config); err != nil {
 		return nil, err
 	}
 
-	return config.Spec.FeatureGates, nil
+	return config, nil
+}
+
+func containsString(strings []string, str string) bool {
+	for _, s := range strings {
+		if s == str {
+			return true
+		}
+	}
+	return false
 }
 
 // HonorWaitForFirstConsumerEnabled - see the HonorWaitForFirstConsumer const
 func (f *CDIConfigFeatureGates) HonorWaitForFirstConsumerEnabled() (bool, error) {
 	return f.isFeatureGateEnabled(HonorWaitForFirstConsumer)
 }
+
+// HonorWaitForFirstConsumerEnabledForNamespace - see the HonorWaitForFirstConsumer const
+func (f *CDIConfigFeatureGates) HonorWaitForFirstConsumerEnabledForNamespace(namespace string) (bool, error) {
+	return f.isFeatureGateEnabledForNamespace(HonorWaitForFirstConsumer, namespace)
+}
+
+// RootlessWorkloadsEnabled - see the RootlessWorkloads const
+func (f *CDIConfigFeatureGates) RootlessWorkloadsEnabled() (bool, error) {
+	return f.isFeatureGateEnabled(RootlessWorkloads)
+}
+
+// AutoVolumeExpansionEnabled - see the AutoVolumeExpansion const
+func (f *CDIConfigFeatureGates) AutoVolumeExpansionEnabled() (bool, error) {
+	return f.isFeatureGateEnabled(AutoVolumeExpansion)
+}
@@ -19,6 +19,7 @@ package featuregates
 import (
 	"context"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -56,6 +57,68 @@ var _ = Describe("Feature Gates", func() {
 		Expect(err).ToNot(HaveOccurred())
 		Expect(featureGates.HonorWaitForFirstConsumerEnabled()).To(BeFalse())
 	})
+
+	It("Should honor gates scoped to namespaces by name", func() {
+		featureGates, client := createFeatureGatesAndClient()
+		cdiConfig := &cdiv1.CDIConfig{}
+		err := client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, cdiConfig)
+		Expect(err).ToNot(HaveOccurred())
+
+		cdiConfig.Spec.NamespacedFeatureGates = []cdiv1.NamespacedFeatureGate{
+			{
+				FeatureGates: []string{HonorWaitForFirstConsumer},
+				Namespaces:   []string{"dev"},
+			},
+		}
+		err = client.Update(context.TODO(), cdiConfig)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(featureGates.HonorWaitForFirstConsumerEnabledForNamespace("dev")).To(BeTrue())
+		Expect(featureGates.HonorWaitForFirstConsumerEnabled()).To(BeFalse())
+	})
+
+	It("Should honor gates scoped to namespaces by label selector", func() {
+		ns := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "dev",
+				Labels: map[string]string{"env": "dev"},
+			},
+		}
+		featureGates, client := createFeatureGatesAndClient(ns)
+		cdiConfig := &cdiv1.CDIConfig{}
+		err := client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, cdiConfig)
+		Expect(err).ToNot(HaveOccurred())
+
+		cdiConfig.Spec.NamespacedFeatureGates = []cdiv1.NamespacedFeatureGate{
+			{
+				FeatureGates:      []string{HonorWaitForFirstConsumer},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev"}},
+			},
+		}
+		err = client.Update(context.TODO(), cdiConfig)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(featureGates.HonorWaitForFirstConsumerEnabledForNamespace("dev")).To(BeTrue())
+	})
+
+	It("Should fall back to global gates for unselected namespaces", func() {
+		featureGates, client := createFeatureGatesAndClient()
+		cdiConfig := &cdiv1.CDIConfig{}
+		err := client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, cdiConfig)
+		Expect(err).ToNot(HaveOccurred())
+
+		cdiConfig.Spec.FeatureGates = []string{HonorWaitForFirstConsumer}
+		cdiConfig.Spec.NamespacedFeatureGates = []cdiv1.NamespacedFeatureGate{
+			{
+				FeatureGates: []string{HonorWaitForFirstConsumer},
+				Namespaces:   []string{"dev"},
+			},
+		}
+		err = client.Update(context.TODO(), cdiConfig)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(featureGates.HonorWaitForFirstConsumerEnabledForNamespace("prod")).To(BeTrue())
+	})
 })
 
 func createFeatureGatesAndClient(objects ...runtime.Object) (FeatureGates, client.Client) {
@@ -25,6 +25,13 @@ var knownHeaders = Headers{
 		SizeOff: 0,
 		SizeLen: 0,
 	},
+	"bz2": Header{
+		Format:      "bz2",
+		magicNumber: []byte{'B', 'Z', 'h'},
+		// TODO: size not in hdr
+		SizeOff: 0,
+		SizeLen: 0,
+	},
 	"qcow2": Header{
 		Format:      "qcow2",
 		magicNumber: []byte{'Q', 'F', 'I', 0xfb},
@@ -29,6 +29,10 @@ var _ = Describe("File format tests", func() {
 			Header{"gz", []byte{0x1F, 0x8B}, 0, 0, 0},
 			[]byte{0x1F, 0x8B},
 			true),
+		table.Entry("match bz2",
+			Header{"bz2", []byte{'B', 'Z', 'h'}, 0, 0, 0},
+			[]byte{'B', 'Z', 'h'},
+			true),
 		table.Entry("match qcow2",
 			Header{"qcow2", []byte{'Q', 'F', 'I', 0xfb}, 0, 24, 8},
 			[]byte{'Q', 'F', 'I', 0xfb},
@@ -0,0 +1,55 @@
+/*
+Copyright 2023 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package image
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+)
+
+// FormatBlankFilesystem creates a filesystem on the blank image at dest,
+// dest may be a raw image file or a block device
+func FormatBlankFilesystem(dest, fsType, label, reservedBlocks string) error {
+	var args []string
+	switch fsType {
+	case "ext4":
+		// mkfs.ext4 asks for confirmation when the target is a regular file
+		args = []string{"-F"}
+		if reservedBlocks != "" {
+			args = append(args, "-m", reservedBlocks)
+		}
+	case "xfs":
+		if reservedBlocks != "" {
+			return errors.Errorf("reserved blocks are only supported on ext4")
+		}
+		args = []string{"-f"}
+	default:
+		return errors.Errorf("unsupported filesystem type %s", fsType)
+	}
+	if label != "" {
+		args = append(args, "-L", label)
+	}
+	args = append(args, dest)
+
+	klog.V(1).Infof("creating %s filesystem on %s", fsType, dest)
+	if _, err := qemuExecFunction(nil, nil, "mkfs."+fsType, args...); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("could not create %s filesystem on %s", fsType, dest))
+	}
+	return nil
+}
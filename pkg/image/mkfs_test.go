@@ -0,0 +1,58 @@
+/*
+Copyright 2023 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package image
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Format blank filesystem", func() {
+	It("Should format ext4 with label and reserved blocks", func() {
+		replaceExecFunction(mockExecFunctionStrict("", "", nil, "-F", "-m", "5", "-L", "data", "image"), func() {
+			err := FormatBlankFilesystem("image", "ext4", "data", "5")
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	It("Should format xfs without reserved blocks", func() {
+		replaceExecFunction(mockExecFunctionStrict("", "", nil, "-f", "image"), func() {
+			err := FormatBlankFilesystem("image", "xfs", "", "")
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	It("Should fail on reserved blocks with xfs", func() {
+		err := FormatBlankFilesystem("image", "xfs", "", "5")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("only supported on ext4"))
+	})
+
+	It("Should fail on an unsupported filesystem type", func() {
+		err := FormatBlankFilesystem("image", "btrfs", "", "")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("unsupported filesystem type"))
+	})
+
+	It("Should fail when mkfs exits with an error", func() {
+		replaceExecFunction(mockExecFunction("", "exit 1", nil, "-F", "image"), func() {
+			err := FormatBlankFilesystem("image", "ext4", "", "")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("could not create ext4 filesystem"))
+		})
+	})
+})
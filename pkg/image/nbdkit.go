@@ -109,6 +109,24 @@ func NewNbdkitCurl(nbdkitPidFile, certDir, socket string, extraHeaders, secretEx
 	}
 }
 
+// FilterAvailable returns true when the given nbdkit filter is installed, so
+// callers can fall back to a scratch space download when it is not.
+func FilterAvailable(filter NbdkitFilter) bool {
+	output, err := exec.Command("nbdkit", "--dump-config").Output()
+	if err != nil {
+		klog.Errorf("Unable to get nbdkit config: %v", err)
+		return false
+	}
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.HasPrefix(line, "filterdir=") {
+			filterDir := strings.TrimSpace(strings.TrimPrefix(line, "filterdir="))
+			_, err = os.Stat(filepath.Join(filterDir, fmt.Sprintf("nbdkit-%s-filter.so", filter)))
+			return err == nil
+		}
+	}
+	return false
+}
+
 // NewNbdkitVddk creates a new Nbdkit instance with the vddk plugin
 func NewNbdkitVddk(nbdkitPidFile, socket, server, username, password, thumbprint, moref string) (NbdkitOperation, error) {
 
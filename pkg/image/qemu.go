@@ -85,6 +85,13 @@ var (
 		},
 		[]string{"ownerUID"},
 	)
+	convertProgress = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: monitoring.MetricOptsList[monitoring.ConvertProgress].Name,
+			Help: monitoring.MetricOptsList[monitoring.ConvertProgress].Help,
+		},
+		[]string{"ownerUID"},
+	)
 	ownerUID                    string
 	convertPreallocationMethods = [][]string{
 		{"-o", "preallocation=falloc"},
@@ -107,6 +114,13 @@ func init() {
 			klog.Errorf("Unable to create prometheus progress counter")
 		}
 	}
+	if err := prometheus.Register(convertProgress); err != nil {
+		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			convertProgress = are.ExistingCollector.(*prometheus.CounterVec)
+		} else {
+			klog.Errorf("Unable to create prometheus conversion progress counter")
+		}
+	}
 	ownerUID, _ = util.ParseEnvVar(common.OwnerUID, false)
 }
 
@@ -115,8 +129,22 @@ func NewQEMUOperations() QEMUOperations {
 	return &qemuOperations{}
 }
 
+// cacheMode returns the qemu cache mode used for writing to dest. Block
+// destinations default to none, bypassing a page cache the data passes through
+// exactly once; file destinations keep writeback. A StorageProfile override
+// reaches the pod through the QEMU_CACHE_MODE environment variable.
+func cacheMode(dest string) string {
+	if mode, _ := util.ParseEnvVar(common.QemuCacheMode, false); mode != "" {
+		return mode
+	}
+	if size, err := util.GetAvailableSpaceBlock(dest); err == nil && size >= 0 {
+		return "none"
+	}
+	return "writeback"
+}
+
 func convertToRaw(src, dest string, preallocate bool) error {
-	args := []string{"convert", "-t", "writeback", "-p", "-O", "raw", src, dest}
+	args := []string{"convert", "-t", cacheMode(dest), "-p", "-O", "raw", src, dest}
 	var err error
 
 	if preallocate {
@@ -264,6 +292,13 @@ func reportProgress(line string) {
 		if err == nil && v > 0 && v > *metric.Counter.Value {
 			progress.WithLabelValues(ownerUID).Add(v - *metric.Counter.Value)
 		}
+		// The overall percentage is already at the transfer result when conversion
+		// starts, so the conversion also reports under its own metric
+		convertMetric := &dto.Metric{}
+		err = convertProgress.WithLabelValues(ownerUID).Write(convertMetric)
+		if err == nil && v > 0 && v > *convertMetric.Counter.Value {
+			convertProgress.WithLabelValues(ownerUID).Add(v - *convertMetric.Counter.Value)
+		}
 	}
 }
 
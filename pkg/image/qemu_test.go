@@ -34,6 +34,7 @@ import (
 
 	dto "github.com/prometheus/client_model/go"
 
+	"kubevirt.io/containerized-data-importer/pkg/common"
 	"kubevirt.io/containerized-data-importer/pkg/system"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -187,6 +188,17 @@ var _ = Describe("Convert to Raw", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 	})
+
+	It("should use the cache mode from the environment if set", func() {
+		os.Setenv(common.QemuCacheMode, "none")
+		defer os.Unsetenv(common.QemuCacheMode)
+		replaceExecFunction(mockExecFunctionStrict("", "", nil, "convert", "-t", "none", "-p", "-O", "raw", "/somefile/somewhere", destPath), func() {
+			ep, err := url.Parse("/somefile/somewhere")
+			Expect(err).NotTo(HaveOccurred())
+			err = ConvertToRawStream(ep, destPath, false)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
 })
 
 var _ = Describe("Resize", func() {
@@ -251,6 +263,13 @@ var _ = Describe("Report Progress", func() {
 			},
 			[]string{"ownerUID"},
 		)
+		convertProgress = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "convert_progress",
+				Help: "The progress of the current qemu-img conversion in percentage",
+			},
+			[]string{"ownerUID"},
+		)
 	})
 
 	It("Parse valid progress line", func() {
@@ -267,6 +286,15 @@ var _ = Describe("Report Progress", func() {
 		Expect(*metric.Counter.Value).To(Equal(45.34))
 	})
 
+	It("Track the conversion under its own metric", func() {
+		By("Calling reportProgress with value")
+		reportProgress("(45.34/100%)")
+		metric := &dto.Metric{}
+		err := convertProgress.WithLabelValues(ownerUID).Write(metric)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*metric.Counter.Value).To(Equal(45.34))
+	})
+
 	It("Parse invalid progress line", func() {
 		By("Verifying the initial value is 0")
 		progress.WithLabelValues(ownerUID).Add(0)
@@ -7,6 +7,8 @@ const (
 	ExtIso = ".iso"
 	// ExtGz is a constant for the .gz extenstion
 	ExtGz = ".gz"
+	// ExtBz2 is a constant for the .bz2 extenstion
+	ExtBz2 = ".bz2"
 	// ExtQcow2 is a constant for the .qcow2 extenstion
 	ExtQcow2 = ".qcow2"
 	// ExtVmdk is a constant for the .vmdk VMware extenstion
@@ -272,6 +272,7 @@ func (dp *DataProcessor) ProcessDataWithPause() error {
 		if !ok {
 			return errors.Errorf("Unknown processing phase %s", dp.currentPhase)
 		}
+		reportProcessingPhase(dp.currentPhase)
 		nextPhase, err := executor()
 		visited[dp.currentPhase] = true
 		if err != nil {
@@ -18,6 +18,7 @@ package importer
 
 import (
 	"bytes"
+	"compress/bzip2"
 	"compress/gzip"
 	"encoding/hex"
 	"io"
@@ -75,11 +76,13 @@ type FormatReaders struct {
 	Archived       bool
 	ArchiveXz      bool
 	ArchiveGz      bool
+	ArchiveBz2     bool
 	progressReader *prometheusutil.ProgressReader
 }
 
 const (
-	rdrGz = iota
+	rdrBz2 = iota
+	rdrGz
 	rdrMulti
 	rdrXz
 	rdrStream
@@ -87,6 +90,7 @@ const (
 
 // map scheme and format to rdrType
 var rdrTypM = map[string]int{
+	"bz2":    rdrBz2,
 	"gz":     rdrGz,
 	"xz":     rdrXz,
 	"stream": rdrStream,
@@ -100,6 +104,7 @@ func NewFormatReaders(stream io.ReadCloser, total uint64) (*FormatReaders, error
 	}
 	if total > uint64(0) {
 		readers.progressReader = prometheusutil.NewProgressReader(stream, total, progress, ownerUID)
+		readers.progressReader.ReportBytes(progressBytesDone, progressBytesTotal)
 		err = readers.constructReaders(readers.progressReader)
 	} else {
 		err = readers.constructReaders(stream)
@@ -164,6 +169,12 @@ func (fr *FormatReaders) fileFormatSelector(hdr *image.Header) {
 	var err error
 	fFmt := hdr.Format
 	switch fFmt {
+	case "bz2":
+		r, err = fr.bz2Reader()
+		if err == nil {
+			fr.Archived = true
+			fr.ArchiveBz2 = true
+		}
 	case "gz":
 		r, err = fr.gzReader()
 		if err == nil {
@@ -211,6 +222,16 @@ func (fr *FormatReaders) gzReader() (io.ReadCloser, error) {
 	return gz, nil
 }
 
+// Return the bz2 reader of the endpoint "through the eye" of the previous reader.
+// Assumes a single file was compressed. Note: the bzip2 reader is not a closer so we wrap a
+// nop Closer around it.
+//NOTE: size is not stored in the bz2 header. This may require the file to be decompressed in
+//  order to get its original size. For now 0 is returned.
+//TODO: support bz2 size.
+func (fr *FormatReaders) bz2Reader() (io.Reader, error) {
+	return bzip2.NewReader(fr.TopReader()), nil
+}
+
 // Return the size of the endpoint "through the eye" of the previous reader. Note: there is no
 // qcow2 reader so nil is returned so that nothing is appended to the reader stack.
 // Note: size is stored at offset 24 in the qcow2 header.
@@ -23,6 +23,7 @@ var (
 	tinyCoreFilePath          = filepath.Join(imageDir, tinyCoreFileName)
 	tinyCoreXzFilePath, _     = utils.FormatTestData(tinyCoreFilePath, os.TempDir(), image.ExtXz)
 	tinyCoreGzFilePath, _     = utils.FormatTestData(tinyCoreFilePath, os.TempDir(), image.ExtGz)
+	tinyCoreBz2FilePath, _    = utils.FormatTestData(tinyCoreFilePath, os.TempDir(), image.ExtBz2)
 	tinyCoreTarFilePath, _    = utils.FormatTestData(tinyCoreFilePath, os.TempDir(), image.ExtTar)
 	archiveFilePath, _        = utils.ArchiveFiles(archiveFileNameWithoutExt, os.TempDir(), tinyCoreFilePath, cirrosFilePath)
 	archiveFileNameWithoutExt = strings.TrimSuffix(archiveFileName, filepath.Ext(archiveFileName))
@@ -62,6 +63,7 @@ var _ = Describe("Format Readers", func() {
 	},
 		table.Entry("successfully construct a xz reader", tinyCoreXzFilePath, 4, false, true, false),              // [stream, multi-r, xz, multi-r] convert = false
 		table.Entry("successfully construct a gz reader", tinyCoreGzFilePath, 4, false, true, false),              // [stream, multi-r, gz, multi-r] convert = false
+		table.Entry("successfully construct a bz2 reader", tinyCoreBz2FilePath, 4, false, true, false),            // [stream, multi-r, bz2, multi-r] convert = false
 		table.Entry("successfully return the base reader when archived", archiveFilePath, 3, false, false, false), // [stream, multi-r, multi-r] convert = false
 		table.Entry("successfully construct qcow2 reader", cirrosFilePath, 2, false, false, true),                 // [stream, multi-r] convert = true
 		table.Entry("successfully construct .iso reader", tinyCoreFilePath, 2, false, false, false),               // [stream, multi-r] convert = false
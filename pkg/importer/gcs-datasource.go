@@ -0,0 +1,241 @@
+package importer
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/util"
+)
+
+const (
+	gcsScheme    = "gs"
+	gcsReadScope = "https://www.googleapis.com/auth/devstorage.read_only"
+	gcsJWTGrant  = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+)
+
+// may be overridden in tests
+var gcsObjectURLFunc = func(bucket, object string) string {
+	return fmt.Sprintf("https://storage.googleapis.com/%s/%s", bucket, object)
+}
+
+// gcsServiceAccount is the subset of the service account JSON key the importer needs
+type gcsServiceAccount struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// GCSDataSource is the struct containing the information needed to import from a GCS data source.
+// Sequence of phases:
+// 1. Info -> Transfer
+// 2. Transfer -> Convert
+type GCSDataSource struct {
+	// GCS endpoint
+	ep *url.URL
+	// Service account JSON key
+	serviceAccount string
+	// Reader
+	gcsReader io.ReadCloser
+	// stack of readers
+	readers *FormatReaders
+	// The image file in scratch space.
+	url *url.URL
+}
+
+// NewGCSDataSource creates a new instance of the GCSDataSource
+func NewGCSDataSource(endpoint, serviceAccount string) (*GCSDataSource, error) {
+	ep, err := ParseEndpoint(endpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("unable to parse endpoint %q", endpoint))
+	}
+	if ep.Scheme != gcsScheme {
+		return nil, errors.Errorf("endpoint %q is not a gs:// URL", endpoint)
+	}
+	gcsReader, err := createGCSReader(ep, serviceAccount)
+	if err != nil {
+		return nil, err
+	}
+	return &GCSDataSource{
+		ep:             ep,
+		serviceAccount: serviceAccount,
+		gcsReader:      gcsReader,
+	}, nil
+}
+
+// Info is called to get initial information about the data.
+func (sd *GCSDataSource) Info() (ProcessingPhase, error) {
+	var err error
+	sd.readers, err = NewFormatReaders(sd.gcsReader, uint64(0))
+	if err != nil {
+		klog.Errorf("Error creating readers: %v", err)
+		return ProcessingPhaseError, err
+	}
+	if !sd.readers.Convert {
+		// Downloading a raw file, we can write that directly to the target.
+		return ProcessingPhaseTransferDataFile, nil
+	}
+
+	return ProcessingPhaseTransferScratch, nil
+}
+
+// Transfer is called to transfer the data from the source to a temporary location.
+func (sd *GCSDataSource) Transfer(path string) (ProcessingPhase, error) {
+	size, _ := util.GetAvailableSpace(path)
+	if size <= int64(0) {
+		//Path provided is invalid.
+		return ProcessingPhaseError, ErrInvalidPath
+	}
+	file := filepath.Join(path, tempFile)
+	err := util.StreamDataToFile(sd.readers.TopReader(), file)
+	if err != nil {
+		return ProcessingPhaseError, err
+	}
+	// If streaming succeeded, then parsing the file into URL will also succeed, no need to check error status
+	sd.url, _ = url.Parse(file)
+	return ProcessingPhaseConvert, nil
+}
+
+// TransferFile is called to transfer the data from the source to the passed in file.
+func (sd *GCSDataSource) TransferFile(fileName string) (ProcessingPhase, error) {
+	err := util.StreamDataToFile(sd.readers.TopReader(), fileName)
+	if err != nil {
+		return ProcessingPhaseError, err
+	}
+	return ProcessingPhaseResize, nil
+}
+
+// GetURL returns the url that the data processor can use when converting the data.
+func (sd *GCSDataSource) GetURL() *url.URL {
+	return sd.url
+}
+
+// Close closes any readers or other open resources.
+func (sd *GCSDataSource) Close() error {
+	var err error
+	if sd.readers != nil {
+		err = sd.readers.Close()
+	}
+	return err
+}
+
+func createGCSReader(ep *url.URL, serviceAccount string) (io.ReadCloser, error) {
+	klog.V(3).Infoln("Using HTTPS to get GCS data")
+
+	bucket := ep.Host
+	object := strings.Trim(ep.Path, "/")
+	klog.V(1).Infof("bucket %s", bucket)
+	klog.V(1).Infof("object %s", object)
+
+	req, err := http.NewRequest(http.MethodGet, gcsObjectURLFunc(bucket, object), nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not build request for gcs object: \"%s/%s\"", bucket, object)
+	}
+	if serviceAccount != "" {
+		token, err := gcsAccessToken(serviceAccount)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get gcs object: \"%s/%s\"", bucket, object)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, errors.Errorf("could not get gcs object \"%s/%s\": expected status code 200, got %d", bucket, object, resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+// gcsAccessToken exchanges the service account JSON key for a short lived OAuth2
+// access token by signing a JWT bearer grant, avoiding a dependency on the
+// Google Cloud SDK
+func gcsAccessToken(serviceAccount string) (string, error) {
+	sa := &gcsServiceAccount{}
+	if err := json.Unmarshal([]byte(serviceAccount), sa); err != nil {
+		return "", errors.Wrap(err, "could not parse service account JSON")
+	}
+	assertion, err := signServiceAccountJWT(sa)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.PostForm(sa.TokenURI, url.Values{
+		"grant_type": {gcsJWTGrant},
+		"assertion":  {assertion},
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "could not exchange service account token")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return "", errors.Errorf("token endpoint returned status %d: %s", resp.StatusCode, string(body))
+	}
+	token := &struct {
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(token); err != nil {
+		return "", errors.Wrap(err, "could not decode token endpoint response")
+	}
+	if token.AccessToken == "" {
+		return "", errors.New("token endpoint returned no access token")
+	}
+	return token.AccessToken, nil
+}
+
+func signServiceAccountJWT(sa *gcsServiceAccount) (string, error) {
+	block, _ := pem.Decode([]byte(sa.PrivateKey))
+	if block == nil {
+		return "", errors.New("no PEM block in service account private key")
+	}
+	var key *rsa.PrivateKey
+	if parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		rsaKey, ok := parsed.(*rsa.PrivateKey)
+		if !ok {
+			return "", errors.New("service account private key is not an RSA key")
+		}
+		key = rsaKey
+	} else if rsaKey, pkcs1Err := x509.ParsePKCS1PrivateKey(block.Bytes); pkcs1Err == nil {
+		key = rsaKey
+	} else {
+		return "", errors.Wrap(err, "could not parse service account private key")
+	}
+	now := time.Now()
+	claims, err := json.Marshal(map[string]interface{}{
+		"iss":   sa.ClientEmail,
+		"scope": gcsReadScope,
+		"aud":   sa.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+	signingInput := header + "." + base64.RawURLEncoding.EncodeToString(claims)
+	hashed := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hashed[:])
+	if err != nil {
+		return "", errors.Wrap(err, "could not sign service account JWT")
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
@@ -0,0 +1,176 @@
+package importer
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GCS data source", func() {
+	var (
+		sd     *GCSDataSource
+		ts     *httptest.Server
+		tmpDir string
+		err    error
+	)
+
+	BeforeEach(func() {
+		ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		gcsObjectURLFunc = func(bucket, object string) string {
+			return fmt.Sprintf("%s/%s/%s", ts.URL, bucket, object)
+		}
+		tmpDir, err = ioutil.TempDir("", "scratch")
+		Expect(err).NotTo(HaveOccurred())
+		By("tmpDir: " + tmpDir)
+	})
+
+	AfterEach(func() {
+		gcsObjectURLFunc = func(bucket, object string) string {
+			return fmt.Sprintf("https://storage.googleapis.com/%s/%s", bucket, object)
+		}
+		ts.Close()
+		if sd != nil {
+			sd.Close()
+		}
+		os.RemoveAll(tmpDir)
+	})
+
+	It("NewGCSDataSource should Error, when passed in an invalid endpoint", func() {
+		sd, err = NewGCSDataSource("thisisinvalid#$%#ep", "")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("NewGCSDataSource should Error, when passed an endpoint without the gs scheme", func() {
+		sd, err = NewGCSDataSource("http://bucket-1/object-1", "")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("NewGCSDataSource should Error, when the object cannot be fetched", func() {
+		ts.Close()
+		sd, err = NewGCSDataSource("gs://bucket-1/object-1", "")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("NewGCSDataSource should Error, when the object does not exist", func() {
+		gcsObjectURLFunc = func(bucket, object string) string {
+			return ts.URL + "/does-not-exist"
+		}
+		ts.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+		sd, err = NewGCSDataSource("gs://bucket-1/object-1", "")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Info should return Transfer, when passed in a valid image", func() {
+		// Don't need to defer close, since sd.Close will close the reader
+		file, err := os.Open(cirrosFilePath)
+		Expect(err).NotTo(HaveOccurred())
+		sd, err = NewGCSDataSource("gs://bucket-1/object-1", "")
+		Expect(err).NotTo(HaveOccurred())
+		sd.gcsReader = file
+		result, err := sd.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ProcessingPhaseTransferScratch).To(Equal(result))
+	})
+
+	It("Info should return TransferDataFile, when passed in a valid raw image", func() {
+		// Don't need to defer close, since sd.Close will close the reader
+		file, err := os.Open(tinyCoreFilePath)
+		Expect(err).NotTo(HaveOccurred())
+		sd, err = NewGCSDataSource("gs://bucket-1/object-1", "")
+		Expect(err).NotTo(HaveOccurred())
+		sd.gcsReader = file
+		result, err := sd.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ProcessingPhaseTransferDataFile).To(Equal(result))
+	})
+
+	It("Transfer should succeed with scratch space and a valid qcow file", func() {
+		sourceFile, err := os.Open(cirrosFilePath)
+		Expect(err).NotTo(HaveOccurred())
+		sd, err = NewGCSDataSource("gs://bucket-1/object-1", "")
+		Expect(err).NotTo(HaveOccurred())
+		sd.gcsReader = sourceFile
+		nextPhase, err := sd.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ProcessingPhaseTransferScratch).To(Equal(nextPhase))
+		result, err := sd.Transfer(tmpDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ProcessingPhaseConvert).To(Equal(result))
+		file, err := os.Open(filepath.Join(tmpDir, tempFile))
+		Expect(err).NotTo(HaveOccurred())
+		defer file.Close()
+		Expect(file.Name()).To(Equal(sd.GetURL().String()))
+	})
+
+	It("TransferFile should succeed when writing to valid file", func() {
+		// Don't need to defer close, since sd.Close will close the reader
+		file, err := os.Open(tinyCoreFilePath)
+		Expect(err).NotTo(HaveOccurred())
+		sd, err = NewGCSDataSource("gs://bucket-1/object-1", "")
+		Expect(err).NotTo(HaveOccurred())
+		sd.gcsReader = file
+		result, err := sd.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ProcessingPhaseTransferDataFile).To(Equal(result))
+		result, err = sd.TransferFile(filepath.Join(tmpDir, "file"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ProcessingPhaseResize).To(Equal(result))
+	})
+
+	It("Should exchange a service account JSON key for an access token and send it", func() {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).NotTo(HaveOccurred())
+		keyPem := pem.EncodeToMemory(&pem.Block{
+			Type:  "PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(key),
+		})
+		tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.FormValue("grant_type")).To(Equal(gcsJWTGrant))
+			Expect(r.FormValue("assertion")).ToNot(BeEmpty())
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"access_token": "test-token"}`)
+		}))
+		defer tokenServer.Close()
+		serviceAccount, err := json.Marshal(map[string]string{
+			"client_email": "importer@example.iam.gserviceaccount.com",
+			"private_key":  string(keyPem),
+			"token_uri":    tokenServer.URL,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		var authorization string
+		ts.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorization = r.Header.Get("Authorization")
+			w.WriteHeader(http.StatusOK)
+		})
+		sd, err = NewGCSDataSource("gs://bucket-1/object-1", string(serviceAccount))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(authorization).To(Equal("Bearer test-token"))
+	})
+
+	It("Should Error on a service account JSON key without a valid private key", func() {
+		serviceAccount, err := json.Marshal(map[string]string{
+			"client_email": "importer@example.iam.gserviceaccount.com",
+			"private_key":  "not a PEM key",
+			"token_uri":    "http://localhost",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		sd, err = NewGCSDataSource("gs://bucket-1/object-1", string(serviceAccount))
+		Expect(err).To(HaveOccurred())
+	})
+})
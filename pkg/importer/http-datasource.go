@@ -18,9 +18,11 @@ package importer
 
 import (
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
+	"hash"
 	"io"
 	"io/fs"
 	"io/ioutil"
@@ -34,6 +36,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/openshift/library-go/pkg/crypto"
 	"github.com/pkg/errors"
 
 	"k8s.io/klog/v2"
@@ -76,6 +79,10 @@ type HTTPDataSource struct {
 	brokenForQemuImg bool
 	// the content length reported by the http server.
 	contentLength uint64
+	// the expected "sha256:" digest of the stream. Empty if not verified
+	expectedChecksum string
+	// hashes the stream while it is downloaded, nil when no checksum is expected
+	checksumReader *checksumReader
 
 	n image.NbdkitOperation
 }
@@ -105,6 +112,9 @@ func NewHTTPDataSource(endpoint, accessKey, secKey, certDir string, contentType
 	if accessKey != "" && secKey != "" {
 		ep.User = url.UserPassword(accessKey, secKey)
 	}
+	// We know this is a counting reader, so no need to check.
+	countingReader := httpReader.(*util.CountingReader)
+
 	httpSource := &HTTPDataSource{
 		ctx:              ctx,
 		cancel:           cancel,
@@ -114,10 +124,13 @@ func NewHTTPDataSource(endpoint, accessKey, secKey, certDir string, contentType
 		customCA:         certDir,
 		brokenForQemuImg: brokenForQemuImg,
 		contentLength:    contentLength,
+		expectedChecksum: os.Getenv(common.ImporterChecksumVar),
+	}
+	if httpSource.expectedChecksum != "" {
+		httpSource.checksumReader = newChecksumReader(httpReader)
+		httpSource.httpReader = httpSource.checksumReader
 	}
 	httpSource.n = createNbdkitCurl(nbdkitPid, certDir, nbdkitSocket, extraHeaders, secretExtraHeaders)
-	// We know this is a counting reader, so no need to check.
-	countingReader := httpReader.(*util.CountingReader)
 	go httpSource.pollProgress(countingReader, 10*time.Minute, time.Second)
 	return httpSource, nil
 }
@@ -131,13 +144,29 @@ func (hs *HTTPDataSource) Info() (ProcessingPhase, error) {
 		return ProcessingPhaseError, err
 	}
 	hs.url, _ = url.Parse(fmt.Sprintf("nbd+unix:///?socket=%s", nbdkitSocket))
+	// nbdkit decompresses on the fly, so qemu-img can read compressed images
+	// straight from the NBD socket. Fall back to a scratch space download when
+	// the needed filter is not installed.
+	missingFilter := false
 	if hs.readers.ArchiveGz {
-		hs.n.AddFilter(image.NbdkitGzipFilter)
-		klog.V(2).Infof("Added nbdkit gzip filter")
+		if image.FilterAvailable(image.NbdkitGzipFilter) {
+			hs.n.AddFilter(image.NbdkitGzipFilter)
+			klog.V(2).Infof("Added nbdkit gzip filter")
+		} else {
+			missingFilter = true
+		}
 	}
 	if hs.readers.ArchiveXz {
-		hs.n.AddFilter(image.NbdkitXzFilter)
-		klog.V(2).Infof("Added nbdkit xz filter")
+		if image.FilterAvailable(image.NbdkitXzFilter) {
+			hs.n.AddFilter(image.NbdkitXzFilter)
+			klog.V(2).Infof("Added nbdkit xz filter")
+		} else {
+			missingFilter = true
+		}
+	}
+	if hs.readers.ArchiveBz2 {
+		// nbdkit has no bzip2 filter, always decompress through scratch space
+		missingFilter = true
 	}
 	if err = hs.n.StartNbdkit(hs.endpoint.String()); err != nil {
 		return ProcessingPhaseError, err
@@ -145,15 +174,21 @@ func (hs *HTTPDataSource) Info() (ProcessingPhase, error) {
 	if hs.contentType == cdiv1.DataVolumeArchive {
 		return ProcessingPhaseTransferDataDir, nil
 	}
-	if hs.brokenForQemuImg || (hs.readers.ArchiveGz && hs.readers.Convert) {
-		// Either broken for qemu-img, so we have to download first OR we are converting
-		// a qcow2 that is gzipped (which means we have to download the image anyway)
+	if hs.brokenForQemuImg || missingFilter {
+		// Either broken for qemu-img, so we have to download first, OR the image
+		// is compressed and nbdkit cannot decompress it on the fly
 		return ProcessingPhaseTransferScratch, nil
 	}
 	if hs.customCA != "" {
 		klog.V(1).Infof("Custom CA requested, using scratch space")
 		return ProcessingPhaseTransferScratch, nil
 	}
+	if hs.expectedChecksum != "" {
+		// the digest can only be computed when the stream passes through our
+		// readers, the direct qemu-img path would bypass it
+		klog.V(1).Infof("Checksum verification requested, using scratch space")
+		return ProcessingPhaseTransferScratch, nil
+	}
 	if !hs.readers.Archived && hs.readers.Convert {
 		// We can pass straight to conversion from the endpoint
 		return ProcessingPhaseConvert, nil
@@ -174,6 +209,9 @@ func (hs *HTTPDataSource) Transfer(path string) (ProcessingPhase, error) {
 		if err != nil {
 			return ProcessingPhaseError, err
 		}
+		if err := hs.verifyChecksum(); err != nil {
+			return ProcessingPhaseError, err
+		}
 		// If we successfully wrote to the file, then the parse will succeed.
 		hs.url, _ = url.Parse(file)
 		return ProcessingPhaseConvert, nil
@@ -181,6 +219,9 @@ func (hs *HTTPDataSource) Transfer(path string) (ProcessingPhase, error) {
 		if err := util.UnArchiveTar(hs.readers.TopReader(), path); err != nil {
 			return ProcessingPhaseError, errors.Wrap(err, "unable to untar files from endpoint")
 		}
+		if err := hs.verifyChecksum(); err != nil {
+			return ProcessingPhaseError, err
+		}
 		hs.url = nil
 		return ProcessingPhaseComplete, nil
 	}
@@ -194,9 +235,25 @@ func (hs *HTTPDataSource) TransferFile(fileName string) (ProcessingPhase, error)
 	if err != nil {
 		return ProcessingPhaseError, err
 	}
+	if err := hs.verifyChecksum(); err != nil {
+		return ProcessingPhaseError, err
+	}
 	return ProcessingPhaseResize, nil
 }
 
+// verifyChecksum compares the digest of the downloaded stream against the
+// expected checksum, called once the stream has been fully consumed
+func (hs *HTTPDataSource) verifyChecksum() error {
+	if hs.checksumReader == nil {
+		return nil
+	}
+	if actual := hs.checksumReader.Checksum(); actual != hs.expectedChecksum {
+		return errors.Errorf("checksum mismatch, endpoint delivered %s instead of %s", actual, hs.expectedChecksum)
+	}
+	klog.V(1).Infof("Verified endpoint checksum %s", hs.expectedChecksum)
+	return nil
+}
+
 // GetURL returns the URI that the data processor can use when converting the data.
 func (hs *HTTPDataSource) GetURL() *url.URL {
 	return hs.url
@@ -217,6 +274,35 @@ func (hs *HTTPDataSource) Close() error {
 	return err
 }
 
+// checksumReader hashes the stream while it is read, so the digest can be
+// compared against the expected checksum once the download completes
+type checksumReader struct {
+	rc   io.ReadCloser
+	hash hash.Hash
+}
+
+func newChecksumReader(rc io.ReadCloser) *checksumReader {
+	return &checksumReader{
+		rc:   rc,
+		hash: sha256.New(),
+	}
+}
+
+func (cr *checksumReader) Read(p []byte) (int, error) {
+	n, err := cr.rc.Read(p)
+	cr.hash.Write(p[:n])
+	return n, err
+}
+
+func (cr *checksumReader) Close() error {
+	return cr.rc.Close()
+}
+
+// Checksum returns the digest of everything read so far
+func (cr *checksumReader) Checksum() string {
+	return fmt.Sprintf("sha256:%x", cr.hash.Sum(nil))
+}
+
 func createCertPool(certDir string) (*x509.CertPool, error) {
 	// let's get system certs as well
 	certPool, err := x509.SystemCertPool()
@@ -270,25 +356,97 @@ func createHTTPClient(certDir string) (*http.Client, error) {
 		// Don't set timeout here, since that will be an absolute timeout, we need a relative to last progress timeout.
 	}
 
-	if certDir == "" {
-		return client, nil
-	}
-
-	certPool, err := createCertPool(certDir)
+	minVersion, ciphers, err := tlsPolicyFromEnv()
 	if err != nil {
 		return nil, err
 	}
 
 	// the default transport contains Proxy configurations to use environment variables and default timeouts
 	transport := http.DefaultTransport.(*http.Transport).Clone()
-	transport.TLSClientConfig = &tls.Config{
-		RootCAs: certPool,
+	transport.Proxy = importProxyFromEnvironment
+
+	if certDir != "" || minVersion != 0 || ciphers != nil {
+		tlsConfig := &tls.Config{
+			MinVersion:   minVersion,
+			CipherSuites: ciphers,
+		}
+
+		if certDir != "" {
+			certPool, err := createCertPool(certDir)
+			if err != nil {
+				return nil, err
+			}
+			tlsConfig.RootCAs = certPool
+		}
+
+		transport.TLSClientConfig = tlsConfig
 	}
+
 	client.Transport = transport
 
 	return client, nil
 }
 
+// importProxyFromEnvironment resolves the proxy like http.ProxyFromEnvironment and attaches the
+// credentials the controller passes through a secret, so they do not have to be embedded in the
+// proxy URLs where they would leak into the pod spec, logs and events.
+func importProxyFromEnvironment(req *http.Request) (*url.URL, error) {
+	proxyURL, err := http.ProxyFromEnvironment(req)
+	if err != nil || proxyURL == nil {
+		return proxyURL, err
+	}
+	username, _ := util.ParseEnvVar(common.ImporterProxyUsernameVar, false)
+	if username == "" {
+		return proxyURL, nil
+	}
+	password, _ := util.ParseEnvVar(common.ImporterProxyPasswordVar, false)
+	// copy the URL, http.ProxyFromEnvironment may return a shared cached instance
+	authURL := *proxyURL
+	authURL.User = url.UserPassword(username, password)
+	return &authURL, nil
+}
+
+// tlsPolicyFromEnv parses the TLS settings the controller propagates from the
+// cluster TLSSecurityProfile. Zero values mean the golang defaults apply.
+func tlsPolicyFromEnv() (uint16, []uint16, error) {
+	var minVersion uint16
+	var ciphers []uint16
+	if name, _ := util.ParseEnvVar(common.ImporterTLSMinVersionVar, false); name != "" {
+		version, err := crypto.TLSVersion(name)
+		if err != nil {
+			return 0, nil, errors.Wrapf(err, "Invalid %s", common.ImporterTLSMinVersionVar)
+		}
+		minVersion = version
+	}
+	if list, _ := util.ParseEnvVar(common.ImporterTLSCiphersVar, false); list != "" {
+		for _, name := range strings.Split(list, ",") {
+			cipher, err := crypto.CipherSuite(name)
+			if err != nil {
+				return 0, nil, errors.Wrapf(err, "Invalid %s", common.ImporterTLSCiphersVar)
+			}
+			ciphers = append(ciphers, cipher)
+		}
+	}
+	return minVersion, ciphers, nil
+}
+
+// wrapHTTPError points at the cluster TLS policy when the handshake fails
+// because the endpoint cannot satisfy it, since the bare TLS alert is hard
+// to diagnose.
+func wrapHTTPError(err error) error {
+	msg := err.Error()
+	if strings.Contains(msg, "protocol version not supported") ||
+		strings.Contains(msg, "unsupported protocol version") ||
+		strings.Contains(msg, "handshake failure") {
+		version, _ := util.ParseEnvVar(common.ImporterTLSMinVersionVar, false)
+		ciphers, _ := util.ParseEnvVar(common.ImporterTLSCiphersVar, false)
+		if version != "" || ciphers != "" {
+			return errors.Wrap(err, "endpoint does not offer a TLS version or cipher allowed by the cluster TLS security profile")
+		}
+	}
+	return errors.Wrap(err, "HTTP request errored")
+}
+
 func addExtraheaders(req *http.Request, extraHeaders []string) {
 	for _, header := range extraHeaders {
 		parts := strings.SplitN(header, ":", 2)
@@ -331,7 +489,7 @@ func createHTTPReader(ctx context.Context, ep *url.URL, accessKey, secKey, certD
 	klog.V(2).Infof("Attempting to get object %q via http client\n", ep.String())
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, uint64(0), true, errors.Wrap(err, "HTTP request errored")
+		return nil, uint64(0), true, wrapHTTPError(err)
 	}
 	if resp.StatusCode != 200 {
 		klog.Errorf("http: expected status code 200, got %d", resp.StatusCode)
@@ -396,7 +554,7 @@ func getContentLength(client *http.Client, ep *url.URL, accessKey, secKey string
 	klog.V(2).Infof("Attempting to HEAD %q via http client\n", ep.String())
 	resp, err := client.Do(req)
 	if err != nil {
-		return uint64(0), errors.Wrap(err, "HTTP request errored")
+		return uint64(0), wrapHTTPError(err)
 	}
 
 	if resp.StatusCode != 200 {
@@ -435,6 +593,11 @@ func parseHTTPHeader(resp *http.Response) uint64 {
 func getExtraHeaders() ([]string, []string, error) {
 	extraHeaders := getExtraHeadersFromEnvironment()
 	secretExtraHeaders, err := getExtraHeadersFromSecrets()
+	if token := os.Getenv(common.ImporterExportTokenVar); token != "" {
+		// the endpoint is the export server of another cluster, present the
+		// token it paired with the export URL
+		secretExtraHeaders = append(secretExtraHeaders, fmt.Sprintf("%s: %s", common.ExportServerTokenHeader, token))
+	}
 	return extraHeaders, secretExtraHeaders, err
 }
 
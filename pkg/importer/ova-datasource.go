@@ -0,0 +1,182 @@
+package importer
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/util"
+)
+
+const (
+	ovaS3Scheme = "s3"
+	ovaDiskExt  = ".vmdk"
+)
+
+// OVADataSource is the struct containing the information needed to import the disk image
+// contained in an OVA archive. The archive is streamed, the first disk image entry is
+// extracted and handed to the data processor for conversion.
+// Sequence of phases:
+// 1. Info -> Transfer
+// 2. Transfer -> Convert
+type OVADataSource struct {
+	// OVA endpoint
+	ep *url.URL
+	// User name
+	accessKey string
+	// Password
+	secKey string
+	// Reader of the raw OVA archive
+	ovaReader io.ReadCloser
+	// stack of readers on top of the disk image entry
+	readers *FormatReaders
+	// The image file in scratch space.
+	url *url.URL
+}
+
+// NewOVADataSource creates a new instance of the OVADataSource
+func NewOVADataSource(endpoint, accessKey, secKey string, certDir string) (*OVADataSource, error) {
+	ep, err := ParseEndpoint(endpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("unable to parse endpoint %q", endpoint))
+	}
+	ovaReader, err := createOVAReader(ep, accessKey, secKey, certDir)
+	if err != nil {
+		return nil, err
+	}
+	return &OVADataSource{
+		ep:        ep,
+		accessKey: accessKey,
+		secKey:    secKey,
+		ovaReader: ovaReader,
+	}, nil
+}
+
+// Info is called to get initial information about the data. The OVA archive is a tar
+// stream, walk it until the first disk image entry and build the format readers on
+// top of that entry.
+func (od *OVADataSource) Info() (ProcessingPhase, error) {
+	diskReader, err := od.findDiskImage()
+	if err != nil {
+		klog.Errorf("Error locating disk image in OVA archive: %v", err)
+		return ProcessingPhaseError, err
+	}
+	od.readers, err = NewFormatReaders(ioutil.NopCloser(diskReader), uint64(0))
+	if err != nil {
+		klog.Errorf("Error creating readers: %v", err)
+		return ProcessingPhaseError, err
+	}
+	if !od.readers.Convert {
+		// The disk image is raw, we can write it directly to the target.
+		return ProcessingPhaseTransferDataFile, nil
+	}
+
+	return ProcessingPhaseTransferScratch, nil
+}
+
+// Transfer is called to transfer the data from the source to a temporary location.
+func (od *OVADataSource) Transfer(path string) (ProcessingPhase, error) {
+	size, _ := util.GetAvailableSpace(path)
+	if size <= int64(0) {
+		//Path provided is invalid.
+		return ProcessingPhaseError, ErrInvalidPath
+	}
+	file := filepath.Join(path, tempFile)
+	err := util.StreamDataToFile(od.readers.TopReader(), file)
+	if err != nil {
+		return ProcessingPhaseError, err
+	}
+	// If streaming succeeded, then parsing the file into URL will also succeed, no need to check error status
+	od.url, _ = url.Parse(file)
+	return ProcessingPhaseConvert, nil
+}
+
+// TransferFile is called to transfer the data from the source to the passed in file.
+func (od *OVADataSource) TransferFile(fileName string) (ProcessingPhase, error) {
+	err := util.StreamDataToFile(od.readers.TopReader(), fileName)
+	if err != nil {
+		return ProcessingPhaseError, err
+	}
+	return ProcessingPhaseResize, nil
+}
+
+// GetURL returns the url that the data processor can use when converting the data.
+func (od *OVADataSource) GetURL() *url.URL {
+	return od.url
+}
+
+// Close closes any readers or other open resources.
+func (od *OVADataSource) Close() error {
+	var err error
+	if od.readers != nil {
+		err = od.readers.Close()
+	}
+	if od.ovaReader != nil {
+		if closeErr := od.ovaReader.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// findDiskImage advances the tar stream to the first disk image entry. An OVA holds
+// the OVF descriptor and one or more disks, a DataVolume targets a single PVC so
+// only the first disk is imported.
+func (od *OVADataSource) findDiskImage() (io.Reader, error) {
+	tarReader := tar.NewReader(od.ovaReader)
+	for {
+		hdr, err := tarReader.Next()
+		if err == io.EOF {
+			return nil, errors.Errorf("no disk image found in OVA archive %q", od.ep.String())
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read OVA archive")
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if strings.EqualFold(filepath.Ext(hdr.Name), ovaDiskExt) {
+			klog.V(1).Infof("importing disk image %q from OVA archive", hdr.Name)
+			return tarReader, nil
+		}
+		klog.V(3).Infof("skipping OVA archive entry %q", hdr.Name)
+	}
+}
+
+func createOVAReader(ep *url.URL, accessKey, secKey string, certDir string) (io.ReadCloser, error) {
+	if ep.Scheme == ovaS3Scheme {
+		klog.V(3).Infoln("Using S3 client to get OVA data")
+		return createS3Reader(ep, accessKey, secKey, certDir)
+	}
+
+	klog.V(3).Infoln("Using HTTP(S) to get OVA data")
+	client, err := createHTTPClient(certDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error creating http client for OVA")
+	}
+	req, err := http.NewRequest(http.MethodGet, ep.String(), nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not build request for OVA archive %q", ep.String())
+	}
+	if accessKey != "" && secKey != "" {
+		req.SetBasicAuth(accessKey, secKey)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get OVA archive %q", ep.String())
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, errors.Errorf("could not get OVA archive %q: expected status code 200, got %d", ep.String(), resp.StatusCode)
+	}
+	return resp.Body, nil
+}
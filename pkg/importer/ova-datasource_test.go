@@ -0,0 +1,111 @@
+package importer
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/containerized-data-importer/pkg/image"
+	"kubevirt.io/containerized-data-importer/tests/utils"
+)
+
+var (
+	tinyCoreVmdkFilePath, _ = utils.FormatTestData(tinyCoreFilePath, os.TempDir(), image.ExtVmdk)
+	applianceOvaFilePath, _ = utils.ArchiveFiles("appliance", os.TempDir(), tinyCoreVmdkFilePath)
+	noDiskOvaFilePath, _    = utils.ArchiveFiles("no-disk", os.TempDir(), tinyCoreFilePath)
+)
+
+var _ = Describe("OVA data source", func() {
+	var (
+		od      *OVADataSource
+		ts      *httptest.Server
+		ovaPath string
+		tmpDir  string
+		err     error
+	)
+
+	BeforeEach(func() {
+		ovaPath = applianceOvaFilePath
+		ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.ServeFile(w, r, ovaPath)
+		}))
+		tmpDir, err = ioutil.TempDir("", "scratch")
+		Expect(err).NotTo(HaveOccurred())
+		By("tmpDir: " + tmpDir)
+	})
+
+	AfterEach(func() {
+		ts.Close()
+		if od != nil {
+			od.Close()
+		}
+		os.RemoveAll(tmpDir)
+	})
+
+	It("NewOVADataSource should Error, when passed in an invalid endpoint", func() {
+		od, err = NewOVADataSource("thisisinvalid#$%#ep", "", "", "")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("NewOVADataSource should Error, when the archive cannot be fetched", func() {
+		ts.Close()
+		od, err = NewOVADataSource(ts.URL+"/appliance.ova", "", "", "")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("NewOVADataSource should Error, when the archive does not exist", func() {
+		ts.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+		od, err = NewOVADataSource(ts.URL+"/appliance.ova", "", "", "")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Info should return Transfer, when the archive contains a disk image", func() {
+		od, err = NewOVADataSource(ts.URL+"/appliance.ova", "", "", "")
+		Expect(err).NotTo(HaveOccurred())
+		result, err := od.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ProcessingPhaseTransferScratch).To(Equal(result))
+	})
+
+	It("Info should Error, when the archive contains no disk image", func() {
+		ovaPath = noDiskOvaFilePath
+		od, err = NewOVADataSource(ts.URL+"/no-disk.ova", "", "", "")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = od.Info()
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Transfer should succeed with scratch space and a valid archive", func() {
+		od, err = NewOVADataSource(ts.URL+"/appliance.ova", "", "", "")
+		Expect(err).NotTo(HaveOccurred())
+		nextPhase, err := od.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ProcessingPhaseTransferScratch).To(Equal(nextPhase))
+		result, err := od.Transfer(tmpDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ProcessingPhaseConvert).To(Equal(result))
+		file, err := os.Open(filepath.Join(tmpDir, tempFile))
+		Expect(err).NotTo(HaveOccurred())
+		defer file.Close()
+		Expect(file.Name()).To(Equal(od.GetURL().String()))
+	})
+
+	It("Should send basic auth credentials, when an access key and secret are set", func() {
+		var user, password string
+		ts.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, password, _ = r.BasicAuth()
+			http.ServeFile(w, r, ovaPath)
+		})
+		od, err = NewOVADataSource(ts.URL+"/appliance.ova", "importer", "topsecret", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(user).To(Equal("importer"))
+		Expect(password).To(Equal("topsecret"))
+	})
+})
@@ -0,0 +1,64 @@
+package importer
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/monitoring"
+)
+
+var (
+	progressBytesDone = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: monitoring.MetricOptsList[monitoring.ProgressBytesDone].Name,
+			Help: monitoring.MetricOptsList[monitoring.ProgressBytesDone].Help,
+		},
+		[]string{"ownerUID"},
+	)
+	progressBytesTotal = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: monitoring.MetricOptsList[monitoring.ProgressBytesTotal].Name,
+			Help: monitoring.MetricOptsList[monitoring.ProgressBytesTotal].Help,
+		},
+		[]string{"ownerUID"},
+	)
+	progressPhase = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: monitoring.MetricOptsList[monitoring.ProgressPhase].Name,
+			Help: monitoring.MetricOptsList[monitoring.ProgressPhase].Help,
+		},
+		[]string{"ownerUID", "phase"},
+	)
+)
+
+func init() {
+	if err := prometheus.Register(progressBytesDone); err != nil {
+		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			progressBytesDone = are.ExistingCollector.(*prometheus.CounterVec)
+		} else {
+			klog.Errorf("Unable to create prometheus progress bytes counter")
+		}
+	}
+	if err := prometheus.Register(progressBytesTotal); err != nil {
+		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			progressBytesTotal = are.ExistingCollector.(*prometheus.GaugeVec)
+		} else {
+			klog.Errorf("Unable to create prometheus total bytes gauge")
+		}
+	}
+	if err := prometheus.Register(progressPhase); err != nil {
+		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			progressPhase = are.ExistingCollector.(*prometheus.GaugeVec)
+		} else {
+			klog.Errorf("Unable to create prometheus phase gauge")
+		}
+	}
+}
+
+// reportProcessingPhase publishes the phase the processor is in, so the controller
+// scrape can surface it in the structured DataVolume progress.
+func reportProcessingPhase(phase ProcessingPhase) {
+	progressPhase.Reset()
+	progressPhase.WithLabelValues(ownerUID, string(phase)).Set(1)
+}
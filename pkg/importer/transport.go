@@ -20,6 +20,7 @@ import (
 	"archive/tar"
 	"context"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -34,6 +35,7 @@ import (
 	"k8s.io/klog/v2"
 
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
 	"kubevirt.io/containerized-data-importer/pkg/util"
 )
 
@@ -53,6 +55,11 @@ func buildSourceContext(accessKey, secKey, certDir string, insecureRegistry bool
 			Password: secKey,
 		}
 	}
+	if authFile, _ := util.ParseEnvVar(common.ImporterAuthFileVar, false); authFile != "" {
+		// dockerconfigjson pull secret, the library picks the matching
+		// credential by registry host
+		ctx.AuthFilePath = authFile
+	}
 	if certDir != "" {
 		ctx.DockerCertPath = certDir
 		ctx.DockerDaemonCertPath = certDir
@@ -124,8 +131,7 @@ func processLayer(ctx context.Context,
 	cache types.BlobInfoCache,
 	stopAtFirst bool) (bool, error) {
 
-	var reader io.ReadCloser
-	reader, _, err := src.GetBlob(ctx, layer, cache)
+	reader, err := layerReader(ctx, src, layer, cache)
 	if err != nil {
 		klog.Errorf("Could not read layer: %v", err)
 		return false, errors.Wrap(err, "Could not read layer")
@@ -172,6 +178,74 @@ func processLayer(ctx context.Context,
 	return found, nil
 }
 
+// layerReader returns a reader for the layer blob. When a node-local layer
+// cache is mounted into the pod, already cached layers are read from it and
+// newly downloaded layers are added to it, keyed by digest.
+func layerReader(ctx context.Context, src types.ImageSource, layer types.BlobInfo, cache types.BlobInfoCache) (io.ReadCloser, error) {
+	cacheDir, _ := util.ParseEnvVar(common.ImporterLayerCacheDirVar, false)
+	if cacheDir == "" || layer.Digest == "" {
+		reader, _, err := src.GetBlob(ctx, layer, cache)
+		return reader, err
+	}
+	cachedFile := filepath.Join(cacheDir, strings.ReplaceAll(layer.Digest.String(), ":", "-"))
+	if f, err := os.Open(cachedFile); err == nil {
+		klog.V(1).Infof("Reading layer %s from the node-local cache", layer.Digest)
+		return f, nil
+	}
+	reader, _, err := src.GetBlob(ctx, layer, cache)
+	if err != nil {
+		return nil, err
+	}
+	return newCachingLayerReader(reader, cachedFile), nil
+}
+
+// cachingLayerReader tees a layer blob into a temporary file and publishes it
+// under its digest keyed name once the blob was read completely, so partially
+// downloaded layers never end up in the cache.
+type cachingLayerReader struct {
+	src    io.ReadCloser
+	tmp    *os.File
+	dest   string
+	failed bool
+}
+
+func newCachingLayerReader(src io.ReadCloser, dest string) io.ReadCloser {
+	tmp, err := ioutil.TempFile(filepath.Dir(dest), ".layer-*")
+	if err != nil {
+		klog.Errorf("Unable to create layer cache file: %v", err)
+		return src
+	}
+	return &cachingLayerReader{src: src, tmp: tmp, dest: dest}
+}
+
+func (r *cachingLayerReader) Read(p []byte) (int, error) {
+	n, err := r.src.Read(p)
+	if n > 0 && !r.failed {
+		if _, werr := r.tmp.Write(p[:n]); werr != nil {
+			klog.Errorf("Unable to write layer cache file: %v", werr)
+			r.failed = true
+		}
+	}
+	if err == io.EOF && !r.failed {
+		if cerr := r.tmp.Close(); cerr == nil {
+			// the rename is atomic, so concurrent importers on the node are safe
+			if rerr := os.Rename(r.tmp.Name(), r.dest); rerr != nil {
+				klog.Errorf("Unable to publish layer cache file: %v", rerr)
+			}
+		}
+		r.tmp = nil
+	}
+	return n, err
+}
+
+func (r *cachingLayerReader) Close() error {
+	if r.tmp != nil {
+		r.tmp.Close()
+		os.Remove(r.tmp.Name())
+	}
+	return r.src.Close()
+}
+
 func copyRegistryImage(url, destDir, pathPrefix, accessKey, secKey, certDir string, insecureRegistry, stopAtFirst bool) error {
 	klog.Infof("Downloading image from '%v', copying file from '%v' to '%v'", url, pathPrefix, destDir)
 
@@ -890,17 +890,21 @@ func (vs *VDDKDataSource) Info() (ProcessingPhase, error) {
 // Close closes any readers or other open resources.
 func (vs *VDDKDataSource) Close() error {
 	if vddkVersion != "" || vddkHost != "" {
-		existingbytes, _ := ioutil.ReadFile(common.PodTerminationMessageFile)
-		existing := string(existingbytes)
-		if existing != "" {
-			existing += "; "
-		}
 		stopinfo := util.VddkInfo{
 			Version: vddkVersion,
 			Host:    vddkHost,
 		}
 		stopmsg, _ := json.Marshal(stopinfo)
-		err := util.WriteTerminationMessage(existing + "VDDK: " + string(stopmsg))
+		existingbytes, _ := ioutil.ReadFile(common.PodTerminationMessageFile)
+		// Append the VDDK info to the message of an already written termination
+		// message, so a single JSON document remains in the file.
+		var err error
+		if termErr := util.TerminationErrorFromMessage(string(existingbytes)); termErr != nil {
+			termErr.Message += "; VDDK: " + string(stopmsg)
+			err = util.WriteTerminationError(termErr)
+		} else {
+			err = util.WriteTerminationMessage("VDDK: " + string(stopmsg))
+		}
 		if err != nil {
 			klog.Errorf("Unable to write termination message: %v", err)
 		}
@@ -7,7 +7,7 @@ import (
 	"bufio"
 	"bytes"
 	"context"
-	"crypto/md5"
+	"crypto/sha256"
 	"errors"
 	"net/url"
 	"os"
@@ -171,8 +171,8 @@ var _ = Describe("VDDK data source", func() {
 		Expect(err).ToNot(HaveOccurred())
 		Expect(phase).To(Equal(ProcessingPhaseResize))
 
-		sourceSum := md5.Sum(sourceBytes)
-		destSum := md5.Sum(mockSinkBuffer)
+		sourceSum := sha256.Sum256(sourceBytes)
+		destSum := sha256.Sum256(mockSinkBuffer)
 		Expect(sourceSum).To(Equal(destSum))
 	})
 
@@ -197,8 +197,8 @@ var _ = Describe("VDDK data source", func() {
 		Expect(err).ToNot(HaveOccurred())
 		Expect(phase).To(Equal(ProcessingPhaseResize))
 
-		sourceSum := md5.Sum(sourceBytes)
-		destSum := md5.Sum(mockSinkBuffer)
+		sourceSum := sha256.Sum256(sourceBytes)
+		destSum := sha256.Sum256(mockSinkBuffer)
 		Expect(sourceSum).To(Equal(destSum))
 
 		// Write some data to the first snapshot, then copy the delta from difference between the two snapshots
@@ -213,13 +213,13 @@ var _ = Describe("VDDK data source", func() {
 				Length: 1024,
 			}},
 		}
-		changedSourceSum := md5.Sum(sourceBytes)
+		changedSourceSum := sha256.Sum256(sourceBytes)
 
 		phase, err = snap2.TransferFile(".")
 		Expect(err).ToNot(HaveOccurred())
 		Expect(phase).To(Equal(ProcessingPhaseResize))
 
-		deltaSum := md5.Sum(mockSinkBuffer)
+		deltaSum := sha256.Sum256(mockSinkBuffer)
 		Expect(changedSourceSum).To(Equal(deltaSum))
 	})
 
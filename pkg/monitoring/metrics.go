@@ -29,6 +29,28 @@ const (
 	IncompleteProfile      MetricsKey = "incompleteProfile"
 	DataImportCronOutdated MetricsKey = "dataImportCronOutdated"
 	CloneProgress          MetricsKey = "cloneProgress"
+	ConvertProgress        MetricsKey = "convertProgress"
+	ExportProgress         MetricsKey = "exportProgress"
+	ProgressBytesDone      MetricsKey = "progressBytesDone"
+	ProgressBytesTotal     MetricsKey = "progressBytesTotal"
+	ProgressPhase          MetricsKey = "progressPhase"
+	CheckpointProgress     MetricsKey = "checkpointProgress"
+	TransferBytes          MetricsKey = "transferBytes"
+	TransferDuration       MetricsKey = "transferDuration"
+	TransferResults        MetricsKey = "transferResults"
+	ReconcileDuration      MetricsKey = "reconcileDuration"
+	ReconcileErrors        MetricsKey = "reconcileErrors"
+
+	UploadBytes            MetricsKey = "uploadBytes"
+	UploadConnections      MetricsKey = "uploadConnections"
+	UploadFailures         MetricsKey = "uploadFailures"
+	UploadProxyConnections MetricsKey = "uploadProxyConnections"
+	UploadProxyFailures    MetricsKey = "uploadProxyFailures"
+
+	DataVolumeInfo                 MetricsKey = "dataVolumeInfo"
+	DataVolumePhase                MetricsKey = "dataVolumePhase"
+	DataVolumeWaitForFirstConsumer MetricsKey = "dataVolumeWaitForFirstConsumer"
+	UploadProxyCertExpiry          MetricsKey = "uploadProxyCertExpiry"
 )
 
 // MetricOptsList list all CDI metrics
@@ -43,9 +65,84 @@ var MetricOptsList = map[MetricsKey]MetricOpts{
 		Help: "DataImportCron has an outdated import",
 		Type: "Gauge",
 	},
+	ConvertProgress: {
+		Name: "convert_progress",
+		Help: "The progress of the current qemu-img conversion in percentage",
+		Type: "Counter",
+	},
+	ExportProgress: {
+		Name: "export_progress",
+		Help: "The export upload progress in percentage",
+		Type: "Counter",
+	},
+	ProgressBytesDone: {
+		Name: "progress_bytes_done",
+		Help: "Bytes of the transfer moved so far, labeled by owner UID",
+		Type: "Counter",
+	},
+	ProgressBytesTotal: {
+		Name: "progress_bytes_total",
+		Help: "Total bytes the transfer moves when known, labeled by owner UID",
+		Type: "Gauge",
+	},
+	ProgressPhase: {
+		Name: "progress_phase",
+		Help: "Current processing phase of the worker pod, labeled by owner UID and phase",
+		Type: "Gauge",
+	},
+	CheckpointProgress: {
+		Name: "checkpoint_progress_bytes",
+		Help: "Bytes copied for a checkpoint of a multi-stage import, labeled by owner UID and checkpoint",
+		Type: "Gauge",
+	},
+	UploadBytes: {
+		Name: "upload_bytes_total",
+		Help: "Bytes received by the upload server; the rate over time is the upload throughput",
+		Type: "Counter",
+	},
+	UploadConnections: {
+		Name: "upload_active_connections",
+		Help: "Upload connections the upload server is currently handling",
+		Type: "Gauge",
+	},
+	UploadFailures: {
+		Name: "upload_failures_total",
+		Help: "Failed uploads, labeled by failure reason",
+		Type: "Counter",
+	},
+	UploadProxyConnections: {
+		Name: "kubevirt_cdi_uploadproxy_active_connections",
+		Help: "Upload connections currently proxied to upload servers",
+		Type: "Gauge",
+	},
+	UploadProxyFailures: {
+		Name: "kubevirt_cdi_uploadproxy_request_failures_total",
+		Help: "Upload requests rejected before reaching an upload server, labeled by reason",
+		Type: "Counter",
+	},
 	IncompleteProfile: {
 		Name: "kubevirt_cdi_incomplete_storageprofiles_total",
-		Help: "Total number of incomplete and hence unusable StorageProfile",
+		Help: "Incomplete and hence unusable StorageProfile, labeled by storage class, provisioner and reason",
+		Type: "Gauge",
+	},
+	DataVolumeInfo: {
+		Name: "kubevirt_cdi_datavolume_info",
+		Help: "Info metric with one series per DataVolume, labeled by namespace, name, source type, storage class, phase and prepopulated",
+		Type: "Gauge",
+	},
+	DataVolumePhase: {
+		Name: "kubevirt_cdi_datavolumes",
+		Help: "Number of DataVolumes, labeled by namespace and phase",
+		Type: "Gauge",
+	},
+	DataVolumeWaitForFirstConsumer: {
+		Name: "kubevirt_cdi_datavolume_waitforfirstconsumer",
+		Help: "DataVolumes in the WaitForFirstConsumer phase, labeled by namespace and name",
+		Type: "Gauge",
+	},
+	UploadProxyCertExpiry: {
+		Name: "kubevirt_cdi_uploadproxy_cert_expiration_seconds",
+		Help: "Unix timestamp at which the upload proxy server certificate expires",
 		Type: "Gauge",
 	},
 	ReadyGauge: {
@@ -53,6 +150,31 @@ var MetricOptsList = map[MetricsKey]MetricOpts{
 		Help: "CDI CR Ready",
 		Type: "Gauge",
 	},
+	ReconcileDuration: {
+		Name: "kubevirt_cdi_reconcile_duration_seconds",
+		Help: "Duration of reconcile loops, labeled by controller and result",
+		Type: "Histogram",
+	},
+	ReconcileErrors: {
+		Name: "kubevirt_cdi_reconcile_errors_total",
+		Help: "Reconcile errors, labeled by controller and error reason",
+		Type: "Counter",
+	},
+	TransferBytes: {
+		Name: "kubevirt_cdi_datavolume_transfer_bytes_total",
+		Help: "Capacity in bytes of successfully completed DataVolume transfers, labeled by namespace, source type and storage class",
+		Type: "Counter",
+	},
+	TransferDuration: {
+		Name: "kubevirt_cdi_datavolume_transfer_duration_seconds",
+		Help: "Time from DataVolume creation to successful completion, labeled by namespace, source type and storage class",
+		Type: "Histogram",
+	},
+	TransferResults: {
+		Name: "kubevirt_cdi_datavolume_transfer_results_total",
+		Help: "Completed DataVolume transfers by final result, labeled by namespace, source type and storage class",
+		Type: "Counter",
+	},
 }
 
 // GetRecordRulesDesc returns CDI Prometheus Record Rules
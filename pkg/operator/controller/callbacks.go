@@ -118,6 +118,11 @@ func reconcileCreateRoute(args *callbacks.ReconcileCallbackArgs) error {
 	}
 	args.Recorder.Event(cr, corev1.EventTypeNormal, createResourceSuccess, "Successfully ensured upload proxy route exists")
 
+	if err := ensureUploadProxyIngressExists(args.Logger, args.Client, args.Scheme, deployment); err != nil {
+		args.Recorder.Event(cr, corev1.EventTypeWarning, createResourceFailed, fmt.Sprintf("Failed to ensure upload proxy ingress exists, %v", err))
+		return err
+	}
+
 	return nil
 }
 
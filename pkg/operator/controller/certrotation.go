@@ -293,11 +293,12 @@ func (cm *certManager) ensureTarget(cd cdicerts.CertificateDefinition, ca *crypt
 	if cd.TargetService != nil {
 		targetCreator = &certrotation.ServingRotation{
 			Hostnames: func() []string {
-				return []string{
+				hostnames := []string{
 					*cd.TargetService,
 					fmt.Sprintf("%s.%s", *cd.TargetService, secret.Namespace),
 					fmt.Sprintf("%s.%s.svc", *cd.TargetService, secret.Namespace),
 				}
+				return append(hostnames, cd.TargetAltNames...)
 			},
 		}
 	} else {
@@ -18,9 +18,13 @@ package controller
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"net/url"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"kubevirt.io/containerized-data-importer/pkg/monitoring"
@@ -44,6 +48,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
 	"kubevirt.io/containerized-data-importer/pkg/operator"
 	cdicerts "kubevirt.io/containerized-data-importer/pkg/operator/resources/cert"
 	cdicluster "kubevirt.io/containerized-data-importer/pkg/operator/resources/cluster"
@@ -63,6 +68,8 @@ const (
 
 	certPollInterval = 1 * time.Minute
 
+	uploadProxyCertSecretName = "cdi-uploadproxy-server-cert"
+
 	createResourceFailed  = "CreateResourceFailed"
 	createResourceSuccess = "CreateResourceSuccess"
 
@@ -79,11 +86,18 @@ var (
 			Name: monitoring.MetricOptsList[monitoring.ReadyGauge].Name,
 			Help: monitoring.MetricOptsList[monitoring.ReadyGauge].Help,
 		})
+
+	uploadProxyCertGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: monitoring.MetricOptsList[monitoring.UploadProxyCertExpiry].Name,
+			Help: monitoring.MetricOptsList[monitoring.UploadProxyCertExpiry].Help,
+		})
 )
 
 func init() {
 	metrics.Registry = prometheus.NewRegistry()
 	metrics.Registry.MustRegister(readyGauge)
+	metrics.Registry.MustRegister(uploadProxyCertGauge)
 	// 0 is our 'something bad is going on' value for alert to start firing, so can't default to that
 	readyGauge.Set(-1)
 }
@@ -233,6 +247,7 @@ func (r *ReconcileCDI) Reconcile(_ context.Context, request reconcile.Request) (
 		// Not an issue if progress is still ongoing
 		readyGauge.Set(0)
 	}
+	r.updateUploadProxyCertMetric()
 	return r.reconciler.Reconcile(request, operatorVersion, reqLogger)
 }
 
@@ -282,11 +297,96 @@ func (r *ReconcileCDI) getCertificateDefinitions(cdi *cdiv1.CDI) []cdicerts.Cert
 				args.TargetRenewBefore = &cdi.Spec.CertConfig.Server.RenewBefore.Duration
 			}
 		}
+
+		if cdi.Spec.CertConfig.Client != nil {
+			if cdi.Spec.CertConfig.Client.Duration != nil {
+				args.ClientDuration = &cdi.Spec.CertConfig.Client.Duration.Duration
+			}
+
+			if cdi.Spec.CertConfig.Client.RenewBefore != nil {
+				args.ClientRenewBefore = &cdi.Spec.CertConfig.Client.RenewBefore.Duration
+			}
+		}
 	}
 
+	args.UploadProxyAltNames = r.getUploadProxyAltNames(cdi)
+
 	return cdicerts.CreateCertificateDefinitions(args)
 }
 
+// getUploadProxyAltNames returns additional hostnames the upload proxy serving cert
+// should be valid for, so externally load balanced endpoints validate TLS correctly
+func (r *ReconcileCDI) getUploadProxyAltNames(cdi *cdiv1.CDI) []string {
+	var altNames []string
+	seen := map[string]struct{}{}
+	addHost := func(host string) {
+		if host == "" {
+			return
+		}
+		if _, ok := seen[host]; ok {
+			return
+		}
+		seen[host] = struct{}{}
+		altNames = append(altNames, host)
+	}
+
+	if cdi != nil && cdi.Spec.UploadProxyExposure != nil && cdi.Spec.UploadProxyExposure.Host != nil {
+		addHost(*cdi.Spec.UploadProxyExposure.Host)
+	}
+
+	config := &cdiv1.CDIConfig{}
+	if err := r.client.Get(context.TODO(), client.ObjectKey{Name: common.ConfigName}, config); err != nil {
+		if !errors.IsNotFound(err) {
+			log.Error(err, "Error getting CDIConfig for upload proxy cert names")
+		}
+		return altNames
+	}
+
+	if config.Spec.UploadProxyURLOverride != nil {
+		addHost(hostFromURL(*config.Spec.UploadProxyURLOverride))
+	}
+
+	if config.Status.UploadProxyURL != nil {
+		addHost(hostFromURL(*config.Status.UploadProxyURL))
+	}
+
+	return altNames
+}
+
+// hostFromURL extracts the hostname from a URL that may omit the scheme
+func hostFromURL(rawURL string) string {
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	return u.Hostname()
+}
+
+// updateUploadProxyCertMetric publishes the expiration time of the upload proxy server
+// certificate, so an alert can fire before clients start failing TLS handshakes
+func (r *ReconcileCDI) updateUploadProxyCertMetric() {
+	secret := &corev1.Secret{}
+	key := client.ObjectKey{Name: uploadProxyCertSecretName, Namespace: r.namespace}
+	if err := r.client.Get(context.TODO(), key, secret); err != nil {
+		return
+	}
+
+	block, _ := pem.Decode(secret.Data["tls.crt"])
+	if block == nil {
+		return
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return
+	}
+	uploadProxyCertGauge.Set(float64(cert.NotAfter.Unix()))
+}
+
 func (r *ReconcileCDI) getConfigMap() (*corev1.ConfigMap, error) {
 	cm := &corev1.ConfigMap{}
 	key := client.ObjectKey{Name: operator.ConfigMapName, Namespace: r.namespace}
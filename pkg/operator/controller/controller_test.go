@@ -38,6 +38,7 @@ import (
 	conditions "github.com/openshift/custom-resource-status/conditions/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -55,6 +56,7 @@ import (
 
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	"kubevirt.io/containerized-data-importer/pkg/common"
+	cdicerts "kubevirt.io/containerized-data-importer/pkg/operator/resources/cert"
 	clusterResources "kubevirt.io/containerized-data-importer/pkg/operator/resources/cluster"
 	namespaceResources "kubevirt.io/containerized-data-importer/pkg/operator/resources/namespaced"
 	utils "kubevirt.io/containerized-data-importer/pkg/operator/resources/utils"
@@ -270,6 +272,89 @@ var _ = Describe("Controller", func() {
 				validateEvents(args.reconciler, createReadyEventValidationMap())
 			})
 
+			It("should create and delete upload proxy ingress", func() {
+				args := createArgs()
+				doReconcile(args)
+
+				host := "cdi-uploadproxy.example.com"
+				ingressClassName := "nginx"
+				tlsSecretName := "upload-proxy-tls"
+				args.cdi.Spec.UploadProxyExposure = &cdiv1.CDIUploadProxyExposure{
+					Host:             &host,
+					IngressClassName: &ingressClassName,
+					TLSSecretName:    &tlsSecretName,
+				}
+				err := args.client.Update(context.TODO(), args.cdi)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(setDeploymentsReady(args)).To(BeTrue())
+
+				ingress := &networkingv1.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uploadProxyServiceName,
+						Namespace: cdiNamespace,
+					},
+				}
+
+				obj, err := getObject(args.client, ingress)
+				Expect(err).ToNot(HaveOccurred())
+				ingress = obj.(*networkingv1.Ingress)
+
+				Expect(ingress.Spec.IngressClassName).ToNot(BeNil())
+				Expect(*ingress.Spec.IngressClassName).To(Equal(ingressClassName))
+				Expect(ingress.Spec.Rules).To(HaveLen(1))
+				Expect(ingress.Spec.Rules[0].Host).To(Equal(host))
+				Expect(ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name).To(Equal(uploadProxyServiceName))
+				Expect(ingress.Spec.TLS).To(HaveLen(1))
+				Expect(ingress.Spec.TLS[0].SecretName).To(Equal(tlsSecretName))
+				Expect(ingress.Labels[common.AppKubernetesPartOfLabel]).To(Equal("testing"))
+
+				args.cdi.Spec.UploadProxyExposure = nil
+				err = args.client.Update(context.TODO(), args.cdi)
+				Expect(err).ToNot(HaveOccurred())
+
+				doReconcile(args)
+
+				_, err = getObject(args.client, ingress)
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+			})
+
+			It("should include external hostnames in upload proxy cert definition", func() {
+				args := createArgs()
+				doReconcile(args)
+
+				host := "cdi-uploadproxy.example.com"
+				args.cdi.Spec.UploadProxyExposure = &cdiv1.CDIUploadProxyExposure{
+					Host: &host,
+				}
+				err := args.client.Update(context.TODO(), args.cdi)
+				Expect(err).ToNot(HaveOccurred())
+
+				override := "https://upload.example.com:8443"
+				cfg := &cdiv1.CDIConfig{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "config",
+					},
+					Spec: cdiv1.CDIConfigSpec{
+						UploadProxyURLOverride: &override,
+					},
+				}
+				err = args.client.Create(context.TODO(), cfg)
+				Expect(err).ToNot(HaveOccurred())
+
+				var uploadProxyDef *cdicerts.CertificateDefinition
+				defs := args.reconciler.getCertificateDefinitions(args.cdi)
+				for i := range defs {
+					def := &defs[i]
+					if def.TargetService != nil && *def.TargetService == uploadProxyServiceName {
+						uploadProxyDef = def
+					}
+				}
+
+				Expect(uploadProxyDef).ToNot(BeNil())
+				Expect(uploadProxyDef.TargetAltNames).To(Equal([]string{host, "upload.example.com"}))
+			})
+
 			It("should have CDIOperatorDown", func() {
 				args := createArgs()
 				doReconcile(args)
@@ -95,11 +95,33 @@ func (r *ReconcileCDI) getNamespacedArgs(cr *cdiv1.CDI) *cdinamespaced.FactoryAr
 			result.PriorityClassName = ""
 		}
 		result.InfraNodePlacement = &cr.Spec.Infra
+		if cr.Spec.ComponentPlacement != nil {
+			result.APIServerNodePlacement = cr.Spec.ComponentPlacement.APIServer
+			result.ControllerNodePlacement = cr.Spec.ComponentPlacement.Controller
+			result.UploadProxyNodePlacement = cr.Spec.ComponentPlacement.UploadProxy
+		}
+		if cr.Spec.InfraPriorityClasses != nil {
+			result.APIServerPriorityClassName = r.verifiedPriorityClass(cr.Spec.InfraPriorityClasses.APIServer)
+			result.ControllerPriorityClassName = r.verifiedPriorityClass(cr.Spec.InfraPriorityClasses.Controller)
+			result.UploadProxyPriorityClassName = r.verifiedPriorityClass(cr.Spec.InfraPriorityClasses.UploadProxy)
+		}
 	}
 
 	return &result
 }
 
+// verifiedPriorityClass returns the given priority class name if the class exists, empty string otherwise
+func (r *ReconcileCDI) verifiedPriorityClass(pc *cdiv1.CDIPriorityClass) string {
+	if pc == nil || string(*pc) == "" {
+		return ""
+	}
+	priorityClass := &schedulingv1.PriorityClass{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: string(*pc)}, priorityClass); err != nil {
+		return ""
+	}
+	return string(*pc)
+}
+
 // GetAllResources provides slice of resources CDI depends on
 func (r *ReconcileCDI) GetAllResources(crObject client.Object) ([]client.Object, error) {
 	cr := crObject.(*cdiv1.CDI)
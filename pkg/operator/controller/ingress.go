@@ -0,0 +1,145 @@
+/*
+Copyright 2018 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"kubevirt.io/containerized-data-importer/pkg/controller"
+	"kubevirt.io/containerized-data-importer/pkg/util"
+)
+
+const (
+	uploadProxyIngressName = uploadProxyServiceName
+)
+
+// ensureUploadProxyIngressExists manages the Ingress exposing cdi-uploadproxy.
+// The Ingress is created when the CDI CR requests a host and removed again
+// when the exposure is unset.
+func ensureUploadProxyIngressExists(logger logr.Logger, c client.Client, scheme *runtime.Scheme, owner metav1.Object) error {
+	namespace := owner.GetNamespace()
+	if namespace == "" {
+		return fmt.Errorf("cluster scoped owner not supported")
+	}
+
+	cr, err := controller.GetActiveCDI(c)
+	if err != nil {
+		return err
+	}
+	if cr == nil {
+		return fmt.Errorf("no active CDI")
+	}
+
+	currentIngress := &networkingv1.Ingress{}
+	key := client.ObjectKey{Namespace: namespace, Name: uploadProxyIngressName}
+	getErr := c.Get(context.TODO(), key, currentIngress)
+	if getErr != nil && !errors.IsNotFound(getErr) {
+		return getErr
+	}
+
+	exposure := cr.Spec.UploadProxyExposure
+	if exposure == nil || exposure.Host == nil {
+		if getErr == nil {
+			logger.V(3).Info("upload proxy exposure removed, deleting ingress")
+			return c.Delete(context.TODO(), currentIngress)
+		}
+		return nil
+	}
+
+	installerLabels := util.GetRecommendedInstallerLabelsFromCr(cr)
+	pathType := networkingv1.PathTypePrefix
+
+	desiredIngress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uploadProxyIngressName,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"cdi.kubevirt.io": "",
+			},
+			Annotations: map[string]string{
+				// the upload proxy terminates TLS itself
+				"nginx.ingress.kubernetes.io/backend-protocol": "HTTPS",
+			},
+		},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: exposure.IngressClassName,
+			Rules: []networkingv1.IngressRule{
+				{
+					Host: *exposure.Host,
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{
+								{
+									Path:     "/",
+									PathType: &pathType,
+									Backend: networkingv1.IngressBackend{
+										Service: &networkingv1.IngressServiceBackend{
+											Name: uploadProxyServiceName,
+											Port: networkingv1.ServiceBackendPort{
+												Number: 443,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if exposure.TLSSecretName != nil {
+		desiredIngress.Spec.TLS = []networkingv1.IngressTLS{
+			{
+				Hosts:      []string{*exposure.Host},
+				SecretName: *exposure.TLSSecretName,
+			},
+		}
+	}
+
+	util.SetRecommendedLabels(desiredIngress, installerLabels, "cdi-operator")
+
+	if getErr == nil {
+		if !reflect.DeepEqual(currentIngress.Spec, desiredIngress.Spec) {
+			currentIngress.Spec = desiredIngress.Spec
+			return c.Update(context.TODO(), currentIngress)
+		}
+		return nil
+	}
+
+	if err = controllerutil.SetControllerReference(owner, desiredIngress, scheme); err != nil {
+		return err
+	}
+
+	return c.Create(context.TODO(), desiredIngress)
+}
+
+func (r *ReconcileCDI) watchIngresses() error {
+	return r.controller.Watch(&source.Kind{Type: &networkingv1.Ingress{}}, enqueueCDI(r.client))
+}
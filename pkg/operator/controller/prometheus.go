@@ -197,6 +197,48 @@ func getAlertRules() []promv1.Rule {
 				componentAlertLabelKey: componentAlertLabelValue,
 			},
 		),
+		generateAlertRule(
+			"CDIDataVolumesFailing",
+			"sum(increase(kubevirt_cdi_datavolume_transfer_results_total{result='failed'}[1h])) > 3",
+			"5m",
+			map[string]string{
+				"summary":     "More than 3 DataVolume transfers failed during the last hour, the sources or the storage need to be investigated",
+				"runbook_url": runbookURLBasePath + "CDIDataVolumesFailing",
+			},
+			map[string]string{
+				severityAlertLabelKey:  "warning",
+				partOfAlertLabelKey:    partOfAlertLabelValue,
+				componentAlertLabelKey: componentAlertLabelValue,
+			},
+		),
+		generateAlertRule(
+			"CDIDataVolumeStuckWaitingForConsumer",
+			"kubevirt_cdi_datavolume_waitforfirstconsumer == 1",
+			"4h",
+			map[string]string{
+				"summary":     "DataVolume has been waiting for its first consumer for more than 4 hours, the workload that should use it probably never got scheduled",
+				"runbook_url": runbookURLBasePath + "CDIDataVolumeStuckWaitingForConsumer",
+			},
+			map[string]string{
+				severityAlertLabelKey:  "warning",
+				partOfAlertLabelKey:    partOfAlertLabelValue,
+				componentAlertLabelKey: componentAlertLabelValue,
+			},
+		),
+		generateAlertRule(
+			"CDIUploadProxyCertExpiringSoon",
+			"(kubevirt_cdi_uploadproxy_cert_expiration_seconds - time()) < 7 * 24 * 3600",
+			"1h",
+			map[string]string{
+				"summary":     "The upload proxy server certificate expires in less than a week",
+				"runbook_url": runbookURLBasePath + "CDIUploadProxyCertExpiringSoon",
+			},
+			map[string]string{
+				severityAlertLabelKey:  "warning",
+				partOfAlertLabelKey:    partOfAlertLabelValue,
+				componentAlertLabelKey: componentAlertLabelValue,
+			},
+		),
 		generateAlertRule(
 			"CDIDataImportCronOutdated",
 			"kubevirt_cdi_dataimportcron_outdated_total > 0",
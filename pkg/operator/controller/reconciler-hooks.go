@@ -30,6 +30,10 @@ func (r *ReconcileCDI) watch() error {
 		return err
 	}
 
+	if err := r.watchIngresses(); err != nil {
+		return err
+	}
+
 	if err := r.watchSecurityContextConstraints(); err != nil {
 		return err
 	}
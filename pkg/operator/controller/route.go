@@ -71,6 +71,11 @@ func ensureUploadProxyRouteExists(logger logr.Logger, c client.Client, scheme *r
 	}
 	installerLabels := util.GetRecommendedInstallerLabelsFromCr(cr)
 
+	host := ""
+	if cr.Spec.UploadProxyExposure != nil && cr.Spec.UploadProxyExposure.Host != nil {
+		host = *cr.Spec.UploadProxyExposure.Host
+	}
+
 	desiredRoute := &routev1.Route{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      uploadProxyRouteName,
@@ -84,6 +89,7 @@ func ensureUploadProxyRouteExists(logger logr.Logger, c client.Client, scheme *r
 			},
 		},
 		Spec: routev1.RouteSpec{
+			Host: host,
 			To: routev1.RouteTargetReference{
 				Kind: "Service",
 				Name: uploadProxyServiceName,
@@ -100,7 +106,9 @@ func ensureUploadProxyRouteExists(logger logr.Logger, c client.Client, scheme *r
 	key = client.ObjectKey{Namespace: namespace, Name: uploadProxyRouteName}
 	err = c.Get(context.TODO(), key, currentRoute)
 	if err == nil {
-		if currentRoute.Spec.To.Kind != desiredRoute.Spec.To.Kind ||
+		// an empty host means the router assigns one, so only compare when requested
+		if (desiredRoute.Spec.Host != "" && currentRoute.Spec.Host != desiredRoute.Spec.Host) ||
+			currentRoute.Spec.To.Kind != desiredRoute.Spec.To.Kind ||
 			currentRoute.Spec.To.Name != desiredRoute.Spec.To.Name ||
 			currentRoute.Spec.TLS == nil ||
 			currentRoute.Spec.TLS.Termination != desiredRoute.Spec.TLS.Termination ||
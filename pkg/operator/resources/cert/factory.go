@@ -27,6 +27,8 @@ import (
 
 const day = 24 * time.Hour
 
+const uploadProxyServiceName = "cdi-uploadproxy"
+
 // FactoryArgs contains the required parameters to generate certs
 type FactoryArgs struct {
 	Namespace string
@@ -38,6 +40,13 @@ type FactoryArgs struct {
 	TargetDuration *time.Duration
 	// Duration to subtract from cert NotAfter value
 	TargetRenewBefore *time.Duration
+
+	ClientDuration *time.Duration
+	// Duration to subtract from cert NotAfter value
+	ClientRenewBefore *time.Duration
+
+	// Additional DNS names for the upload proxy server cert
+	UploadProxyAltNames []string
 }
 
 // CertificateConfig contains cert configuration data
@@ -67,6 +76,9 @@ type CertificateDefinition struct {
 	TargetService *string
 	// contains target user name
 	TargetUser *string
+
+	// additional SANs for the server cert
+	TargetAltNames []string
 }
 
 // CreateCertificateDefinitions creates certificate definitions
@@ -106,6 +118,21 @@ func CreateCertificateDefinitions(args *FactoryArgs) []CertificateDefinition {
 				def.TargetConfig.Refresh = def.TargetConfig.Lifetime - *args.TargetRenewBefore
 			}
 		}
+
+		if def.TargetService != nil && *def.TargetService == uploadProxyServiceName {
+			def.TargetAltNames = args.UploadProxyAltNames
+		}
+
+		if def.TargetUser != nil {
+			if args.ClientDuration != nil {
+				def.TargetConfig.Lifetime = *args.ClientDuration
+			}
+
+			if args.ClientRenewBefore != nil {
+				// convert to time from cert NotBefore
+				def.TargetConfig.Refresh = def.TargetConfig.Lifetime - *args.ClientRenewBefore
+			}
+		}
 	}
 
 	return defs
@@ -147,7 +174,7 @@ func createCertificateDefinitions() []CertificateDefinition {
 				Lifetime: 24 * time.Hour,
 				Refresh:  12 * time.Hour,
 			},
-			TargetService: &[]string{"cdi-uploadproxy"}[0],
+			TargetService: &[]string{uploadProxyServiceName}[0],
 		},
 		{
 			SignerSecret: createSecret("cdi-uploadserver-signer"),
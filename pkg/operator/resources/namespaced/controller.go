@@ -48,10 +48,11 @@ func createControllerResources(args *FactoryArgs) []client.Object {
 			args.ImporterImage,
 			args.ClonerImage,
 			args.UploadServerImage,
+			args.ExportServerImage,
 			args.Verbosity,
 			args.PullPolicy,
-			args.PriorityClassName,
-			args.InfraNodePlacement),
+			args.ControllerPriorityClass(),
+			args.ControllerPlacement()),
 		createInsecureRegConfigMap(),
 		createPrometheusService(),
 	}
@@ -135,7 +136,7 @@ func createControllerServiceAccount() *corev1.ServiceAccount {
 	return utils.ResourceBuilder.CreateServiceAccount(common.ControllerServiceAccountName)
 }
 
-func createControllerDeployment(controllerImage, importerImage, clonerImage, uploadServerImage, verbosity, pullPolicy, priorityClassName string, infraNodePlacement *sdkapi.NodePlacement) *appsv1.Deployment {
+func createControllerDeployment(controllerImage, importerImage, clonerImage, uploadServerImage, exportServerImage, verbosity, pullPolicy, priorityClassName string, infraNodePlacement *sdkapi.NodePlacement) *appsv1.Deployment {
 	defaultMode := corev1.ConfigMapVolumeSourceDefaultMode
 	deployment := utils.CreateDeployment(controllerResourceName, "app", "containerized-data-importer", common.ControllerServiceAccountName, int32(1), infraNodePlacement)
 	if priorityClassName != "" {
@@ -148,6 +149,11 @@ func createControllerDeployment(controllerImage, importerImage, clonerImage, upl
 			ContainerPort: 8080,
 			Protocol:      "TCP",
 		},
+		{
+			Name:          "probes",
+			ContainerPort: 8081,
+			Protocol:      "TCP",
+		},
 	}
 	labels := util.MergeLabels(deployment.Spec.Template.GetLabels(), map[string]string{common.PrometheusLabelKey: common.PrometheusLabelValue})
 	deployment.SetLabels(labels)
@@ -165,6 +171,10 @@ func createControllerDeployment(controllerImage, importerImage, clonerImage, upl
 			Name:  "UPLOADSERVER_IMAGE",
 			Value: uploadServerImage,
 		},
+		{
+			Name:  "EXPORTSERVER_IMAGE",
+			Value: exportServerImage,
+		},
 		{
 			Name:  "UPLOADPROXY_SERVICE",
 			Value: uploadProxyResourceName,
@@ -194,8 +204,9 @@ func createControllerDeployment(controllerImage, importerImage, clonerImage, upl
 	}
 	container.ReadinessProbe = &corev1.Probe{
 		ProbeHandler: corev1.ProbeHandler{
-			Exec: &corev1.ExecAction{
-				Command: []string{"cat", "/tmp/ready"},
+			HTTPGet: &corev1.HTTPGetAction{
+				Path: "/readyz",
+				Port: intstr.FromString("probes"),
 			},
 		},
 		InitialDelaySeconds: 2,
@@ -204,6 +215,19 @@ func createControllerDeployment(controllerImage, importerImage, clonerImage, upl
 		SuccessThreshold:    1,
 		TimeoutSeconds:      1,
 	}
+	container.LivenessProbe = &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path: "/healthz",
+				Port: intstr.FromString("probes"),
+			},
+		},
+		InitialDelaySeconds: 30,
+		PeriodSeconds:       30,
+		FailureThreshold:    5,
+		SuccessThreshold:    1,
+		TimeoutSeconds:      10,
+	}
 	container.VolumeMounts = []corev1.VolumeMount{
 		{
 			Name:      "cdi-api-signing-key",
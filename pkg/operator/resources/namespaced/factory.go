@@ -37,11 +37,69 @@ type FactoryArgs struct {
 	APIServerImage         string `required:"true" envconfig:"apiserver_image"`
 	UploadProxyImage       string `required:"true" split_words:"true"`
 	UploadServerImage      string `required:"true" split_words:"true"`
-	Verbosity              string `required:"true"`
-	PullPolicy             string `required:"true" split_words:"true"`
-	PriorityClassName      string
-	Namespace              string
-	InfraNodePlacement     *sdkapi.NodePlacement
+	// optional, the volume export controller is disabled when empty
+	ExportServerImage  string `split_words:"true"`
+	Verbosity          string `required:"true"`
+	PullPolicy         string `required:"true" split_words:"true"`
+	PriorityClassName  string
+	Namespace          string
+	InfraNodePlacement *sdkapi.NodePlacement
+
+	APIServerNodePlacement   *sdkapi.NodePlacement
+	ControllerNodePlacement  *sdkapi.NodePlacement
+	UploadProxyNodePlacement *sdkapi.NodePlacement
+
+	APIServerPriorityClassName   string
+	ControllerPriorityClassName  string
+	UploadProxyPriorityClassName string
+}
+
+// APIServerPlacement returns the node placement for the cdi-apiserver deployment
+func (args *FactoryArgs) APIServerPlacement() *sdkapi.NodePlacement {
+	if args.APIServerNodePlacement != nil {
+		return args.APIServerNodePlacement
+	}
+	return args.InfraNodePlacement
+}
+
+// ControllerPlacement returns the node placement for the cdi-deployment deployment
+func (args *FactoryArgs) ControllerPlacement() *sdkapi.NodePlacement {
+	if args.ControllerNodePlacement != nil {
+		return args.ControllerNodePlacement
+	}
+	return args.InfraNodePlacement
+}
+
+// UploadProxyPlacement returns the node placement for the cdi-uploadproxy deployment
+func (args *FactoryArgs) UploadProxyPlacement() *sdkapi.NodePlacement {
+	if args.UploadProxyNodePlacement != nil {
+		return args.UploadProxyNodePlacement
+	}
+	return args.InfraNodePlacement
+}
+
+// APIServerPriorityClass returns the priority class for the cdi-apiserver deployment
+func (args *FactoryArgs) APIServerPriorityClass() string {
+	if args.APIServerPriorityClassName != "" {
+		return args.APIServerPriorityClassName
+	}
+	return args.PriorityClassName
+}
+
+// ControllerPriorityClass returns the priority class for the cdi-deployment deployment
+func (args *FactoryArgs) ControllerPriorityClass() string {
+	if args.ControllerPriorityClassName != "" {
+		return args.ControllerPriorityClassName
+	}
+	return args.PriorityClassName
+}
+
+// UploadProxyPriorityClass returns the priority class for the cdi-uploadproxy deployment
+func (args *FactoryArgs) UploadProxyPriorityClass() string {
+	if args.UploadProxyPriorityClassName != "" {
+		return args.UploadProxyPriorityClassName
+	}
+	return args.PriorityClassName
 }
 
 type factoryFunc func(*FactoryArgs) []client.Object
@@ -50,8 +50,10 @@ var CapabilitiesByProvisionerKey = map[string][]StorageCapabilities{
 	// portworx
 	"kubernetes.io/portworx-volume/shared": {{AccessMode: v1.ReadWriteMany, VolumeMode: v1.PersistentVolumeFilesystem}},
 	"pxd.openstorage.org/shared":           {{AccessMode: v1.ReadWriteMany, VolumeMode: v1.PersistentVolumeFilesystem}},
-	"kubernetes.io/portworx-volume":        {{AccessMode: v1.ReadWriteOnce, VolumeMode: v1.PersistentVolumeFilesystem}},
-	"pxd.openstorage.org":                  {{AccessMode: v1.ReadWriteOnce, VolumeMode: v1.PersistentVolumeFilesystem}},
+	"kubernetes.io/portworx-volume":        createPortworxCapabilities(),
+	"pxd.openstorage.org":                  createPortworxCapabilities(),
+	// linstor
+	"linstor.csi.linbit.com": createLinstorCapabilities(),
 	// Trident
 	"csi.trident.netapp.io/ontap-nas": {{AccessMode: v1.ReadWriteMany, VolumeMode: v1.PersistentVolumeFilesystem}},
 	"csi.trident.netapp.io/ontap-san": {{AccessMode: v1.ReadWriteOnce, VolumeMode: v1.PersistentVolumeBlock}},
@@ -159,3 +161,28 @@ func createRbdCapabilities() []StorageCapabilities {
 		{AccessMode: v1.ReadWriteOnce, VolumeMode: v1.PersistentVolumeBlock},
 		{AccessMode: v1.ReadWriteOnce, VolumeMode: v1.PersistentVolumeFilesystem}}
 }
+
+func createLinstorCapabilities() []StorageCapabilities {
+	return []StorageCapabilities{
+		{AccessMode: v1.ReadWriteMany, VolumeMode: v1.PersistentVolumeBlock},
+		{AccessMode: v1.ReadWriteOnce, VolumeMode: v1.PersistentVolumeBlock},
+		{AccessMode: v1.ReadWriteOnce, VolumeMode: v1.PersistentVolumeFilesystem}}
+}
+
+func createPortworxCapabilities() []StorageCapabilities {
+	return []StorageCapabilities{
+		{AccessMode: v1.ReadWriteMany, VolumeMode: v1.PersistentVolumeBlock},
+		{AccessMode: v1.ReadWriteOnce, VolumeMode: v1.PersistentVolumeBlock},
+		{AccessMode: v1.ReadWriteOnce, VolumeMode: v1.PersistentVolumeFilesystem}}
+}
+
+// SupportsRWXBlock tells whether the capabilities include ReadWriteMany access with
+// block volume mode, which KubeVirt requires for live migration
+func SupportsRWXBlock(capabilities []StorageCapabilities) bool {
+	for _, capability := range capabilities {
+		if capability.AccessMode == v1.ReadWriteMany && capability.VolumeMode == v1.PersistentVolumeBlock {
+			return true
+		}
+	}
+	return false
+}
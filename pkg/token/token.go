@@ -21,6 +21,9 @@ package token
 
 import (
 	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
 	"time"
 
 	"gopkg.in/square/go-jose.v2"
@@ -51,6 +54,17 @@ type Payload struct {
 	Params    map[string]string           `json:"params,omitempty"`
 }
 
+// KeyID computes the identifier of a signing key, embedded in token headers
+// so validators can select the right key during key rotation
+func KeyID(key *rsa.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "", errors.Wrap(err, "error marshalling public key")
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:]), nil
+}
+
 // Validator validates tokens
 type Validator interface {
 	Validate(string) (*Payload, error)
@@ -74,19 +88,76 @@ func (v *validator) Validate(token string) (*Payload, error) {
 		return nil, err
 	}
 
+	return validateClaims(tok, v.issuer, v.key, v.leeway)
+}
+
+type multiKeyValidator struct {
+	issuer  string
+	keyByID map[string]*rsa.PublicKey
+	keys    []*rsa.PublicKey
+	leeway  time.Duration
+}
+
+// NewMultiKeyValidator returns a Validator that accepts tokens signed by any of
+// the given keys, selecting the key by the token "kid" header when present.
+// Having several active keys allows rotating the signing key without
+// invalidating in-flight tokens
+func NewMultiKeyValidator(issuer string, keys []*rsa.PublicKey, leeway time.Duration) Validator {
+	keyByID := make(map[string]*rsa.PublicKey, len(keys))
+	for _, key := range keys {
+		if keyID, err := KeyID(key); err == nil {
+			keyByID[keyID] = key
+		}
+	}
+	return &multiKeyValidator{issuer: issuer, keyByID: keyByID, keys: keys, leeway: leeway}
+}
+
+// Validate checks the token signature against the key identified by the token
+// header, falling back to all active keys for tokens without a key ID
+func (v *multiKeyValidator) Validate(token string) (*Payload, error) {
+	tok, err := jwt.ParseSigned(token)
+	if err != nil {
+		return nil, err
+	}
+
+	var keyID string
+	if len(tok.Headers) > 0 {
+		keyID = tok.Headers[0].KeyID
+	}
+
+	if key, ok := v.keyByID[keyID]; ok {
+		return validateClaims(tok, v.issuer, key, v.leeway)
+	}
+
+	var lastErr error
+	for _, key := range v.keys {
+		payload, err := validateClaims(tok, v.issuer, key, v.leeway)
+		if err == nil {
+			return payload, nil
+		}
+		lastErr = err
+	}
+	if lastErr == nil {
+		lastErr = errors.New("no active signing keys")
+	}
+
+	return nil, lastErr
+}
+
+func validateClaims(tok *jwt.JSONWebToken, issuer string, key *rsa.PublicKey, leeway time.Duration) (*Payload, error) {
 	public := &jwt.Claims{}
 	private := &Payload{}
 
-	if err = tok.Claims(v.key, public, private); err != nil {
+	if err := tok.Claims(key, public, private); err != nil {
 		return nil, err
 	}
 
 	e := jwt.Expected{
-		Issuer: v.issuer,
+		Issuer: issuer,
 		Time:   time.Now(),
 	}
 
-	if err = public.ValidateWithLeeway(e, v.leeway); err != nil {
+	if err := public.ValidateWithLeeway(e, leeway); err != nil {
 		return nil, err
 	}
 
@@ -109,9 +180,17 @@ func NewGenerator(issuer string, key *rsa.PrivateKey, lifetime time.Duration) Ge
 	return &generator{issuer: issuer, key: key, lifetime: lifetime}
 }
 
-// Generate generates a token from the given parameters
+// Generate generates a token from the given parameters.
+// The ID of the signing key is recorded in the token header so validators
+// holding multiple active keys can select the right one
 func (g *generator) Generate(payload *Payload) (string, error) {
-	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.PS256, Key: g.key}, nil)
+	keyID, err := KeyID(&g.key.PublicKey)
+	if err != nil {
+		return "", err
+	}
+
+	opts := (&jose.SignerOptions{}).WithHeader(jose.HeaderKey("kid"), keyID)
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.PS256, Key: g.key}, opts)
 	if err != nil {
 		return "", errors.Wrap(err, "error creating JWT signer")
 	}
@@ -165,4 +165,50 @@ var _ = Describe("Token test", func() {
 		_, err = validator.Validate(signedToken)
 		Expect(err).To(HaveOccurred())
 	})
+
+	It("Multiple active keys", func() {
+		issuer := "issuer"
+
+		oldKey, err := generateTestKey()
+		Expect(err).ToNot(HaveOccurred())
+
+		newKey, err := generateTestKey()
+		Expect(err).ToNot(HaveOccurred())
+
+		tokenData := &Payload{
+			Operation: OperationUpload,
+			Name:      "fakepvc",
+			Namespace: "fakenamespace",
+			Resource: metav1.GroupVersionResource{
+				Group:    "",
+				Version:  "v1",
+				Resource: "persistentvolumeclaims",
+			},
+		}
+
+		signedWithOldKey, err := NewGenerator(issuer, oldKey, 5*time.Minute).Generate(tokenData)
+		Expect(err).ToNot(HaveOccurred())
+
+		signedWithNewKey, err := NewGenerator(issuer, newKey, 5*time.Minute).Generate(tokenData)
+		Expect(err).ToNot(HaveOccurred())
+
+		validator := NewMultiKeyValidator(issuer, []*rsa.PublicKey{&oldKey.PublicKey, &newKey.PublicKey}, 0)
+
+		payload, err := validator.Validate(signedWithOldKey)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reflect.DeepEqual(tokenData, payload)).To(BeTrue())
+
+		payload, err = validator.Validate(signedWithNewKey)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reflect.DeepEqual(tokenData, payload)).To(BeTrue())
+
+		otherKey, err := generateTestKey()
+		Expect(err).ToNot(HaveOccurred())
+
+		signedWithOtherKey, err := NewGenerator(issuer, otherKey, 5*time.Minute).Generate(tokenData)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = validator.Validate(signedWithOtherKey)
+		Expect(err).To(HaveOccurred())
+	})
 })
@@ -0,0 +1,170 @@
+/*
+Copyright 2022 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing exports lifecycle spans to an OpenTelemetry collector using
+// the OTLP/HTTP JSON encoding. It is intentionally dependency free; the spans
+// CDI emits are few and retrospective, so the full OTel SDK is not needed.
+package tracing
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// EndpointEnvVar is the standard OTel env var worker pods read the collector endpoint from
+const EndpointEnvVar = "OTEL_EXPORTER_OTLP_ENDPOINT"
+
+// Span is one completed operation in a trace
+type Span struct {
+	TraceID    string
+	SpanID     string
+	ParentID   string
+	Name       string
+	Start      time.Time
+	End        time.Time
+	Attributes map[string]string
+}
+
+// TraceID derives a stable trace id from an object UID, so spans emitted by the
+// controller and the worker pods of one transfer join the same trace
+func TraceID(uid string) string {
+	sum := sha256.Sum256([]byte(uid))
+	return hex.EncodeToString(sum[:16])
+}
+
+// SpanID derives a stable span id from a trace id and a span name
+func SpanID(traceID, name string) string {
+	sum := sha256.Sum256([]byte(traceID + "/" + name))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Exporter posts completed spans to an OpenTelemetry collector
+type Exporter struct {
+	endpoint string
+	service  string
+	client   *http.Client
+}
+
+// NewExporter returns an exporter posting to the given OTLP/HTTP base endpoint,
+// for example http://otel-collector:4318
+func NewExporter(endpoint, service string) *Exporter {
+	return &Exporter{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		service:  service,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// NewExporterFromEnv returns an exporter when the standard OTel endpoint env var
+// is set, nil otherwise. All Exporter methods are safe on a nil receiver.
+func NewExporterFromEnv(service string) *Exporter {
+	endpoint := os.Getenv(EndpointEnvVar)
+	if endpoint == "" {
+		return nil
+	}
+	return NewExporter(endpoint, service)
+}
+
+// Export sends the spans to the collector
+func (e *Exporter) Export(spans []Span) error {
+	if e == nil || len(spans) == 0 {
+		return nil
+	}
+
+	spanValues := make([]interface{}, 0, len(spans))
+	for _, span := range spans {
+		spanValues = append(spanValues, span.toOTLP())
+	}
+
+	payload := map[string]interface{}{
+		"resourceSpans": []interface{}{
+			map[string]interface{}{
+				"resource": map[string]interface{}{
+					"attributes": []interface{}{attribute("service.name", e.service)},
+				},
+				"scopeSpans": []interface{}{
+					map[string]interface{}{
+						"scope": map[string]interface{}{"name": "cdi"},
+						"spans": spanValues,
+					},
+				},
+			},
+		},
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	resp, err := e.client.Post(e.endpoint+"/v1/traces", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("collector returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// ExportAsync sends the spans in the background, logging on failure, so callers
+// in reconcile loops do not block on the collector
+func (e *Exporter) ExportAsync(spans []Span) {
+	if e == nil || len(spans) == 0 {
+		return
+	}
+	go func() {
+		if err := e.Export(spans); err != nil {
+			klog.V(1).Infof("Unable to export trace spans: %v", err)
+		}
+	}()
+}
+
+func (span *Span) toOTLP() map[string]interface{} {
+	attributes := make([]interface{}, 0, len(span.Attributes))
+	for key, value := range span.Attributes {
+		attributes = append(attributes, attribute(key, value))
+	}
+
+	return map[string]interface{}{
+		"traceId":           span.TraceID,
+		"spanId":            span.SpanID,
+		"parentSpanId":      span.ParentID,
+		"name":              span.Name,
+		"kind":              1,
+		"startTimeUnixNano": strconv.FormatInt(span.Start.UnixNano(), 10),
+		"endTimeUnixNano":   strconv.FormatInt(span.End.UnixNano(), 10),
+		"attributes":        attributes,
+	}
+}
+
+func attribute(key, value string) map[string]interface{} {
+	return map[string]interface{}{
+		"key":   key,
+		"value": map[string]interface{}{"stringValue": value},
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2022 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/containerized-data-importer/tests/reporters"
+)
+
+func TestTracing(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecsWithDefaultAndCustomReporters(t, "Tracing Suite", reporters.NewReporters())
+}
+
+var _ = Describe("Tracing exporter", func() {
+	It("Should derive stable trace and span ids", func() {
+		traceID := TraceID("1111-2222")
+		Expect(traceID).To(HaveLen(32))
+		Expect(TraceID("1111-2222")).To(Equal(traceID))
+		spanID := SpanID(traceID, "datavolume")
+		Expect(spanID).To(HaveLen(16))
+		Expect(SpanID(traceID, "pvc")).ToNot(Equal(spanID))
+	})
+
+	It("Should post spans to the OTLP traces endpoint", func() {
+		var path string
+		var payload map[string]interface{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.Path
+			body, err := ioutil.ReadAll(r.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(json.Unmarshal(body, &payload)).To(Succeed())
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		traceID := TraceID("1111-2222")
+		exporter := NewExporter(server.URL, "cdi-controller")
+		err := exporter.Export([]Span{{
+			TraceID:    traceID,
+			SpanID:     SpanID(traceID, "datavolume"),
+			Name:       "datavolume",
+			Start:      time.Now().Add(-time.Minute),
+			End:        time.Now(),
+			Attributes: map[string]string{"namespace": "test"},
+		}})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(Equal("/v1/traces"))
+		Expect(payload).To(HaveKey("resourceSpans"))
+	})
+
+	It("Should be a no-op on a nil exporter", func() {
+		var exporter *Exporter
+		Expect(exporter.Export([]Span{{Name: "noop"}})).To(Succeed())
+	})
+
+	It("Should fail when the collector rejects the spans", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		exporter := NewExporter(server.URL, "cdi-controller")
+		err := exporter.Export([]Span{{Name: "rejected"}})
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,82 @@
+package uploadproxy
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/monitoring"
+)
+
+// reasons for rejected upload requests, the label values of the proxy
+// failure counter
+const (
+	failureReasonBadAuthHeader = "bad-auth-header"
+	failureReasonInvalidToken  = "invalid-token"
+	failureReasonNotReady      = "upload-not-ready"
+	failureReasonBadPath       = "invalid-upload-path"
+	failureReasonInternal      = "internal-error"
+)
+
+var (
+	proxyConnections = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: monitoring.MetricOptsList[monitoring.UploadProxyConnections].Name,
+			Help: monitoring.MetricOptsList[monitoring.UploadProxyConnections].Help,
+		},
+	)
+	proxyFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: monitoring.MetricOptsList[monitoring.UploadProxyFailures].Name,
+			Help: monitoring.MetricOptsList[monitoring.UploadProxyFailures].Help,
+		},
+		[]string{"reason"},
+	)
+	certExpiry = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: monitoring.MetricOptsList[monitoring.UploadProxyCertExpiry].Name,
+			Help: monitoring.MetricOptsList[monitoring.UploadProxyCertExpiry].Help,
+		},
+	)
+)
+
+// RecordCertExpiry publishes the expiration timestamp of the currently served
+// certificate, typically called from the cert watcher on every reload
+func RecordCertExpiry(cert *tls.Certificate) {
+	if cert == nil || len(cert.Certificate) == 0 {
+		return
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		klog.Errorf("Unable to parse served certificate: %v", err)
+		return
+	}
+	certExpiry.Set(float64(leaf.NotAfter.Unix()))
+}
+
+func init() {
+	if err := prometheus.Register(proxyConnections); err != nil {
+		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			proxyConnections = are.ExistingCollector.(prometheus.Gauge)
+		} else {
+			klog.Errorf("Unable to create prometheus proxy connections gauge")
+		}
+	}
+	if err := prometheus.Register(proxyFailures); err != nil {
+		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			proxyFailures = are.ExistingCollector.(*prometheus.CounterVec)
+		} else {
+			klog.Errorf("Unable to create prometheus proxy failures counter")
+		}
+	}
+	if err := prometheus.Register(certExpiry); err != nil {
+		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			certExpiry = are.ExistingCollector.(prometheus.Gauge)
+		} else {
+			klog.Errorf("Unable to create prometheus cert expiry gauge")
+		}
+	}
+}
@@ -12,9 +12,11 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/cors"
 	v1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -32,6 +34,7 @@ import (
 
 const (
 	healthzPath = "/healthz"
+	metricsPath = "/metrics"
 
 	waitReadyTime     = 10 * time.Second
 	waitReadyImterval = time.Second
@@ -39,6 +42,10 @@ const (
 	proxyRequestTimeout = 24 * time.Hour
 
 	uploadTokenLeeway = 10 * time.Second
+
+	// proxyBufferSize is the size of the pooled copy buffers, large enough
+	// that concurrent uploads of disk images are not limited by buffer churn
+	proxyBufferSize = 1024 * 1024
 )
 
 // Server is the public interface to the upload proxy
@@ -73,6 +80,8 @@ type uploadProxyApp struct {
 
 	handler http.Handler
 
+	bufferPool *proxyBufferPool
+
 	// test hooks
 	urlResolver    urlLookupFunc
 	uploadPossible uploadPossibleFunc
@@ -85,6 +94,31 @@ type clientCreator struct {
 
 var authHeaderMatcher = regexp.MustCompile(`(?i)^Bearer\s+([A-Za-z0-9\-\._~\+\/]+)$`)
 
+// proxyBufferPool reuses large copy buffers across upload requests instead of
+// allocating a fresh buffer per request, which dominates proxy CPU usage when
+// many large uploads run concurrently. Implements httputil.BufferPool.
+type proxyBufferPool struct {
+	pool sync.Pool
+}
+
+func newProxyBufferPool() *proxyBufferPool {
+	return &proxyBufferPool{
+		pool: sync.Pool{
+			New: func() interface{} {
+				return make([]byte, proxyBufferSize)
+			},
+		},
+	}
+}
+
+func (p *proxyBufferPool) Get() []byte {
+	return p.pool.Get().([]byte)
+}
+
+func (p *proxyBufferPool) Put(b []byte) {
+	p.pool.Put(b)
+}
+
 // NewUploadProxy returns an initialized uploadProxyApp
 func NewUploadProxy(bindAddress string,
 	bindPort uint,
@@ -100,6 +134,7 @@ func NewUploadProxy(bindAddress string,
 		certWatcher:    certWatcher,
 		clientCreator:  &clientCreator{certFetcher: clientCertFetcher, bundleFetcher: serverCAFetcher},
 		client:         client,
+		bufferPool:     newProxyBufferPool(),
 		urlResolver:    controller.GetUploadServerURL,
 		uploadPossible: controller.UploadPossibleForPVC,
 	}
@@ -153,6 +188,7 @@ func (c *clientCreator) CreateClient() (*http.Client, error) {
 func (app *uploadProxyApp) initHandler() {
 	mux := http.NewServeMux()
 	mux.HandleFunc(healthzPath, app.handleHealthzRequest)
+	mux.Handle(metricsPath, promhttp.Handler())
 	for _, path := range common.ProxyPaths {
 		mux.HandleFunc(path, app.handleUploadRequest)
 	}
@@ -170,18 +206,21 @@ func (app *uploadProxyApp) handleHealthzRequest(w http.ResponseWriter, r *http.R
 func (app *uploadProxyApp) handleUploadRequest(w http.ResponseWriter, r *http.Request) {
 	tokenHeader := r.Header.Get("Authorization")
 	if tokenHeader == "" {
+		proxyFailures.WithLabelValues(failureReasonBadAuthHeader).Inc()
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
 	match := authHeaderMatcher.FindStringSubmatch(tokenHeader)
 	if len(match) != 2 {
+		proxyFailures.WithLabelValues(failureReasonBadAuthHeader).Inc()
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
 	tokenData, err := app.tokenValidator.Validate(match[1])
 	if err != nil {
+		proxyFailures.WithLabelValues(failureReasonInvalidToken).Inc()
 		w.WriteHeader(http.StatusUnauthorized)
 		return
 	}
@@ -191,15 +230,17 @@ func (app *uploadProxyApp) handleUploadRequest(w http.ResponseWriter, r *http.Re
 		tokenData.Namespace == "" ||
 		tokenData.Resource.Resource != "persistentvolumeclaims" {
 		klog.Errorf("Bad token %+v", tokenData)
+		proxyFailures.WithLabelValues(failureReasonInvalidToken).Inc()
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
 	klog.V(1).Infof("Received valid token: pvc: %s, namespace: %s", tokenData.Name, tokenData.Namespace)
 
-	err = app.uploadReady(tokenData.Name, tokenData.Namespace)
+	err = app.uploadReady(tokenData.Name, tokenData.Namespace, tokenData.Params["uid"])
 	if err != nil {
 		klog.Error(err)
+		proxyFailures.WithLabelValues(failureReasonNotReady).Inc()
 		w.WriteHeader(http.StatusServiceUnavailable)
 		// Return the error to the caller in the body.
 		w.Write([]byte(err.Error()))
@@ -209,6 +250,7 @@ func (app *uploadProxyApp) handleUploadRequest(w http.ResponseWriter, r *http.Re
 	uploadPath, err := app.resolveUploadPath(tokenData.Name, tokenData.Namespace, r.URL.Path)
 	if err != nil {
 		klog.Error(err)
+		proxyFailures.WithLabelValues(failureReasonBadPath).Inc()
 		w.WriteHeader(http.StatusServiceUnavailable)
 		// Return the error to the caller in the body.
 		w.Write([]byte(err.Error()))
@@ -247,7 +289,7 @@ func (app *uploadProxyApp) resolveUploadPath(pvcName, pvcNamespace, defaultPath
 	}
 }
 
-func (app *uploadProxyApp) uploadReady(pvcName, pvcNamespace string) error {
+func (app *uploadProxyApp) uploadReady(pvcName, pvcNamespace, tokenUID string) error {
 	return wait.PollImmediate(waitReadyImterval, waitReadyTime, func() (bool, error) {
 		pvc, err := app.client.CoreV1().PersistentVolumeClaims(pvcNamespace).Get(context.TODO(), pvcName, metav1.GetOptions{})
 		if err != nil {
@@ -258,6 +300,10 @@ func (app *uploadProxyApp) uploadReady(pvcName, pvcNamespace string) error {
 			return false, err
 		}
 
+		if tokenUID != "" && tokenUID != string(pvc.UID) {
+			return false, fmt.Errorf("rejecting Upload Request for PVC %s: upload token is scoped to another PVC", pvcName)
+		}
+
 		err = app.uploadPossible(pvc)
 		if err != nil {
 			return false, err
@@ -276,10 +322,14 @@ func (app *uploadProxyApp) proxyUploadRequest(namespace, pvcName, uploadPath str
 	client, err := app.clientCreator.CreateClient()
 	if err != nil {
 		klog.Error("Error creating http client")
+		proxyFailures.WithLabelValues(failureReasonInternal).Inc()
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
+	proxyConnections.Inc()
+	defer proxyConnections.Dec()
+
 	p := &httputil.ReverseProxy{
 		Director: func(req *http.Request) {
 			req.URL, _ = url.Parse(app.urlResolver(namespace, pvcName, uploadPath))
@@ -288,19 +338,21 @@ func (app *uploadProxyApp) proxyUploadRequest(namespace, pvcName, uploadPath str
 				req.Header.Set("User-Agent", "")
 			}
 		},
-		Transport: client.Transport,
+		Transport:  client.Transport,
+		BufferPool: app.bufferPool,
 	}
 
 	p.ServeHTTP(w, r)
 }
 
 func (app *uploadProxyApp) getSigningKey(publicKeyPEM string) error {
-	publicKey, err := controller.DecodePublicKey([]byte(publicKeyPEM))
+	// the PEM bundle may hold several active keys while the signing key is rotated
+	publicKeys, err := controller.DecodePublicKeys([]byte(publicKeyPEM))
 	if err != nil {
 		return err
 	}
 
-	app.tokenValidator = token.NewValidator(common.UploadTokenIssuer, publicKey, uploadTokenLeeway)
+	app.tokenValidator = token.NewMultiKeyValidator(common.UploadTokenIssuer, publicKeys, uploadTokenLeeway)
 	return nil
 }
 
@@ -117,7 +117,7 @@ func submitRequestAndCheckStatusAndCORS(request *http.Request, expectedCode int,
 }
 
 func createApp() *uploadProxyApp {
-	app := &uploadProxyApp{}
+	app := &uploadProxyApp{bufferPool: newProxyBufferPool()}
 	app.initHandler()
 	return app
 }
@@ -270,3 +270,12 @@ var _ = Describe("submit request and check status", func() {
 		submitRequestAndCheckStatus(req, http.StatusOK, nil)
 	})
 })
+
+var _ = Describe("Proxy buffer pool", func() {
+	It("Should hand out buffers of the configured size", func() {
+		pool := newProxyBufferPool()
+		buf := pool.Get()
+		Expect(buf).To(HaveLen(proxyBufferSize))
+		pool.Put(buf)
+	})
+})
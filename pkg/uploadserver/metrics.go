@@ -0,0 +1,76 @@
+package uploadserver
+
+import (
+	"io"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/monitoring"
+	"kubevirt.io/containerized-data-importer/pkg/util"
+)
+
+var (
+	uploadBytes = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: monitoring.MetricOptsList[monitoring.UploadBytes].Name,
+			Help: monitoring.MetricOptsList[monitoring.UploadBytes].Help,
+		},
+	)
+	uploadConnections = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: monitoring.MetricOptsList[monitoring.UploadConnections].Name,
+			Help: monitoring.MetricOptsList[monitoring.UploadConnections].Help,
+		},
+	)
+	uploadFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: monitoring.MetricOptsList[monitoring.UploadFailures].Name,
+			Help: monitoring.MetricOptsList[monitoring.UploadFailures].Help,
+		},
+		[]string{"reason"},
+	)
+)
+
+func init() {
+	if err := prometheus.Register(uploadBytes); err != nil {
+		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			uploadBytes = are.ExistingCollector.(prometheus.Counter)
+		} else {
+			klog.Errorf("Unable to create prometheus upload bytes counter")
+		}
+	}
+	if err := prometheus.Register(uploadConnections); err != nil {
+		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			uploadConnections = are.ExistingCollector.(prometheus.Gauge)
+		} else {
+			klog.Errorf("Unable to create prometheus upload connections gauge")
+		}
+	}
+	if err := prometheus.Register(uploadFailures); err != nil {
+		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			uploadFailures = are.ExistingCollector.(*prometheus.CounterVec)
+		} else {
+			klog.Errorf("Unable to create prometheus upload failures counter")
+		}
+	}
+}
+
+// countingReadCloser feeds the upload byte counter while the processor
+// consumes the stream, so the scraped rate gives the upload throughput
+type countingReadCloser struct {
+	io.ReadCloser
+}
+
+func (c *countingReadCloser) Read(buf []byte) (int, error) {
+	n, err := c.ReadCloser.Read(buf)
+	uploadBytes.Add(float64(n))
+	return n, err
+}
+
+// recordUploadFailure counts a failed upload under the error class the
+// termination message classifier assigns to it
+func recordUploadFailure(err error) {
+	uploadFailures.WithLabelValues(util.ClassifyError(err).Code).Inc()
+}
@@ -31,15 +31,20 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
 	"sync"
+	"syscall"
+	"time"
 
 	"github.com/golang/snappy"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"k8s.io/klog/v2"
 
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/clonestream"
 	"kubevirt.io/containerized-data-importer/pkg/common"
 	"kubevirt.io/containerized-data-importer/pkg/importer"
 	"kubevirt.io/containerized-data-importer/pkg/util"
@@ -48,6 +53,7 @@ import (
 const (
 	healthzPort = 8080
 	healthzPath = "/healthz"
+	metricsPath = "/metrics"
 )
 
 // UploadServer is the interface to uploadServerApp
@@ -60,6 +66,7 @@ type uploadServerApp struct {
 	bindAddress          string
 	bindPort             int
 	destination          string
+	certDir              string
 	tlsKey               string
 	tlsCert              string
 	clientCert           string
@@ -114,11 +121,12 @@ func formReadCloser(r *http.Request) (io.ReadCloser, error) {
 }
 
 // NewUploadServer returns a new instance of uploadServerApp
-func NewUploadServer(bindAddress string, bindPort int, destination, tlsKey, tlsCert, clientCert, clientName, imageSize string, filesystemOverhead float64, preallocation bool) UploadServer {
+func NewUploadServer(bindAddress string, bindPort int, destination, certDir, tlsKey, tlsCert, clientCert, clientName, imageSize string, filesystemOverhead float64, preallocation bool) UploadServer {
 	server := &uploadServerApp{
 		bindAddress:        bindAddress,
 		bindPort:           bindPort,
 		destination:        destination,
+		certDir:            certDir,
 		tlsKey:             tlsKey,
 		tlsCert:            tlsCert,
 		clientCert:         clientCert,
@@ -180,7 +188,8 @@ func (app *uploadServerApp) Run() error {
 		app.bindPort = uploadListener.Addr().(*net.TCPAddr).Port
 
 		if app.keyFile != "" && app.certFile != "" {
-			app.errChan <- uploadServer.ServeTLS(uploadListener, app.certFile, app.keyFile)
+			// the cert is loaded via GetCertificate in the TLS config
+			app.errChan <- uploadServer.ServeTLS(uploadListener, "", "")
 			return
 		}
 
@@ -211,7 +220,10 @@ func (app *uploadServerApp) createUploadServer() (*http.Server, error) {
 		Handler: app,
 	}
 
-	if app.tlsKey != "" && app.tlsCert != "" {
+	if app.certDir != "" {
+		app.keyFile = filepath.Join(app.certDir, "tls.key")
+		app.certFile = filepath.Join(app.certDir, "tls.crt")
+	} else if app.tlsKey != "" && app.tlsCert != "" {
 		certDir, err := ioutil.TempDir("", "uploadserver-tls")
 		if err != nil {
 			return nil, errors.Wrap(err, "Error creating cert dir")
@@ -231,24 +243,92 @@ func (app *uploadServerApp) createUploadServer() (*http.Server, error) {
 		}
 	}
 
+	tlsConfig := &tls.Config{}
+	hasTLS := false
+
+	if app.keyFile != "" && app.certFile != "" {
+		watcher := &certWatcher{certFile: app.certFile, keyFile: app.keyFile}
+		watcher.reloadOnSighup()
+		tlsConfig.GetCertificate = watcher.getCertificate
+		hasTLS = true
+	}
+
 	if app.clientCert != "" {
 		caCertPool := x509.NewCertPool()
 		if ok := caCertPool.AppendCertsFromPEM([]byte(app.clientCert)); !ok {
 			klog.Fatalf("Invalid ca cert file %s", app.clientCert)
 		}
 
-		server.TLSConfig = &tls.Config{
-			ClientCAs:  caCertPool,
-			ClientAuth: tls.RequireAndVerifyClientCert,
-		}
+		tlsConfig.ClientCAs = caCertPool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		hasTLS = true
+	}
+
+	if hasTLS {
+		server.TLSConfig = tlsConfig
 	}
 
 	return server, nil
 }
 
+// certWatcher reloads the server cert from disk when it changes, so a rotated
+// cert is served on new TLS handshakes without interrupting established
+// connections
+type certWatcher struct {
+	certFile string
+	keyFile  string
+
+	mutex   sync.Mutex
+	modTime time.Time
+	cert    *tls.Certificate
+}
+
+func (w *certWatcher) getCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	fi, err := os.Stat(w.certFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if w.cert == nil || fi.ModTime().After(w.modTime) {
+		cert, err := tls.LoadX509KeyPair(w.certFile, w.keyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		if w.cert != nil {
+			klog.V(1).Infof("Reloaded cert from %s", w.certFile)
+		}
+
+		w.cert = &cert
+		w.modTime = fi.ModTime()
+	}
+
+	return w.cert, nil
+}
+
+// reloadOnSighup drops the cached cert when the server receives a SIGHUP, so
+// the next handshake reloads it from disk even if the file modtime did not
+// change, e.g. when the mounted secret was swapped atomically
+func (w *certWatcher) reloadOnSighup() {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGHUP)
+	go func() {
+		for range sigChan {
+			klog.Info("Received SIGHUP, reloading TLS certificate")
+			w.mutex.Lock()
+			w.cert = nil
+			w.mutex.Unlock()
+		}
+	}()
+}
+
 func (app *uploadServerApp) createHealthzServer() (*http.Server, error) {
 	mux := http.NewServeMux()
 	mux.HandleFunc(healthzPath, app.healthzHandler)
+	mux.Handle(metricsPath, promhttp.Handler())
 	return &http.Server{Handler: mux}, nil
 }
 
@@ -315,21 +395,26 @@ func (app *uploadServerApp) uploadHandlerAsync(irc imageReadCloser) http.Handler
 			return
 		}
 
+		uploadConnections.Inc()
+		defer uploadConnections.Dec()
+
 		cdiContentType := r.Header.Get(common.UploadContentTypeHeader)
+		transport := r.Header.Get(common.CloneTransportHeader)
 
-		klog.Infof("Content type header is %q\n", cdiContentType)
+		klog.Infof("Content type header is %q, transport is %q\n", cdiContentType, transport)
 
 		readCloser, err := irc(r)
 		if err != nil {
 			w.WriteHeader(http.StatusBadRequest)
 		}
 
-		processor, err := uploadProcessorFuncAsync(readCloser, app.destination, app.imageSize, app.filesystemOverhead, app.preallocation, cdiContentType)
+		processor, err := uploadProcessorFuncAsync(&countingReadCloser{readCloser}, app.destination, app.imageSize, app.filesystemOverhead, app.preallocation, cdiContentType, transport)
 
 		app.mutex.Lock()
 
 		if err != nil {
 			klog.Errorf("Saving stream failed: %s", err)
+			recordUploadFailure(err)
 			if _, ok := err.(importer.ValidationSizeError); ok {
 				w.WriteHeader(http.StatusBadRequest)
 			} else {
@@ -350,6 +435,7 @@ func (app *uploadServerApp) uploadHandlerAsync(irc imageReadCloser) http.Handler
 			defer close(app.doneChan)
 			if err := processor.ProcessDataResume(); err != nil {
 				klog.Errorf("Error during resumed processing: %v", err)
+				recordUploadFailure(err)
 				app.errChan <- err
 			}
 			app.mutex.Lock()
@@ -369,22 +455,27 @@ func (app *uploadServerApp) processUpload(irc imageReadCloser, w http.ResponseWr
 		return
 	}
 
+	uploadConnections.Inc()
+	defer uploadConnections.Dec()
+
 	cdiContentType := r.Header.Get(common.UploadContentTypeHeader)
+	transport := r.Header.Get(common.CloneTransportHeader)
 
-	klog.Infof("Content type header is %q\n", cdiContentType)
+	klog.Infof("Content type header is %q, transport is %q\n", cdiContentType, transport)
 
 	readCloser, err := irc(r)
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 	}
 
-	app.preallocationApplied, err = uploadProcessorFunc(readCloser, app.destination, app.imageSize, app.filesystemOverhead, app.preallocation, cdiContentType, dvContentType)
+	app.preallocationApplied, err = uploadProcessorFunc(&countingReadCloser{readCloser}, app.destination, app.imageSize, app.filesystemOverhead, app.preallocation, cdiContentType, transport, dvContentType)
 
 	app.mutex.Lock()
 	defer app.mutex.Unlock()
 
 	if err != nil {
 		klog.Errorf("Saving stream failed: %s", err)
+		recordUploadFailure(err)
 		w.WriteHeader(http.StatusInternalServerError)
 		app.uploading = false
 		return
@@ -418,33 +509,33 @@ func (app *uploadServerApp) PreallocationApplied() bool {
 	return app.preallocationApplied
 }
 
-func newAsyncUploadStreamProcessor(stream io.ReadCloser, dest, imageSize string, filesystemOverhead float64, preallocation bool, sourceContentType string) (*importer.DataProcessor, error) {
+func newAsyncUploadStreamProcessor(stream io.ReadCloser, dest, imageSize string, filesystemOverhead float64, preallocation bool, sourceContentType, transport string) (*importer.DataProcessor, error) {
 	if sourceContentType == common.FilesystemCloneContentType {
 		return nil, fmt.Errorf("async filesystem clone not supported")
 	}
 
-	uds := importer.NewAsyncUploadDataSource(newContentReader(stream, sourceContentType))
+	uds := importer.NewAsyncUploadDataSource(newContentReader(stream, sourceContentType, transport))
 	processor := importer.NewDataProcessor(uds, dest, common.ImporterVolumePath, common.ScratchDataDir, imageSize, filesystemOverhead, preallocation)
 	return processor, processor.ProcessDataWithPause()
 }
 
-func newUploadStreamProcessor(stream io.ReadCloser, dest, imageSize string, filesystemOverhead float64, preallocation bool, sourceContentType string, dvContentType cdiv1.DataVolumeContentType) (bool, error) {
+func newUploadStreamProcessor(stream io.ReadCloser, dest, imageSize string, filesystemOverhead float64, preallocation bool, sourceContentType, transport string, dvContentType cdiv1.DataVolumeContentType) (bool, error) {
 	if sourceContentType == common.FilesystemCloneContentType {
-		return false, filesystemCloneProcessor(stream, dest)
+		return false, filesystemCloneProcessor(stream, dest, transport)
 	}
 
 	// Clone block device to block device or file system
-	uds := importer.NewUploadDataSource(newContentReader(stream, sourceContentType), dvContentType)
+	uds := importer.NewUploadDataSource(newContentReader(stream, sourceContentType, transport), dvContentType)
 	processor := importer.NewDataProcessor(uds, dest, common.ImporterVolumePath, common.ScratchDataDir, imageSize, filesystemOverhead, preallocation)
 	err := processor.ProcessData()
 	return processor.PreallocationApplied(), err
 }
 
 // Clone file system to block device or file system
-func filesystemCloneProcessor(stream io.ReadCloser, dest string) error {
+func filesystemCloneProcessor(stream io.ReadCloser, dest string, transport string) error {
 	// Clone to block device
 	if dest == common.WriteBlockPath {
-		if err := untarToBlockdev(newSnappyReadCloser(stream), dest); err != nil {
+		if err := untarToBlockdev(newCloneStreamReader(stream, transport), dest); err != nil {
 			return errors.Wrapf(err, "error unarchiving to %s", dest)
 		}
 		return nil
@@ -455,7 +546,7 @@ func filesystemCloneProcessor(stream io.ReadCloser, dest string) error {
 	if err := importer.CleanDir(destDir); err != nil {
 		return errors.Wrapf(err, "error removing contents of %s", destDir)
 	}
-	if err := util.UnArchiveTar(newSnappyReadCloser(stream), destDir); err != nil {
+	if err := util.UnArchiveTar(newCloneStreamReader(stream, transport), destDir); err != nil {
 		return errors.Wrapf(err, "error unarchiving to %s", destDir)
 	}
 	return nil
@@ -490,14 +581,19 @@ func untarToBlockdev(stream io.Reader, dest string) error {
 	}
 }
 
-func newContentReader(stream io.ReadCloser, contentType string) io.ReadCloser {
+func newContentReader(stream io.ReadCloser, contentType, transport string) io.ReadCloser {
 	if contentType == common.BlockdeviceClone {
-		return newSnappyReadCloser(stream)
+		return newCloneStreamReader(stream, transport)
 	}
 
 	return stream
 }
 
-func newSnappyReadCloser(stream io.ReadCloser) io.ReadCloser {
+// newCloneStreamReader decodes the body of a network clone according to the
+// transport the source advertised, falling back to the legacy snappy stream
+func newCloneStreamReader(stream io.ReadCloser, transport string) io.ReadCloser {
+	if transport == clonestream.Transport {
+		return clonestream.NewReadCloser(stream)
+	}
 	return ioutil.NopCloser(snappy.NewReader(stream))
 }
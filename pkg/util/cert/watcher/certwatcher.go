@@ -18,7 +18,10 @@ package watcher
 
 import (
 	"crypto/tls"
+	"os"
+	"os/signal"
 	"sync"
+	"syscall"
 
 	"gopkg.in/fsnotify.v1"
 	"k8s.io/klog/v2"
@@ -32,6 +35,8 @@ type CertWatcher struct {
 
 	currentCert *tls.Certificate
 	watcher     *fsnotify.Watcher
+	callback    func(*tls.Certificate)
+	sigChan     chan os.Signal
 
 	certPath string
 	keyPath  string
@@ -66,7 +71,20 @@ func (cw *CertWatcher) GetCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate,
 	return cw.currentCert, nil
 }
 
-// Start starts the watch on the certificate and key files.
+// RegisterCallback registers a function invoked with every newly loaded
+// certificate, including the one already loaded.
+func (cw *CertWatcher) RegisterCallback(callback func(*tls.Certificate)) {
+	cw.Lock()
+	cw.callback = callback
+	cert := cw.currentCert
+	cw.Unlock()
+	if cert != nil {
+		callback(cert)
+	}
+}
+
+// Start starts the watch on the certificate and key files. A SIGHUP forces a
+// reload in case the file watch missed a rotation.
 func (cw *CertWatcher) Start(stopCh <-chan struct{}) error {
 	files := []string{cw.certPath, cw.keyPath}
 
@@ -76,6 +94,9 @@ func (cw *CertWatcher) Start(stopCh <-chan struct{}) error {
 		}
 	}
 
+	cw.sigChan = make(chan os.Signal, 1)
+	signal.Notify(cw.sigChan, syscall.SIGHUP)
+
 	go cw.Watch()
 
 	klog.Info("Starting certificate watcher")
@@ -105,6 +126,12 @@ func (cw *CertWatcher) Watch() {
 			}
 
 			klog.Error(err, "certificate watch error")
+
+		case <-cw.sigChan:
+			klog.Info("Received SIGHUP, reloading TLS certificate")
+			if err := cw.ReadCertificate(); err != nil {
+				klog.Error(err, "error re-reading certificate")
+			}
 		}
 	}
 }
@@ -120,10 +147,15 @@ func (cw *CertWatcher) ReadCertificate() error {
 
 	cw.Lock()
 	cw.currentCert = &cert
+	callback := cw.callback
 	cw.Unlock()
 
 	klog.Info("Updated current TLS certificate")
 
+	if callback != nil {
+		callback(&cert)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,118 @@
+package util
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/pkg/errors"
+)
+
+// DigestAlgorithm names a digest algorithm supported by NewDigestWriter and NewDigestReader
+type DigestAlgorithm string
+
+const (
+	// DigestSHA256 is the FIPS-approved default algorithm
+	DigestSHA256 DigestAlgorithm = "sha256"
+	// DigestSHA512 is a FIPS-approved algorithm for longer digests
+	DigestSHA512 DigestAlgorithm = "sha512"
+	// DigestXXHash is a fast non-cryptographic algorithm, suitable for integrity checks only
+	DigestXXHash DigestAlgorithm = "xxhash"
+)
+
+func newHash(algorithm DigestAlgorithm) (hash.Hash, error) {
+	switch algorithm {
+	case DigestSHA256, "":
+		return sha256.New(), nil
+	case DigestSHA512:
+		return sha512.New(), nil
+	case DigestXXHash:
+		return xxhash.New(), nil
+	default:
+		return nil, errors.Errorf("unsupported digest algorithm: %s", algorithm)
+	}
+}
+
+// DigestWriter wraps an io.Writer and digests everything written through it
+type DigestWriter struct {
+	io.Writer
+	hash hash.Hash
+}
+
+// NewDigestWriter returns a DigestWriter passing writes through to w
+func NewDigestWriter(w io.Writer, algorithm DigestAlgorithm) (*DigestWriter, error) {
+	h, err := newHash(algorithm)
+	if err != nil {
+		return nil, err
+	}
+	return &DigestWriter{Writer: io.MultiWriter(w, h), hash: h}, nil
+}
+
+// Digest returns the hex digest of everything written so far
+func (w *DigestWriter) Digest() string {
+	return hex.EncodeToString(w.hash.Sum(nil))
+}
+
+// DigestReader wraps an io.Reader and digests everything read through it
+type DigestReader struct {
+	io.Reader
+	hash hash.Hash
+}
+
+// NewDigestReader returns a DigestReader passing reads through to r
+func NewDigestReader(r io.Reader, algorithm DigestAlgorithm) (*DigestReader, error) {
+	h, err := newHash(algorithm)
+	if err != nil {
+		return nil, err
+	}
+	return &DigestReader{Reader: io.TeeReader(r, h), hash: h}, nil
+}
+
+// Digest returns the hex digest of everything read so far
+func (r *DigestReader) Digest() string {
+	return hex.EncodeToString(r.hash.Sum(nil))
+}
+
+// FileHasher produces a hex digest of a file, hiding the hash algorithm from
+// the callers so it can be chosen according to the cluster crypto policy.
+type FileHasher interface {
+	Hash(filePath string) (string, error)
+}
+
+// NewFileHasher returns a SHA-256 based FileHasher, usable on FIPS-enabled nodes.
+func NewFileHasher() FileHasher {
+	return NewFileHasherForAlgorithm(DigestSHA256)
+}
+
+// NewFileHasherForAlgorithm returns a FileHasher using the given digest algorithm.
+func NewFileHasherForAlgorithm(algorithm DigestAlgorithm) FileHasher {
+	return &fileHasher{algorithm: algorithm}
+}
+
+type fileHasher struct {
+	algorithm DigestAlgorithm
+}
+
+func (h *fileHasher) Hash(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	reader, err := NewDigestReader(file, h.algorithm)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := io.Copy(ioutil.Discard, reader); err != nil {
+		return "", err
+	}
+
+	return reader.Digest(), nil
+}
@@ -0,0 +1,50 @@
+package util
+
+import (
+	"bytes"
+	"io/ioutil"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Digest readers and writers", func() {
+	DescribeTable("Should produce the same digest when writing and when reading", func(algorithm DigestAlgorithm) {
+		data := []byte("Hello digest!")
+
+		buf := &bytes.Buffer{}
+		writer, err := NewDigestWriter(buf, algorithm)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = writer.Write(data)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buf.Bytes()).To(Equal(data))
+
+		reader, err := NewDigestReader(bytes.NewReader(data), algorithm)
+		Expect(err).ToNot(HaveOccurred())
+		read, err := ioutil.ReadAll(reader)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(read).To(Equal(data))
+
+		Expect(reader.Digest()).To(Equal(writer.Digest()))
+	},
+		Entry("with sha256", DigestSHA256),
+		Entry("with sha512", DigestSHA512),
+		Entry("with xxhash", DigestXXHash),
+	)
+
+	It("Should default to sha256", func() {
+		reader, err := NewDigestReader(bytes.NewReader([]byte("abc")), "")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = ioutil.ReadAll(reader)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reader.Digest()).To(Equal("ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"))
+	})
+
+	It("Should reject an unsupported algorithm", func() {
+		_, err := NewDigestWriter(&bytes.Buffer{}, "md5")
+		Expect(err).To(HaveOccurred())
+		_, err = NewDigestReader(&bytes.Buffer{}, "md5")
+		Expect(err).To(HaveOccurred())
+	})
+})
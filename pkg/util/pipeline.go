@@ -0,0 +1,153 @@
+package util
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+const (
+	// pipelineBufferSize is the size of a single pipeline buffer, reads from
+	// the source are coalesced into blocks of this size before they are written
+	pipelineBufferSize = 8 * 1024 * 1024
+	// DefaultWriteQueueDepth is the number of buffers in flight between the
+	// reader and the writer when no override is configured
+	DefaultWriteQueueDepth = 4
+	// maxWriteQueueDepth caps the queue depth, each buffer pins
+	// pipelineBufferSize bytes of memory for the lifetime of the copy
+	maxWriteQueueDepth = 32
+
+	cgroupV2MemoryLimitFile = "/sys/fs/cgroup/memory.max"
+	cgroupV1MemoryLimitFile = "/sys/fs/cgroup/memory/memory.limit_in_bytes"
+)
+
+type pipelineBlock struct {
+	buf []byte
+	n   int
+}
+
+// PipelinedCopy copies src to dst through a queue of recycled buffers. A
+// producer goroutine keeps reading ahead while the caller drains filled
+// buffers to dst, so time spent waiting on the network overlaps with time
+// spent waiting on the disk.
+func PipelinedCopy(dst io.Writer, src io.Reader, queueDepth int) (written int64, err error) {
+	if queueDepth < 1 {
+		queueDepth = 1
+	}
+
+	free := make(chan []byte, queueDepth)
+	for i := 0; i < queueDepth; i++ {
+		free <- make([]byte, pipelineBufferSize)
+	}
+	filled := make(chan pipelineBlock, queueDepth)
+	done := make(chan struct{})
+	defer close(done)
+
+	var readErr error
+	go func() {
+		defer close(filled)
+		for {
+			var buf []byte
+			select {
+			case buf = <-free:
+			case <-done:
+				return
+			}
+			n, err := io.ReadFull(src, buf)
+			if n > 0 {
+				select {
+				case filled <- pipelineBlock{buf: buf, n: n}:
+				case <-done:
+					return
+				}
+			}
+			if err != nil {
+				if err != io.EOF && err != io.ErrUnexpectedEOF {
+					readErr = err
+				}
+				return
+			}
+		}
+	}()
+
+	for block := range filled {
+		n, err := dst.Write(block.buf[:block.n])
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+		select {
+		case free <- block.buf:
+		default:
+		}
+	}
+	// the producer closed the filled channel before publishing readErr, so
+	// reading it here is safe
+	return written, readErr
+}
+
+// GetWriteQueueDepth returns the pipeline queue depth configured through the
+// IMPORTER_WRITE_QUEUE_DEPTH environment variable, or the default, capped so
+// the in-flight buffers fit into the pod's memory limit
+func GetWriteQueueDepth() int {
+	depth, err := strconv.Atoi(os.Getenv(common.ImporterWriteQueueDepth))
+	if err != nil || depth < 1 {
+		depth = DefaultWriteQueueDepth
+	}
+	if depth > maxWriteQueueDepth {
+		depth = maxWriteQueueDepth
+	}
+	return capWriteQueueDepth(depth, GetMemoryLimit())
+}
+
+// capWriteQueueDepth limits the number of in-flight pipeline buffers to half
+// of the pod's memory limit, leaving the other half for decompression and
+// conversion, so lowering podResourceRequirements does not get imports
+// OOMKilled. A limit of 0 means no limit is configured.
+func capWriteQueueDepth(depth int, memoryLimit int64) int {
+	if memoryLimit <= 0 {
+		return depth
+	}
+	maxBuffers := int(memoryLimit / 2 / pipelineBufferSize)
+	if maxBuffers < 1 {
+		maxBuffers = 1
+	}
+	if depth > maxBuffers {
+		return maxBuffers
+	}
+	return depth
+}
+
+// GetMemoryLimit returns the memory limit of the pod's cgroup in bytes, or 0
+// when no limit is configured
+func GetMemoryLimit() int64 {
+	return readMemoryLimit(cgroupV2MemoryLimitFile, cgroupV1MemoryLimitFile)
+}
+
+func readMemoryLimit(limitFiles ...string) int64 {
+	for _, limitFile := range limitFiles {
+		content, err := ioutil.ReadFile(limitFile)
+		if err != nil {
+			continue
+		}
+		value := strings.TrimSpace(string(content))
+		if value == "max" {
+			// cgroup v2 without a limit
+			return 0
+		}
+		limit, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			continue
+		}
+		if limit <= 0 || limit >= 1<<62 {
+			// cgroup v1 reports a huge page-rounded number without a limit
+			return 0
+		}
+		return limit
+	}
+	return 0
+}
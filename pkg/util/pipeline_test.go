@@ -0,0 +1,119 @@
+package util
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"math/rand"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+type failingReader struct {
+	data []byte
+	err  error
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if len(r.data) == 0 {
+		return 0, r.err
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+type failingWriter struct {
+	err error
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	return 0, w.err
+}
+
+var _ = Describe("Pipelined copy", func() {
+	table.DescribeTable("should copy all data", func(size int) {
+		data := make([]byte, size)
+		rand.Read(data)
+		dst := &bytes.Buffer{}
+
+		written, err := PipelinedCopy(dst, bytes.NewReader(data), 2)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(written).To(Equal(int64(size)))
+		Expect(dst.Bytes()).To(Equal(data))
+	},
+		table.Entry("empty source", 0),
+		table.Entry("less than one buffer", 1234),
+		table.Entry("exactly one buffer", pipelineBufferSize),
+		table.Entry("several buffers plus a partial one", 2*pipelineBufferSize+42),
+	)
+
+	It("should return the read error after writing the data read so far", func() {
+		readErr := errors.New("read failed")
+		dst := &bytes.Buffer{}
+
+		written, err := PipelinedCopy(dst, &failingReader{data: []byte("partial"), err: readErr}, 2)
+		Expect(err).To(MatchError(readErr))
+		Expect(written).To(Equal(int64(len("partial"))))
+		Expect(dst.String()).To(Equal("partial"))
+	})
+
+	It("should return the write error", func() {
+		writeErr := errors.New("write failed")
+		data := make([]byte, 2*pipelineBufferSize)
+
+		_, err := PipelinedCopy(&failingWriter{err: writeErr}, bytes.NewReader(data), 2)
+		Expect(err).To(MatchError(writeErr))
+	})
+})
+
+var _ = Describe("Write queue depth", func() {
+	AfterEach(func() {
+		os.Unsetenv("IMPORTER_WRITE_QUEUE_DEPTH")
+	})
+
+	table.DescribeTable("should be read from the environment", func(value string, expected int) {
+		if value != "" {
+			os.Setenv("IMPORTER_WRITE_QUEUE_DEPTH", value)
+		}
+		Expect(GetWriteQueueDepth()).To(Equal(expected))
+	},
+		table.Entry("unset", "", DefaultWriteQueueDepth),
+		table.Entry("valid", "8", 8),
+		table.Entry("not a number", "many", DefaultWriteQueueDepth),
+		table.Entry("zero", "0", DefaultWriteQueueDepth),
+		table.Entry("above the cap", "1000", maxWriteQueueDepth),
+	)
+})
+
+var _ = Describe("Memory aware queue depth", func() {
+	table.DescribeTable("should cap the queue depth to half the memory limit", func(depth int, memoryLimit int64, expected int) {
+		Expect(capWriteQueueDepth(depth, memoryLimit)).To(Equal(expected))
+	},
+		table.Entry("no limit", 4, int64(0), 4),
+		table.Entry("large limit", 4, int64(1024*1024*1024), 4),
+		table.Entry("small limit", 4, int64(32*1024*1024), 2),
+		table.Entry("tiny limit keeps one buffer", 4, int64(4*1024*1024), 1),
+	)
+
+	table.DescribeTable("should read the cgroup memory limit", func(content string, expected int64) {
+		limitFile, err := ioutil.TempFile("", "memory-limit")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.Remove(limitFile.Name())
+		_, err = limitFile.WriteString(content)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(limitFile.Close()).To(Succeed())
+		Expect(readMemoryLimit(limitFile.Name())).To(Equal(expected))
+	},
+		table.Entry("cgroup v2 limit", "268435456\n", int64(268435456)),
+		table.Entry("cgroup v2 without a limit", "max\n", int64(0)),
+		table.Entry("cgroup v1 without a limit", "9223372036854771712\n", int64(0)),
+	)
+
+	It("should return no limit when the limit files do not exist", func() {
+		Expect(readMemoryLimit("/this/file/does/not/exist")).To(Equal(int64(0)))
+	})
+})
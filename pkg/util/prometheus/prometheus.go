@@ -20,10 +20,12 @@ import (
 // ProgressReader is a counting reader that reports progress to prometheus.
 type ProgressReader struct {
 	util.CountingReader
-	total    uint64
-	progress *prometheus.CounterVec
-	ownerUID string
-	final    bool
+	total      uint64
+	progress   *prometheus.CounterVec
+	bytesDone  *prometheus.CounterVec
+	bytesTotal *prometheus.GaugeVec
+	ownerUID   string
+	final      bool
 }
 
 // NewProgressReader creates a new instance of a prometheus updating progress reader.
@@ -42,6 +44,14 @@ func NewProgressReader(r io.ReadCloser, total uint64, progress *prometheus.Count
 	return promReader
 }
 
+// ReportBytes makes the reader also publish byte counters next to the percentage,
+// backing the structured progress in the DataVolume status.
+func (r *ProgressReader) ReportBytes(done *prometheus.CounterVec, total *prometheus.GaugeVec) {
+	r.bytesDone = done
+	r.bytesTotal = total
+	r.bytesTotal.WithLabelValues(r.ownerUID).Set(float64(r.total))
+}
+
 // StartTimedUpdate starts the update timer to automatically update every second.
 func (r *ProgressReader) StartTimedUpdate() {
 	// Start the progress update thread.
@@ -69,6 +79,17 @@ func (r *ProgressReader) updateProgress() bool {
 		if currentProgress > *metric.Counter.Value {
 			r.progress.WithLabelValues(r.ownerUID).Add(currentProgress - *metric.Counter.Value)
 		}
+		if r.bytesDone != nil {
+			currentBytes := float64(r.Current)
+			if finished {
+				currentBytes = float64(r.total)
+			}
+			bytesMetric := &dto.Metric{}
+			r.bytesDone.WithLabelValues(r.ownerUID).Write(bytesMetric)
+			if currentBytes > *bytesMetric.Counter.Value {
+				r.bytesDone.WithLabelValues(r.ownerUID).Add(currentBytes - *bytesMetric.Counter.Value)
+			}
+		}
 		klog.V(1).Infoln(fmt.Sprintf("%.2f", currentProgress))
 		return !finished
 	}
@@ -3,24 +3,30 @@ package util
 import (
 	"bufio"
 	"bytes"
-	"crypto/md5"
+	"context"
 	"encoding/base64"
-	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"math"
 	"math/rand"
+	"net"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
+	"unsafe"
 
 	"github.com/pkg/errors"
 	"golang.org/x/sys/unix"
+	"golang.org/x/time/rate"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -31,7 +37,6 @@ import (
 )
 
 const (
-	blockdevFileName = "/usr/sbin/blockdev"
 	// DefaultAlignBlockSize is the alignment size we use to align disk images, its a multiple of all known hardware block sizes 512/4k/8k/32k/64k.
 	DefaultAlignBlockSize = 1024 * 1024
 )
@@ -41,6 +46,16 @@ type CountingReader struct {
 	Reader  io.ReadCloser
 	Current uint64
 	Done    bool
+	// Context cancels in-flight reads when set, so a transfer can be aborted without
+	// waiting for the stream to drain
+	Context context.Context
+	// Limiter throttles the read rate when set. The limiter burst must be at least
+	// the largest read buffer size, or reads fail
+	Limiter *rate.Limiter
+
+	windowStart time.Time
+	windowBytes uint64
+	throughput  float64
 }
 
 // VddkInfo holds VDDK version and connection information returned by an importer pod
@@ -89,9 +104,20 @@ func ParseEnvVar(envVarName string, decode bool) (string, error) {
 
 // Read reads bytes from the stream and updates the prometheus clone_progress metric according to the progress.
 func (r *CountingReader) Read(p []byte) (n int, err error) {
+	if r.Context != nil {
+		if ctxErr := r.Context.Err(); ctxErr != nil {
+			return 0, ctxErr
+		}
+	}
 	n, err = r.Reader.Read(p)
 	r.Current += uint64(n)
+	r.updateThroughput(n)
 	r.Done = err == io.EOF
+	if n > 0 && r.Limiter != nil {
+		if limitErr := r.Limiter.WaitN(r.limiterContext(), n); limitErr != nil && err == nil {
+			err = limitErr
+		}
+	}
 	return n, err
 }
 
@@ -100,6 +126,35 @@ func (r *CountingReader) Close() error {
 	return r.Reader.Close()
 }
 
+func (r *CountingReader) limiterContext() context.Context {
+	if r.Context != nil {
+		return r.Context
+	}
+	return context.Background()
+}
+
+// throughputWindow is the sampling window of the rolling throughput calculation
+const throughputWindow = time.Second
+
+func (r *CountingReader) updateThroughput(n int) {
+	now := time.Now()
+	if r.windowStart.IsZero() {
+		r.windowStart = now
+	}
+	r.windowBytes += uint64(n)
+	if elapsed := now.Sub(r.windowStart); elapsed >= throughputWindow {
+		r.throughput = float64(r.windowBytes) / elapsed.Seconds()
+		r.windowStart = now
+		r.windowBytes = 0
+	}
+}
+
+// Throughput returns the rolling average transfer rate in bytes per second,
+// 0 until the first sampling window has elapsed.
+func (r *CountingReader) Throughput() float64 {
+	return r.throughput
+}
+
 // GetAvailableSpaceByVolumeMode calls another method based on the volumeMode parameter to get the amount of
 // available space at the path specified.
 func GetAvailableSpaceByVolumeMode(volumeMode v1.PersistentVolumeMode) (int64, error) {
@@ -129,21 +184,18 @@ func GetAvailableSpaceBlock(deviceName string) (int64, error) {
 	if !isDevice(info.Mode()) {
 		return int64(-1), nil
 	}
-	// Device exists, attempt to get size.
-	cmd := exec.Command(blockdevFileName, "--getsize64", deviceName)
-	var out bytes.Buffer
-	var errBuf bytes.Buffer
-	cmd.Stdout = &out
-	cmd.Stderr = &errBuf
-	err = cmd.Run()
-	if err != nil {
-		return int64(-1), errors.Errorf("%v, %s", err, errBuf.String())
-	}
-	i, err := strconv.ParseInt(strings.TrimSpace(out.String()), 10, 64)
+	// Device exists, query its size with the BLKGETSIZE64 ioctl.
+	file, err := os.Open(deviceName)
 	if err != nil {
 		return int64(-1), err
 	}
-	return i, nil
+	defer file.Close()
+	var size uint64
+	_, _, errno := unix.Syscall(unix.SYS_IOCTL, file.Fd(), unix.BLKGETSIZE64, uintptr(unsafe.Pointer(&size)))
+	if errno != 0 {
+		return int64(-1), errors.Wrapf(errno, "error getting size of device %s", deviceName)
+	}
+	return int64(size), nil
 }
 
 // isDevice returns true if it's a device file
@@ -162,6 +214,57 @@ func MinQuantity(availableSpace, imageSize *resource.Quantity) resource.Quantity
 	return *imageSize
 }
 
+// IOErrorKind classifies fatal read and write failures
+type IOErrorKind string
+
+const (
+	// IOErrorNoSpace indicates the destination or the scratch space ran out of room
+	IOErrorNoSpace IOErrorKind = "NoSpace"
+	// IOErrorDevice indicates the underlying device reported an I/O error
+	IOErrorDevice IOErrorKind = "DeviceError"
+	// IOErrorTimeout indicates the source stopped delivering data in time
+	IOErrorTimeout IOErrorKind = "Timeout"
+	// IOErrorChecksum indicates the transferred data did not match the expected digest
+	IOErrorChecksum IOErrorKind = "ChecksumMismatch"
+)
+
+// IOError wraps a fatal transfer failure with a machine readable kind, so callers
+// can decide retryability without matching on message substrings.
+type IOError struct {
+	Kind IOErrorKind
+	Err  error
+}
+
+func (e *IOError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Kind, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *IOError) Unwrap() error {
+	return e.Err
+}
+
+// Retryable returns true if the failure may resolve on a fresh attempt
+func (e *IOError) Retryable() bool {
+	return e.Kind == IOErrorTimeout
+}
+
+// classifyIOError wraps err in an IOError when it matches a known failure mode,
+// and returns it unchanged otherwise
+func classifyIOError(err error) error {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, syscall.ENOSPC):
+		return &IOError{Kind: IOErrorNoSpace, Err: err}
+	case errors.Is(err, syscall.EIO):
+		return &IOError{Kind: IOErrorDevice, Err: err}
+	case errors.Is(err, os.ErrDeadlineExceeded),
+		errors.As(err, &netErr) && netErr.Timeout():
+		return &IOError{Kind: IOErrorTimeout, Err: err}
+	}
+	return err
+}
+
 // OpenFileOrBlockDevice opens the destination data file, whether it is a block device or regular file
 func OpenFileOrBlockDevice(fileName string) (*os.File, error) {
 	var outFile *os.File
@@ -177,7 +280,7 @@ func OpenFileOrBlockDevice(fileName string) (*os.File, error) {
 		outFile, err = os.OpenFile(fileName, os.O_CREATE|os.O_EXCL|os.O_WRONLY, os.ModePerm)
 	}
 	if err != nil {
-		return nil, errors.Wrapf(err, "could not open file %q", fileName)
+		return nil, classifyIOError(errors.Wrapf(err, "could not open file %q", fileName))
 	}
 	return outFile, nil
 }
@@ -190,13 +293,15 @@ func StreamDataToFile(r io.Reader, fileName string) error {
 	}
 	defer outFile.Close()
 	klog.V(1).Infof("Writing data...\n")
-	if _, err = io.Copy(outFile, r); err != nil {
+	if _, err = PipelinedCopy(outFile, r, GetWriteQueueDepth()); err != nil {
 		klog.Errorf("Unable to write file from dataReader: %v\n", err)
 		os.Remove(outFile.Name())
-		return errors.Wrapf(err, "unable to write to file")
+		return classifyIOError(errors.Wrapf(err, "unable to write to file"))
 	}
-	err = outFile.Sync()
-	return err
+	if err := outFile.Sync(); err != nil {
+		return classifyIOError(err)
+	}
+	return nil
 }
 
 // UnArchiveTar unarchives a tar file and streams its files
@@ -249,9 +354,10 @@ func CopyFile(src, dst string) error {
 	return out.Close()
 }
 
-// WriteTerminationMessage writes the passed in message to the default termination message file
+// WriteTerminationMessage writes the passed in message to the default termination
+// message file, JSON encoded so the controller does not have to guess at free text
 func WriteTerminationMessage(message string) error {
-	return WriteTerminationMessageToFile(common.PodTerminationMessageFile, message)
+	return WriteTerminationError(&TerminationError{Code: ErrCodeCompleted, Message: message})
 }
 
 // WriteTerminationMessageToFile writes the passed in message to the passed in message file
@@ -268,6 +374,101 @@ func WriteTerminationMessageToFile(file, message string) error {
 	return nil
 }
 
+// Termination message codes worker pods write for the controller
+const (
+	// ErrCodeCompleted indicates the worker finished successfully
+	ErrCodeCompleted = "Completed"
+	// ErrCodeAuthFailed indicates the source rejected the provided credentials
+	ErrCodeAuthFailed = "AuthFailed"
+	// ErrCodeNoSpace indicates the target or the scratch space ran out of room
+	ErrCodeNoSpace = "NoSpace"
+	// ErrCodeSourceUnavailable indicates the source could not be reached
+	ErrCodeSourceUnavailable = "SourceUnavailable"
+	// ErrCodeSourceNotFound indicates the source endpoint does not exist
+	ErrCodeSourceNotFound = "SourceNotFound"
+	// ErrCodeError is the catch all code for unclassified failures
+	ErrCodeError = "Error"
+)
+
+// TerminationError is the machine readable form of a fatal worker error. It is written
+// as JSON to the pod termination message, so the controller can map failures to typed
+// DataVolume condition reasons instead of free form strings.
+type TerminationError struct {
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+	Retryable  bool   `json:"retryable"`
+	HTTPStatus int    `json:"httpStatus,omitempty"`
+	BytesDone  int64  `json:"bytesDone,omitempty"`
+}
+
+var httpErrorStatusRegExp = regexp.MustCompile(`\b(4\d\d|5\d\d)\b`)
+
+// ClassifyError derives a TerminationError from a fatal worker error, based on well
+// known substrings of the error chain
+func ClassifyError(err error) *TerminationError {
+	msg := err.Error()
+	termErr := &TerminationError{Code: ErrCodeError, Message: msg, Retryable: true}
+	if match := httpErrorStatusRegExp.FindStringSubmatch(msg); match != nil {
+		termErr.HTTPStatus, _ = strconv.Atoi(match[1])
+	}
+
+	// Typed I/O errors carry their own classification, no substring matching needed.
+	var ioErr *IOError
+	if errors.As(err, &ioErr) {
+		termErr.Retryable = ioErr.Retryable()
+		switch ioErr.Kind {
+		case IOErrorNoSpace:
+			termErr.Code = ErrCodeNoSpace
+		case IOErrorTimeout:
+			termErr.Code = ErrCodeSourceUnavailable
+		}
+		return termErr
+	}
+
+	lower := strings.ToLower(msg)
+	switch {
+	case strings.Contains(lower, "no space left on device") ||
+		strings.Contains(lower, "is larger than the reported available") ||
+		strings.Contains(lower, "file largest block is bigger than maxblock"):
+		termErr.Code = ErrCodeNoSpace
+		termErr.Retryable = false
+	case termErr.HTTPStatus == 401 || termErr.HTTPStatus == 403 ||
+		strings.Contains(lower, "unauthorized") || strings.Contains(lower, "authentication") ||
+		strings.Contains(lower, "invalid credentials"):
+		termErr.Code = ErrCodeAuthFailed
+		termErr.Retryable = false
+	case termErr.HTTPStatus == 404 || strings.Contains(lower, "not found"):
+		termErr.Code = ErrCodeSourceNotFound
+		termErr.Retryable = false
+	case strings.Contains(lower, "connection refused") || strings.Contains(lower, "no such host") ||
+		strings.Contains(lower, "i/o timeout") || termErr.HTTPStatus >= 500:
+		termErr.Code = ErrCodeSourceUnavailable
+	}
+	return termErr
+}
+
+// WriteTerminationError writes the JSON encoded termination error to the default termination message file
+func WriteTerminationError(termErr *TerminationError) error {
+	message, err := json.Marshal(termErr)
+	if err != nil {
+		return err
+	}
+	return WriteTerminationMessageToFile(common.PodTerminationMessageFile, string(message))
+}
+
+// TerminationErrorFromMessage parses a pod termination message produced by
+// WriteTerminationMessage or WriteTerminationError, returning nil for plain text messages
+func TerminationErrorFromMessage(message string) *TerminationError {
+	if !strings.HasPrefix(message, "{") {
+		return nil
+	}
+	termErr := &TerminationError{}
+	if err := json.Unmarshal([]byte(message), termErr); err != nil || termErr.Code == "" {
+		return nil
+	}
+	return termErr
+}
+
 // CopyDir copies a dir from one location to another.
 func CopyDir(source string, dest string) (err error) {
 	// get properties of source dir
@@ -369,24 +570,6 @@ func SetRecommendedLabels(obj metav1.Object, installerLabels map[string]string,
 	obj.SetLabels(mergedLabels)
 }
 
-// Md5sum calculates the md5sum of a given file
-func Md5sum(filePath string) (string, error) {
-	file, err := os.Open(filePath)
-	if err != nil {
-		return "", err
-	}
-	defer file.Close()
-
-	hash := md5.New()
-
-	if _, err := io.Copy(hash, file); err != nil {
-		return "", err
-	}
-
-	hashInBytes := hash.Sum(nil)[:16]
-	return hex.EncodeToString(hashInBytes), nil
-}
-
 // Three functions for zeroing a range in the destination file:
 
 // PunchHole attempts to zero a range in a file with fallocate, for block devices and pre-allocated files.
@@ -449,6 +632,75 @@ func AppendZeroWithWrite(outFile *os.File, start, length int64) error {
 	return nil
 }
 
+// Preallocation allocates destination space in the background, so full-preallocation
+// transfers do not pay the allocation cost inline. Start it as soon as the destination
+// file is open and Wait for it before streaming data into the file.
+type Preallocation struct {
+	total    int64
+	written  int64
+	done     chan error
+	waitOnce sync.Once
+	err      error
+}
+
+// StartPreallocation begins preallocating length bytes of outFile in the background,
+// using fallocate when the filesystem supports it and writing zeroes otherwise.
+func StartPreallocation(outFile *os.File, length int64) *Preallocation {
+	p := &Preallocation{total: length, done: make(chan error, 1)}
+	go func() {
+		p.done <- p.preallocate(outFile, length)
+	}()
+	return p
+}
+
+func (p *Preallocation) preallocate(outFile *os.File, length int64) error {
+	if length <= 0 {
+		return nil
+	}
+	// fallocate reserves the whole range with a single cheap call
+	err := syscall.Fallocate(int(outFile.Fd()), 0, 0, length)
+	if err == nil {
+		atomic.StoreInt64(&p.written, length)
+		return nil
+	}
+	if !errors.Is(err, syscall.EOPNOTSUPP) && !errors.Is(err, syscall.ENOSYS) {
+		return classifyIOError(errors.Wrap(err, "unable to fallocate destination"))
+	}
+	klog.V(1).Infof("fallocate not supported, writing zeroes instead")
+	// Write zeroes in chunks so progress stays meaningful.
+	buffer := bytes.Repeat([]byte{0}, 32<<20)
+	for count := int64(0); count < length; {
+		blockSize := int64(len(buffer))
+		if remaining := length - count; remaining < blockSize {
+			blockSize = remaining
+		}
+		written, err := outFile.WriteAt(buffer[:blockSize], count)
+		if err != nil {
+			return classifyIOError(errors.Wrapf(err, "unable to preallocate %d bytes at offset %d", length, count))
+		}
+		count += int64(written)
+		atomic.StoreInt64(&p.written, count)
+	}
+	return nil
+}
+
+// Progress returns the preallocated fraction, between 0 and 1.
+func (p *Preallocation) Progress() float64 {
+	if p.total <= 0 {
+		return 1
+	}
+	return float64(atomic.LoadInt64(&p.written)) / float64(p.total)
+}
+
+// Wait blocks until the preallocation finishes and returns its result.
+// It is safe to call more than once.
+func (p *Preallocation) Wait() error {
+	p.waitOnce.Do(func() {
+		p.err = <-p.done
+	})
+	return p.err
+}
+
 // GetUsableSpace calculates space to use taking file system overhead into account
 func GetUsableSpace(filesystemOverhead float64, availableSpace int64) int64 {
 	// +1 always rounds up.
@@ -2,16 +2,24 @@ package util
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"regexp"
+	"syscall"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 
+	"golang.org/x/time/rate"
+
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
@@ -66,6 +74,50 @@ var _ = Describe("Util", func() {
 	)
 })
 
+var _ = Describe("CountingReader", func() {
+	createReader := func(data string) *CountingReader {
+		return &CountingReader{
+			Reader: ioutil.NopCloser(bytes.NewReader([]byte(data))),
+		}
+	}
+
+	It("Should count the bytes read through it", func() {
+		reader := createReader("0123456789")
+		read, err := ioutil.ReadAll(reader)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(read).To(HaveLen(10))
+		Expect(reader.Current).To(Equal(uint64(10)))
+		Expect(reader.Done).To(BeTrue())
+	})
+
+	It("Should fail reads once the context is cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		reader := createReader("0123456789")
+		reader.Context = ctx
+		cancel()
+		_, err := ioutil.ReadAll(reader)
+		Expect(err).To(MatchError(context.Canceled))
+		Expect(reader.Current).To(Equal(uint64(0)))
+	})
+
+	It("Should read through a rate limiter", func() {
+		reader := createReader("0123456789")
+		reader.Limiter = rate.NewLimiter(rate.Inf, 0)
+		read, err := ioutil.ReadAll(reader)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(read).To(HaveLen(10))
+	})
+
+	It("Should report the rolling throughput after a sampling window", func() {
+		reader := createReader("0123456789")
+		Expect(reader.Throughput()).To(BeZero())
+		reader.windowStart = time.Now().Add(-2 * throughputWindow)
+		_, err := ioutil.ReadAll(reader)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reader.Throughput()).To(BeNumerically(">", 0))
+	})
+})
+
 var _ = Describe("GetNameSpace", func() {
 	It("Report default namespace outside container", func() {
 		Expect("cdi").To(Equal(GetNamespace()))
@@ -105,6 +157,75 @@ var _ = Describe("ParseEnv", func() {
 	})
 })
 
+var _ = Describe("Termination errors", func() {
+	table.DescribeTable("Classify errors by well known substrings", func(message, expectedCode string, retryable bool) {
+		termErr := ClassifyError(errors.New(message))
+		Expect(termErr.Code).To(Equal(expectedCode))
+		Expect(termErr.Retryable).To(Equal(retryable))
+	},
+		table.Entry("out of space", "write /data/disk.img: no space left on device", ErrCodeNoSpace, false),
+		table.Entry("authentication", "expected status code 200, got 401. Status: 401 Unauthorized", ErrCodeAuthFailed, false),
+		table.Entry("missing source", "expected status code 200, got 404. Status: 404 Not Found", ErrCodeSourceNotFound, false),
+		table.Entry("unreachable source", "dial tcp 10.0.0.1:443: connect: connection refused", ErrCodeSourceUnavailable, true),
+		table.Entry("anything else", "qemu-img: error while reading sector", ErrCodeError, true),
+	)
+
+	It("Round trip through the termination message", func() {
+		termErr := ClassifyError(errors.New("expected status code 200, got 401"))
+		message, err := json.Marshal(termErr)
+		Expect(err).ToNot(HaveOccurred())
+		parsed := TerminationErrorFromMessage(string(message))
+		Expect(parsed).ToNot(BeNil())
+		Expect(parsed.Code).To(Equal(ErrCodeAuthFailed))
+		Expect(parsed.HTTPStatus).To(Equal(401))
+	})
+
+	It("Round trip an informational message", func() {
+		termErr := &TerminationError{Code: ErrCodeCompleted, Message: "Import Complete", BytesDone: 1024}
+		message, err := json.Marshal(termErr)
+		Expect(err).ToNot(HaveOccurred())
+		parsed := TerminationErrorFromMessage(string(message))
+		Expect(parsed).ToNot(BeNil())
+		Expect(parsed.Code).To(Equal(ErrCodeCompleted))
+		Expect(parsed.Message).To(Equal("Import Complete"))
+		Expect(parsed.BytesDone).To(Equal(int64(1024)))
+	})
+
+	It("Return nil for plain text messages", func() {
+		Expect(TerminationErrorFromMessage("Unable to process data: read timeout")).To(BeNil())
+	})
+})
+
+var _ = Describe("Typed I/O errors", func() {
+	table.DescribeTable("Classify I/O errors by the wrapped errno", func(cause error, expectedKind IOErrorKind, retryable bool) {
+		err := classifyIOError(fmt.Errorf("unable to write to file: %w", cause))
+		ioErr := &IOError{}
+		Expect(errors.As(err, &ioErr)).To(BeTrue())
+		Expect(ioErr.Kind).To(Equal(expectedKind))
+		Expect(ioErr.Retryable()).To(Equal(retryable))
+	},
+		table.Entry("out of space", syscall.ENOSPC, IOErrorNoSpace, false),
+		table.Entry("device error", syscall.EIO, IOErrorDevice, false),
+		table.Entry("read timeout", os.ErrDeadlineExceeded, IOErrorTimeout, true),
+	)
+
+	It("Should leave unrecognized errors unchanged", func() {
+		cause := errors.New("something else")
+		Expect(classifyIOError(cause)).To(BeIdenticalTo(cause))
+	})
+
+	table.DescribeTable("Map typed I/O errors to termination error codes", func(kind IOErrorKind, expectedCode string, retryable bool) {
+		termErr := ClassifyError(&IOError{Kind: kind, Err: errors.New("boom")})
+		Expect(termErr.Code).To(Equal(expectedCode))
+		Expect(termErr.Retryable).To(Equal(retryable))
+	},
+		table.Entry("out of space", IOErrorNoSpace, ErrCodeNoSpace, false),
+		table.Entry("device error", IOErrorDevice, ErrCodeError, false),
+		table.Entry("read timeout", IOErrorTimeout, ErrCodeSourceUnavailable, true),
+		table.Entry("checksum mismatch", IOErrorChecksum, ErrCodeError, false),
+	)
+})
+
 var _ = Describe("Compare quantities", func() {
 	It("Should properly compare quantities", func() {
 		small := resource.NewScaledQuantity(int64(1000), 0)
@@ -134,11 +255,12 @@ var _ = Describe("Copy files", func() {
 	It("Should copy file from source to dest, with valid source and dest", func() {
 		err = CopyFile(filepath.Join(TestImagesDir, "content.tar"), filepath.Join(destTmp, "target.tar"))
 		Expect(err).ToNot(HaveOccurred())
-		sourceMd5, err := Md5sum(filepath.Join(TestImagesDir, "content.tar"))
+		hasher := NewFileHasher()
+		sourceSum, err := hasher.Hash(filepath.Join(TestImagesDir, "content.tar"))
 		Expect(err).ToNot(HaveOccurred())
-		targetMd5, err := Md5sum(filepath.Join(destTmp, "target.tar"))
+		targetSum, err := hasher.Hash(filepath.Join(destTmp, "target.tar"))
 		Expect(err).ToNot(HaveOccurred())
-		Expect(sourceMd5).Should(Equal(targetMd5))
+		Expect(sourceSum).Should(Equal(targetSum))
 	})
 
 	It("Should not copy file from source to dest, with invalid source", func() {
@@ -225,6 +347,39 @@ var _ = Describe("Zero out ranges in files", func() {
 		table.Entry("using write", AppendZeroWithWrite),
 	)
 })
+
+var _ = Describe("Background preallocation", func() {
+	var testFile *os.File
+
+	BeforeEach(func() {
+		var err error
+		testFile, err = ioutil.TempFile("", "prealloc")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		testFile.Close()
+		os.Remove(testFile.Name())
+	})
+
+	It("Should preallocate the requested length", func() {
+		length := int64(1024 * 1024)
+		prealloc := StartPreallocation(testFile, length)
+		Expect(prealloc.Wait()).To(Succeed())
+		Expect(prealloc.Progress()).To(Equal(float64(1)))
+		info, err := testFile.Stat()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.Size()).To(Equal(length))
+	})
+
+	It("Should report completion for a zero length", func() {
+		prealloc := StartPreallocation(testFile, 0)
+		Expect(prealloc.Wait()).To(Succeed())
+		Expect(prealloc.Wait()).To(Succeed())
+		Expect(prealloc.Progress()).To(Equal(float64(1)))
+	})
+})
+
 var _ = Describe("Usable Space calculation", func() {
 
 	const (
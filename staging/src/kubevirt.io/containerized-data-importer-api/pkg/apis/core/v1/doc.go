@@ -0,0 +1,6 @@
+// +k8s:deepcopy-gen=package
+// +k8s:openapi-gen=true
+
+// Package v1 is the v1 version of the API.
+// +groupName=cdi.kubevirt.io
+package v1
@@ -0,0 +1,138 @@
+/*
+Copyright 2018 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cdiv1beta1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+)
+
+// DataVolume is an abstraction on top of PersistentVolumeClaims to allow easy population of those PersistentVolumeClaims with relation to VirtualMachines
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=dv;dvs,categories=all
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="The phase the data volume is in"
+// +kubebuilder:printcolumn:name="Progress",type="string",JSONPath=".status.progress",description="Transfer progress in percentage if known, N/A otherwise"
+// +kubebuilder:printcolumn:name="Restarts",type="integer",JSONPath=".status.restartCount",description="The number of times the transfer has been restarted."
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type DataVolume struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DataVolumeSpec   `json:"spec"`
+	Status DataVolumeStatus `json:"status,omitempty"`
+}
+
+// DataVolumeSpec defines the DataVolume type specification.
+// Unlike v1beta1 the target is always described by the storage section,
+// the pvc section has been removed.
+// The source sub-types are unchanged from v1beta1 and shared during the migration.
+type DataVolumeSpec struct {
+	//Source is the src of the data for the requested DataVolume
+	// +optional
+	Source *cdiv1beta1.DataVolumeSource `json:"source,omitempty"`
+	//SourceRef is an indirect reference to the source of data for the requested DataVolume
+	// +optional
+	SourceRef *cdiv1beta1.DataVolumeSourceRef `json:"sourceRef,omitempty"`
+	// Storage is the requested storage specification
+	Storage *cdiv1beta1.StorageSpec `json:"storage"`
+	//PriorityClassName for Importer, Cloner and Uploader pod
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	//DataVolumeContentType options: "kubevirt", "archive"
+	// +kubebuilder:validation:Enum="kubevirt";"archive"
+	ContentType cdiv1beta1.DataVolumeContentType `json:"contentType,omitempty"`
+	// Checkpoints is a list of DataVolumeCheckpoints, representing stages in a multistage import.
+	Checkpoints []cdiv1beta1.DataVolumeCheckpoint `json:"checkpoints,omitempty"`
+	// FinalCheckpoint indicates whether the current DataVolumeCheckpoint is the final checkpoint.
+	FinalCheckpoint bool `json:"finalCheckpoint,omitempty"`
+	// Preallocation controls whether storage for DataVolumes should be allocated in advance.
+	Preallocation *bool `json:"preallocation,omitempty"`
+	// RetryPolicy controls how failed transfers are retried
+	// +optional
+	RetryPolicy *DataVolumeRetryPolicy `json:"retryPolicy,omitempty"`
+}
+
+// DataVolumeRetryPolicy is a typed description of how failed transfers are retried,
+// replacing the annotation based conventions used with v1beta1
+type DataVolumeRetryPolicy struct {
+	// MaxRetries is the number of times a failing transfer is restarted before the DataVolume is marked Failed
+	// +optional
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+	// Backoff selects how the delay between retries grows
+	// +kubebuilder:validation:Enum="Linear";"Exponential"
+	// +optional
+	Backoff *DataVolumeRetryBackoff `json:"backoff,omitempty"`
+}
+
+// DataVolumeRetryBackoff is the backoff strategy applied between transfer retries
+type DataVolumeRetryBackoff string
+
+const (
+	// RetryBackoffLinear retries with a constant delay
+	RetryBackoffLinear DataVolumeRetryBackoff = "Linear"
+	// RetryBackoffExponential retries with an exponentially growing delay
+	RetryBackoffExponential DataVolumeRetryBackoff = "Exponential"
+)
+
+// DataVolumeStatus contains the current status of the DataVolume
+type DataVolumeStatus struct {
+	//Phase is the current phase of the data volume
+	Phase cdiv1beta1.DataVolumePhase `json:"phase,omitempty"`
+	//Progress is the progress of the data volume transfer
+	Progress cdiv1beta1.DataVolumeProgress `json:"progress,omitempty"`
+	// RestartCount is the number of times the pod populating the DataVolume has restarted
+	RestartCount int32 `json:"restartCount,omitempty"`
+	//Conditions contains the current conditions of the data volume
+	Conditions []cdiv1beta1.DataVolumeCondition `json:"conditions,omitempty"`
+}
+
+//DataVolumeList provides the needed parameters to do request a list of Data Volumes from the system
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type DataVolumeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// Items provides a list of DataVolumes
+	Items []DataVolume `json:"items"`
+}
+
+//CDIConfig provides a user configuration for CDI
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+type CDIConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is unchanged from v1beta1 and shared during the migration
+	Spec cdiv1beta1.CDIConfigSpec `json:"spec"`
+	// Status is unchanged from v1beta1 and shared during the migration
+	Status cdiv1beta1.CDIConfigStatus `json:"status,omitempty"`
+}
+
+//CDIConfigList provides the needed parameters to do request a list of CDIConfigs from the system
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type CDIConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// Items provides a list of CDIConfigs
+	Items []CDIConfig `json:"items"`
+}
@@ -46,8 +46,20 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&DataSourceList{},
 		&DataImportCron{},
 		&DataImportCronList{},
+		&DataExport{},
+		&DataExportList{},
+		&VolumeUploadSource{},
+		&VolumeUploadSourceList{},
+		&VolumeCloneSource{},
+		&VolumeCloneSourceList{},
+		&CDIQuota{},
+		&CDIQuotaList{},
+		&CloneGrant{},
+		&CloneGrantList{},
 		&ObjectTransfer{},
 		&ObjectTransferList{},
+		&VolumeExport{},
+		&VolumeExportList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
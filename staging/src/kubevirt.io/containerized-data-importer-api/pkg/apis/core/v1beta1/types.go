@@ -17,7 +17,9 @@ limitations under the License.
 package v1beta1
 
 import (
+	ocpconfigv1 "github.com/openshift/api/config/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	sdkapi "kubevirt.io/controller-lifecycle-operator-sdk/api"
 )
@@ -63,8 +65,25 @@ type DataVolumeSpec struct {
 	FinalCheckpoint bool `json:"finalCheckpoint,omitempty"`
 	// Preallocation controls whether storage for DataVolumes should be allocated in advance.
 	Preallocation *bool `json:"preallocation,omitempty"`
+	// OverwritePolicy controls how a pre-existing target PVC that is not controlled by
+	// this DataVolume is handled, options: "fail" (default), "wipe", "resume"
+	// +kubebuilder:validation:Enum="fail";"wipe";"resume"
+	// +optional
+	OverwritePolicy DataVolumeOverwritePolicy `json:"overwritePolicy,omitempty"`
 }
 
+// DataVolumeOverwritePolicy defines how a DataVolume treats a pre-existing target PVC
+type DataVolumeOverwritePolicy string
+
+const (
+	// OverwritePolicyFail refuses to touch a pre-existing PVC, this is the default
+	OverwritePolicyFail DataVolumeOverwritePolicy = "fail"
+	// OverwritePolicyWipe adopts a pre-existing PVC and populates it again from the source
+	OverwritePolicyWipe DataVolumeOverwritePolicy = "wipe"
+	// OverwritePolicyResume adopts a pre-existing PVC together with its current content
+	OverwritePolicyResume DataVolumeOverwritePolicy = "resume"
+)
+
 // StorageSpec defines the Storage type specification
 type StorageSpec struct {
 	// AccessModes contains the desired access modes the volume should have.
@@ -116,12 +135,18 @@ const (
 type DataVolumeSource struct {
 	HTTP     *DataVolumeSourceHTTP     `json:"http,omitempty"`
 	S3       *DataVolumeSourceS3       `json:"s3,omitempty"`
+	GCS      *DataVolumeSourceGCS      `json:"gcs,omitempty"`
+	OVA      *DataVolumeSourceOVA      `json:"ova,omitempty"`
 	Registry *DataVolumeSourceRegistry `json:"registry,omitempty"`
 	PVC      *DataVolumeSourcePVC      `json:"pvc,omitempty"`
+	Snapshot *DataVolumeSourceSnapshot `json:"snapshot,omitempty"`
 	Upload   *DataVolumeSourceUpload   `json:"upload,omitempty"`
 	Blank    *DataVolumeBlankImage     `json:"blank,omitempty"`
 	Imageio  *DataVolumeSourceImageIO  `json:"imageio,omitempty"`
 	VDDK     *DataVolumeSourceVDDK     `json:"vddk,omitempty"`
+	// VolumeExport pulls the image served by a VolumeExport, usually one in another cluster
+	// +optional
+	VolumeExport *DataVolumeSourceVolumeExport `json:"volumeExport,omitempty"`
 }
 
 // DataVolumeSourcePVC provides the parameters to create a Data Volume from an existing PVC
@@ -132,8 +157,28 @@ type DataVolumeSourcePVC struct {
 	Name string `json:"name"`
 }
 
+// DataVolumeSourceSnapshot provides the parameters to create a Data Volume from an existing VolumeSnapshot
+type DataVolumeSourceSnapshot struct {
+	// The namespace of the source VolumeSnapshot
+	Namespace string `json:"namespace"`
+	// The name of the source VolumeSnapshot
+	Name string `json:"name"`
+}
+
 // DataVolumeBlankImage provides the parameters to create a new raw blank image for the PVC
-type DataVolumeBlankImage struct{}
+type DataVolumeBlankImage struct {
+	// Filesystem to create inside the blank image, either "ext4" or "xfs".
+	// When empty the volume stays a raw zeroed image
+	// +optional
+	Filesystem string `json:"filesystem,omitempty"`
+	// Label of the created filesystem
+	// +optional
+	Label string `json:"label,omitempty"`
+	// ReservedBlocksPercentage is the percentage of blocks reserved for the
+	// super-user, only valid with the ext4 filesystem
+	// +optional
+	ReservedBlocksPercentage *int32 `json:"reservedBlocksPercentage,omitempty"`
+}
 
 // DataVolumeSourceUpload provides the parameters to create a Data Volume by uploading the source
 type DataVolumeSourceUpload struct {
@@ -148,6 +193,32 @@ type DataVolumeSourceS3 struct {
 	// CertConfigMap is a configmap reference, containing a Certificate Authority(CA) public key, and a base64 encoded pem certificate
 	// +optional
 	CertConfigMap string `json:"certConfigMap,omitempty"`
+	// CertSecret is a secret reference, containing a Certificate Authority(CA) bundle in PEM format, mutually exclusive with CertConfigMap
+	// +optional
+	CertSecret string `json:"certSecret,omitempty"`
+}
+
+// DataVolumeSourceGCS provides the parameters to create a Data Volume from a Google Cloud Storage source
+type DataVolumeSourceGCS struct {
+	//URL is the url of the GCS source, starting with the gs scheme
+	URL string `json:"url"`
+	//SecretRef provides the secret reference containing the service account JSON needed to access the GCS source
+	SecretRef string `json:"secretRef,omitempty"`
+}
+
+// DataVolumeSourceOVA provides the parameters to create a Data Volume from an OVA archive,
+// the first disk image found in the archive is imported
+type DataVolumeSourceOVA struct {
+	//URL is the http(s) or s3 url of the OVA archive
+	URL string `json:"url"`
+	//SecretRef provides the secret reference needed to access the OVA source
+	SecretRef string `json:"secretRef,omitempty"`
+	// CertConfigMap is a configmap reference, containing a Certificate Authority(CA) public key, and a base64 encoded pem certificate
+	// +optional
+	CertConfigMap string `json:"certConfigMap,omitempty"`
+	// CertSecret is a secret reference, containing a Certificate Authority(CA) bundle in PEM format, mutually exclusive with CertConfigMap
+	// +optional
+	CertSecret string `json:"certSecret,omitempty"`
 }
 
 // DataVolumeSourceRegistry provides the parameters to create a Data Volume from an registry source
@@ -167,6 +238,9 @@ type DataVolumeSourceRegistry struct {
 	//CertConfigMap provides a reference to the Registry certs
 	// +optional
 	CertConfigMap *string `json:"certConfigMap,omitempty"`
+	//CertSecret provides a reference to a secret containing the Registry certs in PEM format, mutually exclusive with CertConfigMap
+	// +optional
+	CertSecret *string `json:"certSecret,omitempty"`
 }
 
 const (
@@ -196,6 +270,9 @@ type DataVolumeSourceHTTP struct {
 	// CertConfigMap is a configmap reference, containing a Certificate Authority(CA) public key, and a base64 encoded pem certificate
 	// +optional
 	CertConfigMap string `json:"certConfigMap,omitempty"`
+	// CertSecret is a secret reference, containing a Certificate Authority(CA) bundle in PEM format, mutually exclusive with CertConfigMap
+	// +optional
+	CertSecret string `json:"certSecret,omitempty"`
 	// ExtraHeaders is a list of strings containing extra headers to include with HTTP transfer requests
 	// +optional
 	ExtraHeaders []string `json:"extraHeaders,omitempty"`
@@ -204,6 +281,23 @@ type DataVolumeSourceHTTP struct {
 	SecretExtraHeaders []string `json:"secretExtraHeaders,omitempty"`
 }
 
+// DataVolumeSourceVolumeExport provides the parameters to create a Data Volume from a VolumeExport
+// served by another cluster, pairing the export URL with the token it was issued with
+type DataVolumeSourceVolumeExport struct {
+	// URL is the download URL from the status of the source VolumeExport
+	URL string `json:"url"`
+	// TokenSecretRef names a Secret holding the export token under the "token" key
+	TokenSecretRef string `json:"tokenSecretRef"`
+	// CertConfigMap is a configmap reference holding the serving certificate of the export
+	// server, published in the status of the source VolumeExport, the download fails when
+	// the server does not present it
+	// +optional
+	CertConfigMap string `json:"certConfigMap,omitempty"`
+	// Checksum is the expected "sha256:" digest of the downloaded stream, the import fails on a mismatch
+	// +optional
+	Checksum string `json:"checksum,omitempty"`
+}
+
 // DataVolumeSourceImageIO provides the parameters to create a Data Volume from an imageio source
 type DataVolumeSourceImageIO struct {
 	//URL is the URL of the ovirt-engine
@@ -214,6 +308,9 @@ type DataVolumeSourceImageIO struct {
 	SecretRef string `json:"secretRef,omitempty"`
 	//CertConfigMap provides a reference to the CA cert
 	CertConfigMap string `json:"certConfigMap,omitempty"`
+	//CertSecret provides a reference to a secret containing the CA cert in PEM format, mutually exclusive with CertConfigMap
+	// +optional
+	CertSecret string `json:"certSecret,omitempty"`
 }
 
 // DataVolumeSourceVDDK provides the parameters to create a Data Volume from a Vmware source
@@ -255,11 +352,37 @@ type DataVolumeStatus struct {
 	//Phase is the current phase of the data volume
 	Phase    DataVolumePhase    `json:"phase,omitempty"`
 	Progress DataVolumeProgress `json:"progress,omitempty"`
+	// ProgressDetail is the structured byte level progress of the transfer, superseding the Progress percentage
+	// +optional
+	ProgressDetail *DataVolumeProgressDetail `json:"progressDetail,omitempty"`
 	// RestartCount is the number of times the pod populating the DataVolume has restarted
 	RestartCount int32                 `json:"restartCount,omitempty"`
 	Conditions   []DataVolumeCondition `json:"conditions,omitempty" optional:"true"`
 }
 
+// DataVolumeProgressDetail is the structured progress of a DataVolume transfer, reported by the worker pod
+type DataVolumeProgressDetail struct {
+	// Phase is the processing phase the worker pod is in, for example TransferDataFile or Convert
+	// +optional
+	Phase string `json:"phase,omitempty"`
+	// BytesTotal is the total number of bytes the transfer moves, when known
+	// +optional
+	BytesTotal int64 `json:"bytesTotal,omitempty"`
+	// BytesDone is the number of bytes moved so far
+	// +optional
+	BytesDone int64 `json:"bytesDone,omitempty"`
+	// StartedAt is the time the worker pod started moving data
+	// +optional
+	StartedAt metav1.Time `json:"startedAt,omitempty"`
+	// CurrentCheckpoint is the checkpoint the running stage of a multi-stage import copies,
+	// the byte counters above cover only its delta
+	// +optional
+	CurrentCheckpoint string `json:"currentCheckpoint,omitempty"`
+	// CheckpointIndex is the 1-based position of the current checkpoint in spec.checkpoints
+	// +optional
+	CheckpointIndex int32 `json:"checkpointIndex,omitempty"`
+}
+
 //DataVolumeList provides the needed parameters to do request a list of Data Volumes from the system
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type DataVolumeList struct {
@@ -349,6 +472,12 @@ const (
 	DataVolumeBound DataVolumeConditionType = "Bound"
 	// DataVolumeRunning is the condition that indicates if the import/upload/clone container is running.
 	DataVolumeRunning DataVolumeConditionType = "Running"
+	// DataVolumeDegraded is the condition that indicates the storage cannot fully satisfy the requested capabilities.
+	DataVolumeDegraded DataVolumeConditionType = "Degraded"
+	// DataVolumeSourceRefNotReady is the condition that indicates the referenced DataSource is not ready yet.
+	DataVolumeSourceRefNotReady DataVolumeConditionType = "SourceRefNotReady"
+	// DataVolumeSourceUnreachable is the condition that indicates the import source failed the connectivity pre-check.
+	DataVolumeSourceUnreachable DataVolumeConditionType = "SourceUnreachable"
 )
 
 // DataVolumeCloneSourceSubresource is the subresource checked for permission to clone
@@ -378,6 +507,15 @@ type StorageProfileSpec struct {
 	CloneStrategy *CDICloneStrategy `json:"cloneStrategy,omitempty"`
 	// ClaimPropertySets is a provided set of properties applicable to PVC
 	ClaimPropertySets []ClaimPropertySet `json:"claimPropertySets,omitempty"`
+	// DataImportCacheMode is the qemu cache mode worker pods use when writing
+	// to volumes of this storage class, overriding the destination based default
+	// +optional
+	DataImportCacheMode *CacheMode `json:"dataImportCacheMode,omitempty"`
+	// SupportsReflink indicates volumes of this storage class are provisioned from
+	// a shared filesystem supporting reflinks, so same-filesystem clones can be
+	// performed instantly in a single pod instead of a network copy
+	// +optional
+	SupportsReflink *bool `json:"supportsReflink,omitempty"`
 }
 
 //StorageProfileStatus provides the most recently observed status of the StorageProfile
@@ -388,10 +526,29 @@ type StorageProfileStatus struct {
 	Provisioner *string `json:"provisioner,omitempty"`
 	// CloneStrategy defines the preferred method for performing a CDI clone
 	CloneStrategy *CDICloneStrategy `json:"cloneStrategy,omitempty"`
+	// SupportsRWXBlock indicates whether the storage supports ReadWriteMany access
+	// with block volume mode, as required by KubeVirt live migration
+	SupportsRWXBlock *bool `json:"supportsRWXBlock,omitempty"`
 	// ClaimPropertySets computed from the spec and detected in the system
 	ClaimPropertySets []ClaimPropertySet `json:"claimPropertySets,omitempty"`
+	// DataImportCacheMode is the qemu cache mode worker pods use when writing
+	// to volumes of this storage class
+	DataImportCacheMode *CacheMode `json:"dataImportCacheMode,omitempty"`
+	// SupportsReflink indicates volumes of this storage class are provisioned from
+	// a shared filesystem supporting reflinks
+	SupportsReflink *bool `json:"supportsReflink,omitempty"`
 }
 
+// CacheMode is the qemu cache mode used when writing to a destination volume
+type CacheMode string
+
+const (
+	// CacheModeNone bypasses the host page cache, the default for block destinations
+	CacheModeNone CacheMode = "none"
+	// CacheModeWriteback writes through the host page cache, the default for filesystem destinations
+	CacheModeWriteback CacheMode = "writeback"
+)
+
 // ClaimPropertySet is a set of properties applicable to PVC
 type ClaimPropertySet struct {
 	// AccessModes contains the desired access modes the volume should have.
@@ -438,6 +595,8 @@ type DataSourceSpec struct {
 type DataSourceSource struct {
 	// +optional
 	PVC *DataVolumeSourcePVC `json:"pvc,omitempty"`
+	// +optional
+	Snapshot *DataVolumeSourceSnapshot `json:"snapshot,omitempty"`
 }
 
 // DataSourceStatus provides the most recently observed status of the DataSource
@@ -621,6 +780,11 @@ type CDICertConfig struct {
 	// Server configuration
 	// Certs are rotated and discarded
 	Server *CertConfig `json:"server,omitempty"`
+
+	// Client configuration
+	// Certs are rotated and discarded
+	// Used for the client certs presented to the upload server
+	Client *CertConfig `json:"client,omitempty"`
 }
 
 // CDISpec defines our specification for the CDI installation
@@ -635,6 +799,8 @@ type CDISpec struct {
 	Infra sdkapi.NodePlacement `json:"infra,omitempty"`
 	// Restrict on which nodes CDI workload pods will be scheduled
 	Workloads sdkapi.NodePlacement `json:"workload,omitempty"`
+	// ComponentPlacement overrides the infra node placement per CDI deployment
+	ComponentPlacement *CDIComponentPlacement `json:"componentPlacement,omitempty"`
 	// Clone strategy override: should we use a host-assisted copy even if snapshots are available?
 	// +kubebuilder:validation:Enum="copy";"snapshot"
 	CloneStrategyOverride *CDICloneStrategy `json:"cloneStrategyOverride,omitempty"`
@@ -644,11 +810,49 @@ type CDISpec struct {
 	CertConfig *CDICertConfig `json:"certConfig,omitempty"`
 	// PriorityClass of the CDI control plane
 	PriorityClass *CDIPriorityClass `json:"priorityClass,omitempty"`
+	// InfraPriorityClasses overrides the control plane priority class per CDI deployment
+	InfraPriorityClasses *CDIInfraPriorityClasses `json:"infraPriorityClasses,omitempty"`
+	// UploadProxyExposure configures automatic external exposure of the upload proxy
+	UploadProxyExposure *CDIUploadProxyExposure `json:"uploadProxyExposure,omitempty"`
+}
+
+// CDIUploadProxyExposure controls the Route or Ingress the operator creates
+// for cdi-uploadproxy. On OpenShift the host is applied to the managed Route,
+// elsewhere an Ingress is created when a host is given.
+type CDIUploadProxyExposure struct {
+	// Host is the DNS name under which the upload proxy is exposed
+	Host *string `json:"host,omitempty"`
+	// IngressClassName selects the ingress controller handling the Ingress
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+	// TLSSecretName references a secret with tls.crt/tls.key terminating TLS on the Ingress
+	TLSSecretName *string `json:"tlsSecretName,omitempty"`
+}
+
+// CDIComponentPlacement contains per component node placement overrides.
+// A component without an override falls back to the infra node placement.
+type CDIComponentPlacement struct {
+	// APIServer is the node placement for the cdi-apiserver deployment
+	APIServer *sdkapi.NodePlacement `json:"apiserver,omitempty"`
+	// Controller is the node placement for the cdi-deployment deployment
+	Controller *sdkapi.NodePlacement `json:"controller,omitempty"`
+	// UploadProxy is the node placement for the cdi-uploadproxy deployment
+	UploadProxy *sdkapi.NodePlacement `json:"uploadProxy,omitempty"`
 }
 
 // CDIPriorityClass defines the priority class of the CDI control plane.
 type CDIPriorityClass string
 
+// CDIInfraPriorityClasses contains per component priority class overrides.
+// A component without an override falls back to the control plane priority class.
+type CDIInfraPriorityClasses struct {
+	// APIServer is the priority class for the cdi-apiserver deployment
+	APIServer *CDIPriorityClass `json:"apiserver,omitempty"`
+	// Controller is the priority class for the cdi-deployment deployment
+	Controller *CDIPriorityClass `json:"controller,omitempty"`
+	// UploadProxy is the priority class for the cdi-uploadproxy deployment
+	UploadProxy *CDIPriorityClass `json:"uploadProxy,omitempty"`
+}
+
 // CDICloneStrategy defines the preferred method for performing a CDI clone (override snapshot?)
 type CDICloneStrategy string
 
@@ -732,19 +936,199 @@ type CDIConfigSpec struct {
 	ImportProxy *ImportProxy `json:"importProxy,omitempty"`
 	// Override the storage class to used for scratch space during transfer operations. The scratch space storage class is determined in the following order: 1. value of scratchSpaceStorageClass, if that doesn't exist, use the default storage class, if there is no default storage class, use the storage class of the DataVolume, if no storage class specified, use no storage class for scratch space
 	ScratchSpaceStorageClass *string `json:"scratchSpaceStorageClass,omitempty"`
+	// ScratchSpaceSizeFactor is a decimal factor (e.g. "1.2") applied to the target size when requesting scratch space. If not defined the scratch space matches the target size
+	// +optional
+	ScratchSpaceSizeFactor *string `json:"scratchSpaceSizeFactor,omitempty"`
+	// ScratchSpaceMaxSize caps the requested scratch space size regardless of the size factor
+	// +optional
+	ScratchSpaceMaxSize *resource.Quantity `json:"scratchSpaceMaxSize,omitempty"`
 	// ResourceRequirements describes the compute resource requirements.
 	PodResourceRequirements *corev1.ResourceRequirements `json:"podResourceRequirements,omitempty"`
+	// PodResourceRequirementsByWorkload overrides podResourceRequirements per workload type
+	// +optional
+	PodResourceRequirementsByWorkload *WorkloadResourceRequirements `json:"podResourceRequirementsByWorkload,omitempty"`
 	// FeatureGates are a list of specific enabled feature gates
 	FeatureGates []string `json:"featureGates,omitempty"`
+	// NamespacedFeatureGates enable feature gates only for selected namespaces
+	// +optional
+	NamespacedFeatureGates []NamespacedFeatureGate `json:"namespacedFeatureGates,omitempty"`
 	// FilesystemOverhead describes the space reserved for overhead when using Filesystem volumes. A value is between 0 and 1, if not defined it is 0.055 (5.5% overhead)
 	FilesystemOverhead *FilesystemOverhead `json:"filesystemOverhead,omitempty"`
 	// Preallocation controls whether storage for DataVolumes should be allocated in advance.
 	Preallocation *bool `json:"preallocation,omitempty"`
 	// InsecureRegistries is a list of TLS disabled registries
 	InsecureRegistries []string `json:"insecureRegistries,omitempty"`
+	// RegistryTLSConfigs configures TLS per registry host, replacing the insecure registry ConfigMap conventions
+	// +optional
+	RegistryTLSConfigs []RegistryTLSConfig `json:"registryTLSConfigs,omitempty"`
+	// RestrictedEgress makes the webhook reject DataVolume sources pointing outside the allowed hosts and registries
+	// +optional
+	RestrictedEgress *RestrictedEgress `json:"restrictedEgress,omitempty"`
 	// dataVolumeTTLSeconds is the time in seconds after DataVolume completion it can be garbage collected.
 	// +optional
 	DataVolumeTTLSeconds *int32 `json:"dataVolumeTTLSeconds,omitempty"`
+	// LogVerbosity overrides the klog verbosity of the CDI controller and the worker pods it creates, without redeploying
+	// +optional
+	LogVerbosity *int32 `json:"logVerbosity,omitempty"`
+	// DataVolumeDefaults are defaults applied to DataVolumes at admission, optionally scoped to namespaces
+	// +optional
+	DataVolumeDefaults []DataVolumeDefaults `json:"dataVolumeDefaults,omitempty"`
+	// WorkerImageOverrides redirects the importer, clone and upload worker images, for example to an air-gapped mirror registry
+	// +optional
+	WorkerImageOverrides *WorkerImageOverrides `json:"workerImageOverrides,omitempty"`
+	// WorkloadMetadata are labels and annotations added to every pod and PVC CDI creates for transfers
+	// +optional
+	WorkloadMetadata *WorkloadMetadata `json:"workloadMetadata,omitempty"`
+	// WorkerRetryPolicy controls how often failed importer and clone pods are restarted
+	// +optional
+	WorkerRetryPolicy *WorkerRetryPolicy `json:"workerRetryPolicy,omitempty"`
+	// Tracing configures export of transfer lifecycle spans to an OpenTelemetry collector
+	// +optional
+	Tracing *TracingConfig `json:"tracing,omitempty"`
+	// MaxParallelReconciles is the number of DataVolumes and ObjectTransfers the controller
+	// works on concurrently, defaults to 1. A change takes effect when the controller restarts
+	// +optional
+	MaxParallelReconciles *int32 `json:"maxParallelReconciles,omitempty"`
+	// RegistryLayerCacheHostPath is a node-local directory importer pods mount to cache
+	// pulled containerDisk layers by digest, so repeated imports of the same image on a
+	// node skip the registry download. Empty disables the cache
+	// +optional
+	RegistryLayerCacheHostPath *string `json:"registryLayerCacheHostPath,omitempty"`
+	// TLSSecurityProfile is the TLS policy (minimum version and allowed ciphers)
+	// importer pods apply to their outbound TLS connections
+	// +optional
+	TLSSecurityProfile *ocpconfigv1.TLSSecurityProfile `json:"tlsSecurityProfile,omitempty"`
+	// FIPSMode restricts worker pods to FIPS-approved crypto. When no explicit
+	// tlsSecurityProfile is configured it applies a TLS 1.2 AES-GCM baseline
+	// +optional
+	FIPSMode *bool `json:"fipsMode,omitempty"`
+	// ManageNetworkPolicies has the controller create NetworkPolicies that
+	// restrict worker pods to their declared endpoints
+	// +optional
+	ManageNetworkPolicies *bool `json:"manageNetworkPolicies,omitempty"`
+	// WorkerSecurityProfile is the seccomp and AppArmor runtime profile configuration
+	// applied to every pod CDI creates, for clusters enforcing custom runtime profiles
+	// +optional
+	WorkerSecurityProfile *WorkerSecurityProfile `json:"workerSecurityProfile,omitempty"`
+}
+
+// TracingConfig configures span export for end-to-end transfer latency analysis
+type TracingConfig struct {
+	// OTLPEndpoint is the base URL of an OpenTelemetry collector OTLP/HTTP endpoint, for example http://otel-collector:4318
+	OTLPEndpoint string `json:"otlpEndpoint"`
+}
+
+// WorkerRetryPolicy controls how CDI retries failed worker pods
+type WorkerRetryPolicy struct {
+	// MaxRestarts is the number of restarts after which a failing transfer is marked failed
+	// and its worker pod deleted, 0 means fail on the first restart, unset means restart indefinitely
+	// +optional
+	MaxRestarts *int32 `json:"maxRestarts,omitempty"`
+}
+
+// WorkloadResourceRequirements holds per workload type overrides of the default pod resource requirements
+type WorkloadResourceRequirements struct {
+	// Importer overrides requirements for importer pods
+	// +optional
+	Importer *corev1.ResourceRequirements `json:"importer,omitempty"`
+	// CloneSource overrides requirements for clone source pods
+	// +optional
+	CloneSource *corev1.ResourceRequirements `json:"cloneSource,omitempty"`
+	// UploadServer overrides requirements for upload server pods
+	// +optional
+	UploadServer *corev1.ResourceRequirements `json:"uploadServer,omitempty"`
+	// ScratchPrep overrides requirements for pods preparing volumes, such as the PVC expansion pod
+	// +optional
+	ScratchPrep *corev1.ResourceRequirements `json:"scratchPrep,omitempty"`
+}
+
+// WorkerSecurityProfile holds the runtime security profiles applied to the CDI worker pods
+type WorkerSecurityProfile struct {
+	// SeccompProfile set on the pod securityContext of the worker pods
+	// +optional
+	SeccompProfile *corev1.SeccompProfile `json:"seccompProfile,omitempty"`
+	// AppArmorProfile set on the worker pod containers through the
+	// container.apparmor.security.beta.kubernetes.io annotations, for example "localhost/cdi-workers"
+	// +optional
+	AppArmorProfile *string `json:"appArmorProfile,omitempty"`
+}
+
+// WorkloadMetadata holds labels and annotations copied onto the CDI worker pods and PVCs
+type WorkloadMetadata struct {
+	// Labels added to the worker pod and PVC metadata
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations added to the worker pod and PVC metadata
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// RestrictedEgress restricts DataVolume sources to an allowlisted set of hosts, enforcing data-origin policy for regulated clusters
+type RestrictedEgress struct {
+	// Enabled turns source host validation on
+	Enabled bool `json:"enabled"`
+	// AllowedHosts are hosts that URL based sources (http, s3, imageio, vddk) may point at, a leading "*." matches any subdomain
+	// +optional
+	AllowedHosts []string `json:"allowedHosts,omitempty"`
+	// AllowedRegistries are registry hosts the registry source may pull from, a leading "*." matches any subdomain
+	// +optional
+	AllowedRegistries []string `json:"allowedRegistries,omitempty"`
+}
+
+// RegistryTLSConfig configures how TLS is used when importing from one registry host
+type RegistryTLSConfig struct {
+	// Host is the registry host, optionally with a port, the configuration applies to
+	Host string `json:"host"`
+	// CertConfigMap is the name of a ConfigMap containing the certificate authority (CA) bundle used to verify the registry
+	// +optional
+	CertConfigMap *string `json:"certConfigMap,omitempty"`
+	// Insecure disables TLS certificate verification for the registry
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+}
+
+// WorkerImageOverrides overrides parts of the worker images the controllers were deployed with
+type WorkerImageOverrides struct {
+	// Registry replaces the registry of the worker image names, empty means keep the deployed registry
+	// +optional
+	Registry *string `json:"registry,omitempty"`
+	// Tag replaces the tag of the worker images, empty means keep the deployed tag
+	// +optional
+	Tag *string `json:"tag,omitempty"`
+	// PullPolicy replaces the image pull policy of the worker pods
+	// +optional
+	PullPolicy *corev1.PullPolicy `json:"pullPolicy,omitempty"`
+}
+
+// DataVolumeDefaults defines defaults applied to new DataVolumes by the mutating webhook
+type DataVolumeDefaults struct {
+	// NamespaceSelector restricts the namespaces the defaults apply to, an empty selector matches all namespaces
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// Preallocation applied when the DataVolume does not specify it
+	// +optional
+	Preallocation *bool `json:"preallocation,omitempty"`
+	// StorageClass applied when the DataVolume does not specify one
+	// +optional
+	StorageClass *string `json:"storageClass,omitempty"`
+	// PriorityClassName applied when the DataVolume does not specify one
+	// +optional
+	PriorityClassName *string `json:"priorityClassName,omitempty"`
+	// Labels added to the DataVolume metadata
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// NamespacedFeatureGate enables feature gates for a subset of namespaces, allowing gradual rollout
+type NamespacedFeatureGate struct {
+	// FeatureGates are the feature gates enabled for the selected namespaces
+	FeatureGates []string `json:"featureGates"`
+	// Namespaces explicitly lists namespaces the gates are enabled for
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+	// NamespaceSelector selects namespaces the gates are enabled for by label
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
 }
 
 //CDIConfigStatus provides the most recently observed status of the CDI Config resource
@@ -762,8 +1146,25 @@ type CDIConfigStatus struct {
 	FilesystemOverhead *FilesystemOverhead `json:"filesystemOverhead,omitempty"`
 	// Preallocation controls whether storage for DataVolumes should be allocated in advance.
 	Preallocation bool `json:"preallocation,omitempty"`
+	// Conditions reports the validity of the CDIConfig spec, surfacing problems such as invalid import proxy URLs or unknown feature gates
+	// +optional
+	Conditions []CDIConfigCondition `json:"conditions,omitempty"`
+}
+
+// CDIConfigCondition represents the state of a CDIConfig condition
+type CDIConfigCondition struct {
+	Type           CDIConfigConditionType `json:"type" description:"type of condition ie. Valid"`
+	ConditionState `json:",inline"`
 }
 
+// CDIConfigConditionType is the string representation of known condition types
+type CDIConfigConditionType string
+
+const (
+	// CDIConfigValid is the condition that indicates the CDIConfig spec was applied without problems
+	CDIConfigValid CDIConfigConditionType = "Valid"
+)
+
 //CDIConfigList provides the needed parameters to do request a list of CDIConfigs from the system
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type CDIConfigList struct {
@@ -774,6 +1175,362 @@ type CDIConfigList struct {
 	Items []CDIConfig `json:"items"`
 }
 
+// CDIQuota defines CDI specific storage quota for a namespace,
+// limiting transfers in ways ResourceQuota cannot express
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+type CDIQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CDIQuotaSpec `json:"spec"`
+	// +optional
+	Status CDIQuotaStatus `json:"status,omitempty"`
+}
+
+// CDIQuotaSpec defines the transfer limits enforced in the namespace
+type CDIQuotaSpec struct {
+	// MaxConcurrentTransfers limits how many DataVolume transfers may run at once in the namespace
+	// +optional
+	MaxConcurrentTransfers *int32 `json:"maxConcurrentTransfers,omitempty"`
+	// MaxImportedBytesPerDay limits the total size imported into the namespace during one day
+	// +optional
+	MaxImportedBytesPerDay *resource.Quantity `json:"maxImportedBytesPerDay,omitempty"`
+}
+
+// CDIQuotaStatus shows the current usage of the quota
+type CDIQuotaStatus struct {
+	// CurrentTransfers is the number of DataVolume transfers currently running in the namespace
+	CurrentTransfers int32 `json:"currentTransfers,omitempty"`
+	// ImportedBytes is the total size imported into the namespace since DayStart
+	ImportedBytes *resource.Quantity `json:"importedBytes,omitempty"`
+	// DayStart marks the beginning of the current accounting day
+	DayStart *metav1.Time `json:"dayStart,omitempty"`
+}
+
+// CDIQuotaList provides the needed parameters to do request a list of CDIQuotas from the system
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type CDIQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// Items provides a list of CDIQuotas
+	Items []CDIQuota `json:"items"`
+}
+
+// CloneGrant allows a ServiceAccount to clone PVCs from the namespace of the
+// grant without a per-DataVolume authorization check
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+type CloneGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CloneGrantSpec `json:"spec"`
+}
+
+// CloneGrantSpec names the grantee and optionally restricts the source PVCs
+type CloneGrantSpec struct {
+	// ServiceAccount is allowed to clone from the namespace of the grant
+	ServiceAccount CloneGrantServiceAccount `json:"serviceAccount"`
+	// PVCSelector restricts the grant to source PVCs matching the selector, an empty selector matches all PVCs in the namespace
+	// +optional
+	PVCSelector *metav1.LabelSelector `json:"pvcSelector,omitempty"`
+}
+
+// CloneGrantServiceAccount identifies the ServiceAccount the grant applies to
+type CloneGrantServiceAccount struct {
+	// Namespace of the ServiceAccount
+	Namespace string `json:"namespace"`
+	// Name of the ServiceAccount
+	Name string `json:"name"`
+}
+
+// CloneGrantList provides the needed parameters to do request a list of CloneGrants from the system
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type CloneGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// Items provides a list of CloneGrants
+	Items []CloneGrant `json:"items"`
+}
+
+// VolumeExport makes the contents of a PVC or VolumeSnapshot downloadable over
+// HTTPS, so images can be pulled out of the cluster by external tooling
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+type VolumeExport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VolumeExportSpec `json:"spec"`
+	// +optional
+	Status VolumeExportStatus `json:"status,omitempty"`
+}
+
+// VolumeExportSpec defines the volume to export and how to serve it
+type VolumeExportSpec struct {
+	// Source names the volume to export
+	Source VolumeExportSource `json:"source"`
+	// Format is the on-the-wire format of the download stream, defaults to raw
+	// +optional
+	Format VolumeExportFormat `json:"format,omitempty"`
+	// TTLDuration is how long the export stays available after becoming ready,
+	// the export does not expire when unset
+	// +optional
+	TTLDuration *metav1.Duration `json:"ttlDuration,omitempty"`
+}
+
+// VolumeExportSource names the volume to export, exactly one of the fields
+// must be set and it refers to the namespace of the VolumeExport
+type VolumeExportSource struct {
+	// PVC is the name of a PersistentVolumeClaim
+	// +optional
+	PVC string `json:"pvc,omitempty"`
+	// Snapshot is the name of a VolumeSnapshot
+	// +optional
+	Snapshot string `json:"snapshot,omitempty"`
+}
+
+// VolumeExportFormat is the on-the-wire format of an exported volume
+type VolumeExportFormat string
+
+const (
+	// VolumeExportRaw streams the volume as a raw disk image
+	VolumeExportRaw VolumeExportFormat = "raw"
+	// VolumeExportGzip streams the volume as a gzip compressed raw disk image
+	VolumeExportGzip VolumeExportFormat = "gzip"
+	// VolumeExportQcow2 streams the volume converted to the qcow2 format
+	VolumeExportQcow2 VolumeExportFormat = "qcow2"
+)
+
+// VolumeExportPhase is the current phase of the VolumeExport
+type VolumeExportPhase string
+
+const (
+	// VolumeExportPending is the phase while the export server is not ready to serve yet
+	VolumeExportPending VolumeExportPhase = "Pending"
+	// VolumeExportReady is the phase while the volume can be downloaded
+	VolumeExportReady VolumeExportPhase = "Ready"
+	// VolumeExportExpired is the phase after the TTL ran out and the export server was torn down
+	VolumeExportExpired VolumeExportPhase = "Expired"
+)
+
+// VolumeExportStatus shows where and until when the volume can be downloaded
+type VolumeExportStatus struct {
+	// Phase is the current phase of the export
+	Phase VolumeExportPhase `json:"phase,omitempty"`
+	// URL is the in-cluster address the volume can be downloaded from
+	URL string `json:"url,omitempty"`
+	// TokenSecretRef names the Secret in the namespace of the VolumeExport holding the bearer token download requests must present
+	TokenSecretRef string `json:"tokenSecretRef,omitempty"`
+	// Cert is the PEM encoded serving certificate of the export server, consumers pin it to
+	// authenticate the server when downloading from another cluster
+	Cert string `json:"cert,omitempty"`
+	// ExpiresAt is when the export stops being served
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+}
+
+// VolumeExportList provides the needed parameters to do request a list of VolumeExports from the system
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VolumeExportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// Items provides a list of VolumeExports
+	Items []VolumeExport `json:"items"`
+}
+
+// DataExport pushes the disk image on a PVC to an external target, the mirror
+// image of the import flow
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+type DataExport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec DataExportSpec `json:"spec"`
+	// +optional
+	Status DataExportStatus `json:"status,omitempty"`
+}
+
+// DataExportSpec defines the volume to push and where to push it
+type DataExportSpec struct {
+	// Source is the name of the PersistentVolumeClaim holding the disk image,
+	// in the namespace of the DataExport
+	Source string `json:"source"`
+	// Target is the endpoint the image gets pushed to
+	Target DataExportTarget `json:"target"`
+	// Format is the on-the-wire format of the pushed image, defaults to raw
+	// +optional
+	Format VolumeExportFormat `json:"format,omitempty"`
+}
+
+// DataExportTarget is the endpoint the image gets pushed to, exactly one of
+// the fields must be set
+type DataExportTarget struct {
+	// S3 uploads the image as an S3 object
+	// +optional
+	S3 *DataExportTargetS3 `json:"s3,omitempty"`
+	// HTTP uploads the image with a single HTTP PUT request
+	// +optional
+	HTTP *DataExportTargetHTTP `json:"http,omitempty"`
+}
+
+// DataExportTargetS3 names the S3 object to upload to
+type DataExportTargetS3 struct {
+	// URL holds the endpoint, bucket and object key, for example
+	// https://s3.us-west-2.amazonaws.com/bucket/disk.img
+	URL string `json:"url"`
+	// SecretRef names a Secret in the namespace of the DataExport holding
+	// accessKeyId and secretKey
+	// +optional
+	SecretRef string `json:"secretRef,omitempty"`
+}
+
+// DataExportTargetHTTP names the URL the image gets PUT to
+type DataExportTargetHTTP struct {
+	// URL is the full upload URL
+	URL string `json:"url"`
+	// SecretRef names a Secret in the namespace of the DataExport holding
+	// accessKeyId and secretKey, sent as basic auth credentials
+	// +optional
+	SecretRef string `json:"secretRef,omitempty"`
+}
+
+// DataExportPhase is the current phase of the DataExport
+type DataExportPhase string
+
+const (
+	// DataExportPending is the phase before the exporter pod started pushing
+	DataExportPending DataExportPhase = "Pending"
+	// DataExportInProgress is the phase while the exporter pod pushes the image
+	DataExportInProgress DataExportPhase = "InProgress"
+	// DataExportSucceeded is the phase after the image reached the target
+	DataExportSucceeded DataExportPhase = "Succeeded"
+	// DataExportFailed is the phase after the exporter pod gave up
+	DataExportFailed DataExportPhase = "Failed"
+)
+
+// DataExportCondition represents the state of a data export condition
+type DataExportCondition struct {
+	Type           DataExportConditionType `json:"type" description:"type of condition ie. Completed"`
+	ConditionState `json:",inline"`
+}
+
+// DataExportConditionType is the string representation of known condition types
+type DataExportConditionType string
+
+const (
+	// DataExportCompleted is true once the image was pushed to the target
+	DataExportCompleted DataExportConditionType = "Completed"
+)
+
+// DataExportStatus reports how far the push got
+type DataExportStatus struct {
+	// Phase is the current phase of the export
+	Phase DataExportPhase `json:"phase,omitempty"`
+	// Progress is the percentage of the image pushed so far
+	Progress DataVolumeProgress `json:"progress,omitempty"`
+	// Checksum is the sha256 of the pushed stream, set once the push completed
+	Checksum string `json:"checksum,omitempty"`
+	// Conditions tracks the state of the export
+	Conditions []DataExportCondition `json:"conditions,omitempty" optional:"true"`
+}
+
+// DataExportList provides the needed parameters to do request a list of DataExports from the system
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type DataExportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// Items provides a list of DataExports
+	Items []DataExport `json:"items"`
+}
+
+// VolumeUploadSource is a specification to populate PersistentVolumeClaims
+// with upload data; a PVC referencing it through spec.dataSourceRef gets an
+// upload server without a wrapping DataVolume
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+type VolumeUploadSource struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VolumeUploadSourceSpec `json:"spec"`
+	// +optional
+	Status VolumeUploadSourceStatus `json:"status,omitempty"`
+}
+
+// VolumeUploadSourceSpec defines how the uploaded data gets processed
+type VolumeUploadSourceSpec struct {
+	// ContentType is the content type of the uploaded data
+	// +optional
+	ContentType DataVolumeContentType `json:"contentType,omitempty"`
+	// Preallocation controls whether storage for the target PVC should be allocated in advance
+	// +optional
+	Preallocation *bool `json:"preallocation,omitempty"`
+}
+
+// VolumeUploadSourceStatus provides the most recently observed status of the VolumeUploadSource
+type VolumeUploadSourceStatus struct {
+}
+
+// VolumeUploadSourceList provides the needed parameters to do request a list of VolumeUploadSources from the system
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VolumeUploadSourceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// Items provides a list of VolumeUploadSources
+	Items []VolumeUploadSource `json:"items"`
+}
+
+// VolumeCloneSource is a specification to populate PersistentVolumeClaims
+// with the content of an existing PVC; a PVC referencing it through
+// spec.dataSourceRef gets cloned without a wrapping DataVolume
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+type VolumeCloneSource struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VolumeCloneSourceSpec `json:"spec"`
+	// +optional
+	Status VolumeCloneSourceStatus `json:"status,omitempty"`
+}
+
+// VolumeCloneSourceSpec defines the PVC to clone from
+type VolumeCloneSourceSpec struct {
+	// Source is the name of the PVC to clone, in the namespace of the VolumeCloneSource
+	Source string `json:"source"`
+	// Preallocation controls whether storage for the target PVC should be allocated in advance
+	// +optional
+	Preallocation *bool `json:"preallocation,omitempty"`
+}
+
+// VolumeCloneSourceStatus provides the most recently observed status of the VolumeCloneSource
+type VolumeCloneSourceStatus struct {
+}
+
+// VolumeCloneSourceList provides the needed parameters to do request a list of VolumeCloneSources from the system
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VolumeCloneSourceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// Items provides a list of VolumeCloneSources
+	Items []VolumeCloneSource `json:"items"`
+}
+
 //ImportProxy provides the information on how to configure the importer pod proxy.
 type ImportProxy struct {
 	// HTTPProxy is the URL http://<username>:<pswd>@<ip>:<port> of the import proxy for HTTP requests.  Empty means unset and will not result in the import pod env var.
@@ -801,4 +1558,31 @@ type ImportProxy struct {
 	// 	   -----END CERTIFICATE-----
 	// +optional
 	TrustedCAProxy *string `json:"trustedCAProxy,omitempty"`
+	// CredentialsSecret is the name of a Secret in the cdi namespace holding the proxy username and password
+	// under the accessKeyId and secretKey keys, so they do not have to be embedded in the proxy URLs where
+	// they leak into pod specs, logs and events
+	// +optional
+	CredentialsSecret *string `json:"credentialsSecret,omitempty"`
+	// NamespaceOverrides are proxy settings applied to importer pods in the selected namespaces instead of the defaults above
+	// +optional
+	NamespaceOverrides []ImportProxyNamespaceOverride `json:"namespaceOverrides,omitempty"`
+}
+
+// ImportProxyNamespaceOverride scopes alternative proxy settings to the namespaces matched by the selector
+type ImportProxyNamespaceOverride struct {
+	// NamespaceSelector selects the namespaces the override applies to, an empty selector matches all namespaces
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// HTTPProxy overrides the default HTTP proxy URL for the selected namespaces, empty means keep the default
+	// +optional
+	HTTPProxy *string `json:"HTTPProxy,omitempty"`
+	// HTTPSProxy overrides the default HTTPS proxy URL for the selected namespaces, empty means keep the default
+	// +optional
+	HTTPSProxy *string `json:"HTTPSProxy,omitempty"`
+	// NoProxy overrides the default list of hostnames and/or CIDRs for which the proxy should not be used, empty means keep the default
+	// +optional
+	NoProxy *string `json:"noProxy,omitempty"`
+	// TrustedCAProxy overrides the default trusted certificate authority (CA) bundle ConfigMap name, empty means keep the default
+	// +optional
+	TrustedCAProxy *string `json:"trustedCAProxy,omitempty"`
 }
@@ -22,9 +22,11 @@ limitations under the License.
 package v1beta1
 
 import (
+	configv1 "github.com/openshift/api/config/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	api "kubevirt.io/controller-lifecycle-operator-sdk/api"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -68,6 +70,11 @@ func (in *CDICertConfig) DeepCopyInto(out *CDICertConfig) {
 		*out = new(CertConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Client != nil {
+		in, out := &in.Client, &out.Client
+		*out = new(CertConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -81,6 +88,37 @@ func (in *CDICertConfig) DeepCopy() *CDICertConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CDIComponentPlacement) DeepCopyInto(out *CDIComponentPlacement) {
+	*out = *in
+	if in.APIServer != nil {
+		in, out := &in.APIServer, &out.APIServer
+		*out = new(api.NodePlacement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Controller != nil {
+		in, out := &in.Controller, &out.Controller
+		*out = new(api.NodePlacement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UploadProxy != nil {
+		in, out := &in.UploadProxy, &out.UploadProxy
+		*out = new(api.NodePlacement)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CDIComponentPlacement.
+func (in *CDIComponentPlacement) DeepCopy() *CDIComponentPlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(CDIComponentPlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CDIConfig) DeepCopyInto(out *CDIConfig) {
 	*out = *in
@@ -109,6 +147,23 @@ func (in *CDIConfig) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CDIConfigCondition) DeepCopyInto(out *CDIConfigCondition) {
+	*out = *in
+	in.ConditionState.DeepCopyInto(&out.ConditionState)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CDIConfigCondition.
+func (in *CDIConfigCondition) DeepCopy() *CDIConfigCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(CDIConfigCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CDIConfigList) DeepCopyInto(out *CDIConfigList) {
 	*out = *in
@@ -160,16 +215,38 @@ func (in *CDIConfigSpec) DeepCopyInto(out *CDIConfigSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ScratchSpaceSizeFactor != nil {
+		in, out := &in.ScratchSpaceSizeFactor, &out.ScratchSpaceSizeFactor
+		*out = new(string)
+		**out = **in
+	}
+	if in.ScratchSpaceMaxSize != nil {
+		in, out := &in.ScratchSpaceMaxSize, &out.ScratchSpaceMaxSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 	if in.PodResourceRequirements != nil {
 		in, out := &in.PodResourceRequirements, &out.PodResourceRequirements
 		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PodResourceRequirementsByWorkload != nil {
+		in, out := &in.PodResourceRequirementsByWorkload, &out.PodResourceRequirementsByWorkload
+		*out = new(WorkloadResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.FeatureGates != nil {
 		in, out := &in.FeatureGates, &out.FeatureGates
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.NamespacedFeatureGates != nil {
+		in, out := &in.NamespacedFeatureGates, &out.NamespacedFeatureGates
+		*out = make([]NamespacedFeatureGate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.FilesystemOverhead != nil {
 		in, out := &in.FilesystemOverhead, &out.FilesystemOverhead
 		*out = new(FilesystemOverhead)
@@ -185,11 +262,85 @@ func (in *CDIConfigSpec) DeepCopyInto(out *CDIConfigSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.RegistryTLSConfigs != nil {
+		in, out := &in.RegistryTLSConfigs, &out.RegistryTLSConfigs
+		*out = make([]RegistryTLSConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RestrictedEgress != nil {
+		in, out := &in.RestrictedEgress, &out.RestrictedEgress
+		*out = new(RestrictedEgress)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.DataVolumeTTLSeconds != nil {
 		in, out := &in.DataVolumeTTLSeconds, &out.DataVolumeTTLSeconds
 		*out = new(int32)
 		**out = **in
 	}
+	if in.LogVerbosity != nil {
+		in, out := &in.LogVerbosity, &out.LogVerbosity
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DataVolumeDefaults != nil {
+		in, out := &in.DataVolumeDefaults, &out.DataVolumeDefaults
+		*out = make([]DataVolumeDefaults, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WorkerImageOverrides != nil {
+		in, out := &in.WorkerImageOverrides, &out.WorkerImageOverrides
+		*out = new(WorkerImageOverrides)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WorkloadMetadata != nil {
+		in, out := &in.WorkloadMetadata, &out.WorkloadMetadata
+		*out = new(WorkloadMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WorkerRetryPolicy != nil {
+		in, out := &in.WorkerRetryPolicy, &out.WorkerRetryPolicy
+		*out = new(WorkerRetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(TracingConfig)
+		**out = **in
+	}
+	if in.MaxParallelReconciles != nil {
+		in, out := &in.MaxParallelReconciles, &out.MaxParallelReconciles
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RegistryLayerCacheHostPath != nil {
+		in, out := &in.RegistryLayerCacheHostPath, &out.RegistryLayerCacheHostPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.TLSSecurityProfile != nil {
+		in, out := &in.TLSSecurityProfile, &out.TLSSecurityProfile
+		*out = new(configv1.TLSSecurityProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FIPSMode != nil {
+		in, out := &in.FIPSMode, &out.FIPSMode
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ManageNetworkPolicies != nil {
+		in, out := &in.ManageNetworkPolicies, &out.ManageNetworkPolicies
+		*out = new(bool)
+		**out = **in
+	}
+	if in.WorkerSecurityProfile != nil {
+		in, out := &in.WorkerSecurityProfile, &out.WorkerSecurityProfile
+		*out = new(WorkerSecurityProfile)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -226,6 +377,13 @@ func (in *CDIConfigStatus) DeepCopyInto(out *CDIConfigStatus) {
 		*out = new(FilesystemOverhead)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]CDIConfigCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -239,6 +397,37 @@ func (in *CDIConfigStatus) DeepCopy() *CDIConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CDIInfraPriorityClasses) DeepCopyInto(out *CDIInfraPriorityClasses) {
+	*out = *in
+	if in.APIServer != nil {
+		in, out := &in.APIServer, &out.APIServer
+		*out = new(CDIPriorityClass)
+		**out = **in
+	}
+	if in.Controller != nil {
+		in, out := &in.Controller, &out.Controller
+		*out = new(CDIPriorityClass)
+		**out = **in
+	}
+	if in.UploadProxy != nil {
+		in, out := &in.UploadProxy, &out.UploadProxy
+		*out = new(CDIPriorityClass)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CDIInfraPriorityClasses.
+func (in *CDIInfraPriorityClasses) DeepCopy() *CDIInfraPriorityClasses {
+	if in == nil {
+		return nil
+	}
+	out := new(CDIInfraPriorityClasses)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CDIList) DeepCopyInto(out *CDIList) {
 	*out = *in
@@ -272,6 +461,118 @@ func (in *CDIList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CDIQuota) DeepCopyInto(out *CDIQuota) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CDIQuota.
+func (in *CDIQuota) DeepCopy() *CDIQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(CDIQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CDIQuota) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CDIQuotaList) DeepCopyInto(out *CDIQuotaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CDIQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CDIQuotaList.
+func (in *CDIQuotaList) DeepCopy() *CDIQuotaList {
+	if in == nil {
+		return nil
+	}
+	out := new(CDIQuotaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CDIQuotaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CDIQuotaSpec) DeepCopyInto(out *CDIQuotaSpec) {
+	*out = *in
+	if in.MaxConcurrentTransfers != nil {
+		in, out := &in.MaxConcurrentTransfers, &out.MaxConcurrentTransfers
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxImportedBytesPerDay != nil {
+		in, out := &in.MaxImportedBytesPerDay, &out.MaxImportedBytesPerDay
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CDIQuotaSpec.
+func (in *CDIQuotaSpec) DeepCopy() *CDIQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CDIQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CDIQuotaStatus) DeepCopyInto(out *CDIQuotaStatus) {
+	*out = *in
+	if in.ImportedBytes != nil {
+		in, out := &in.ImportedBytes, &out.ImportedBytes
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.DayStart != nil {
+		in, out := &in.DayStart, &out.DayStart
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CDIQuotaStatus.
+func (in *CDIQuotaStatus) DeepCopy() *CDIQuotaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CDIQuotaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CDISpec) DeepCopyInto(out *CDISpec) {
 	*out = *in
@@ -282,6 +583,11 @@ func (in *CDISpec) DeepCopyInto(out *CDISpec) {
 	}
 	in.Infra.DeepCopyInto(&out.Infra)
 	in.Workloads.DeepCopyInto(&out.Workloads)
+	if in.ComponentPlacement != nil {
+		in, out := &in.ComponentPlacement, &out.ComponentPlacement
+		*out = new(CDIComponentPlacement)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CloneStrategyOverride != nil {
 		in, out := &in.CloneStrategyOverride, &out.CloneStrategyOverride
 		*out = new(CDICloneStrategy)
@@ -302,6 +608,16 @@ func (in *CDISpec) DeepCopyInto(out *CDISpec) {
 		*out = new(CDIPriorityClass)
 		**out = **in
 	}
+	if in.InfraPriorityClasses != nil {
+		in, out := &in.InfraPriorityClasses, &out.InfraPriorityClasses
+		*out = new(CDIInfraPriorityClasses)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UploadProxyExposure != nil {
+		in, out := &in.UploadProxyExposure, &out.UploadProxyExposure
+		*out = new(CDIUploadProxyExposure)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -332,6 +648,37 @@ func (in *CDIStatus) DeepCopy() *CDIStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CDIUploadProxyExposure) DeepCopyInto(out *CDIUploadProxyExposure) {
+	*out = *in
+	if in.Host != nil {
+		in, out := &in.Host, &out.Host
+		*out = new(string)
+		**out = **in
+	}
+	if in.IngressClassName != nil {
+		in, out := &in.IngressClassName, &out.IngressClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.TLSSecretName != nil {
+		in, out := &in.TLSSecretName, &out.TLSSecretName
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CDIUploadProxyExposure.
+func (in *CDIUploadProxyExposure) DeepCopy() *CDIUploadProxyExposure {
+	if in == nil {
+		return nil
+	}
+	out := new(CDIUploadProxyExposure)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertConfig) DeepCopyInto(out *CertConfig) {
 	*out = *in
@@ -385,45 +732,59 @@ func (in *ClaimPropertySet) DeepCopy() *ClaimPropertySet {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ConditionState) DeepCopyInto(out *ConditionState) {
+func (in *CloneGrant) DeepCopyInto(out *CloneGrant) {
 	*out = *in
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
-	in.LastHeartbeatTime.DeepCopyInto(&out.LastHeartbeatTime)
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConditionState.
-func (in *ConditionState) DeepCopy() *ConditionState {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneGrant.
+func (in *CloneGrant) DeepCopy() *CloneGrant {
 	if in == nil {
 		return nil
 	}
-	out := new(ConditionState)
+	out := new(CloneGrant)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloneGrant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataImportCron) DeepCopyInto(out *DataImportCron) {
+func (in *CloneGrantList) DeepCopyInto(out *CloneGrantList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CloneGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataImportCron.
-func (in *DataImportCron) DeepCopy() *DataImportCron {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneGrantList.
+func (in *CloneGrantList) DeepCopy() *CloneGrantList {
 	if in == nil {
 		return nil
 	}
-	out := new(DataImportCron)
+	out := new(CloneGrantList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DataImportCron) DeepCopyObject() runtime.Object {
+func (in *CloneGrantList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -431,28 +792,288 @@ func (in *DataImportCron) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataImportCronCondition) DeepCopyInto(out *DataImportCronCondition) {
+func (in *CloneGrantServiceAccount) DeepCopyInto(out *CloneGrantServiceAccount) {
 	*out = *in
-	in.ConditionState.DeepCopyInto(&out.ConditionState)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataImportCronCondition.
-func (in *DataImportCronCondition) DeepCopy() *DataImportCronCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneGrantServiceAccount.
+func (in *CloneGrantServiceAccount) DeepCopy() *CloneGrantServiceAccount {
 	if in == nil {
 		return nil
 	}
-	out := new(DataImportCronCondition)
+	out := new(CloneGrantServiceAccount)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataImportCronList) DeepCopyInto(out *DataImportCronList) {
+func (in *CloneGrantSpec) DeepCopyInto(out *CloneGrantSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
+	out.ServiceAccount = in.ServiceAccount
+	if in.PVCSelector != nil {
+		in, out := &in.PVCSelector, &out.PVCSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneGrantSpec.
+func (in *CloneGrantSpec) DeepCopy() *CloneGrantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CloneGrantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConditionState) DeepCopyInto(out *ConditionState) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	in.LastHeartbeatTime.DeepCopyInto(&out.LastHeartbeatTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConditionState.
+func (in *ConditionState) DeepCopy() *ConditionState {
+	if in == nil {
+		return nil
+	}
+	out := new(ConditionState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataExport) DeepCopyInto(out *DataExport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataExport.
+func (in *DataExport) DeepCopy() *DataExport {
+	if in == nil {
+		return nil
+	}
+	out := new(DataExport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DataExport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataExportCondition) DeepCopyInto(out *DataExportCondition) {
+	*out = *in
+	in.ConditionState.DeepCopyInto(&out.ConditionState)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataExportCondition.
+func (in *DataExportCondition) DeepCopy() *DataExportCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(DataExportCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataExportList) DeepCopyInto(out *DataExportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DataExport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataExportList.
+func (in *DataExportList) DeepCopy() *DataExportList {
+	if in == nil {
+		return nil
+	}
+	out := new(DataExportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DataExportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataExportSpec) DeepCopyInto(out *DataExportSpec) {
+	*out = *in
+	in.Target.DeepCopyInto(&out.Target)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataExportSpec.
+func (in *DataExportSpec) DeepCopy() *DataExportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DataExportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataExportStatus) DeepCopyInto(out *DataExportStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]DataExportCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataExportStatus.
+func (in *DataExportStatus) DeepCopy() *DataExportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataExportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataExportTarget) DeepCopyInto(out *DataExportTarget) {
+	*out = *in
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(DataExportTargetS3)
+		**out = **in
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(DataExportTargetHTTP)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataExportTarget.
+func (in *DataExportTarget) DeepCopy() *DataExportTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(DataExportTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataExportTargetHTTP) DeepCopyInto(out *DataExportTargetHTTP) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataExportTargetHTTP.
+func (in *DataExportTargetHTTP) DeepCopy() *DataExportTargetHTTP {
+	if in == nil {
+		return nil
+	}
+	out := new(DataExportTargetHTTP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataExportTargetS3) DeepCopyInto(out *DataExportTargetS3) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataExportTargetS3.
+func (in *DataExportTargetS3) DeepCopy() *DataExportTargetS3 {
+	if in == nil {
+		return nil
+	}
+	out := new(DataExportTargetS3)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataImportCron) DeepCopyInto(out *DataImportCron) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataImportCron.
+func (in *DataImportCron) DeepCopy() *DataImportCron {
+	if in == nil {
+		return nil
+	}
+	out := new(DataImportCron)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DataImportCron) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataImportCronCondition) DeepCopyInto(out *DataImportCronCondition) {
+	*out = *in
+	in.ConditionState.DeepCopyInto(&out.ConditionState)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataImportCronCondition.
+func (in *DataImportCronCondition) DeepCopy() *DataImportCronCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(DataImportCronCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataImportCronList) DeepCopyInto(out *DataImportCronList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
 		in, out := &in.Items, &out.Items
 		*out = make([]DataImportCron, len(*in))
 		for i := range *in {
@@ -639,6 +1260,11 @@ func (in *DataSourceSource) DeepCopyInto(out *DataSourceSource) {
 		*out = new(DataVolumeSourcePVC)
 		**out = **in
 	}
+	if in.Snapshot != nil {
+		in, out := &in.Snapshot, &out.Snapshot
+		*out = new(DataVolumeSourceSnapshot)
+		**out = **in
+	}
 	return
 }
 
@@ -723,6 +1349,11 @@ func (in *DataVolume) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataVolumeBlankImage) DeepCopyInto(out *DataVolumeBlankImage) {
 	*out = *in
+	if in.ReservedBlocksPercentage != nil {
+		in, out := &in.ReservedBlocksPercentage, &out.ReservedBlocksPercentage
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -771,31 +1402,74 @@ func (in *DataVolumeCondition) DeepCopy() *DataVolumeCondition {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataVolumeList) DeepCopyInto(out *DataVolumeList) {
+func (in *DataVolumeDefaults) DeepCopyInto(out *DataVolumeDefaults) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]DataVolume, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Preallocation != nil {
+		in, out := &in.Preallocation, &out.Preallocation
+		*out = new(bool)
+		**out = **in
+	}
+	if in.StorageClass != nil {
+		in, out := &in.StorageClass, &out.StorageClass
+		*out = new(string)
+		**out = **in
+	}
+	if in.PriorityClassName != nil {
+		in, out := &in.PriorityClassName, &out.PriorityClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeList.
-func (in *DataVolumeList) DeepCopy() *DataVolumeList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeDefaults.
+func (in *DataVolumeDefaults) DeepCopy() *DataVolumeDefaults {
 	if in == nil {
 		return nil
 	}
-	out := new(DataVolumeList)
+	out := new(DataVolumeDefaults)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeList) DeepCopyInto(out *DataVolumeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DataVolume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeList.
+func (in *DataVolumeList) DeepCopy() *DataVolumeList {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
 func (in *DataVolumeList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
@@ -803,6 +1477,23 @@ func (in *DataVolumeList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeProgressDetail) DeepCopyInto(out *DataVolumeProgressDetail) {
+	*out = *in
+	in.StartedAt.DeepCopyInto(&out.StartedAt)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeProgressDetail.
+func (in *DataVolumeProgressDetail) DeepCopy() *DataVolumeProgressDetail {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeProgressDetail)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataVolumeSource) DeepCopyInto(out *DataVolumeSource) {
 	*out = *in
@@ -816,6 +1507,16 @@ func (in *DataVolumeSource) DeepCopyInto(out *DataVolumeSource) {
 		*out = new(DataVolumeSourceS3)
 		**out = **in
 	}
+	if in.GCS != nil {
+		in, out := &in.GCS, &out.GCS
+		*out = new(DataVolumeSourceGCS)
+		**out = **in
+	}
+	if in.OVA != nil {
+		in, out := &in.OVA, &out.OVA
+		*out = new(DataVolumeSourceOVA)
+		**out = **in
+	}
 	if in.Registry != nil {
 		in, out := &in.Registry, &out.Registry
 		*out = new(DataVolumeSourceRegistry)
@@ -826,6 +1527,11 @@ func (in *DataVolumeSource) DeepCopyInto(out *DataVolumeSource) {
 		*out = new(DataVolumeSourcePVC)
 		**out = **in
 	}
+	if in.Snapshot != nil {
+		in, out := &in.Snapshot, &out.Snapshot
+		*out = new(DataVolumeSourceSnapshot)
+		**out = **in
+	}
 	if in.Upload != nil {
 		in, out := &in.Upload, &out.Upload
 		*out = new(DataVolumeSourceUpload)
@@ -834,7 +1540,7 @@ func (in *DataVolumeSource) DeepCopyInto(out *DataVolumeSource) {
 	if in.Blank != nil {
 		in, out := &in.Blank, &out.Blank
 		*out = new(DataVolumeBlankImage)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Imageio != nil {
 		in, out := &in.Imageio, &out.Imageio
@@ -846,6 +1552,11 @@ func (in *DataVolumeSource) DeepCopyInto(out *DataVolumeSource) {
 		*out = new(DataVolumeSourceVDDK)
 		**out = **in
 	}
+	if in.VolumeExport != nil {
+		in, out := &in.VolumeExport, &out.VolumeExport
+		*out = new(DataVolumeSourceVolumeExport)
+		**out = **in
+	}
 	return
 }
 
@@ -859,6 +1570,22 @@ func (in *DataVolumeSource) DeepCopy() *DataVolumeSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSourceGCS) DeepCopyInto(out *DataVolumeSourceGCS) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceGCS.
+func (in *DataVolumeSourceGCS) DeepCopy() *DataVolumeSourceGCS {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSourceGCS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataVolumeSourceHTTP) DeepCopyInto(out *DataVolumeSourceHTTP) {
 	*out = *in
@@ -901,6 +1628,22 @@ func (in *DataVolumeSourceImageIO) DeepCopy() *DataVolumeSourceImageIO {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSourceOVA) DeepCopyInto(out *DataVolumeSourceOVA) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceOVA.
+func (in *DataVolumeSourceOVA) DeepCopy() *DataVolumeSourceOVA {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSourceOVA)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataVolumeSourcePVC) DeepCopyInto(out *DataVolumeSourcePVC) {
 	*out = *in
@@ -966,6 +1709,11 @@ func (in *DataVolumeSourceRegistry) DeepCopyInto(out *DataVolumeSourceRegistry)
 		*out = new(string)
 		**out = **in
 	}
+	if in.CertSecret != nil {
+		in, out := &in.CertSecret, &out.CertSecret
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -995,6 +1743,22 @@ func (in *DataVolumeSourceS3) DeepCopy() *DataVolumeSourceS3 {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSourceSnapshot) DeepCopyInto(out *DataVolumeSourceSnapshot) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceSnapshot.
+func (in *DataVolumeSourceSnapshot) DeepCopy() *DataVolumeSourceSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSourceSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataVolumeSourceUpload) DeepCopyInto(out *DataVolumeSourceUpload) {
 	*out = *in
@@ -1027,6 +1791,22 @@ func (in *DataVolumeSourceVDDK) DeepCopy() *DataVolumeSourceVDDK {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSourceVolumeExport) DeepCopyInto(out *DataVolumeSourceVolumeExport) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceVolumeExport.
+func (in *DataVolumeSourceVolumeExport) DeepCopy() *DataVolumeSourceVolumeExport {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSourceVolumeExport)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataVolumeSpec) DeepCopyInto(out *DataVolumeSpec) {
 	*out = *in
@@ -1076,6 +1856,11 @@ func (in *DataVolumeSpec) DeepCopy() *DataVolumeSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataVolumeStatus) DeepCopyInto(out *DataVolumeStatus) {
 	*out = *in
+	if in.ProgressDetail != nil {
+		in, out := &in.ProgressDetail, &out.ProgressDetail
+		*out = new(DataVolumeProgressDetail)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]DataVolumeCondition, len(*in))
@@ -1142,6 +1927,18 @@ func (in *ImportProxy) DeepCopyInto(out *ImportProxy) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.CredentialsSecret != nil {
+		in, out := &in.CredentialsSecret, &out.CredentialsSecret
+		*out = new(string)
+		**out = **in
+	}
+	if in.NamespaceOverrides != nil {
+		in, out := &in.NamespaceOverrides, &out.NamespaceOverrides
+		*out = make([]ImportProxyNamespaceOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1155,6 +1952,47 @@ func (in *ImportProxy) DeepCopy() *ImportProxy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImportProxyNamespaceOverride) DeepCopyInto(out *ImportProxyNamespaceOverride) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTPProxy != nil {
+		in, out := &in.HTTPProxy, &out.HTTPProxy
+		*out = new(string)
+		**out = **in
+	}
+	if in.HTTPSProxy != nil {
+		in, out := &in.HTTPSProxy, &out.HTTPSProxy
+		*out = new(string)
+		**out = **in
+	}
+	if in.NoProxy != nil {
+		in, out := &in.NoProxy, &out.NoProxy
+		*out = new(string)
+		**out = **in
+	}
+	if in.TrustedCAProxy != nil {
+		in, out := &in.TrustedCAProxy, &out.TrustedCAProxy
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportProxyNamespaceOverride.
+func (in *ImportProxyNamespaceOverride) DeepCopy() *ImportProxyNamespaceOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ImportProxyNamespaceOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImportStatus) DeepCopyInto(out *ImportStatus) {
 	*out = *in
@@ -1171,6 +2009,37 @@ func (in *ImportStatus) DeepCopy() *ImportStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacedFeatureGate) DeepCopyInto(out *NamespacedFeatureGate) {
+	*out = *in
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacedFeatureGate.
+func (in *NamespacedFeatureGate) DeepCopy() *NamespacedFeatureGate {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedFeatureGate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectTransfer) DeepCopyInto(out *ObjectTransfer) {
 	*out = *in
@@ -1303,6 +2172,53 @@ func (in *ObjectTransferStatus) DeepCopy() *ObjectTransferStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryTLSConfig) DeepCopyInto(out *RegistryTLSConfig) {
+	*out = *in
+	if in.CertConfigMap != nil {
+		in, out := &in.CertConfigMap, &out.CertConfigMap
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryTLSConfig.
+func (in *RegistryTLSConfig) DeepCopy() *RegistryTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestrictedEgress) DeepCopyInto(out *RestrictedEgress) {
+	*out = *in
+	if in.AllowedHosts != nil {
+		in, out := &in.AllowedHosts, &out.AllowedHosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedRegistries != nil {
+		in, out := &in.AllowedRegistries, &out.AllowedRegistries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestrictedEgress.
+func (in *RestrictedEgress) DeepCopy() *RestrictedEgress {
+	if in == nil {
+		return nil
+	}
+	out := new(RestrictedEgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageProfile) DeepCopyInto(out *StorageProfile) {
 	*out = *in
@@ -1379,6 +2295,16 @@ func (in *StorageProfileSpec) DeepCopyInto(out *StorageProfileSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DataImportCacheMode != nil {
+		in, out := &in.DataImportCacheMode, &out.DataImportCacheMode
+		*out = new(CacheMode)
+		**out = **in
+	}
+	if in.SupportsReflink != nil {
+		in, out := &in.SupportsReflink, &out.SupportsReflink
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -1410,6 +2336,11 @@ func (in *StorageProfileStatus) DeepCopyInto(out *StorageProfileStatus) {
 		*out = new(CDICloneStrategy)
 		**out = **in
 	}
+	if in.SupportsRWXBlock != nil {
+		in, out := &in.SupportsRWXBlock, &out.SupportsRWXBlock
+		*out = new(bool)
+		**out = **in
+	}
 	if in.ClaimPropertySets != nil {
 		in, out := &in.ClaimPropertySets, &out.ClaimPropertySets
 		*out = make([]ClaimPropertySet, len(*in))
@@ -1417,6 +2348,16 @@ func (in *StorageProfileStatus) DeepCopyInto(out *StorageProfileStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DataImportCacheMode != nil {
+		in, out := &in.DataImportCacheMode, &out.DataImportCacheMode
+		*out = new(CacheMode)
+		**out = **in
+	}
+	if in.SupportsReflink != nil {
+		in, out := &in.SupportsReflink, &out.SupportsReflink
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -1472,6 +2413,22 @@ func (in *StorageSpec) DeepCopy() *StorageSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingConfig) DeepCopyInto(out *TracingConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingConfig.
+func (in *TracingConfig) DeepCopy() *TracingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TransferSource) DeepCopyInto(out *TransferSource) {
 	*out = *in
@@ -1520,3 +2477,462 @@ func (in *TransferTarget) DeepCopy() *TransferTarget {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeCloneSource) DeepCopyInto(out *VolumeCloneSource) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeCloneSource.
+func (in *VolumeCloneSource) DeepCopy() *VolumeCloneSource {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeCloneSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeCloneSource) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeCloneSourceList) DeepCopyInto(out *VolumeCloneSourceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VolumeCloneSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeCloneSourceList.
+func (in *VolumeCloneSourceList) DeepCopy() *VolumeCloneSourceList {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeCloneSourceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeCloneSourceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeCloneSourceSpec) DeepCopyInto(out *VolumeCloneSourceSpec) {
+	*out = *in
+	if in.Preallocation != nil {
+		in, out := &in.Preallocation, &out.Preallocation
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeCloneSourceSpec.
+func (in *VolumeCloneSourceSpec) DeepCopy() *VolumeCloneSourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeCloneSourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeCloneSourceStatus) DeepCopyInto(out *VolumeCloneSourceStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeCloneSourceStatus.
+func (in *VolumeCloneSourceStatus) DeepCopy() *VolumeCloneSourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeCloneSourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeExport) DeepCopyInto(out *VolumeExport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeExport.
+func (in *VolumeExport) DeepCopy() *VolumeExport {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeExport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeExport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeExportList) DeepCopyInto(out *VolumeExportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VolumeExport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeExportList.
+func (in *VolumeExportList) DeepCopy() *VolumeExportList {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeExportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeExportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeExportSource) DeepCopyInto(out *VolumeExportSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeExportSource.
+func (in *VolumeExportSource) DeepCopy() *VolumeExportSource {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeExportSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeExportSpec) DeepCopyInto(out *VolumeExportSpec) {
+	*out = *in
+	out.Source = in.Source
+	if in.TTLDuration != nil {
+		in, out := &in.TTLDuration, &out.TTLDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeExportSpec.
+func (in *VolumeExportSpec) DeepCopy() *VolumeExportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeExportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeExportStatus) DeepCopyInto(out *VolumeExportStatus) {
+	*out = *in
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeExportStatus.
+func (in *VolumeExportStatus) DeepCopy() *VolumeExportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeExportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeUploadSource) DeepCopyInto(out *VolumeUploadSource) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeUploadSource.
+func (in *VolumeUploadSource) DeepCopy() *VolumeUploadSource {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeUploadSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeUploadSource) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeUploadSourceList) DeepCopyInto(out *VolumeUploadSourceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VolumeUploadSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeUploadSourceList.
+func (in *VolumeUploadSourceList) DeepCopy() *VolumeUploadSourceList {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeUploadSourceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeUploadSourceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeUploadSourceSpec) DeepCopyInto(out *VolumeUploadSourceSpec) {
+	*out = *in
+	if in.Preallocation != nil {
+		in, out := &in.Preallocation, &out.Preallocation
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeUploadSourceSpec.
+func (in *VolumeUploadSourceSpec) DeepCopy() *VolumeUploadSourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeUploadSourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeUploadSourceStatus) DeepCopyInto(out *VolumeUploadSourceStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeUploadSourceStatus.
+func (in *VolumeUploadSourceStatus) DeepCopy() *VolumeUploadSourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeUploadSourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerImageOverrides) DeepCopyInto(out *WorkerImageOverrides) {
+	*out = *in
+	if in.Registry != nil {
+		in, out := &in.Registry, &out.Registry
+		*out = new(string)
+		**out = **in
+	}
+	if in.Tag != nil {
+		in, out := &in.Tag, &out.Tag
+		*out = new(string)
+		**out = **in
+	}
+	if in.PullPolicy != nil {
+		in, out := &in.PullPolicy, &out.PullPolicy
+		*out = new(v1.PullPolicy)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerImageOverrides.
+func (in *WorkerImageOverrides) DeepCopy() *WorkerImageOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerImageOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerRetryPolicy) DeepCopyInto(out *WorkerRetryPolicy) {
+	*out = *in
+	if in.MaxRestarts != nil {
+		in, out := &in.MaxRestarts, &out.MaxRestarts
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerRetryPolicy.
+func (in *WorkerRetryPolicy) DeepCopy() *WorkerRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerSecurityProfile) DeepCopyInto(out *WorkerSecurityProfile) {
+	*out = *in
+	if in.SeccompProfile != nil {
+		in, out := &in.SeccompProfile, &out.SeccompProfile
+		*out = new(v1.SeccompProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AppArmorProfile != nil {
+		in, out := &in.AppArmorProfile, &out.AppArmorProfile
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerSecurityProfile.
+func (in *WorkerSecurityProfile) DeepCopy() *WorkerSecurityProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerSecurityProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadMetadata) DeepCopyInto(out *WorkloadMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadMetadata.
+func (in *WorkloadMetadata) DeepCopy() *WorkloadMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadResourceRequirements) DeepCopyInto(out *WorkloadResourceRequirements) {
+	*out = *in
+	if in.Importer != nil {
+		in, out := &in.Importer, &out.Importer
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CloneSource != nil {
+		in, out := &in.CloneSource, &out.CloneSource
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UploadServer != nil {
+		in, out := &in.UploadServer, &out.UploadServer
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScratchPrep != nil {
+		in, out := &in.ScratchPrep, &out.ScratchPrep
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadResourceRequirements.
+func (in *WorkloadResourceRequirements) DeepCopy() *WorkloadResourceRequirements {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadResourceRequirements)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -193,6 +193,51 @@ func (f *Framework) GetMD5(namespace *k8sv1.Namespace, pvc *k8sv1.PersistentVolu
 	return output[:32], nil
 }
 
+// VerifyTargetPVCContentSha256 provides a function to check the sha256 of data on a PVC and ensure it matches that which is provided
+func (f *Framework) VerifyTargetPVCContentSha256(namespace *k8sv1.Namespace, pvc *k8sv1.PersistentVolumeClaim, fileName string, expectedHash string, numBytes ...int64) (bool, error) {
+	if len(numBytes) == 0 {
+		numBytes = append(numBytes, 0)
+	}
+
+	hash, err := f.GetSha256(namespace, pvc, fileName, numBytes[0])
+	if err != nil {
+		return false, err
+	}
+	return expectedHash == hash, nil
+}
+
+// GetSha256 returns the sha256 of a file on a PVC
+func (f *Framework) GetSha256(namespace *k8sv1.Namespace, pvc *k8sv1.PersistentVolumeClaim, fileName string, numBytes int64) (string, error) {
+	var err error
+	executorPod, err := f.startVerifierPod(namespace, pvc)
+	if err != nil {
+		fmt.Fprintf(ginkgo.GinkgoWriter, "INFO: could not start verifier pod: [%s]\n", err)
+		return "", err
+	}
+
+	cmd := "sha256sum " + fileName
+	if numBytes > 0 {
+		cmd = fmt.Sprintf("head -c %d %s 1> null && head -c %d %s | sha256sum", numBytes, fileName, numBytes, fileName)
+	}
+
+	var output, stderr string
+	err = wait.PollImmediate(2*time.Second, 10*time.Second, func() (bool, error) {
+		output, stderr, err = f.ExecShellInPod(executorPod.Name, namespace.Name, cmd)
+		if err != nil {
+			fmt.Fprintf(ginkgo.GinkgoWriter, "INFO: pod command execution failed, retrying: stderr: [%s]\n", stderr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	fmt.Fprintf(ginkgo.GinkgoWriter, "INFO: sha256sum found %s\n", string(output[:64]))
+	// Don't delete pod, other verification might happen.
+	return output[:64], nil
+}
+
 func (f *Framework) verifyInPod(namespace *k8sv1.Namespace, pvc *k8sv1.PersistentVolumeClaim, cmd string, verifyFn func(output, stderr string) (bool, error)) (bool, error) {
 	executorPod, err := f.startVerifierPod(namespace, pvc)
 	if err != nil {
@@ -210,11 +210,12 @@ var _ = Describe("[rfe_id:138][crit:high][vendor:cnv-qe@redhat.com][level:compon
 
 	DescribeTable("Archive upload should", func(uploader uploadArchiveFunc, validToken bool, format string) {
 		By("Create archive file to upload")
-		cirrosFileMd5, err := util.Md5sum(utils.UploadCirrosFile)
+		hasher := util.NewFileHasher()
+		cirrosFileHash, err := hasher.Hash(utils.UploadCirrosFile)
 		Expect(err).ToNot(HaveOccurred())
-		tinyCoreFileMd5, err := util.Md5sum(utils.UploadFile)
+		tinyCoreFileHash, err := hasher.Hash(utils.UploadFile)
 		Expect(err).ToNot(HaveOccurred())
-		filesToUpload := map[string]string{utils.TinyCoreFile: tinyCoreFileMd5, utils.CirrosQCow2File: cirrosFileMd5}
+		filesToUpload := map[string]string{utils.TinyCoreFile: tinyCoreFileHash, utils.CirrosQCow2File: cirrosFileHash}
 		archiveFilePath, err := utils.ArchiveFiles("archive", os.TempDir(), utils.UploadFile, utils.UploadCirrosFile)
 		Expect(err).ToNot(HaveOccurred())
 		if format != "" {
@@ -255,9 +256,9 @@ var _ = Describe("[rfe_id:138][crit:high][vendor:cnv-qe@redhat.com][level:compon
 			Expect(found).To(BeTrue())
 
 			By("Verify content")
-			for file, expectedMd5 := range filesToUpload {
+			for file, expectedHash := range filesToUpload {
 				pathInPvc := filepath.Join(utils.DefaultPvcMountPath, file)
-				same, err := f.VerifyTargetPVCContentMD5(f.Namespace, archivePVC, pathInPvc, expectedMd5)
+				same, err := f.VerifyTargetPVCContentSha256(f.Namespace, archivePVC, pathInPvc, expectedHash)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(same).To(BeTrue())
 				By("Verifying the image is sparse")
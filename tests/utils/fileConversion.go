@@ -2,6 +2,7 @@ package utils
 
 import (
 	"archive/tar"
+	"bytes"
 	"compress/gzip"
 	"io"
 	"os"
@@ -17,6 +18,7 @@ import (
 
 var formatTable = map[string]func(string, string, string) (string, error){
 	image.ExtGz:    toGz,
+	image.ExtBz2:   toBz2,
 	image.ExtXz:    toXz,
 	image.ExtTar:   toTar,
 	image.ExtQcow2: convertUsingQemuImg,
@@ -111,6 +113,21 @@ func toGz(src, tgtDir, ext string) (string, error) {
 	return tgtPath, nil
 }
 
+// toBz2 shells out to the bzip2 binary since the standard library can only decompress bzip2.
+func toBz2(src, tgtDir, ext string) (string, error) {
+	tgtFile, tgtPath, _ := createTargetFile(src, tgtDir, image.ExtBz2)
+	defer tgtFile.Close()
+
+	cmd := exec.Command("bzip2", "--stdout", "--keep", src)
+	cmd.Stdout = tgtFile
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", errors.Wrapf(err, "Error writing to file %s: %s", tgtPath, stderr.String())
+	}
+	return tgtPath, nil
+}
+
 func toXz(src, tgtDir, ext string) (string, error) {
 	tgtFile, tgtPath, _ := createTargetFile(src, tgtDir, image.ExtXz)
 	defer tgtFile.Close()